@@ -2,6 +2,7 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -31,11 +32,16 @@ const (
 	filterGit          = "git"
 	filterFile         = "file"
 	filterNotification = "notification"
+	filterPost         = "post"
 )
 
 // Default values for global settings display.
 const defaultGlobalTimeout = 10 * time.Second
 
+// maxExplainValueWidth caps the value column of debug config --explain, so
+// long lists do not push the source column off the screen.
+const maxExplainValueWidth = 40
+
 var validatorFilter string
 
 var debugCmd = &cobra.Command{
@@ -73,9 +79,14 @@ Displays configuration files loaded, their paths and modification times,
 and the final merged configuration values. Useful for debugging configuration
 issues and understanding which settings are active.
 
+With --explain, shows every effective value with the file, environment
+variable or flag that set it, and the lower-precedence values it overrides.
+
 Examples:
   klaudiush debug config               # Show all configuration
-  klaudiush debug config --validator git.push  # Show git.push config only`,
+  klaudiush debug config --validator git.push  # Show git.push config only
+  klaudiush debug config --explain     # Show where each value came from
+  klaudiush debug config --explain --json      # Same, as JSON`,
 	RunE: runDebugConfig,
 }
 
@@ -95,6 +106,11 @@ Examples:
 
 var showState bool
 
+var (
+	explainConfig bool
+	explainJSON   bool
+)
+
 func init() {
 	rootCmd.AddCommand(debugCmd)
 	debugCmd.AddCommand(debugConfigCmd)
@@ -114,6 +130,20 @@ func init() {
 		"Filter config by validator type (e.g., git.push, file.markdown)",
 	)
 
+	debugConfigCmd.Flags().BoolVar(
+		&explainConfig,
+		"explain",
+		false,
+		"Show the source of every effective value and the values it overrides",
+	)
+
+	debugConfigCmd.Flags().BoolVar(
+		&explainJSON,
+		"json",
+		false,
+		"Output --explain as JSON",
+	)
+
 	debugRulesCmd.Flags().StringVar(
 		&validatorFilter,
 		"validator",
@@ -142,6 +172,21 @@ func runDebugRules(_ *cobra.Command, _ []string) error {
 
 // setupDebugContext initializes logging and loads configuration for debug commands.
 func setupDebugContext(cmdName, extraKey, extraVal string) (*config.Config, error) {
+	log, err := setupDebugLogger(cmdName, extraKey, extraVal)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, _, err := loadConfigForDebug(log)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load configuration")
+	}
+
+	return cfg, nil
+}
+
+// setupDebugLogger initializes logging for debug commands.
+func setupDebugLogger(cmdName, extraKey, extraVal string) (logger.Logger, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get home directory")
@@ -156,30 +201,27 @@ func setupDebugContext(cmdName, extraKey, extraVal string) (*config.Config, erro
 
 	log.Info(cmdName+" command invoked", extraKey, extraVal)
 
-	cfg, err := loadConfigForDebug(log)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to load configuration")
-	}
-
-	return cfg, nil
+	return log, nil
 }
 
-func loadConfigForDebug(log logger.Logger) (*config.Config, error) {
+// loadConfigForDebug loads configuration and returns the loader, which knows
+// the source of each loaded value.
+func loadConfigForDebug(log logger.Logger) (*config.Config, *internalconfig.KoanfLoader, error) {
 	flags := buildFlagsMap()
 
 	loader, err := internalconfig.NewKoanfLoader()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create config loader")
+		return nil, nil, errors.Wrap(err, "failed to create config loader")
 	}
 
 	cfg, err := loader.Load(flags)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to load config")
+		return nil, nil, errors.Wrap(err, "failed to load config")
 	}
 
 	log.Debug("configuration loaded for debug")
 
-	return cfg, nil
+	return cfg, loader, nil
 }
 
 func displayRulesConfig(cfg *config.Config, filter string) {
@@ -207,6 +249,8 @@ func displayRulesConfig(cfg *config.Config, filter string) {
 	fmt.Printf("Total Rules: %d\n", len(rules.Rules))
 	fmt.Println("")
 
+	displayFreezes(rules.Freezes)
+
 	// Filter rules if needed
 	filteredRules := filterRules(rules.Rules, filter)
 
@@ -303,6 +347,10 @@ func displayRule(index int, rule *config.RuleConfig) {
 		if rule.Action.Reference != "" {
 			fmt.Printf("    Reference: %s\n", rule.Action.Reference)
 		}
+
+		if rule.Action.FixHint != "" {
+			fmt.Printf("    Fix Hint: %s\n", rule.Action.FixHint)
+		}
 	}
 
 	fmt.Println("")
@@ -344,6 +392,89 @@ func displayMatchCondition(indent string, match *config.RuleMatchConfig) {
 	if match.EventType != "" {
 		fmt.Printf("%sEvent Type: %s\n", indent, match.EventType)
 	}
+
+	displayGitStateCondition(indent, match)
+	displayScheduleCondition(indent, match.Schedule)
+
+	if match.When != "" {
+		fmt.Printf("%sWhen: %s\n", indent, match.When)
+	}
+}
+
+func displayGitStateCondition(indent string, match *config.RuleMatchConfig) {
+	if match.Dirty != nil {
+		fmt.Printf("%sDirty: %t\n", indent, *match.Dirty)
+	}
+
+	if match.StagedPattern != "" {
+		fmt.Printf("%sStaged Pattern: %s\n", indent, match.StagedPattern)
+	}
+
+	if match.UpstreamPattern != "" {
+		fmt.Printf("%sUpstream Pattern: %s\n", indent, match.UpstreamPattern)
+	}
+
+	if match.MinAhead > 0 {
+		fmt.Printf("%sMin Ahead: %d\n", indent, match.MinAhead)
+	}
+
+	if match.MinBehind > 0 {
+		fmt.Printf("%sMin Behind: %d\n", indent, match.MinBehind)
+	}
+
+	if match.HeadSigned != nil {
+		fmt.Printf("%sHEAD Signed: %t\n", indent, *match.HeadSigned)
+	}
+
+	if match.HeadAuthorPattern != "" {
+		fmt.Printf("%sHEAD Author Pattern: %s\n", indent, match.HeadAuthorPattern)
+	}
+}
+
+func displayFreezes(freezes []config.FreezeConfig) {
+	if len(freezes) == 0 {
+		return
+	}
+
+	fmt.Println("Freezes:")
+
+	for _, f := range freezes {
+		fmt.Printf("  %s: %s - %s", f.Name, f.Start, f.End)
+
+		if f.Timezone != "" {
+			fmt.Printf(" (%s)", f.Timezone)
+		}
+
+		if f.Reason != "" {
+			fmt.Printf(", %s", f.Reason)
+		}
+
+		fmt.Println("")
+	}
+
+	fmt.Println("")
+}
+
+func displayScheduleCondition(indent string, schedule *config.ScheduleConfig) {
+	if schedule == nil {
+		return
+	}
+
+	for _, window := range schedule.Windows {
+		fmt.Printf("%sSchedule Window: %s\n", indent, window)
+	}
+
+	for _, d := range schedule.Dates {
+		fmt.Printf("%sSchedule Dates: %s - %s\n", indent, d.Start, d.End)
+	}
+
+	if len(schedule.Freezes) > 0 {
+		fmt.Printf("%sSchedule Freezes: %s\n", indent, strings.Join(schedule.Freezes, ", "))
+	}
+
+	if schedule.Timezone != "" {
+		fmt.Printf("%sSchedule Timezone: %s\n", indent, schedule.Timezone)
+	}
 }
 
 func runDebugExceptions(_ *cobra.Command, _ []string) error {
@@ -609,24 +740,122 @@ func formatLimits(maxHour, maxDay int) string {
 }
 
 func runDebugConfig(_ *cobra.Command, _ []string) error {
-	cfg, err := setupDebugContext("debug config", "validatorFilter", validatorFilter)
+	log, err := setupDebugLogger("debug config", "validatorFilter", validatorFilter)
 	if err != nil {
 		return err
 	}
 
-	displayFullConfig(cfg, validatorFilter)
+	cfg, loader, err := loadConfigForDebug(log)
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
+	if explainJSON {
+		return outputConfigExplainJSON(loader, validatorFilter)
+	}
+
+	if explainConfig {
+		displayConfigExplain(loader, validatorFilter)
+
+		return nil
+	}
+
+	displayFullConfig(cfg, loader, validatorFilter)
 
 	return nil
 }
 
-func displayFullConfig(cfg *config.Config, filter string) {
+// filterProvenance returns the provenance of the keys matching the filter.
+func filterProvenance(
+	loader *internalconfig.KoanfLoader,
+	filter string,
+) []internalconfig.KeyProvenance {
+	var filtered []internalconfig.KeyProvenance
+
+	for _, entry := range loader.Explain() {
+		if matchesSourceFilter(entry.Key, filter) {
+			filtered = append(filtered, entry)
+		}
+	}
+
+	return filtered
+}
+
+func outputConfigExplainJSON(loader *internalconfig.KoanfLoader, filter string) error {
+	entries := filterProvenance(loader, filter)
+	if entries == nil {
+		entries = []internalconfig.KeyProvenance{}
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(entries); err != nil {
+		return errors.Wrap(err, "encoding JSON output")
+	}
+
+	return nil
+}
+
+// displayConfigExplain prints every effective value with its source, followed
+// by the lower-precedence values it overrides.
+func displayConfigExplain(loader *internalconfig.KoanfLoader, filter string) {
+	fmt.Println("Configuration Provenance")
+	fmt.Println("========================")
+	fmt.Println("")
+
+	entries := filterProvenance(loader, filter)
+	if len(entries) == 0 {
+		fmt.Printf("No configuration keys match filter: %s\n", filter)
+
+		return
+	}
+
+	keyWidth := len("KEY")
+	valueWidth := len("VALUE")
+
+	for _, entry := range entries {
+		keyWidth = max(keyWidth, len(entry.Key))
+		valueWidth = max(valueWidth, len(fmt.Sprint(entry.Value)))
+	}
+
+	valueWidth = min(valueWidth, maxExplainValueWidth)
+
+	fmt.Printf("%-*s  %-*s  %s\n", keyWidth, "KEY", valueWidth, "VALUE", "SOURCE")
+
+	for _, entry := range entries {
+		fmt.Printf("%-*s  %-*s  %s\n",
+			keyWidth, entry.Key,
+			valueWidth, fmt.Sprint(entry.Value),
+			formatValueOrigin(entry.ValueOrigin),
+		)
+
+		for _, overridden := range entry.Overridden {
+			fmt.Printf("  overrides %v  ← %s\n", overridden.Value, formatValueOrigin(overridden))
+		}
+	}
+
+	fmt.Println("")
+}
+
+// formatValueOrigin formats a source with the environment variable or flag
+// that set the value, e.g. "env (KLAUDIUSH_GLOBAL_DEFAULT_TIMEOUT)".
+func formatValueOrigin(origin internalconfig.ValueOrigin) string {
+	if origin.Name == "" {
+		return origin.Source
+	}
+
+	return origin.Source + " (" + origin.Name + ")"
+}
+
+func displayFullConfig(cfg *config.Config, loader *internalconfig.KoanfLoader, filter string) {
 	// Header
 	fmt.Println("Configuration")
 	fmt.Println("=============")
 	fmt.Println("")
 
 	// Config sources
-	displayConfigSources()
+	displayConfigSources(loader)
 
 	// Global settings - always display with defaults
 	fmt.Println("Global Settings")
@@ -652,35 +881,86 @@ func displayFullConfig(cfg *config.Config, filter string) {
 
 	// Validators config
 	displayValidatorsConfig(cfg, filter)
+
+	// Where each non-default value came from
+	displayValueSources(loader, filter)
 }
 
-func displayConfigSources() {
+func displayConfigSources(loader *internalconfig.KoanfLoader) {
 	fmt.Println("Configuration Sources")
 	fmt.Println("--------------------")
 
-	loader, err := internalconfig.NewKoanfLoader()
-	if err != nil {
-		fmt.Printf("  ⚠️  Could not create loader: %v\n", err)
-		fmt.Println("")
-
-		return
-	}
-
 	// Global config
 	globalPath := loader.GlobalConfigPath()
 	displayConfigFile("Global", globalPath)
 
-	// Project config
-	projectPath := loader.FindProjectConfigPath()
-	if projectPath != "" {
-		displayConfigFile("Project", projectPath)
-	} else {
+	// Project configs, from the repository root down to the working directory
+	projectPaths := loader.FindProjectConfigPaths()
+	if len(projectPaths) == 0 {
 		fmt.Println("  Project: (none)")
 	}
 
+	for i, projectPath := range projectPaths {
+		label := "Project"
+		if len(projectPaths) > 1 {
+			label = fmt.Sprintf("Project [%d/%d]", i+1, len(projectPaths))
+		}
+
+		displayConfigFile(label, projectPath)
+	}
+
 	fmt.Println("")
 }
 
+// displayValueSources lists the values that are not defaults with the file,
+// environment or flags they came from. Later sources take precedence.
+func displayValueSources(loader *internalconfig.KoanfLoader, filter string) {
+	sources := loader.Sources()
+
+	keys := make([]string, 0, len(sources))
+
+	for key, source := range sources {
+		if source == internalconfig.SourceDefaults || !matchesSourceFilter(key, filter) {
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return
+	}
+
+	slices.Sort(keys)
+
+	fmt.Println("Value Sources")
+	fmt.Println("-------------")
+
+	for _, key := range keys {
+		if ruleName, ok := strings.CutPrefix(key, "rules.rules."); ok {
+			fmt.Printf("  rule %q  ← %s\n", ruleName, sources[key])
+
+			continue
+		}
+
+		fmt.Printf("  %s = %v  ← %s\n", key, loader.Get(key), sources[key])
+	}
+
+	fmt.Println("")
+}
+
+// matchesSourceFilter returns true if a config key belongs to the filtered
+// validators (e.g., "git.push" matches "validators.git.push.enabled").
+func matchesSourceFilter(key, filter string) bool {
+	if filter == "" {
+		return true
+	}
+
+	prefix := "validators." + strings.TrimSuffix(filter, ".*")
+
+	return key == prefix || strings.HasPrefix(key, prefix+".")
+}
+
 func displayConfigFile(label, path string) {
 	info, err := os.Lstat(path)
 	if err != nil {
@@ -722,6 +1002,11 @@ func displayValidatorsConfig(cfg *config.Config, filter string) {
 	if filter == "" || filter == filterNotification || strings.HasPrefix(filter, "notification.") {
 		displayNotificationValidators(cfg.Validators.Notification, filter)
 	}
+
+	// Show post validators if no filter or filter matches
+	if filter == "" || filter == filterPost || strings.HasPrefix(filter, "post.") {
+		displayPostValidators(cfg.Validators.Post, filter)
+	}
 }
 
 func displayGitValidators(git *config.GitConfig, filter string) {
@@ -792,6 +1077,13 @@ func displayGitCommitConfig(git *config.GitConfig, filter string) {
 	}
 
 	fmt.Printf("    Check Staging Area: %v\n", checkStaging)
+
+	checkStagedChanges := true
+	if git.Commit.CheckStagedChanges != nil {
+		checkStagedChanges = *git.Commit.CheckStagedChanges
+	}
+
+	fmt.Printf("    Check Staged Changes: %v\n", checkStagedChanges)
 }
 
 func displayFileValidators(file *config.FileConfig, filter string) {
@@ -852,3 +1144,39 @@ func displayNotificationValidators(notif *config.NotificationConfig, filter stri
 
 	fmt.Println("")
 }
+
+func displayPostValidators(post *config.PostConfig, filter string) {
+	if post == nil {
+		return
+	}
+
+	showFileLint := (filter == "" || filter == filterPost || filter == "post.file_lint") &&
+		post.FileLint != nil
+	showCommitSignoff := (filter == "" || filter == filterPost || filter == "post.commit_signoff") &&
+		post.CommitSignoff != nil
+
+	if !showFileLint && !showCommitSignoff {
+		return
+	}
+
+	fmt.Println("Post Validators")
+	fmt.Println("---------------")
+
+	if showFileLint {
+		fmt.Println("  post.file_lint:")
+		fmt.Printf("    Enabled: %v\n", post.FileLint.IsEnabled())
+		fmt.Printf("    Severity: %s\n", post.FileLint.GetSeverity())
+	}
+
+	if showCommitSignoff {
+		fmt.Println("  post.commit_signoff:")
+		fmt.Printf("    Enabled: %v\n", post.CommitSignoff.IsEnabled())
+		fmt.Printf("    Severity: %s\n", post.CommitSignoff.GetSeverity())
+
+		if post.CommitSignoff.ExpectedSignoff != "" {
+			fmt.Printf("    Expected Signoff: %s\n", post.CommitSignoff.ExpectedSignoff)
+		}
+	}
+
+	fmt.Println("")
+}
@@ -159,6 +159,7 @@ func buildDoctorRegistry() *doctor.Registry {
 	registry.RegisterChecker(configchecker.NewGlobalChecker())
 	registry.RegisterChecker(configchecker.NewProjectChecker())
 	registry.RegisterChecker(configchecker.NewPermissionsChecker())
+	registry.RegisterChecker(configchecker.NewGitleaksChecker())
 
 	// Register rules checkers
 	registry.RegisterChecker(ruleschecker.NewRulesChecker())
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/spf13/cobra"
@@ -15,6 +16,7 @@ import (
 	"github.com/smykla-labs/klaudiush/internal/config/factory"
 	"github.com/smykla-labs/klaudiush/internal/crashdump"
 	"github.com/smykla-labs/klaudiush/internal/dispatcher"
+	"github.com/smykla-labs/klaudiush/internal/exceptions"
 	"github.com/smykla-labs/klaudiush/internal/parser"
 	"github.com/smykla-labs/klaudiush/internal/session"
 	"github.com/smykla-labs/klaudiush/pkg/config"
@@ -42,6 +44,7 @@ var (
 	traceMode    bool
 	configPath   string
 	globalConfig string
+	outputFormat string
 	disableList  []string
 
 	// crashContext stores the current hook context for crash recovery.
@@ -93,7 +96,8 @@ func init() {
 		"hook-type",
 		"T",
 		"",
-		"Hook event type (PreToolUse, PostToolUse, Notification)",
+		"Hook event type (PreToolUse, PostToolUse, Notification, UserPromptSubmit, Stop, "+
+			"SubagentStop, SessionStart, SessionEnd, PreCompact); defaults to hook_event_name from input",
 	)
 	rootCmd.Flags().BoolVar(&debugMode, "debug", true, "Enable debug logging")
 	rootCmd.Flags().BoolVar(&traceMode, "trace", false, "Enable trace logging")
@@ -110,6 +114,12 @@ func init() {
 		"",
 		"Path to global configuration file (default: ~/.klaudiush/config.toml)",
 	)
+	rootCmd.Flags().StringVar(
+		&outputFormat,
+		"output-format",
+		"",
+		"Hook output format: text (stderr + exit code) or json (permission decision on stdout)",
+	)
 	rootCmd.Flags().StringSliceVar(
 		&disableList,
 		"disable",
@@ -137,10 +147,10 @@ func run(_ *cobra.Command, _ []string) error {
 		log.Error("first-run migration failed", "error", migErr)
 	}
 
-	// Determine event type using enumer-generated function
-	eventType, err := hook.EventTypeString(hookType)
+	// Determine event type; the parser falls back to hook_event_name when unset
+	eventType, err := parseHookType(hookType)
 	if err != nil {
-		eventType = hook.EventTypePreToolUse // Default to PreToolUse
+		return err
 	}
 
 	log.Info("hook invoked",
@@ -149,12 +159,6 @@ func run(_ *cobra.Command, _ []string) error {
 		"trace", traceMode,
 	)
 
-	// Load configuration
-	cfg, err := loadConfig(log)
-	if err != nil {
-		return errors.Wrap(err, "failed to load configuration")
-	}
-
 	// Parse JSON input
 	jsonParser := parser.NewJSONParser(os.Stdin)
 
@@ -169,7 +173,14 @@ func run(_ *cobra.Command, _ []string) error {
 		return errors.Wrap(err, "failed to parse input")
 	}
 
+	// Load configuration, discovering project configs from the session cwd
+	cfg, err := loadConfigForDir(log, ctx.Cwd)
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
 	log.Info("context parsed",
+		"event", ctx.EventType,
 		"tool", ctx.ToolName,
 		"command", ctx.GetCommand(),
 		"file", filepath.Base(ctx.GetFilePath()),
@@ -181,17 +192,30 @@ func run(_ *cobra.Command, _ []string) error {
 
 	// Build validator registry from configuration
 	registryBuilder := factory.NewRegistryBuilder(log)
-	registry := registryBuilder.Build(cfg)
+
+	// Fail open on rule errors: a broken rule must not disable the hook, so
+	// fall back to the built-in validators and log the error.
+	registry, _, err := registryBuilder.BuildWithRuleEngine(cfg)
+	if err != nil {
+		log.Error("failed to create rule engine, continuing without rules",
+			"error", err,
+		)
+
+		registry = registryBuilder.Build(cfg)
+	}
 
 	// Create and initialize session tracker if enabled
 	sessionTracker := initSessionTracker(cfg, log)
 
-	// Create dispatcher with session tracker
+	// Create exception handler if enabled
+	exceptionHandler := initExceptionHandler(cfg, log)
+
+	// Create dispatcher with executor, exceptions and session tracking from config
 	disp := dispatcher.NewDispatcherWithOptions(
 		registry,
 		log,
-		dispatcher.NewSequentialExecutor(log),
-		dispatcher.WithSessionTracker(sessionTracker),
+		newExecutor(cfg, log),
+		dispatcherOptions(cfg, log, sessionTracker, exceptionHandler)...,
 	)
 
 	// Dispatch validation
@@ -204,8 +228,52 @@ func run(_ *cobra.Command, _ []string) error {
 		}
 	}
 
-	// Check if we should block
-	if dispatcher.ShouldBlock(errs) {
+	// Save exception rate limit state after dispatch
+	if exceptionHandler != nil {
+		if err := exceptionHandler.SaveState(); err != nil {
+			log.Info("failed to save exception rate limit state", "error", err)
+		}
+	}
+
+	if cfg.GetGlobal().GetOutputFormat() == config.OutputFormatJSON ||
+		dispatcher.IsFeedbackEvent(ctx.EventType) {
+		return reportJSON(ctx.EventType, errs, log)
+	}
+
+	reportText(ctx.EventType, errs, log)
+
+	return nil
+}
+
+// parseHookType parses the --hook-type flag. An empty value yields
+// EventTypeUnknown so the event type is taken from the hook input.
+func parseHookType(value string) (hook.EventType, error) {
+	if value == "" {
+		return hook.EventTypeUnknown, nil
+	}
+
+	eventType, err := hook.EventTypeString(value)
+	if err != nil || eventType == hook.EventTypeUnknown {
+		return hook.EventTypeUnknown, errors.Newf(
+			"unknown hook type %q (valid: %s)",
+			value,
+			strings.Join(hook.EventTypeStrings()[1:], ", "),
+		)
+	}
+
+	return eventType, nil
+}
+
+// reportText prints validation errors to stderr and exits with ExitCodeBlock
+// when the operation must not proceed. Approval requests cannot be expressed
+// through exit codes, so they block in text mode. For events whose stdout is
+// added to Claude's context, warnings are printed to stdout instead.
+func reportText(
+	eventType hook.EventType,
+	errs []*dispatcher.ValidationError,
+	log logger.Logger,
+) {
+	if dispatcher.ShouldBlock(errs) || dispatcher.ShouldAsk(errs) {
 		errorMsg := dispatcher.FormatErrors(errs)
 		fmt.Fprint(os.Stderr, errorMsg)
 
@@ -218,8 +286,13 @@ func run(_ *cobra.Command, _ []string) error {
 
 	// If there are warnings, log them
 	if len(errs) > 0 {
+		out := os.Stderr
+		if dispatcher.InjectsContext(eventType) {
+			out = os.Stdout
+		}
+
 		errorMsg := dispatcher.FormatErrors(errs)
-		fmt.Fprint(os.Stderr, errorMsg)
+		fmt.Fprint(out, errorMsg)
 
 		log.Info("validation passed with warnings",
 			"warningCount", len(errs),
@@ -227,17 +300,48 @@ func run(_ *cobra.Command, _ []string) error {
 	} else {
 		log.Info("validation passed")
 	}
+}
+
+// reportJSON writes the hook decision as JSON to stdout. The process always
+// exits with ExitCodeAllow because Claude Code ignores stdout JSON otherwise.
+func reportJSON(
+	eventType hook.EventType,
+	errs []*dispatcher.ValidationError,
+	log logger.Logger,
+) error {
+	output := dispatcher.BuildHookOutput(eventType, errs)
+
+	if err := dispatcher.WriteHookOutput(os.Stdout, output); err != nil {
+		return errors.Wrap(err, "failed to write hook output")
+	}
+
+	switch {
+	case dispatcher.ShouldBlock(errs):
+		log.Error("validation denied", "errorCount", len(errs))
+	case dispatcher.ShouldAsk(errs):
+		log.Info("validation asked for approval", "errorCount", len(errs))
+	case len(errs) > 0:
+		log.Info("validation passed with warnings", "warningCount", len(errs))
+	default:
+		log.Info("validation passed")
+	}
 
 	return nil
 }
 
 // loadConfig loads configuration from all sources with precedence.
 func loadConfig(log logger.Logger) (*config.Config, error) {
+	return loadConfigForDir(log, "")
+}
+
+// loadConfigForDir loads configuration with project configs discovered from
+// workDir up to the repository root. An empty workDir uses the current directory.
+func loadConfigForDir(log logger.Logger, workDir string) (*config.Config, error) {
 	// Build flags map from CLI arguments
 	flags := buildFlagsMap()
 
 	// Create koanf loader
-	loader, err := internalconfig.NewKoanfLoader()
+	loader, err := internalconfig.NewKoanfLoaderForDir(workDir)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create config loader")
 	}
@@ -278,6 +382,94 @@ func initSessionTracker(cfg *config.Config, log logger.Logger) *session.Tracker
 	return tracker
 }
 
+// initExceptionHandler creates an exception handler if enabled in the config.
+// Rate limit state is loaded so exception quotas persist across hook invocations.
+func initExceptionHandler(cfg *config.Config, log logger.Logger) *exceptions.Handler {
+	exceptionsCfg := cfg.GetExceptions()
+	if !exceptionsCfg.IsEnabled() {
+		return nil
+	}
+
+	handler := exceptions.NewHandler(
+		exceptionsCfg,
+		exceptions.WithHandlerLogger(log),
+	)
+
+	// Load existing rate limit state
+	if err := handler.LoadState(); err != nil {
+		log.Info("failed to load exception rate limit state, starting fresh", "error", err)
+	}
+
+	log.Debug("exception handler initialized",
+		"token_prefix", exceptionsCfg.GetTokenPrefix(),
+	)
+
+	return handler
+}
+
+// newExecutor creates the validator executor selected by global.parallel_execution.
+func newExecutor(cfg *config.Config, log logger.Logger) dispatcher.Executor {
+	global := cfg.GetGlobal()
+	if !global.IsParallelExecutionEnabled() {
+		return dispatcher.NewSequentialExecutor(log)
+	}
+
+	parallelCfg := dispatcher.DefaultParallelConfig()
+
+	if global.MaxCPUWorkers != nil {
+		parallelCfg.MaxCPUWorkers = *global.MaxCPUWorkers
+	}
+
+	if global.MaxIOWorkers != nil {
+		parallelCfg.MaxIOWorkers = *global.MaxIOWorkers
+	}
+
+	if global.MaxGitWorkers != nil {
+		parallelCfg.MaxGitWorkers = *global.MaxGitWorkers
+	}
+
+	log.Debug("parallel execution enabled",
+		"max_cpu_workers", parallelCfg.MaxCPUWorkers,
+		"max_io_workers", parallelCfg.MaxIOWorkers,
+		"max_git_workers", parallelCfg.MaxGitWorkers,
+	)
+
+	return dispatcher.NewParallelExecutor(log, parallelCfg)
+}
+
+// dispatcherOptions builds dispatcher options for the enabled session and exception features.
+func dispatcherOptions(
+	cfg *config.Config,
+	log logger.Logger,
+	sessionTracker *session.Tracker,
+	exceptionHandler *exceptions.Handler,
+) []dispatcher.DispatcherOption {
+	opts := []dispatcher.DispatcherOption{
+		dispatcher.WithMaxNestingDepth(cfg.GetGlobal().GetMaxNestingDepth()),
+	}
+
+	if sessionTracker != nil {
+		opts = append(opts,
+			dispatcher.WithSessionTracker(sessionTracker),
+			dispatcher.WithSessionAuditLogger(session.NewAuditLogger(
+				cfg.GetSession().GetAudit(),
+				session.WithAuditLoggerLogger(log),
+			)),
+		)
+	}
+
+	if exceptionHandler != nil {
+		opts = append(opts, dispatcher.WithExceptionChecker(
+			dispatcher.NewExceptionChecker(
+				exceptionHandler,
+				dispatcher.WithExceptionCheckerLogger(log),
+			),
+		))
+	}
+
+	return opts
+}
+
 // buildFlagsMap converts CLI flags to a map for the config provider.
 func buildFlagsMap() map[string]any {
 	flags := make(map[string]any)
@@ -290,6 +482,10 @@ func buildFlagsMap() map[string]any {
 		flags["global_config"] = globalConfig
 	}
 
+	if outputFormat != "" {
+		flags["output-format"] = outputFormat
+	}
+
 	if len(disableList) > 0 {
 		flags["disable"] = disableList
 	}
@@ -0,0 +1,541 @@
+// Package main provides the CLI entry point for klaudiush.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/smykla-labs/klaudiush/internal/config/factory"
+	"github.com/smykla-labs/klaudiush/internal/git"
+	"github.com/smykla-labs/klaudiush/internal/validators/secrets"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// Secrets baseline audit statuses.
+const (
+	// baselineStatusKnown marks a recorded finding that is still present.
+	baselineStatusKnown = "known"
+
+	// baselineStatusChanged marks a recorded finding whose line has changed.
+	baselineStatusChanged = "changed"
+
+	// baselineStatusStale marks a recorded finding that is no longer present.
+	baselineStatusStale = "stale"
+
+	// baselineStatusNew marks a finding that is not recorded in the baseline.
+	baselineStatusNew = "new"
+)
+
+// ErrBaselineOutOfDate is returned by the audit when the baseline does not
+// match the findings in the project.
+var ErrBaselineOutOfDate = errors.New("secrets baseline is out of date")
+
+// Secrets command flags.
+var (
+	secretsBaselineForce bool
+	secretsBaselineJSON  bool
+)
+
+var secretsCmd = &cobra.Command{
+	Use:   "secrets",
+	Short: "Manage secrets detection",
+	Long: `Manage secrets detection.
+
+Subcommands:
+  baseline  Manage the baseline of known findings`,
+}
+
+var secretsBaselineCmd = &cobra.Command{
+	Use:   "baseline",
+	Short: "Manage the secrets baseline",
+	Long: `Manage the secrets baseline.
+
+The baseline records known findings, such as test fixtures, so the secrets
+validator stops reporting them. It stores hashes only, never the secrets, and
+is meant to be committed (default: .klaudiush/secrets-baseline.json).
+
+Subcommands:
+  create  Scan the project and create the baseline
+  audit   Compare the baseline with the findings in the project
+  update  Scan the project and rewrite the baseline`,
+}
+
+var secretsBaselineCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create the secrets baseline",
+	Long: `Scan the files of the project and record all findings in the baseline.
+
+In a git repository, tracked and untracked files that are not ignored are
+scanned. Review the findings before committing the baseline: every recorded
+secret stops being reported.
+
+Examples:
+  klaudiush secrets baseline create           # Create the baseline
+  klaudiush secrets baseline create --force   # Overwrite an existing baseline`,
+	RunE: runSecretsBaselineCreate,
+}
+
+var secretsBaselineAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Audit the secrets baseline",
+	Long: `Compare the baseline with the findings in the project.
+
+Each finding is reported as:
+  known    recorded and still present
+  changed  recorded, but the line holding it has changed
+  stale    recorded, but no longer present
+  new      present, but not recorded
+
+Exits with an error when there are new or stale findings.
+
+Examples:
+  klaudiush secrets baseline audit          # Show the audit
+  klaudiush secrets baseline audit --json   # Output as JSON`,
+	RunE: runSecretsBaselineAudit,
+}
+
+var secretsBaselineUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update the secrets baseline",
+	Long: `Scan the project and rewrite the baseline with the current findings.
+
+New findings are added and stale ones removed. Run "klaudiush secrets baseline
+audit" first to review what changes.
+
+Examples:
+  klaudiush secrets baseline update`,
+	RunE: runSecretsBaselineUpdate,
+}
+
+// baselineProject is the project scanned for the secrets baseline.
+type baselineProject struct {
+	root    string
+	path    string
+	cfg     *config.SecretsValidatorConfig
+	scanner *secrets.SecretsValidator
+	log     logger.Logger
+}
+
+// baselineAuditEntry is a finding reported by the baseline audit.
+type baselineAuditEntry struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Pattern string `json:"pattern"`
+	Status  string `json:"status"`
+}
+
+func init() {
+	rootCmd.AddCommand(secretsCmd)
+	secretsCmd.AddCommand(secretsBaselineCmd)
+	secretsBaselineCmd.AddCommand(secretsBaselineCreateCmd)
+	secretsBaselineCmd.AddCommand(secretsBaselineAuditCmd)
+	secretsBaselineCmd.AddCommand(secretsBaselineUpdateCmd)
+
+	secretsBaselineCreateCmd.Flags().BoolVar(
+		&secretsBaselineForce,
+		"force",
+		false,
+		"Overwrite an existing baseline",
+	)
+
+	secretsBaselineAuditCmd.Flags().BoolVar(
+		&secretsBaselineJSON,
+		"json",
+		false,
+		"Output the audit as JSON",
+	)
+}
+
+func runSecretsBaselineCreate(_ *cobra.Command, _ []string) error {
+	project, err := setupBaselineProject()
+	if err != nil {
+		return err
+	}
+
+	project.log.Info("secrets baseline create command invoked", "force", secretsBaselineForce)
+
+	if _, err := os.Stat(project.path); err == nil && !secretsBaselineForce {
+		return errors.Newf(
+			"secrets baseline %s already exists, use 'update' or --force",
+			project.relPath(),
+		)
+	}
+
+	entries, err := project.scan()
+	if err != nil {
+		return err
+	}
+
+	if err := secrets.NewBaseline(entries).Save(project.path); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Created secrets baseline %s\n", project.relPath())
+	fmt.Printf("   Findings: %d\n", len(entries))
+
+	printBaselineFindings(entries)
+
+	return nil
+}
+
+func runSecretsBaselineAudit(_ *cobra.Command, _ []string) error {
+	project, err := setupBaselineProject()
+	if err != nil {
+		return err
+	}
+
+	project.log.Info("secrets baseline audit command invoked", "json", secretsBaselineJSON)
+
+	baseline, err := project.load()
+	if err != nil {
+		return err
+	}
+
+	entries, err := project.scan()
+	if err != nil {
+		return err
+	}
+
+	audit := auditBaseline(baseline.Entries, entries)
+
+	if secretsBaselineJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(audit); err != nil {
+			return errors.Wrap(err, "encoding JSON output")
+		}
+	} else {
+		outputBaselineAudit(audit)
+	}
+
+	counts := make(map[string]int)
+	for _, entry := range audit {
+		counts[entry.Status]++
+	}
+
+	if counts[baselineStatusNew] > 0 || counts[baselineStatusStale] > 0 {
+		return errors.Wrapf(
+			ErrBaselineOutOfDate,
+			"%d new and %d stale finding(s), review them and run '%s'",
+			counts[baselineStatusNew],
+			counts[baselineStatusStale],
+			"klaudiush secrets baseline update",
+		)
+	}
+
+	return nil
+}
+
+func runSecretsBaselineUpdate(_ *cobra.Command, _ []string) error {
+	project, err := setupBaselineProject()
+	if err != nil {
+		return err
+	}
+
+	project.log.Info("secrets baseline update command invoked")
+
+	baseline, err := project.load()
+	if err != nil {
+		return err
+	}
+
+	entries, err := project.scan()
+	if err != nil {
+		return err
+	}
+
+	audit := auditBaseline(baseline.Entries, entries)
+
+	if err := secrets.NewBaseline(entries).Save(project.path); err != nil {
+		return err
+	}
+
+	var added, removed int
+
+	for _, entry := range audit {
+		switch entry.Status {
+		case baselineStatusNew:
+			added++
+		case baselineStatusStale:
+			removed++
+		}
+	}
+
+	fmt.Printf("✅ Updated secrets baseline %s\n", project.relPath())
+	fmt.Printf("   Findings: %d\n", len(entries))
+	fmt.Printf("   Added: %d\n", added)
+	fmt.Printf("   Removed: %d\n", removed)
+
+	return nil
+}
+
+// setupBaselineProject loads the configuration and finds the project root.
+func setupBaselineProject() (*baselineProject, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get home directory")
+	}
+
+	logFile := filepath.Join(homeDir, ".claude", "hooks", "dispatcher.log")
+
+	log, err := logger.NewFileLogger(logFile, false, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create logger")
+	}
+
+	cfg, err := loadAuditConfig(log)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load configuration")
+	}
+
+	var secretsCfg *config.SecretsValidatorConfig
+	if cfg.Validators != nil && cfg.Validators.Secrets != nil {
+		secretsCfg = cfg.Validators.Secrets.Secrets
+	}
+
+	root, err := projectRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	detector := factory.NewSecretsValidatorFactory(log).CreateDetector(secretsCfg)
+
+	return &baselineProject{
+		root:    root,
+		path:    filepath.Join(root, secretsCfg.GetBaselineFile()),
+		cfg:     secretsCfg,
+		scanner: secrets.NewSecretsValidator(log, detector, nil, secretsCfg, nil),
+		log:     log,
+	}, nil
+}
+
+// projectRoot returns the root of the git repository, or the working
+// directory outside of a repository.
+func projectRoot() (string, error) {
+	if repo, err := git.DiscoverRepository(); err == nil {
+		return repo.GetRoot()
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get working directory")
+	}
+
+	return cwd, nil
+}
+
+// relPath returns the baseline path relative to the project root.
+func (p *baselineProject) relPath() string {
+	if rel, err := filepath.Rel(p.root, p.path); err == nil {
+		return rel
+	}
+
+	return p.path
+}
+
+// load reads the existing baseline.
+func (p *baselineProject) load() (*secrets.Baseline, error) {
+	baseline, err := secrets.LoadBaseline(p.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errors.Newf(
+			"secrets baseline %s does not exist, run 'klaudiush secrets baseline create'",
+			p.relPath(),
+		)
+	}
+
+	return baseline, err
+}
+
+// scan returns baseline entries for all findings in the project files.
+func (p *baselineProject) scan() ([]secrets.BaselineEntry, error) {
+	files, err := p.files()
+	if err != nil {
+		return nil, err
+	}
+
+	maxSize := int64(p.cfg.GetMaxFileSize())
+
+	var entries []secrets.BaselineEntry
+
+	for _, file := range files {
+		path := filepath.Join(p.root, filepath.FromSlash(file))
+
+		info, err := os.Lstat(path)
+		if err != nil || !info.Mode().IsRegular() || info.Size() > maxSize {
+			continue
+		}
+
+		//nolint:gosec // G304: path is a project file
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s", file)
+		}
+
+		// Skip binary files
+		if bytes.IndexByte(data, 0) >= 0 {
+			continue
+		}
+
+		content := string(data)
+		entries = append(entries,
+			secrets.NewBaselineEntries(file, content, p.scanner.Scan(p.root, file, content))...)
+	}
+
+	return entries, nil
+}
+
+// files returns the slash-separated paths of the files to scan, relative to
+// the project root. The baseline itself is excluded.
+func (p *baselineProject) files() ([]string, error) {
+	var files []string
+
+	if repo, err := git.DiscoverRepository(); err == nil {
+		tracked, err := repo.GetTrackedFiles()
+		if err != nil {
+			return nil, errors.Wrap(err, "listing tracked files")
+		}
+
+		untracked, err := repo.GetUntrackedFiles()
+		if err != nil {
+			return nil, errors.Wrap(err, "listing untracked files")
+		}
+
+		files = slices.Concat(tracked, untracked)
+	} else {
+		walked, err := walkProjectFiles(p.root)
+		if err != nil {
+			return nil, err
+		}
+
+		files = walked
+	}
+
+	baseline := filepath.ToSlash(p.relPath())
+	files = slices.DeleteFunc(files, func(file string) bool { return file == baseline })
+
+	slices.Sort(files)
+
+	return slices.Compact(files), nil
+}
+
+// walkProjectFiles returns the files below root, skipping hidden directories.
+func walkProjectFiles(root string) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if path != root && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, filepath.ToSlash(rel))
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing project files")
+	}
+
+	return files, nil
+}
+
+// auditBaseline compares recorded entries with the entries of a fresh scan.
+func auditBaseline(recorded, scanned []secrets.BaselineEntry) []baselineAuditEntry {
+	audit := make([]baselineAuditEntry, 0, len(recorded)+len(scanned))
+	matched := make([]bool, len(scanned))
+
+	for _, entry := range recorded {
+		status := baselineStatusStale
+		line := entry.Line
+
+		for i, current := range scanned {
+			if matched[i] || current.File != entry.File ||
+				current.Fingerprint != entry.Fingerprint {
+				continue
+			}
+
+			matched[i] = true
+			line = current.Line
+
+			status = baselineStatusKnown
+			if current.LineHash != entry.LineHash {
+				status = baselineStatusChanged
+			}
+
+			break
+		}
+
+		audit = append(audit, baselineAuditEntry{
+			File:    entry.File,
+			Line:    line,
+			Pattern: entry.Pattern,
+			Status:  status,
+		})
+	}
+
+	for i, current := range scanned {
+		if matched[i] {
+			continue
+		}
+
+		audit = append(audit, baselineAuditEntry{
+			File:    current.File,
+			Line:    current.Line,
+			Pattern: current.Pattern,
+			Status:  baselineStatusNew,
+		})
+	}
+
+	slices.SortFunc(audit, func(a, b baselineAuditEntry) int {
+		if c := strings.Compare(a.File, b.File); c != 0 {
+			return c
+		}
+
+		return a.Line - b.Line
+	})
+
+	return audit
+}
+
+func printBaselineFindings(entries []secrets.BaselineEntry) {
+	for _, entry := range entries {
+		fmt.Printf("   %s:%d  %s\n", entry.File, entry.Line, entry.Pattern)
+	}
+}
+
+func outputBaselineAudit(audit []baselineAuditEntry) {
+	if len(audit) == 0 {
+		fmt.Println("No findings.")
+
+		return
+	}
+
+	fmt.Printf("Found %d finding(s):\n\n", len(audit))
+
+	for _, entry := range audit {
+		fmt.Printf("  %-8s %s:%d  %s\n", entry.Status, entry.File, entry.Line, entry.Pattern)
+	}
+}
@@ -0,0 +1,518 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/smykla-labs/klaudiush/internal/config/factory"
+	"github.com/smykla-labs/klaudiush/internal/dispatcher"
+	"github.com/smykla-labs/klaudiush/internal/exceptions"
+	"github.com/smykla-labs/klaudiush/internal/parser"
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// contentFilePrefix marks flag values read from a file, e.g. --content @main.go.
+const contentFilePrefix = "@"
+
+// stdinPath is the --input value that reads the payload from stdin.
+const stdinPath = "-"
+
+var (
+	testTool      string
+	testEvent     string
+	testCommand   string
+	testFile      string
+	testContent   string
+	testOldString string
+	testNewString string
+	testURL       string
+	testCwd       string
+	testSessionID string
+	testInput     string
+	testJSON      bool
+)
+
+var testCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Show what klaudiush would decide for a tool invocation",
+	Long: `Show what klaudiush would decide for a tool invocation, without running it.
+
+Builds a hook payload from flags (or replays a captured one with --input), runs
+it through the validators and rules of the current configuration, and prints
+every validator consulted, whether its predicate matched, its result, and any
+rule, exception or session effects.
+
+Session and exception state is read but never saved, so the test does not
+affect real sessions.
+
+Examples:
+  klaudiush test --command 'git push -f origin main'
+  klaudiush test --tool Write --file README.md --content @README.md
+  klaudiush test --tool Edit --file main.go --old-string foo --new-string bar
+  klaudiush test --input payload.json --json`,
+	Args: cobra.NoArgs,
+	RunE: runTest,
+}
+
+func init() {
+	rootCmd.AddCommand(testCmd)
+
+	testCmd.Flags().StringVar(
+		&testTool,
+		"tool",
+		"",
+		"Tool name, e.g. Bash, Write, Edit, WebFetch or mcp__server__tool "+
+			"(default: Bash with --command, Write with --file)",
+	)
+	testCmd.Flags().StringVar(
+		&testEvent,
+		"event",
+		hook.EventTypePreToolUse.String(),
+		"Hook event type",
+	)
+	testCmd.Flags().StringVar(&testCommand, "command", "", "Bash command")
+	testCmd.Flags().StringVar(&testFile, "file", "", "File path for Write and Edit")
+	testCmd.Flags().StringVar(
+		&testContent,
+		"content",
+		"",
+		"File content for Write, or new string for Edit (@path reads a file)",
+	)
+	testCmd.Flags().StringVar(&testOldString, "old-string", "", "String to replace for Edit")
+	testCmd.Flags().StringVar(
+		&testNewString,
+		"new-string",
+		"",
+		"Replacement string for Edit (@path reads a file)",
+	)
+	testCmd.Flags().StringVar(&testURL, "url", "", "URL for WebFetch")
+	testCmd.Flags().StringVar(
+		&testCwd,
+		"cwd",
+		"",
+		"Working directory of the session (default: current directory)",
+	)
+	testCmd.Flags().StringVar(&testSessionID, "session-id", "", "Session ID for session tracking")
+	testCmd.Flags().StringVar(
+		&testInput,
+		"input",
+		"",
+		"Replay a hook payload from a JSON file ('-' reads stdin) instead of building one",
+	)
+	testCmd.Flags().BoolVar(&testJSON, "json", false, "Output the decision as JSON")
+}
+
+// testReport is the result of klaudiush test.
+type testReport struct {
+	Event      string                      `json:"event"`
+	Tool       string                      `json:"tool"`
+	Decision   string                      `json:"decision"`
+	Validators []dispatcher.ValidatorTrace `json:"validators"`
+	Rules      []dispatcher.RuleTrace      `json:"rules"`
+	Exceptions []dispatcher.ExceptionTrace `json:"exceptions"`
+	Session    []dispatcher.SessionTrace   `json:"session"`
+	Errors     []testReportError           `json:"errors"`
+}
+
+// testReportError is a validation error of klaudiush test.
+type testReportError struct {
+	Validator string `json:"validator"`
+	Outcome   string `json:"outcome"`
+	Message   string `json:"message"`
+	Reference string `json:"reference,omitempty"`
+	FixHint   string `json:"fix_hint,omitempty"`
+}
+
+func runTest(_ *cobra.Command, _ []string) error {
+	log, err := setupDebugLogger("test", "tool", testTool)
+	if err != nil {
+		return err
+	}
+
+	payload, err := testPayload()
+	if err != nil {
+		return err
+	}
+
+	eventType, err := parseHookType(testEvent)
+	if err != nil {
+		return err
+	}
+
+	// A replayed payload carries its own event type
+	if testInput != "" {
+		eventType = hook.EventTypeUnknown
+	}
+
+	ctx, err := parser.NewJSONParser(bytes.NewReader(payload)).Parse(eventType)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse hook payload")
+	}
+
+	cfg, err := loadConfigForDir(log, ctx.Cwd)
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
+	trace := dispatcher.NewTrace()
+
+	registry, ruleEngine, err := factory.NewRegistryBuilder(log).BuildWithRuleEngine(cfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to build validator registry")
+	}
+
+	if ruleEngine != nil {
+		ruleEngine.SetMatchObserver(func(matchCtx *rules.MatchContext, result *rules.RuleResult) {
+			trace.AddRule(dispatcher.RuleTrace{
+				Rule:          result.Rule.Name,
+				ValidatorType: string(matchCtx.ValidatorType),
+				Action:        string(result.Action),
+				Message:       result.Message,
+			})
+		})
+	}
+
+	disp := dispatcher.NewDispatcherWithOptions(
+		registry,
+		log,
+		newExecutor(cfg, log),
+		testDispatcherOptions(cfg, log, trace)...,
+	)
+
+	errs := disp.Dispatch(context.Background(), ctx)
+
+	report := buildTestReport(ctx, trace, errs)
+
+	if testJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(report); err != nil {
+			return errors.Wrap(err, "encoding JSON output")
+		}
+
+		return nil
+	}
+
+	displayTestReport(ctx, report, errs)
+
+	return nil
+}
+
+// testPayload returns the hook payload to test: the --input file, or a
+// payload built from the flags.
+func testPayload() ([]byte, error) {
+	if testInput == stdinPath {
+		data, err := io.ReadAll(os.Stdin)
+
+		return data, errors.Wrap(err, "failed to read payload from stdin")
+	}
+
+	if testInput != "" {
+		data, err := os.ReadFile(testInput)
+
+		return data, errors.Wrap(err, "failed to read payload")
+	}
+
+	toolName := testTool
+
+	switch {
+	case toolName != "":
+	case testCommand != "":
+		toolName = hook.ToolTypeBash.String()
+	case testFile != "":
+		toolName = hook.ToolTypeWrite.String()
+	default:
+		return nil, errors.New("--tool, --command, --file or --input is required")
+	}
+
+	content, err := readFlagValue(testContent)
+	if err != nil {
+		return nil, err
+	}
+
+	newString, err := readFlagValue(testNewString)
+	if err != nil {
+		return nil, err
+	}
+
+	toolInput := map[string]string{}
+	setIfNotEmpty(toolInput, "command", testCommand)
+	setIfNotEmpty(toolInput, "file_path", testFile)
+	setIfNotEmpty(toolInput, "url", testURL)
+	setIfNotEmpty(toolInput, "old_string", testOldString)
+
+	// --content is the new string of an Edit
+	if toolName == hook.ToolTypeEdit.String() && newString == "" {
+		newString, content = content, ""
+	}
+
+	setIfNotEmpty(toolInput, "content", content)
+	setIfNotEmpty(toolInput, "new_string", newString)
+
+	payload := map[string]any{
+		"hook_event_name": testEvent,
+		"tool_name":       toolName,
+		"tool_input":      toolInput,
+	}
+
+	if testCwd != "" {
+		payload["cwd"] = testCwd
+	}
+
+	if testSessionID != "" {
+		payload["session_id"] = testSessionID
+	}
+
+	data, err := json.Marshal(payload)
+
+	return data, errors.Wrap(err, "failed to build payload")
+}
+
+// readFlagValue returns the flag value, or the content of the file it names
+// when prefixed with "@".
+func readFlagValue(value string) (string, error) {
+	path, ok := strings.CutPrefix(value, contentFilePrefix)
+	if !ok {
+		return value, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read %s", path)
+	}
+
+	return string(data), nil
+}
+
+func setIfNotEmpty(m map[string]string, key, value string) {
+	if value != "" {
+		m[key] = value
+	}
+}
+
+// testDispatcherOptions builds dispatcher options like the hook does, but
+// without audit logging, so testing leaves no trace in the audit logs.
+func testDispatcherOptions(
+	cfg *config.Config,
+	log logger.Logger,
+	trace *dispatcher.Trace,
+) []dispatcher.DispatcherOption {
+	opts := []dispatcher.DispatcherOption{
+		dispatcher.WithTrace(trace),
+		dispatcher.WithMaxNestingDepth(cfg.GetGlobal().GetMaxNestingDepth()),
+	}
+
+	if tracker := initSessionTracker(cfg, log); tracker != nil {
+		opts = append(opts, dispatcher.WithSessionTracker(tracker))
+	}
+
+	exceptionsCfg := cfg.GetExceptions()
+	if exceptionsCfg.IsEnabled() {
+		disabled := false
+
+		handler := exceptions.NewHandler(
+			exceptionsCfg,
+			exceptions.WithHandlerLogger(log),
+			exceptions.WithAuditLogger(exceptions.NewAuditLogger(
+				&config.ExceptionAuditConfig{Enabled: &disabled},
+			)),
+		)
+
+		if err := handler.LoadState(); err != nil {
+			log.Info("failed to load exception rate limit state, starting fresh", "error", err)
+		}
+
+		opts = append(opts, dispatcher.WithExceptionChecker(
+			dispatcher.NewExceptionChecker(
+				handler,
+				dispatcher.WithExceptionCheckerLogger(log),
+			),
+		))
+	}
+
+	return opts
+}
+
+func buildTestReport(
+	ctx *hook.Context,
+	trace *dispatcher.Trace,
+	errs []*dispatcher.ValidationError,
+) *testReport {
+	report := &testReport{
+		Event:      ctx.EventType.String(),
+		Tool:       ctx.ToolName.String(),
+		Decision:   dispatcher.Decision(errs),
+		Validators: nonNil(trace.Validators()),
+		Rules:      nonNil(trace.Rules()),
+		Exceptions: nonNil(trace.Exceptions()),
+		Session:    nonNil(trace.Session()),
+		Errors:     make([]testReportError, 0, len(errs)),
+	}
+
+	if ctx.ToolName == hook.ToolTypeMCP {
+		report.Tool = hook.MCPToolPrefix + ctx.MCPServer + "__" + ctx.MCPTool
+	}
+
+	for _, verr := range errs {
+		report.Errors = append(report.Errors, testReportError{
+			Validator: verr.Validator,
+			Outcome:   dispatcher.Decision([]*dispatcher.ValidationError{verr}),
+			Message:   verr.Message,
+			Reference: string(verr.Reference),
+			FixHint:   verr.FixHint,
+		})
+	}
+
+	return report
+}
+
+// nonNil returns an empty slice for nil, so JSON output has [] instead of null.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+
+	return s
+}
+
+func displayTestReport(
+	ctx *hook.Context,
+	report *testReport,
+	errs []*dispatcher.ValidationError,
+) {
+	fmt.Printf("Event: %s\n", report.Event)
+	fmt.Printf("Tool: %s\n", report.Tool)
+
+	if command := ctx.GetCommand(); command != "" {
+		fmt.Printf("Command: %s\n", command)
+	}
+
+	if filePath := ctx.GetFilePath(); filePath != "" {
+		fmt.Printf("File: %s\n", filePath)
+	}
+
+	fmt.Printf("Decision: %s\n", strings.ToUpper(report.Decision))
+	fmt.Println("")
+
+	displayTestValidators(report.Validators)
+	displayTestRules(report.Rules)
+	displayTestExceptions(report.Exceptions)
+	displayTestSession(report.Session)
+
+	if len(errs) > 0 {
+		fmt.Println("Output")
+		fmt.Println("------")
+		fmt.Print(strings.TrimPrefix(dispatcher.FormatErrors(errs), "\n"))
+	}
+}
+
+// displayTestValidators lists the validators per context: the hook context
+// first, then each synthetic Write of a Bash file write.
+func displayTestValidators(validators []dispatcher.ValidatorTrace) {
+	nameWidth := 0
+
+	for _, entry := range validators {
+		nameWidth = max(nameWidth, len(entry.Validator))
+	}
+
+	target := ""
+
+	for i, entry := range validators {
+		if i == 0 || entry.Target != target {
+			target = entry.Target
+
+			header := "Validators"
+			if target != "" {
+				header = "Validators (write to " + target + ")"
+			}
+
+			fmt.Println(header)
+			fmt.Println(strings.Repeat("-", len(header)))
+		}
+
+		if !entry.Matched {
+			fmt.Printf("  %-*s  no match\n", nameWidth, entry.Validator)
+		} else {
+			line := fmt.Sprintf("  %-*s  matched   %-5s  %s",
+				nameWidth, entry.Validator, entry.Outcome, firstLine(entry.Message))
+			fmt.Println(strings.TrimRight(line, " "))
+		}
+
+		if i == len(validators)-1 || validators[i+1].Target != target {
+			fmt.Println("")
+		}
+	}
+}
+
+func displayTestRules(matched []dispatcher.RuleTrace) {
+	fmt.Println("Rules")
+	fmt.Println("-----")
+
+	if len(matched) == 0 {
+		fmt.Println("  No rules matched")
+	}
+
+	for _, rule := range matched {
+		fmt.Printf("  %s (%s)  %s  %s\n", rule.Rule, rule.ValidatorType, rule.Action, rule.Message)
+	}
+
+	fmt.Println("")
+}
+
+func displayTestExceptions(checks []dispatcher.ExceptionTrace) {
+	if len(checks) == 0 {
+		return
+	}
+
+	fmt.Println("Exceptions")
+	fmt.Println("----------")
+
+	for _, check := range checks {
+		status := "not bypassed"
+		if check.Bypassed {
+			status = "bypassed"
+		}
+
+		fmt.Printf("  %s  %s  %s\n", check.Validator, check.Reference, status)
+	}
+
+	fmt.Println("")
+}
+
+func displayTestSession(effects []dispatcher.SessionTrace) {
+	if len(effects) == 0 {
+		return
+	}
+
+	fmt.Println("Session")
+	fmt.Println("-------")
+
+	for _, effect := range effects {
+		if len(effect.Codes) > 0 {
+			fmt.Printf("  %s  %s\n", effect.Effect, strings.Join(effect.Codes, ", "))
+		} else {
+			fmt.Printf("  %s\n", effect.Effect)
+		}
+	}
+
+	fmt.Println("")
+}
+
+// firstLine returns the first line of a multi-line message.
+func firstLine(message string) string {
+	line, _, _ := strings.Cut(message, "\n")
+
+	return line
+}
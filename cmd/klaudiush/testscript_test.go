@@ -8,6 +8,7 @@ import (
 	"github.com/rogpeppe/go-internal/testscript"
 
 	gitpkg "github.com/smykla-labs/klaudiush/internal/git"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
 )
 
 func TestMain(m *testing.M) {
@@ -24,6 +25,7 @@ func mainFunc() {
 	traceMode = false
 	configPath = ""
 	globalConfig = ""
+	outputFormat = ""
 	disableList = []string{}
 	globalFlag = false
 	forceFlag = false
@@ -32,6 +34,22 @@ func mainFunc() {
 	fixFlag = false
 	categoryFlag = []string{}
 	validatorFilter = ""
+	explainConfig = false
+	explainJSON = false
+	testTool = ""
+	testEvent = hook.EventTypePreToolUse.String()
+	testCommand = ""
+	testFile = ""
+	testContent = ""
+	testOldString = ""
+	testNewString = ""
+	testURL = ""
+	testCwd = ""
+	testSessionID = ""
+	testInput = ""
+	testJSON = false
+	secretsBaselineForce = false
+	secretsBaselineJSON = false
 
 	// Reset git repository cache so each test discovers its own repo
 	gitpkg.ResetRepositoryCache()
@@ -87,9 +105,37 @@ func TestScriptMarkdown(t *testing.T) {
 	})
 }
 
+func TestScriptLifecycle(t *testing.T) {
+	testscript.Run(t, testscript.Params{
+		Dir:   "testdata/scripts/lifecycle",
+		Setup: setupTestEnv,
+	})
+}
+
+func TestScriptPost(t *testing.T) {
+	testscript.Run(t, testscript.Params{
+		Dir:   "testdata/scripts/post",
+		Setup: setupTestEnv,
+	})
+}
+
 func TestScriptDebug(t *testing.T) {
 	testscript.Run(t, testscript.Params{
 		Dir:   "testdata/scripts/debug",
 		Setup: setupTestEnv,
 	})
 }
+
+func TestScriptSecrets(t *testing.T) {
+	testscript.Run(t, testscript.Params{
+		Dir:   "testdata/scripts/secrets",
+		Setup: setupTestEnv,
+	})
+}
+
+func TestScriptTest(t *testing.T) {
+	testscript.Run(t, testscript.Params{
+		Dir:   "testdata/scripts/test",
+		Setup: setupTestEnv,
+	})
+}
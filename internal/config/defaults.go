@@ -2,9 +2,11 @@
 package config
 
 import (
+	"slices"
 	"time"
 
 	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
 )
 
 const (
@@ -41,10 +43,12 @@ func DefaultRulesConfig() *config.RulesConfig {
 // DefaultGlobalConfig returns the default global configuration.
 func DefaultGlobalConfig() *config.GlobalConfig {
 	useSDKGit := true
+	maxNestingDepth := parser.DefaultMaxNestingDepth
 
 	return &config.GlobalConfig{
-		UseSDKGit:      &useSDKGit,
-		DefaultTimeout: config.Duration(DefaultTimeout),
+		UseSDKGit:       &useSDKGit,
+		DefaultTimeout:  config.Duration(DefaultTimeout),
+		MaxNestingDepth: &maxNestingDepth,
 	}
 }
 
@@ -55,19 +59,22 @@ func DefaultValidatorsConfig() *config.ValidatorsConfig {
 		GitHub:       DefaultGitHubConfig(),
 		File:         DefaultFileConfig(),
 		Notification: DefaultNotificationConfig(),
+		Post:         DefaultPostConfig(),
+		Shell:        DefaultShellConfig(),
 	}
 }
 
 // DefaultGitConfig returns the default git validators configuration.
 func DefaultGitConfig() *config.GitConfig {
 	return &config.GitConfig{
-		Commit:   DefaultCommitValidatorConfig(),
-		Push:     DefaultPushValidatorConfig(),
-		Fetch:    DefaultFetchValidatorConfig(),
-		Add:      DefaultAddValidatorConfig(),
-		PR:       DefaultPRValidatorConfig(),
-		Branch:   DefaultBranchValidatorConfig(),
-		NoVerify: DefaultNoVerifyValidatorConfig(),
+		Commit:      DefaultCommitValidatorConfig(),
+		Push:        DefaultPushValidatorConfig(),
+		Fetch:       DefaultFetchValidatorConfig(),
+		Add:         DefaultAddValidatorConfig(),
+		PR:          DefaultPRValidatorConfig(),
+		Branch:      DefaultBranchValidatorConfig(),
+		NoVerify:    DefaultNoVerifyValidatorConfig(),
+		Destructive: DefaultDestructiveValidatorConfig(),
 	}
 }
 
@@ -102,12 +109,106 @@ func DefaultIssueValidatorConfig() *config.IssueValidatorConfig {
 // DefaultFileConfig returns the default file validators configuration.
 func DefaultFileConfig() *config.FileConfig {
 	return &config.FileConfig{
-		Markdown:    DefaultMarkdownValidatorConfig(),
-		ShellScript: DefaultShellScriptValidatorConfig(),
-		Terraform:   DefaultTerraformValidatorConfig(),
-		Workflow:    DefaultWorkflowValidatorConfig(),
-		Python:      DefaultPythonValidatorConfig(),
-		JavaScript:  DefaultJavaScriptValidatorConfig(),
+		Markdown:       DefaultMarkdownValidatorConfig(),
+		ShellScript:    DefaultShellScriptValidatorConfig(),
+		Terraform:      DefaultTerraformValidatorConfig(),
+		Workflow:       DefaultWorkflowValidatorConfig(),
+		Python:         DefaultPythonValidatorConfig(),
+		JavaScript:     DefaultJavaScriptValidatorConfig(),
+		SensitiveRead:  DefaultSensitiveReadValidatorConfig(),
+		ProtectedPaths: DefaultProtectedPathsValidatorConfig(),
+	}
+}
+
+// DefaultSensitiveReadValidatorConfig returns the default sensitive file read
+// validator configuration.
+func DefaultSensitiveReadValidatorConfig() *config.SensitiveReadValidatorConfig {
+	enabled := true
+
+	return &config.SensitiveReadValidatorConfig{
+		ValidatorConfig: config.ValidatorConfig{
+			Enabled:  &enabled,
+			Severity: config.SeverityError,
+		},
+		Patterns: []string{
+			".env",
+			".env.*",
+			"*.pem",
+			"*.key",
+			"*.p12",
+			"*.pfx",
+			"id_rsa",
+			"id_dsa",
+			"id_ecdsa",
+			"id_ed25519",
+			".netrc",
+			".pgpass",
+			".git-credentials",
+			"~/.ssh/**",
+			"~/.aws/credentials",
+			"~/.config/gcloud/**",
+			"~/.azure/**",
+			"~/.kube/config",
+			"~/.docker/config.json",
+			"~/.gnupg/**",
+			"~/.npmrc",
+			"~/.pypirc",
+		},
+		Allow: []string{
+			".env.example",
+			".env.sample",
+			".env.template",
+			"*.pub",
+			"~/.ssh/config",
+			"~/.ssh/known_hosts",
+		},
+		Action: config.SensitiveReadActionBlock,
+	}
+}
+
+// DefaultProtectedPathsValidatorConfig returns the default protected paths
+// validator configuration.
+func DefaultProtectedPathsValidatorConfig() *config.ProtectedPathsValidatorConfig {
+	enabled := false
+
+	return &config.ProtectedPathsValidatorConfig{
+		ValidatorConfig: config.ValidatorConfig{
+			Enabled:  &enabled,
+			Severity: config.SeverityError,
+		},
+		Paths: []config.ProtectedPathConfig{
+			{
+				Pattern:        "/tmp",
+				Action:         config.ProtectedPathActionBlock,
+				ReplacementDir: "tmp",
+			},
+			{
+				Pattern:        "/var/tmp",
+				Action:         config.ProtectedPathActionBlock,
+				ReplacementDir: "tmp",
+			},
+		},
+	}
+}
+
+// DefaultShellConfig returns the default shell validators configuration.
+func DefaultShellConfig() *config.ShellConfig {
+	return &config.ShellConfig{
+		Destructive: DefaultShellDestructiveValidatorConfig(),
+	}
+}
+
+// DefaultShellDestructiveValidatorConfig returns the default destructive shell command
+// validator configuration.
+func DefaultShellDestructiveValidatorConfig() *config.ShellDestructiveValidatorConfig {
+	enabled := true
+
+	return &config.ShellDestructiveValidatorConfig{
+		ValidatorConfig: config.ValidatorConfig{
+			Enabled:  &enabled,
+			Severity: config.SeverityError,
+		},
+		SafeTargets: slices.Clone(config.DefaultShellSafeTargets),
 	}
 }
 
@@ -118,19 +219,29 @@ func DefaultNotificationConfig() *config.NotificationConfig {
 	}
 }
 
+// DefaultPostConfig returns the default post validators configuration.
+func DefaultPostConfig() *config.PostConfig {
+	return &config.PostConfig{
+		FileLint:      DefaultFileLintValidatorConfig(),
+		CommitSignoff: DefaultCommitSignoffValidatorConfig(),
+	}
+}
+
 // DefaultCommitValidatorConfig returns the default commit validator configuration.
 func DefaultCommitValidatorConfig() *config.CommitValidatorConfig {
 	enabled := true
 	checkStagingArea := true
+	checkStagedChanges := true
 
 	return &config.CommitValidatorConfig{
 		ValidatorConfig: config.ValidatorConfig{
 			Enabled:  &enabled,
 			Severity: config.SeverityError,
 		},
-		RequiredFlags:    []string{"-s", "-S"},
-		CheckStagingArea: &checkStagingArea,
-		Message:          DefaultCommitMessageConfig(),
+		RequiredFlags:      []string{"-s", "-S"},
+		CheckStagingArea:   &checkStagingArea,
+		CheckStagedChanges: &checkStagedChanges,
+		Message:            DefaultCommitMessageConfig(),
 	}
 }
 
@@ -177,14 +288,17 @@ func DefaultCommitMessageConfig() *config.CommitMessageConfig {
 func DefaultPushValidatorConfig() *config.PushValidatorConfig {
 	enabled := true
 	requireTracking := true
+	requireForceWithLease := true
 
 	return &config.PushValidatorConfig{
 		ValidatorConfig: config.ValidatorConfig{
 			Enabled:  &enabled,
 			Severity: config.SeverityError,
 		},
-		BlockedRemotes:  []string{},
-		RequireTracking: &requireTracking,
+		BlockedRemotes:        []string{},
+		RequireTracking:       &requireTracking,
+		ProtectedBranches:     []string{"main", "master"},
+		RequireForceWithLease: &requireForceWithLease,
 	}
 }
 
@@ -290,6 +404,19 @@ func DefaultNoVerifyValidatorConfig() *config.NoVerifyValidatorConfig {
 	}
 }
 
+// DefaultDestructiveValidatorConfig returns the default destructive git operation
+// validator configuration.
+func DefaultDestructiveValidatorConfig() *config.DestructiveValidatorConfig {
+	enabled := true
+
+	return &config.DestructiveValidatorConfig{
+		ValidatorConfig: config.ValidatorConfig{
+			Enabled:  &enabled,
+			Severity: config.SeverityError,
+		},
+	}
+}
+
 // DefaultMarkdownValidatorConfig returns the default markdown validator configuration.
 func DefaultMarkdownValidatorConfig() *config.MarkdownValidatorConfig {
 	enabled := true
@@ -435,3 +562,28 @@ func DefaultBellValidatorConfig() *config.BellValidatorConfig {
 		CustomCommand: "",
 	}
 }
+
+// DefaultFileLintValidatorConfig returns the default post-edit file lint validator configuration.
+func DefaultFileLintValidatorConfig() *config.FileLintValidatorConfig {
+	enabled := true
+
+	return &config.FileLintValidatorConfig{
+		ValidatorConfig: config.ValidatorConfig{
+			Enabled:  &enabled,
+			Severity: config.SeverityError,
+		},
+	}
+}
+
+// DefaultCommitSignoffValidatorConfig returns the default post-commit signoff validator configuration.
+func DefaultCommitSignoffValidatorConfig() *config.CommitSignoffValidatorConfig {
+	enabled := true
+
+	return &config.CommitSignoffValidatorConfig{
+		ValidatorConfig: config.ValidatorConfig{
+			Enabled:  &enabled,
+			Severity: config.SeverityError,
+		},
+		ExpectedSignoff: "",
+	}
+}
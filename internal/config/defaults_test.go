@@ -107,6 +107,17 @@ var _ = Describe("Defaults", func() {
 		})
 	})
 
+	Describe("DefaultPostConfig", func() {
+		It("should return post config with enabled validators", func() {
+			cfg := DefaultPostConfig()
+			Expect(cfg).NotTo(BeNil())
+			Expect(cfg.FileLint).NotTo(BeNil())
+			Expect(cfg.FileLint.IsEnabled()).To(BeTrue())
+			Expect(cfg.CommitSignoff).NotTo(BeNil())
+			Expect(cfg.CommitSignoff.IsEnabled()).To(BeTrue())
+		})
+	})
+
 	Describe("DefaultCommitValidatorConfig", func() {
 		It("should return commit validator config with correct defaults", func() {
 			cfg := DefaultCommitValidatorConfig()
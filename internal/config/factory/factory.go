@@ -6,6 +6,7 @@ import (
 	"github.com/smykla-labs/klaudiush/internal/validator"
 	"github.com/smykla-labs/klaudiush/pkg/config"
 	"github.com/smykla-labs/klaudiush/pkg/logger"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
 )
 
 // ValidatorWithPredicate pairs a validator with its registration predicate.
@@ -37,6 +38,15 @@ type ValidatorFactory interface {
 	// CreateShellValidators creates all shell validators from config.
 	CreateShellValidators(cfg *config.Config) []ValidatorWithPredicate
 
+	// CreateLifecycleValidators creates all lifecycle event validators from config.
+	CreateLifecycleValidators(cfg *config.Config) []ValidatorWithPredicate
+
+	// CreateToolValidators creates all MCP, web, task, notebook and todo tool validators from config.
+	CreateToolValidators(cfg *config.Config) []ValidatorWithPredicate
+
+	// CreatePostValidators creates all PostToolUse validators from config.
+	CreatePostValidators(cfg *config.Config) []ValidatorWithPredicate
+
 	// CreatePluginValidators creates all plugin validators from config.
 	CreatePluginValidators(cfg *config.Config) []ValidatorWithPredicate
 
@@ -52,77 +62,112 @@ type DefaultValidatorFactory struct {
 	notificationFactory *NotificationValidatorFactory
 	secretsFactory      *SecretsValidatorFactory
 	shellFactory        *ShellValidatorFactory
+	lifecycleFactory    *LifecycleValidatorFactory
+	toolFactory         *ToolValidatorFactory
+	postFactory         *PostValidatorFactory
 	pluginFactory       *PluginValidatorFactory
 }
 
 // NewValidatorFactory creates a new DefaultValidatorFactory.
 func NewValidatorFactory(log logger.Logger) *DefaultValidatorFactory {
+	fileFactory := NewFileValidatorFactory(log)
+
 	return &DefaultValidatorFactory{
 		gitFactory:          NewGitValidatorFactory(log),
 		githubFactory:       NewGitHubValidatorFactory(log),
-		fileFactory:         NewFileValidatorFactory(log),
+		fileFactory:         fileFactory,
 		notificationFactory: NewNotificationValidatorFactory(log),
 		secretsFactory:      NewSecretsValidatorFactory(log),
 		shellFactory:        NewShellValidatorFactory(log),
+		lifecycleFactory:    NewLifecycleValidatorFactory(log),
+		toolFactory:         NewToolValidatorFactory(log),
+		postFactory:         NewPostValidatorFactory(log, fileFactory),
 		pluginFactory:       NewPluginValidatorFactory(log),
 	}
 }
 
 // SetRuleEngine sets the rule engine for all factories.
 func (f *DefaultValidatorFactory) SetRuleEngine(engine *rules.RuleEngine) {
+	if engine != nil {
+		engine.SetGitContextProvider(f.gitFactory.GitContextProvider())
+	}
+
 	f.gitFactory.SetRuleEngine(engine)
 	f.githubFactory.SetRuleEngine(engine)
 	f.fileFactory.SetRuleEngine(engine)
 	f.notificationFactory.SetRuleEngine(engine)
 	f.secretsFactory.SetRuleEngine(engine)
 	f.shellFactory.SetRuleEngine(engine)
+	f.lifecycleFactory.SetRuleEngine(engine)
+	f.toolFactory.SetRuleEngine(engine)
 }
 
 // CreateGitValidators creates all git validators from config.
 func (f *DefaultValidatorFactory) CreateGitValidators(cfg *config.Config) []ValidatorWithPredicate {
-	return f.gitFactory.CreateValidators(cfg)
+	return withNestingDepth(cfg, f.gitFactory.CreateValidators(cfg))
 }
 
 // CreateGitHubValidators creates all GitHub CLI validators from config.
 func (f *DefaultValidatorFactory) CreateGitHubValidators(
 	cfg *config.Config,
 ) []ValidatorWithPredicate {
-	return f.githubFactory.CreateValidators(cfg)
+	return withNestingDepth(cfg, f.githubFactory.CreateValidators(cfg))
 }
 
 // CreateFileValidators creates all file validators from config.
 func (f *DefaultValidatorFactory) CreateFileValidators(
 	cfg *config.Config,
 ) []ValidatorWithPredicate {
-	return f.fileFactory.CreateValidators(cfg)
+	return withNestingDepth(cfg, f.fileFactory.CreateValidators(cfg))
 }
 
 // CreateNotificationValidators creates all notification validators from config.
 func (f *DefaultValidatorFactory) CreateNotificationValidators(
 	cfg *config.Config,
 ) []ValidatorWithPredicate {
-	return f.notificationFactory.CreateValidators(cfg)
+	return withNestingDepth(cfg, f.notificationFactory.CreateValidators(cfg))
 }
 
 // CreateSecretsValidators creates all secrets validators from config.
 func (f *DefaultValidatorFactory) CreateSecretsValidators(
 	cfg *config.Config,
 ) []ValidatorWithPredicate {
-	return f.secretsFactory.CreateValidators(cfg)
+	return withNestingDepth(cfg, f.secretsFactory.CreateValidators(cfg))
 }
 
 // CreateShellValidators creates all shell validators from config.
 func (f *DefaultValidatorFactory) CreateShellValidators(
 	cfg *config.Config,
 ) []ValidatorWithPredicate {
-	return f.shellFactory.CreateValidators(cfg)
+	return withNestingDepth(cfg, f.shellFactory.CreateValidators(cfg))
+}
+
+// CreateLifecycleValidators creates all lifecycle event validators from config.
+func (f *DefaultValidatorFactory) CreateLifecycleValidators(
+	cfg *config.Config,
+) []ValidatorWithPredicate {
+	return withNestingDepth(cfg, f.lifecycleFactory.CreateValidators(cfg))
+}
+
+// CreateToolValidators creates all MCP, web, task, notebook and todo tool validators from config.
+func (f *DefaultValidatorFactory) CreateToolValidators(
+	cfg *config.Config,
+) []ValidatorWithPredicate {
+	return withNestingDepth(cfg, f.toolFactory.CreateValidators(cfg))
+}
+
+// CreatePostValidators creates all PostToolUse validators from config.
+func (f *DefaultValidatorFactory) CreatePostValidators(
+	cfg *config.Config,
+) []ValidatorWithPredicate {
+	return withNestingDepth(cfg, f.postFactory.CreateValidators(cfg))
 }
 
 // CreatePluginValidators creates all plugin validators from config.
 func (f *DefaultValidatorFactory) CreatePluginValidators(
 	cfg *config.Config,
 ) []ValidatorWithPredicate {
-	return f.pluginFactory.CreateValidators(cfg)
+	return withNestingDepth(cfg, f.pluginFactory.CreateValidators(cfg))
 }
 
 // CreateAll creates all validators from config.
@@ -135,7 +180,41 @@ func (f *DefaultValidatorFactory) CreateAll(cfg *config.Config) []ValidatorWithP
 	all = append(all, f.CreateNotificationValidators(cfg)...)
 	all = append(all, f.CreateSecretsValidators(cfg)...)
 	all = append(all, f.CreateShellValidators(cfg)...)
+	all = append(all, f.CreateLifecycleValidators(cfg)...)
+	all = append(all, f.CreateToolValidators(cfg)...)
+	all = append(all, f.CreatePostValidators(cfg)...)
 	all = append(all, f.CreatePluginValidators(cfg)...)
 
 	return all
 }
+
+// nestingDepthSetter is implemented by validators that parse Bash commands,
+// see validator.BaseValidator.
+type nestingDepthSetter interface {
+	SetMaxNestingDepth(depth int)
+}
+
+// withNestingDepth makes the validators unwrap up to global.max_nesting_depth
+// wrapper levels when parsing commands.
+func withNestingDepth(
+	cfg *config.Config,
+	validators []ValidatorWithPredicate,
+) []ValidatorWithPredicate {
+	depth := cfg.GetGlobal().GetMaxNestingDepth()
+
+	for _, vp := range validators {
+		if v, ok := vp.Validator.(nestingDepthSetter); ok {
+			v.SetMaxNestingDepth(depth)
+		}
+	}
+
+	return validators
+}
+
+// bashPredicates returns the predicates parsing commands with the configured
+// global.max_nesting_depth.
+func bashPredicates(cfg *config.Config) *validator.BashPredicates {
+	return validator.NewBashPredicates(
+		parser.WithMaxNestingDepth(cfg.GetGlobal().GetMaxNestingDepth()),
+	)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/smykla-labs/klaudiush/internal/config/factory"
 	"github.com/smykla-labs/klaudiush/internal/rules"
 	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
 	"github.com/smykla-labs/klaudiush/pkg/logger"
 )
 
@@ -404,6 +405,196 @@ var _ = Describe("DefaultValidatorFactory", func() {
 		})
 	})
 
+	Describe("CreateLifecycleValidators", func() {
+		It("should not create validators without a rule engine", func() {
+			validators := validatorFactory.CreateLifecycleValidators(&config.Config{})
+			Expect(validators).To(BeEmpty())
+		})
+
+		It("should create a lifecycle validator for lifecycle events", func() {
+			engine, err := rules.NewRuleEngine([]*rules.Rule{
+				{
+					Name:    "block-stop",
+					Enabled: true,
+					Match:   &rules.RuleMatch{EventType: "Stop"},
+					Action:  &rules.RuleAction{Type: rules.ActionBlock},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			validatorFactory.SetRuleEngine(engine)
+
+			validators := validatorFactory.CreateLifecycleValidators(&config.Config{})
+			Expect(validators).To(HaveLen(1))
+			Expect(validators[0].Predicate(&hook.Context{EventType: hook.EventTypeStop})).
+				To(BeTrue())
+			Expect(validators[0].Predicate(&hook.Context{EventType: hook.EventTypePreToolUse})).
+				To(BeFalse())
+		})
+	})
+
+	Describe("CreateToolValidators", func() {
+		It("should not create validators without a rule engine", func() {
+			validators := validatorFactory.CreateToolValidators(&config.Config{})
+			Expect(validators).To(BeEmpty())
+		})
+
+		It("should create a tool use validator for MCP and web tools", func() {
+			engine, err := rules.NewRuleEngine([]*rules.Rule{
+				{
+					Name:    "block-github-mcp",
+					Enabled: true,
+					Match:   &rules.RuleMatch{MCPServer: "github"},
+					Action:  &rules.RuleAction{Type: rules.ActionBlock},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			validatorFactory.SetRuleEngine(engine)
+
+			validators := validatorFactory.CreateToolValidators(&config.Config{})
+			Expect(validators).To(HaveLen(1))
+			Expect(validators[0].Predicate(&hook.Context{
+				EventType: hook.EventTypePreToolUse,
+				ToolName:  hook.ToolTypeMCP,
+			})).To(BeTrue())
+			Expect(validators[0].Predicate(&hook.Context{
+				EventType: hook.EventTypePreToolUse,
+				ToolName:  hook.ToolTypeWebFetch,
+			})).To(BeTrue())
+			Expect(validators[0].Predicate(&hook.Context{
+				EventType: hook.EventTypePreToolUse,
+				ToolName:  hook.ToolTypeBash,
+			})).To(BeFalse())
+		})
+	})
+
+	Describe("CreatePostValidators", func() {
+		It("should not create validators without post config", func() {
+			cfg := &config.Config{Validators: &config.ValidatorsConfig{}}
+
+			validators := validatorFactory.CreatePostValidators(cfg)
+			Expect(validators).To(BeEmpty())
+		})
+
+		It("should wrap file validators for PostToolUse events", func() {
+			cfg := &config.Config{
+				Validators: &config.ValidatorsConfig{
+					File: &config.FileConfig{
+						Markdown: &config.MarkdownValidatorConfig{},
+					},
+					Post: &config.PostConfig{
+						FileLint: &config.FileLintValidatorConfig{},
+					},
+				},
+			}
+
+			validators := validatorFactory.CreatePostValidators(cfg)
+			Expect(validators).To(HaveLen(1))
+			Expect(validators[0].Validator.Name()).To(Equal("post-validate-markdown"))
+
+			postCtx := &hook.Context{
+				EventType: hook.EventTypePostToolUse,
+				ToolName:  hook.ToolTypeEdit,
+				ToolInput: hook.ToolInput{FilePath: "README.md"},
+			}
+			Expect(validators[0].Predicate(postCtx)).To(BeTrue())
+
+			preCtx := *postCtx
+			preCtx.EventType = hook.EventTypePreToolUse
+			Expect(validators[0].Predicate(&preCtx)).To(BeFalse())
+		})
+
+		It("should not wrap file policy validators", func() {
+			cfg := &config.Config{
+				Validators: &config.ValidatorsConfig{
+					File: &config.FileConfig{
+						Markdown:       &config.MarkdownValidatorConfig{},
+						SensitiveRead:  &config.SensitiveReadValidatorConfig{},
+						ProtectedPaths: &config.ProtectedPathsValidatorConfig{},
+					},
+					Post: &config.PostConfig{
+						FileLint: &config.FileLintValidatorConfig{},
+					},
+				},
+			}
+
+			validators := validatorFactory.CreatePostValidators(cfg)
+			Expect(validators).To(HaveLen(1))
+			Expect(validators[0].Validator.Name()).To(Equal("post-validate-markdown"))
+		})
+
+		It("should create the commit signoff validator for successful commits", func() {
+			cfg := &config.Config{
+				Validators: &config.ValidatorsConfig{
+					Git: &config.GitConfig{
+						Commit: &config.CommitValidatorConfig{},
+					},
+					Post: &config.PostConfig{
+						CommitSignoff: &config.CommitSignoffValidatorConfig{},
+					},
+				},
+			}
+
+			validators := validatorFactory.CreatePostValidators(cfg)
+			Expect(validators).To(HaveLen(1))
+
+			commitCtx := &hook.Context{
+				EventType:    hook.EventTypePostToolUse,
+				ToolName:     hook.ToolTypeBash,
+				ToolInput:    hook.ToolInput{Command: `git commit -m "feat: add"`},
+				ToolResponse: &hook.ToolResponse{},
+			}
+			Expect(validators[0].Predicate(commitCtx)).To(BeTrue())
+
+			commitCtx.ToolResponse.Interrupted = true
+			Expect(validators[0].Predicate(commitCtx)).To(BeFalse())
+		})
+
+		It("should skip the commit signoff validator when commits need no signoff", func() {
+			cfg := &config.Config{
+				Validators: &config.ValidatorsConfig{
+					Post: &config.PostConfig{
+						CommitSignoff: &config.CommitSignoffValidatorConfig{},
+					},
+				},
+			}
+
+			Expect(validatorFactory.CreatePostValidators(cfg)).To(BeEmpty())
+
+			cfg.Validators.Git = &config.GitConfig{
+				Commit: &config.CommitValidatorConfig{
+					ValidatorConfig: config.ValidatorConfig{Enabled: ptrBool(false)},
+				},
+			}
+			Expect(validatorFactory.CreatePostValidators(cfg)).To(BeEmpty())
+
+			cfg.Validators.Git.Commit = &config.CommitValidatorConfig{RequiredFlags: []string{"-S"}}
+			Expect(validatorFactory.CreatePostValidators(cfg)).To(BeEmpty())
+
+			cfg.Validators.Git.Commit.RequiredFlags = []string{"--signoff"}
+			Expect(validatorFactory.CreatePostValidators(cfg)).To(HaveLen(1))
+		})
+
+		It("should skip disabled post validators", func() {
+			cfg := &config.Config{
+				Validators: &config.ValidatorsConfig{
+					Post: &config.PostConfig{
+						FileLint: &config.FileLintValidatorConfig{
+							ValidatorConfig: config.ValidatorConfig{Enabled: ptrBool(false)},
+						},
+						CommitSignoff: &config.CommitSignoffValidatorConfig{
+							ValidatorConfig: config.ValidatorConfig{Enabled: ptrBool(false)},
+						},
+					},
+				},
+			}
+
+			validators := validatorFactory.CreatePostValidators(cfg)
+			Expect(validators).To(BeEmpty())
+		})
+	})
+
 	Describe("CreateSecretsValidators", func() {
 		It("should create secrets validator when enabled", func() {
 			cfg := &config.Config{
@@ -37,16 +37,18 @@ func (f *FileValidatorFactory) SetRuleEngine(engine *rules.RuleEngine) {
 
 // CreateValidators creates all file validators based on configuration.
 func (f *FileValidatorFactory) CreateValidators(cfg *config.Config) []ValidatorWithPredicate {
-	var validators []ValidatorWithPredicate
+	return append(f.CreateLinterValidators(cfg), f.createPolicyValidators(cfg)...)
+}
 
-	// Determine timeout from config or use default
-	timeout := DefaultLinterTimeout
-	if cfg.Global != nil && cfg.Global.DefaultTimeout.ToDuration() > 0 {
-		timeout = cfg.Global.DefaultTimeout.ToDuration()
-	}
+// CreateLinterValidators creates the file validators that lint file content.
+// Unlike the policy validators, they can also check files after they are written.
+func (f *FileValidatorFactory) CreateLinterValidators(
+	cfg *config.Config,
+) []ValidatorWithPredicate {
+	var validators []ValidatorWithPredicate
 
 	// Initialize linters
-	runner := execpkg.NewCommandRunner(timeout)
+	runner := execpkg.NewCommandRunner(linterTimeout(cfg))
 	shellChecker := linters.NewShellChecker(runner)
 	terraformFormatter := linters.NewTerraformFormatter(runner)
 	tfLinter := linters.NewTfLinter(runner)
@@ -115,6 +117,41 @@ func (f *FileValidatorFactory) CreateValidators(cfg *config.Config) []ValidatorW
 	return validators
 }
 
+// createPolicyValidators creates the file validators that decide whether a
+// file may be read or written at all.
+func (f *FileValidatorFactory) createPolicyValidators(
+	cfg *config.Config,
+) []ValidatorWithPredicate {
+	var validators []ValidatorWithPredicate
+
+	if cfg.Validators.File.SensitiveRead != nil &&
+		cfg.Validators.File.SensitiveRead.IsEnabled() {
+		validators = append(
+			validators,
+			f.createSensitiveReadValidator(cfg.Validators.File.SensitiveRead),
+		)
+	}
+
+	if cfg.Validators.File.ProtectedPaths != nil &&
+		cfg.Validators.File.ProtectedPaths.IsEnabled() {
+		validators = append(
+			validators,
+			f.createProtectedPathsValidator(cfg.Validators.File.ProtectedPaths),
+		)
+	}
+
+	return validators
+}
+
+// linterTimeout returns the timeout for external commands from config or the default.
+func linterTimeout(cfg *config.Config) time.Duration {
+	if cfg.Global != nil && cfg.Global.DefaultTimeout.ToDuration() > 0 {
+		return cfg.Global.DefaultTimeout.ToDuration()
+	}
+
+	return DefaultLinterTimeout
+}
+
 func (f *FileValidatorFactory) createMarkdownValidator(
 	cfg *config.MarkdownValidatorConfig,
 	linter linters.MarkdownLinter,
@@ -319,3 +356,57 @@ func (f *FileValidatorFactory) createRustValidator(
 		),
 	}
 }
+
+func (f *FileValidatorFactory) createSensitiveReadValidator(
+	cfg *config.SensitiveReadValidatorConfig,
+) ValidatorWithPredicate {
+	var ruleAdapter *rules.RuleValidatorAdapter
+	if f.ruleEngine != nil {
+		ruleAdapter = rules.NewRuleValidatorAdapter(
+			f.ruleEngine,
+			rules.ValidatorFileSensitiveRead,
+			rules.WithAdapterLogger(f.log),
+		)
+	}
+
+	// Bash commands are parsed by the validator to find the files they read
+	return ValidatorWithPredicate{
+		Validator: filevalidators.NewSensitiveReadValidator(f.log, cfg, ruleAdapter),
+		Predicate: validator.And(
+			validator.EventTypeIs(hook.EventTypePreToolUse),
+			validator.ToolTypeIn(
+				hook.ToolTypeRead,
+				hook.ToolTypeGrep,
+				hook.ToolTypeGlob,
+				hook.ToolTypeBash,
+			),
+		),
+	}
+}
+
+func (f *FileValidatorFactory) createProtectedPathsValidator(
+	cfg *config.ProtectedPathsValidatorConfig,
+) ValidatorWithPredicate {
+	var ruleAdapter *rules.RuleValidatorAdapter
+	if f.ruleEngine != nil {
+		ruleAdapter = rules.NewRuleValidatorAdapter(
+			f.ruleEngine,
+			rules.ValidatorFileProtectedPaths,
+			rules.WithAdapterLogger(f.log),
+		)
+	}
+
+	// Bash file writes are validated as synthetic Write calls by the dispatcher
+	return ValidatorWithPredicate{
+		Validator: filevalidators.NewProtectedPathsValidator(f.log, cfg, ruleAdapter),
+		Predicate: validator.And(
+			validator.EventTypeIs(hook.EventTypePreToolUse),
+			validator.ToolTypeIn(
+				hook.ToolTypeWrite,
+				hook.ToolTypeEdit,
+				hook.ToolTypeMultiEdit,
+				hook.ToolTypeNotebookEdit,
+			),
+		),
+	}
+}
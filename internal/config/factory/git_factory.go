@@ -1,10 +1,13 @@
 package factory
 
 import (
+	"sync"
+
 	"github.com/smykla-labs/klaudiush/internal/git"
 	"github.com/smykla-labs/klaudiush/internal/rules"
 	"github.com/smykla-labs/klaudiush/internal/validator"
 	gitvalidators "github.com/smykla-labs/klaudiush/internal/validators/git"
+	"github.com/smykla-labs/klaudiush/internal/validators/secrets"
 	"github.com/smykla-labs/klaudiush/pkg/config"
 	"github.com/smykla-labs/klaudiush/pkg/hook"
 	"github.com/smykla-labs/klaudiush/pkg/logger"
@@ -37,6 +40,28 @@ func (f *GitValidatorFactory) getGitRunner() git.Runner {
 	return f.gitRunner
 }
 
+// GitContextProvider returns a provider of the git context for rule matching,
+// backed by the shared cached git runner. The context is built on first use,
+// and its repository state is only queried when a rule needs it.
+func (f *GitValidatorFactory) GitContextProvider() func() *rules.GitContext {
+	return sync.OnceValue(func() *rules.GitContext {
+		runner := f.getGitRunner()
+		if !runner.IsInRepo() {
+			return &rules.GitContext{}
+		}
+
+		root, _ := runner.GetRepoRoot()
+		branch, _ := runner.GetCurrentBranch()
+
+		return &rules.GitContext{
+			RepoRoot: root,
+			Branch:   branch,
+			IsInRepo: true,
+			State:    git.NewRepoState(runner, branch),
+		}
+	})
+}
+
 // SetRuleEngine sets the rule engine for the factory.
 func (f *GitValidatorFactory) SetRuleEngine(engine *rules.RuleEngine) {
 	f.ruleEngine = engine
@@ -80,6 +105,13 @@ func (f *GitValidatorFactory) CreateValidators(cfg *config.Config) []ValidatorWi
 		validators = append(validators, f.createMergeValidator(cfg.Validators.Git.Merge))
 	}
 
+	if cfg.Validators.Git.Destructive != nil && cfg.Validators.Git.Destructive.IsEnabled() {
+		validators = append(
+			validators,
+			f.createDestructiveValidator(cfg.Validators.Git.Destructive),
+		)
+	}
+
 	return validators
 }
 
@@ -99,7 +131,7 @@ func (f *GitValidatorFactory) createAddValidator(
 		Validator: gitvalidators.NewAddValidator(f.log, f.getGitRunner(), cfg, ruleAdapter),
 		Predicate: validator.And(
 			validator.EventTypeIs(hook.EventTypePreToolUse),
-			validator.GitSubcommandIs("add"),
+			bashPredicates(f.cfg).GitSubcommandIs("add"),
 		),
 	}
 }
@@ -120,7 +152,7 @@ func (f *GitValidatorFactory) createNoVerifyValidator(
 		Validator: gitvalidators.NewNoVerifyValidator(f.log, cfg, ruleAdapter),
 		Predicate: validator.And(
 			validator.EventTypeIs(hook.EventTypePreToolUse),
-			validator.GitSubcommandIs("commit"),
+			bashPredicates(f.cfg).GitSubcommandIs("commit"),
 		),
 	}
 }
@@ -138,14 +170,83 @@ func (f *GitValidatorFactory) createCommitValidator(
 	}
 
 	return ValidatorWithPredicate{
-		Validator: gitvalidators.NewCommitValidator(f.log, f.getGitRunner(), cfg, ruleAdapter),
+		Validator: gitvalidators.NewCommitValidator(
+			f.log,
+			f.getGitRunner(),
+			cfg,
+			ruleAdapter,
+			f.stagedChangesOptions()...,
+		),
 		Predicate: validator.And(
 			validator.EventTypeIs(hook.EventTypePreToolUse),
-			validator.GitSubcommandIs("commit"),
+			bashPredicates(f.cfg).GitSubcommandIs("commit"),
 		),
 	}
 }
 
+// stagedChangesOptions returns the options checking the staged changes of a
+// commit with the policies of the add and secrets validators, if enabled.
+func (f *GitValidatorFactory) stagedChangesOptions() []gitvalidators.CommitValidatorOption {
+	if f.cfg == nil || f.cfg.Validators == nil {
+		return nil
+	}
+
+	var opts []gitvalidators.CommitValidatorOption
+
+	if f.cfg.Validators.Git != nil {
+		if addCfg := f.cfg.Validators.Git.Add; addCfg != nil && addCfg.IsEnabled() {
+			opts = append(opts, gitvalidators.WithBlockedPatterns(
+				gitvalidators.BlockedPatterns(addCfg),
+			))
+		}
+	}
+
+	if f.cfg.Validators.Secrets != nil {
+		secretsCfg := f.cfg.Validators.Secrets.Secrets
+		if secretsCfg != nil && secretsCfg.IsEnabled() {
+			detector := NewSecretsValidatorFactory(f.log).CreateDetector(secretsCfg)
+			opts = append(opts, gitvalidators.WithSecretsScanner(stagedSecretsScanner{
+				SecretsValidator: secrets.NewSecretsValidator(f.log, detector, nil, secretsCfg, nil),
+				maxFileSize:      int64(secretsCfg.GetMaxFileSize()),
+			}))
+		}
+	}
+
+	return opts
+}
+
+// stagedSecretsScanner scans the staged changes of a commit with the secrets
+// validator.
+type stagedSecretsScanner struct {
+	*secrets.SecretsValidator
+
+	maxFileSize int64
+}
+
+// MaxFileSize returns the size in bytes above which content is not scanned.
+func (s stagedSecretsScanner) MaxFileSize() int64 {
+	return s.maxFileSize
+}
+
+// ScanRepoFile returns the secrets found in content of file.
+func (s stagedSecretsScanner) ScanRepoFile(
+	root, file, content string,
+) []gitvalidators.SecretFinding {
+	found := s.SecretsValidator.ScanRepoFile(root, file, content)
+	findings := make([]gitvalidators.SecretFinding, 0, len(found))
+
+	for _, finding := range found {
+		findings = append(findings, gitvalidators.SecretFinding{
+			Line:        finding.Line,
+			Name:        finding.Pattern.Name,
+			Description: finding.Pattern.Description,
+			Reference:   finding.Pattern.Reference,
+		})
+	}
+
+	return findings
+}
+
 func (f *GitValidatorFactory) createPushValidator(
 	cfg *config.PushValidatorConfig,
 ) ValidatorWithPredicate {
@@ -162,7 +263,7 @@ func (f *GitValidatorFactory) createPushValidator(
 		Validator: gitvalidators.NewPushValidator(f.log, f.getGitRunner(), cfg, ruleAdapter),
 		Predicate: validator.And(
 			validator.EventTypeIs(hook.EventTypePreToolUse),
-			validator.GitSubcommandIs("push"),
+			bashPredicates(f.cfg).GitSubcommandIs("push"),
 		),
 	}
 }
@@ -183,7 +284,7 @@ func (f *GitValidatorFactory) createFetchValidator(
 		Validator: gitvalidators.NewFetchValidator(f.log, f.getGitRunner(), cfg, ruleAdapter),
 		Predicate: validator.And(
 			validator.EventTypeIs(hook.EventTypePreToolUse),
-			validator.GitSubcommandIs("fetch"),
+			bashPredicates(f.cfg).GitSubcommandIs("fetch"),
 		),
 	}
 }
@@ -228,9 +329,9 @@ func (f *GitValidatorFactory) createBranchValidator(
 			validator.EventTypeIs(hook.EventTypePreToolUse),
 			validator.Or(
 				// git checkout -b or --branch (create new branch)
-				validator.GitSubcommandWithAnyFlag("checkout", "-b", "--branch"),
+				bashPredicates(f.cfg).GitSubcommandWithAnyFlag("checkout", "-b", "--branch"),
 				// git switch -c/--create/-C/--force-create (create new branch)
-				validator.GitSubcommandWithAnyFlag(
+				bashPredicates(f.cfg).GitSubcommandWithAnyFlag(
 					"switch",
 					"-c",
 					"--create",
@@ -238,12 +339,38 @@ func (f *GitValidatorFactory) createBranchValidator(
 					"--force-create",
 				),
 				// git branch without delete flags (create new branch)
-				validator.GitSubcommandWithoutAnyFlag("branch", "-d", "-D", "--delete"),
+				bashPredicates(f.cfg).GitSubcommandWithoutAnyFlag("branch", "-d", "-D", "--delete"),
 			),
 		),
 	}
 }
 
+func (f *GitValidatorFactory) createDestructiveValidator(
+	cfg *config.DestructiveValidatorConfig,
+) ValidatorWithPredicate {
+	var ruleAdapter *rules.RuleValidatorAdapter
+	if f.ruleEngine != nil {
+		ruleAdapter = rules.NewRuleValidatorAdapter(
+			f.ruleEngine,
+			rules.ValidatorGitDestructive,
+			rules.WithAdapterLogger(f.log),
+		)
+	}
+
+	return ValidatorWithPredicate{
+		Validator: gitvalidators.NewDestructiveValidator(
+			f.log,
+			f.getGitRunner(),
+			cfg,
+			ruleAdapter,
+		),
+		Predicate: validator.And(
+			validator.EventTypeIs(hook.EventTypePreToolUse),
+			bashPredicates(f.cfg).GitSubcommandIn(gitvalidators.DestructiveSubcommands...),
+		),
+	}
+}
+
 func (f *GitValidatorFactory) createMergeValidator(
 	cfg *config.MergeValidatorConfig,
 ) ValidatorWithPredicate {
@@ -0,0 +1,49 @@
+package factory
+
+import (
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	lifecyclevalidators "github.com/smykla-labs/klaudiush/internal/validators/lifecycle"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// LifecycleValidatorFactory creates lifecycle event validators from configuration.
+type LifecycleValidatorFactory struct {
+	log        logger.Logger
+	ruleEngine *rules.RuleEngine
+}
+
+// NewLifecycleValidatorFactory creates a new LifecycleValidatorFactory.
+func NewLifecycleValidatorFactory(log logger.Logger) *LifecycleValidatorFactory {
+	return &LifecycleValidatorFactory{log: log}
+}
+
+// SetRuleEngine sets the rule engine for the factory.
+func (f *LifecycleValidatorFactory) SetRuleEngine(engine *rules.RuleEngine) {
+	f.ruleEngine = engine
+}
+
+// CreateValidators creates lifecycle event validators.
+// Lifecycle events only have rule-based validation, so nothing is created
+// when the rule engine is disabled.
+func (f *LifecycleValidatorFactory) CreateValidators(
+	_ *config.Config,
+) []ValidatorWithPredicate {
+	if f.ruleEngine == nil {
+		return nil
+	}
+
+	ruleAdapter := rules.NewRuleValidatorAdapter(
+		f.ruleEngine,
+		rules.ValidatorLifecycle,
+		rules.WithAdapterLogger(f.log),
+	)
+
+	return []ValidatorWithPredicate{
+		{
+			Validator: lifecyclevalidators.NewEventValidator(f.log, ruleAdapter),
+			Predicate: validator.LifecycleEvent(),
+		},
+	}
+}
@@ -0,0 +1,130 @@
+package factory
+
+import (
+	"slices"
+
+	execpkg "github.com/smykla-labs/klaudiush/internal/exec"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	postvalidators "github.com/smykla-labs/klaudiush/internal/validators/post"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// PostValidatorFactory creates PostToolUse validators from configuration.
+type PostValidatorFactory struct {
+	log         logger.Logger
+	fileFactory *FileValidatorFactory
+}
+
+// NewPostValidatorFactory creates a new PostValidatorFactory.
+// The file factory provides the file validators that are re-run on the written files.
+func NewPostValidatorFactory(
+	log logger.Logger,
+	fileFactory *FileValidatorFactory,
+) *PostValidatorFactory {
+	return &PostValidatorFactory{
+		log:         log,
+		fileFactory: fileFactory,
+	}
+}
+
+// CreateValidators creates all post validators based on configuration.
+func (f *PostValidatorFactory) CreateValidators(cfg *config.Config) []ValidatorWithPredicate {
+	var validators []ValidatorWithPredicate
+
+	if cfg.Validators.Post == nil {
+		return validators
+	}
+
+	post := cfg.Validators.Post
+
+	if post.FileLint != nil && post.FileLint.IsEnabled() {
+		validators = append(validators, f.createFileLintValidators(cfg)...)
+	}
+
+	// Commits only need a Signed-off-by trailer when the commit policy asks for one
+	if post.CommitSignoff != nil && post.CommitSignoff.IsEnabled() && commitRequiresSignoff(cfg) {
+		validators = append(validators, f.createCommitSignoffValidator(cfg, post.CommitSignoff))
+	}
+
+	return validators
+}
+
+// createFileLintValidators wraps every enabled file linter so that it lints
+// the file on disk after a successful Write, Edit or MultiEdit. Policy
+// validators such as protected paths are not re-run, as the write already
+// happened.
+func (f *PostValidatorFactory) createFileLintValidators(
+	cfg *config.Config,
+) []ValidatorWithPredicate {
+	if cfg.Validators.File == nil {
+		return nil
+	}
+
+	fileValidators := f.fileFactory.CreateLinterValidators(cfg)
+	validators := make([]ValidatorWithPredicate, 0, len(fileValidators))
+
+	for _, fileValidator := range fileValidators {
+		validators = append(validators, ValidatorWithPredicate{
+			Validator: postvalidators.NewFileLintValidator(f.log, fileValidator.Validator),
+			Predicate: validator.And(
+				postvalidators.FilePredicate(fileValidator.Predicate),
+				validator.ToolSucceeded(),
+			),
+		})
+	}
+
+	return validators
+}
+
+func (f *PostValidatorFactory) createCommitSignoffValidator(
+	cfg *config.Config,
+	signoffCfg *config.CommitSignoffValidatorConfig,
+) ValidatorWithPredicate {
+	// Fall back to the expected signoff of the commit message validator
+	resolved := *signoffCfg
+	if resolved.ExpectedSignoff == "" {
+		resolved.ExpectedSignoff = commitExpectedSignoff(cfg)
+	}
+
+	return ValidatorWithPredicate{
+		Validator: postvalidators.NewCommitSignoffValidator(
+			f.log,
+			execpkg.NewCommandRunner(linterTimeout(cfg)),
+			&resolved,
+		),
+		Predicate: validator.And(
+			validator.EventTypeIs(hook.EventTypePostToolUse),
+			validator.ToolTypeIs(hook.ToolTypeBash),
+			bashPredicates(cfg).GitSubcommandIs("commit"),
+			validator.ToolSucceeded(),
+		),
+	}
+}
+
+// commitRequiresSignoff reports whether the git commit validator is enabled and
+// its required flags, ["-s", "-S"] unless configured, include -s.
+func commitRequiresSignoff(cfg *config.Config) bool {
+	git := cfg.Validators.Git
+	if git == nil || git.Commit == nil || !git.Commit.IsEnabled() {
+		return false
+	}
+
+	flags := git.Commit.RequiredFlags
+	if len(flags) == 0 {
+		return true
+	}
+
+	return slices.Contains(flags, "-s") || slices.Contains(flags, "--signoff")
+}
+
+// commitExpectedSignoff returns the expected signoff of the git commit message config.
+func commitExpectedSignoff(cfg *config.Config) string {
+	git := cfg.Validators.Git
+	if git == nil || git.Commit == nil || git.Commit.Message == nil {
+		return ""
+	}
+
+	return git.Commit.Message.ExpectedSignoff
+}
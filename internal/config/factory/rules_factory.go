@@ -2,6 +2,8 @@
 package factory
 
 import (
+	"github.com/cockroachdb/errors"
+
 	"github.com/smykla-labs/klaudiush/internal/rules"
 	"github.com/smykla-labs/klaudiush/pkg/config"
 	"github.com/smykla-labs/klaudiush/pkg/logger"
@@ -49,7 +51,11 @@ func (f *RulesFactory) CreateRuleEngine(cfg *config.Config) (*rules.RuleEngine,
 			continue
 		}
 
-		internalRule := convertRuleConfig(ruleConfig)
+		internalRule, err := convertRuleConfig(ruleConfig, rulesConfig)
+		if err != nil {
+			return nil, err
+		}
+
 		internalRules = append(internalRules, internalRule)
 	}
 
@@ -63,6 +69,7 @@ func (f *RulesFactory) CreateRuleEngine(cfg *config.Config) (*rules.RuleEngine,
 	opts := []rules.EngineOption{
 		rules.WithLogger(f.log),
 		rules.WithEngineStopOnFirstMatch(rulesConfig.ShouldStopOnFirstMatch()),
+		rules.WithEngineMaxNestingDepth(cfg.GetGlobal().GetMaxNestingDepth()),
 	}
 
 	engine, err := rules.NewRuleEngine(internalRules, opts...)
@@ -77,8 +84,12 @@ func (f *RulesFactory) CreateRuleEngine(cfg *config.Config) (*rules.RuleEngine,
 	return engine, nil
 }
 
-// convertRuleConfig converts a config.RuleConfig to a rules.Rule.
-func convertRuleConfig(cfg config.RuleConfig) *rules.Rule {
+// convertRuleConfig converts a config.RuleConfig to a rules.Rule, resolving
+// the freezes of its schedule from the freeze calendar.
+func convertRuleConfig(
+	cfg config.RuleConfig,
+	rulesConfig *config.RulesConfig,
+) (*rules.Rule, error) {
 	rule := &rules.Rule{
 		Name:        cfg.Name,
 		Description: cfg.Description,
@@ -89,23 +100,42 @@ func convertRuleConfig(cfg config.RuleConfig) *rules.Rule {
 	// Convert match conditions
 	if cfg.Match != nil {
 		rule.Match = &rules.RuleMatch{
-			ValidatorType:   rules.ValidatorType(cfg.Match.ValidatorType),
-			RepoPattern:     cfg.Match.RepoPattern,
-			RepoPatterns:    cfg.Match.RepoPatterns,
-			Remote:          cfg.Match.Remote,
-			BranchPattern:   cfg.Match.BranchPattern,
-			BranchPatterns:  cfg.Match.BranchPatterns,
-			FilePattern:     cfg.Match.FilePattern,
-			FilePatterns:    cfg.Match.FilePatterns,
-			ContentPattern:  cfg.Match.ContentPattern,
-			ContentPatterns: cfg.Match.ContentPatterns,
-			CommandPattern:  cfg.Match.CommandPattern,
-			CommandPatterns: cfg.Match.CommandPatterns,
-			ToolType:        cfg.Match.ToolType,
-			EventType:       cfg.Match.EventType,
-			CaseInsensitive: cfg.Match.IsCaseInsensitive(),
-			PatternMode:     cfg.Match.GetPatternMode(),
+			ValidatorType:     rules.ValidatorType(cfg.Match.ValidatorType),
+			RepoPattern:       cfg.Match.RepoPattern,
+			RepoPatterns:      cfg.Match.RepoPatterns,
+			Remote:            cfg.Match.Remote,
+			BranchPattern:     cfg.Match.BranchPattern,
+			BranchPatterns:    cfg.Match.BranchPatterns,
+			FilePattern:       cfg.Match.FilePattern,
+			FilePatterns:      cfg.Match.FilePatterns,
+			ContentPattern:    cfg.Match.ContentPattern,
+			ContentPatterns:   cfg.Match.ContentPatterns,
+			CommandPattern:    cfg.Match.CommandPattern,
+			CommandPatterns:   cfg.Match.CommandPatterns,
+			ToolType:          cfg.Match.ToolType,
+			EventType:         cfg.Match.EventType,
+			MCPServer:         cfg.Match.MCPServer,
+			MCPTool:           cfg.Match.MCPTool,
+			URLDomain:         cfg.Match.URLDomain,
+			Dirty:             cfg.Match.Dirty,
+			StagedPattern:     cfg.Match.StagedPattern,
+			StagedPatterns:    cfg.Match.StagedPatterns,
+			UpstreamPattern:   cfg.Match.UpstreamPattern,
+			MinAhead:          cfg.Match.MinAhead,
+			MinBehind:         cfg.Match.MinBehind,
+			HeadSigned:        cfg.Match.HeadSigned,
+			HeadAuthorPattern: cfg.Match.HeadAuthorPattern,
+			When:              cfg.Match.When,
+			CaseInsensitive:   cfg.Match.IsCaseInsensitive(),
+			PatternMode:       cfg.Match.GetPatternMode(),
+		}
+
+		schedule, err := convertSchedule(cfg.Match.Schedule, rulesConfig)
+		if err != nil {
+			return nil, errors.Wrapf(err, "rule %q", cfg.Name)
 		}
+
+		rule.Match.Schedule = schedule
 	}
 
 	// Convert action
@@ -114,10 +144,46 @@ func convertRuleConfig(cfg config.RuleConfig) *rules.Rule {
 			Type:      convertActionType(cfg.Action.GetActionType()),
 			Message:   cfg.Action.Message,
 			Reference: cfg.Action.Reference,
+			FixHint:   cfg.Action.FixHint,
+		}
+	}
+
+	return rule, nil
+}
+
+// convertSchedule converts a config.ScheduleConfig to a rules.Schedule.
+func convertSchedule(
+	cfg *config.ScheduleConfig,
+	rulesConfig *config.RulesConfig,
+) (*rules.Schedule, error) {
+	if cfg == nil {
+		return nil, nil //nolint:nilnil // no schedule is valid
+	}
+
+	schedule := &rules.Schedule{
+		Windows:  cfg.Windows,
+		Timezone: cfg.Timezone,
+	}
+
+	for _, d := range cfg.Dates {
+		schedule.Dates = append(schedule.Dates, rules.DateRange{Start: d.Start, End: d.End})
+	}
+
+	freezes, unknown := rulesConfig.ResolveFreezes(cfg.Freezes)
+	if len(unknown) > 0 {
+		return nil, errors.Wrapf(rules.ErrInvalidSchedule, "unknown freezes %v", unknown)
+	}
+
+	for _, f := range freezes {
+		freeze, err := rules.NewFreeze(f.Name, f.Start, f.End, f.Reason, f.Timezone)
+		if err != nil {
+			return nil, err
 		}
+
+		schedule.Freezes = append(schedule.Freezes, freeze)
 	}
 
-	return rule
+	return schedule, nil
 }
 
 // convertActionType converts a string action type to rules.ActionType.
@@ -129,6 +195,8 @@ func convertActionType(actionType string) rules.ActionType {
 		return rules.ActionWarn
 	case "allow":
 		return rules.ActionAllow
+	case "ask":
+		return rules.ActionAsk
 	default:
 		return rules.ActionBlock
 	}
@@ -114,6 +114,33 @@ var _ = Describe("RulesFactory", func() {
 			Expect(engine.Size()).To(Equal(1))
 		})
 
+		It("should convert action fix hints", func() {
+			enabled := true
+			cfg := &config.Config{
+				Rules: &config.RulesConfig{
+					Enabled: &enabled,
+					Rules: []config.RuleConfig{
+						{
+							Name:  "hint-rule",
+							Match: &config.RuleMatchConfig{ValidatorType: "git.push"},
+							Action: &config.RuleActionConfig{
+								Type:    "block",
+								Message: "Push of {{.Git.Branch}} blocked",
+								FixHint: "Open a pull request instead",
+							},
+						},
+					},
+				},
+			}
+
+			engine, err := rulesFactory.CreateRuleEngine(cfg)
+			Expect(err).NotTo(HaveOccurred())
+
+			action := engine.GetRule("hint-rule").Action
+			Expect(action.Message).To(Equal("Push of {{.Git.Branch}} blocked"))
+			Expect(action.FixHint).To(Equal("Open a pull request instead"))
+		})
+
 		It("should create engine with multiple rules", func() {
 			enabled := true
 			cfg := &config.Config{
@@ -218,5 +245,98 @@ var _ = Describe("RulesFactory", func() {
 			rule := engine.GetRule("unknown-action-rule")
 			Expect(rule.Action.Type).To(Equal(rules.ActionBlock))
 		})
+
+		It("should resolve schedule freezes from the freeze calendar", func() {
+			enabled := true
+			cfg := &config.Config{
+				Rules: &config.RulesConfig{
+					Enabled: &enabled,
+					Freezes: []config.FreezeConfig{
+						{Name: "q4", Start: "2026-12-20", End: "2027-01-03", Reason: "Holidays"},
+						{Name: "release", Start: "2026-11-01", End: "2026-11-02"},
+					},
+					Rules: []config.RuleConfig{
+						{
+							Name: "freeze-rule",
+							Match: &config.RuleMatchConfig{
+								Schedule: &config.ScheduleConfig{
+									Windows: []string{"* * * * sat,sun"},
+									Dates: []config.DateRangeConfig{
+										{Start: "2026-12-24", End: "2026-12-26"},
+									},
+									Freezes: []string{"q4"},
+								},
+							},
+							Action: &config.RuleActionConfig{Type: "block"},
+						},
+					},
+				},
+			}
+
+			engine, err := rulesFactory.CreateRuleEngine(cfg)
+			Expect(err).NotTo(HaveOccurred())
+
+			schedule := engine.GetRule("freeze-rule").Match.Schedule
+			Expect(schedule.Windows).To(Equal([]string{"* * * * sat,sun"}))
+			Expect(schedule.Dates).
+				To(Equal([]rules.DateRange{{Start: "2026-12-24", End: "2026-12-26"}}))
+			Expect(schedule.Freezes).To(HaveLen(1))
+			Expect(schedule.Freezes[0].Name).To(Equal("q4"))
+			Expect(schedule.Freezes[0].Reason).To(Equal("Holidays"))
+		})
+
+		It("should fail for unknown schedule freezes", func() {
+			enabled := true
+			cfg := &config.Config{
+				Rules: &config.RulesConfig{
+					Enabled: &enabled,
+					Rules: []config.RuleConfig{
+						{
+							Name: "freeze-rule",
+							Match: &config.RuleMatchConfig{
+								Schedule: &config.ScheduleConfig{Freezes: []string{"q4"}},
+							},
+							Action: &config.RuleActionConfig{Type: "block"},
+						},
+					},
+				},
+			}
+
+			_, err := rulesFactory.CreateRuleEngine(cfg)
+			Expect(err).To(MatchError(ContainSubstring(`rule "freeze-rule": unknown freezes [q4]`)))
+		})
+
+		It("should convert git state conditions", func() {
+			enabled := true
+			dirty := true
+			cfg := &config.Config{
+				Rules: &config.RulesConfig{
+					Enabled: &enabled,
+					Rules: []config.RuleConfig{
+						{
+							Name: "git-state-rule",
+							Match: &config.RuleMatchConfig{
+								Dirty:             &dirty,
+								StagedPatterns:    []string{"**/migrations/**"},
+								UpstreamPattern:   "origin/*",
+								MinBehind:         1,
+								HeadAuthorPattern: "*@example.com>",
+							},
+							Action: &config.RuleActionConfig{Type: "warn"},
+						},
+					},
+				},
+			}
+
+			engine, err := rulesFactory.CreateRuleEngine(cfg)
+			Expect(err).NotTo(HaveOccurred())
+
+			match := engine.GetRule("git-state-rule").Match
+			Expect(match.Dirty).To(HaveValue(BeTrue()))
+			Expect(match.StagedPatterns).To(Equal([]string{"**/migrations/**"}))
+			Expect(match.UpstreamPattern).To(Equal("origin/*"))
+			Expect(match.MinBehind).To(Equal(1))
+			Expect(match.HeadAuthorPattern).To(Equal("*@example.com>"))
+		})
 	})
 })
@@ -47,7 +47,7 @@ func (f *SecretsValidatorFactory) CreateValidators(cfg *config.Config) []Validat
 	}
 
 	// Create detector with patterns
-	detector := f.createDetector(secretsCfg)
+	detector := f.CreateDetector(secretsCfg)
 
 	// Create gitleaks checker
 	gitleaks := f.createGitleaksChecker(timeout)
@@ -66,7 +66,12 @@ func (f *SecretsValidatorFactory) CreateValidators(cfg *config.Config) []Validat
 		Validator: secrets.NewSecretsValidator(f.log, detector, gitleaks, secretsCfg, ruleAdapter),
 		Predicate: validator.And(
 			validator.EventTypeIs(hook.EventTypePreToolUse),
-			validator.ToolTypeIn(hook.ToolTypeWrite, hook.ToolTypeEdit),
+			validator.ToolTypeIn(
+				hook.ToolTypeWrite,
+				hook.ToolTypeEdit,
+				hook.ToolTypeMultiEdit,
+				hook.ToolTypeBash,
+			),
 		),
 	})
 
@@ -84,18 +89,39 @@ func (*SecretsValidatorFactory) getSecretsConfig(
 	return cfg.Validators.Secrets.Secrets
 }
 
-// createDetector creates a pattern detector with custom patterns if configured.
+// CreateDetector creates a pattern detector with custom patterns if configured,
+// followed by the entropy detector unless it is disabled. The rules of the
+// gitleaks config, if set, are combined with both by the validator, which
+// resolves the config from the project root.
 //
 //nolint:ireturn // interface for polymorphism
-func (f *SecretsValidatorFactory) createDetector(
+func (f *SecretsValidatorFactory) CreateDetector(
 	cfg *config.SecretsValidatorConfig,
 ) secrets.Detector {
-	detector := secrets.NewDefaultPatternDetector()
+	patternDetector := secrets.NewDefaultPatternDetector()
 
 	// Add custom patterns from config
 	if cfg != nil && len(cfg.CustomPatterns) > 0 {
 		customPatterns := f.buildCustomPatterns(cfg.CustomPatterns)
-		detector.AddPatterns(customPatterns...)
+		patternDetector.AddPatterns(customPatterns...)
+	}
+
+	var detector secrets.Detector = patternDetector
+
+	var entropyCfg *config.SecretsEntropyConfig
+	if cfg != nil {
+		entropyCfg = cfg.Entropy
+	}
+
+	if entropyCfg.IsEnabled() {
+		detector = secrets.NewCompositeDetector(
+			detector,
+			secrets.NewEntropyDetector(
+				entropyCfg.GetBase64Threshold(),
+				entropyCfg.GetHexThreshold(),
+				entropyCfg.GetMinLength(),
+			),
+		)
 	}
 
 	return detector
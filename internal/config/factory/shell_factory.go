@@ -41,6 +41,13 @@ func (f *ShellValidatorFactory) CreateValidators(cfg *config.Config) []Validator
 		validators = append(validators, f.createBacktickValidator(cfg.Validators.Shell.Backtick))
 	}
 
+	if cfg.Validators.Shell.Destructive != nil && cfg.Validators.Shell.Destructive.IsEnabled() {
+		validators = append(
+			validators,
+			f.createDestructiveValidator(cfg.Validators.Shell.Destructive),
+		)
+	}
+
 	return validators
 }
 
@@ -72,3 +79,31 @@ func (f *ShellValidatorFactory) createBacktickValidator(
 		),
 	}
 }
+
+func (f *ShellValidatorFactory) createDestructiveValidator(
+	cfg *config.ShellDestructiveValidatorConfig,
+) ValidatorWithPredicate {
+	var ruleAdapter *rules.RuleValidatorAdapter
+	if f.ruleEngine != nil {
+		ruleAdapter = rules.NewRuleValidatorAdapter(
+			f.ruleEngine,
+			rules.ValidatorShellDestructive,
+			rules.WithAdapterLogger(f.log),
+		)
+	}
+
+	// Cheap substring pre-filter; the validator parses the command itself
+	commandPredicates := make([]validator.Predicate, 0, len(shellvalidators.DestructiveCommands))
+	for _, command := range shellvalidators.DestructiveCommands {
+		commandPredicates = append(commandPredicates, validator.CommandContains(command))
+	}
+
+	return ValidatorWithPredicate{
+		Validator: shellvalidators.NewDestructiveValidator(f.log, cfg, ruleAdapter),
+		Predicate: validator.And(
+			validator.EventTypeIs(hook.EventTypePreToolUse),
+			validator.ToolTypeIs(hook.ToolTypeBash),
+			validator.Or(commandPredicates...),
+		),
+	}
+}
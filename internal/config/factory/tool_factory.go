@@ -0,0 +1,60 @@
+package factory
+
+import (
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	toolvalidators "github.com/smykla-labs/klaudiush/internal/validators/tool"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// ToolValidatorFactory creates validators for MCP, web, task, notebook and todo tools.
+type ToolValidatorFactory struct {
+	log        logger.Logger
+	ruleEngine *rules.RuleEngine
+}
+
+// NewToolValidatorFactory creates a new ToolValidatorFactory.
+func NewToolValidatorFactory(log logger.Logger) *ToolValidatorFactory {
+	return &ToolValidatorFactory{log: log}
+}
+
+// SetRuleEngine sets the rule engine for the factory.
+func (f *ToolValidatorFactory) SetRuleEngine(engine *rules.RuleEngine) {
+	f.ruleEngine = engine
+}
+
+// CreateValidators creates tool use validators.
+// These tools only have rule-based validation, so nothing is created
+// when the rule engine is disabled.
+func (f *ToolValidatorFactory) CreateValidators(
+	_ *config.Config,
+) []ValidatorWithPredicate {
+	if f.ruleEngine == nil {
+		return nil
+	}
+
+	ruleAdapter := rules.NewRuleValidatorAdapter(
+		f.ruleEngine,
+		rules.ValidatorToolUse,
+		rules.WithAdapterLogger(f.log),
+	)
+
+	return []ValidatorWithPredicate{
+		{
+			Validator: toolvalidators.NewUseValidator(f.log, ruleAdapter),
+			Predicate: validator.And(
+				validator.EventTypeIs(hook.EventTypePreToolUse),
+				validator.ToolTypeIn(
+					hook.ToolTypeWebFetch,
+					hook.ToolTypeWebSearch,
+					hook.ToolTypeTask,
+					hook.ToolTypeNotebookEdit,
+					hook.ToolTypeTodoWrite,
+					hook.ToolTypeMCP,
+				),
+			),
+		},
+	}
+}
@@ -4,6 +4,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/cockroachdb/errors"
@@ -14,6 +15,8 @@ import (
 	"github.com/knadh/koanf/v2"
 
 	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
+	"github.com/smykla-labs/klaudiush/pkg/pathutil"
 )
 
 var (
@@ -44,6 +47,21 @@ const (
 	ProjectConfigFileAlt = "klaudiush.toml"
 )
 
+// Configuration sources that are not files, as reported by KoanfLoader.Sources.
+const (
+	// SourceDefaults marks values that come from the built-in defaults.
+	SourceDefaults = "defaults"
+
+	// SourceEnv marks values that come from KLAUDIUSH_* environment variables.
+	SourceEnv = "env"
+
+	// SourceFlags marks values that come from CLI flags.
+	SourceFlags = "flags"
+)
+
+// rulesKey is the koanf key of the rules list.
+const rulesKey = "rules.rules"
+
 // Default configuration constants for koanf map defaults.
 const (
 	defaultTimeoutStr        = "10s"
@@ -79,11 +97,33 @@ var defaultBranchValidTypes = []string{
 	"fix", "perf", "refactor", "style", "test",
 }
 
+// defaultSensitiveReadPatterns is the list of secret files reads of which are blocked.
+var defaultSensitiveReadPatterns = []string{
+	".env", ".env.*", "*.pem", "*.key", "*.p12", "*.pfx",
+	"id_rsa", "id_dsa", "id_ecdsa", "id_ed25519", ".netrc", ".pgpass", ".git-credentials",
+	"~/.ssh/**", "~/.aws/credentials", "~/.config/gcloud/**", "~/.azure/**",
+	"~/.kube/config", "~/.docker/config.json", "~/.gnupg/**", "~/.npmrc", "~/.pypirc",
+}
+
+// defaultSensitiveReadAllow is the list of files matching sensitive patterns that may be read.
+var defaultSensitiveReadAllow = []string{
+	".env.example", ".env.sample", ".env.template", "*.pub",
+	"~/.ssh/config", "~/.ssh/known_hosts",
+}
+
+// defaultProtectedPaths is the list of paths writes to which are blocked when the
+// protected paths validator is enabled.
+var defaultProtectedPaths = []map[string]any{
+	{"pattern": "/tmp", "action": "block", "replacement_dir": "tmp"},
+	{"pattern": "/var/tmp", "action": "block", "replacement_dir": "tmp"},
+}
+
 // KoanfLoader handles configuration loading from multiple sources using koanf.
 // Precedence order (highest to lowest):
 // 1. CLI Flags
 // 2. Environment Variables (KLAUDIUSH_*)
-// 3. Project Config (.klaudiush/config.toml or klaudiush.toml)
+// 3. Project Configs (.klaudiush/config.toml or klaudiush.toml), closest to the
+// working directory first, up to the git repository root
 // 4. Global Config (~/.klaudiush/config.toml)
 // 5. Defaults
 type KoanfLoader struct {
@@ -91,21 +131,44 @@ type KoanfLoader struct {
 	homeDir  string
 	workDir  string
 	tomlOpts koanf.UnmarshalConf
+
+	// origins records, per loaded key, every layer that set it in load order.
+	origins map[string][]ValueOrigin
+
+	// envNames maps keys loaded from the environment to their variable names.
+	envNames map[string]string
+
+	// files lists the loaded config files, in load order.
+	files []string
 }
 
 // NewKoanfLoader creates a new KoanfLoader with default directories.
 func NewKoanfLoader() (*KoanfLoader, error) {
+	return NewKoanfLoaderForDir("")
+}
+
+// NewKoanfLoaderForDir creates a new KoanfLoader that discovers project
+// configuration from the given directory, e.g. the cwd of the hook input.
+// An empty workDir uses the current working directory.
+func NewKoanfLoaderForDir(workDir string) (*KoanfLoader, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get home directory")
 	}
 
-	workDir, err := os.Getwd()
+	if workDir == "" {
+		workDir, err = os.Getwd()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get working directory")
+		}
+	}
+
+	absWorkDir, err := filepath.Abs(workDir)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get working directory")
+		return nil, errors.Wrap(err, "failed to resolve working directory")
 	}
 
-	return NewKoanfLoaderWithDirs(homeDir, workDir)
+	return NewKoanfLoaderWithDirs(homeDir, absWorkDir)
 }
 
 // NewKoanfLoaderWithDirs creates a new KoanfLoader with custom directories (for testing).
@@ -115,19 +178,21 @@ func NewKoanfLoaderWithDirs(homeDir, workDir string) (*KoanfLoader, error) {
 	return &KoanfLoader{
 		k:       k,
 		homeDir: homeDir,
-		workDir: workDir,
+		workDir: filepath.Clean(workDir),
 		tomlOpts: koanf.UnmarshalConf{
 			Tag:       "koanf",
 			FlatPaths: false,
 		},
+		origins:  make(map[string][]ValueOrigin),
+		envNames: make(map[string]string),
 	}, nil
 }
 
 // Load loads configuration from all sources with precedence.
-// Defaults → Global TOML → Project TOML → Env Vars → CLI Flags
+// Defaults → Global TOML → Project TOMLs (farthest first) → Env Vars → CLI Flags
 //
 // Rules have special merge semantics:
-// - Rules with the same name: project overrides global
+// - Rules with the same name: the closer config overrides the other
 // - Rules with different names: combined (both included)
 func (l *KoanfLoader) Load(flags map[string]any) (*config.Config, error) {
 	cfg, err := l.LoadWithoutValidation(flags)
@@ -147,36 +212,41 @@ func (l *KoanfLoader) Load(flags map[string]any) (*config.Config, error) {
 // LoadWithoutValidation loads configuration without running validation.
 // This is useful for tools that need to fix invalid configurations.
 func (l *KoanfLoader) LoadWithoutValidation(flags map[string]any) (*config.Config, error) {
-	// Reset koanf instance for fresh load
+	// Reset koanf instance and provenance for fresh load
 	l.k = koanf.New(".")
+	l.origins = make(map[string][]ValueOrigin)
+	l.envNames = make(map[string]string)
+	l.files = nil
 
-	// Track rules from each source for proper merging
-	var globalRules []config.RuleConfig
-
-	var projectRules []config.RuleConfig
+	// Rules are merged by name across config files
+	var rules []config.RuleConfig
 
 	// 1. Load defaults first (lowest priority)
 	defaults := defaultsToMap()
-	if err := l.k.Load(confmap.Provider(defaults, "."), nil); err != nil {
+	if _, err := l.loadSource(confmap.Provider(defaults, "."), nil, SourceDefaults, nil); err != nil {
 		return nil, errors.Wrap(err, "failed to load defaults")
 	}
 
 	// 2. Global config: ~/.klaudiush/config.toml
 	globalPath := l.GlobalConfigPath()
-	if err := l.loadTOMLFile(globalPath); err != nil && !os.IsNotExist(err) {
+	if globalK, err := l.loadTOMLFile(globalPath); err != nil && !os.IsNotExist(err) {
 		return nil, errors.Wrap(err, "failed to load global config")
 	} else if err == nil {
-		globalRules = l.extractRules()
+		if rules, err = l.mergeFileRules(rules, globalK, globalPath); err != nil {
+			return nil, errors.Wrap(err, "failed to load global config")
+		}
 	}
 
-	// 3. Project config: .klaudiush/config.toml or klaudiush.toml
-	projectPath := l.findProjectConfig()
-	if projectPath != "" {
-		if err := l.loadTOMLFile(projectPath); err != nil {
-			return nil, errors.Wrap(err, "failed to load project config")
+	// 3. Project configs: from the repository root down to the working directory
+	for _, projectPath := range l.findProjectConfigs() {
+		projectK, err := l.loadTOMLFile(projectPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load project config %s", projectPath)
 		}
 
-		projectRules = l.extractRules()
+		if rules, err = l.mergeFileRules(rules, projectK, projectPath); err != nil {
+			return nil, errors.Wrapf(err, "failed to load project config %s", projectPath)
+		}
 	}
 
 	// 4. Environment variables: KLAUDIUSH_*
@@ -185,14 +255,19 @@ func (l *KoanfLoader) LoadWithoutValidation(flags map[string]any) (*config.Confi
 		TransformFunc: l.envTransform,
 	}
 
-	if err := l.k.Load(env.Provider(".", envOpt), nil); err != nil {
+	if _, err := l.loadSource(env.Provider(".", envOpt), nil, SourceEnv, l.envName); err != nil {
 		return nil, errors.Wrap(err, "failed to load env vars")
 	}
 
 	// 5. CLI flags (highest priority)
 	if len(flags) > 0 {
 		flagConfig := l.flagsToConfig(flags)
-		if err := l.k.Load(confmap.Provider(flagConfig, "."), nil); err != nil {
+		if _, err := l.loadSource(
+			confmap.Provider(flagConfig, "."),
+			nil,
+			SourceFlags,
+			flagName,
+		); err != nil {
 			return nil, errors.Wrap(err, "failed to load flags")
 		}
 	}
@@ -203,21 +278,101 @@ func (l *KoanfLoader) LoadWithoutValidation(flags map[string]any) (*config.Confi
 		return nil, errors.Wrap(err, "failed to unmarshal config")
 	}
 
-	// Merge rules: project overrides global by name, different names are combined
-	mergedRules := mergeRules(globalRules, projectRules)
-
 	if cfg.Rules == nil {
 		cfg.Rules = &config.RulesConfig{}
 	}
 
-	cfg.Rules.Rules = mergedRules
+	cfg.Rules.Rules = rules
 
 	return &cfg, nil
 }
 
-// extractRules extracts rules from the current koanf state.
-func (l *KoanfLoader) extractRules() []config.RuleConfig {
-	rulesSlice := l.k.Slices("rules.rules")
+// loadSource loads a provider into a separate koanf instance, records the
+// origin of each of its keys and merges it into the loader state. nameOf, if
+// set, returns the environment variable or flag name of a key.
+func (l *KoanfLoader) loadSource(
+	provider koanf.Provider,
+	parser koanf.Parser,
+	source string,
+	nameOf func(key string) string,
+) (*koanf.Koanf, error) {
+	k := koanf.New(".")
+	if err := k.Load(provider, parser); err != nil {
+		return nil, err
+	}
+
+	for _, key := range k.Keys() {
+		origin := ValueOrigin{Source: source, Value: k.Get(key)}
+		if nameOf != nil {
+			origin.Name = nameOf(key)
+		}
+
+		l.recordOrigin(key, origin)
+	}
+
+	if err := l.k.Merge(k); err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// mergeFileRules merges the rules of a config file into the rules loaded so far
+// and records the file as the source of each named rule.
+func (l *KoanfLoader) mergeFileRules(
+	rules []config.RuleConfig,
+	fileK *koanf.Koanf,
+	path string,
+) ([]config.RuleConfig, error) {
+	// The rules list is merged by name, so it is tracked per rule
+	delete(l.origins, rulesKey)
+
+	fileRules, err := extractRules(fileK)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range fileRules {
+		if rule.Name != "" {
+			l.recordOrigin(rulesKey+"."+rule.Name, ValueOrigin{
+				Source: path,
+				Value:  rule.Action.GetActionType(),
+			})
+		}
+	}
+
+	return mergeRules(rules, fileRules), nil
+}
+
+// Sources returns the source of each configuration key of the last load:
+// a config file path, SourceDefaults, SourceEnv or SourceFlags. Rules are
+// reported per rule name as "rules.rules.<name>".
+func (l *KoanfLoader) Sources() map[string]string {
+	sources := make(map[string]string, len(l.origins))
+
+	for key, origins := range l.origins {
+		if len(origins) > 0 {
+			sources[key] = origins[len(origins)-1].Source
+		}
+	}
+
+	return sources
+}
+
+// LoadedFiles returns the config files of the last load, from the lowest to
+// the highest precedence.
+func (l *KoanfLoader) LoadedFiles() []string {
+	return slices.Clone(l.files)
+}
+
+// Get returns the merged value of a configuration key of the last load.
+func (l *KoanfLoader) Get(key string) any {
+	return l.k.Get(key)
+}
+
+// extractRules extracts rules from the given koanf state.
+func extractRules(k *koanf.Koanf) ([]config.RuleConfig, error) {
+	rulesSlice := k.Slices(rulesKey)
 	rules := make([]config.RuleConfig, 0, len(rulesSlice))
 
 	for _, ruleK := range rulesSlice {
@@ -233,18 +388,13 @@ func (l *KoanfLoader) extractRules() []config.RuleConfig {
 			rule.Enabled = &enabled
 		}
 
-		// Extract match conditions
+		// Extract match conditions, including pattern lists and flags
 		if ruleK.Exists("match") {
-			rule.Match = &config.RuleMatchConfig{
-				ValidatorType:  ruleK.String("match.validator_type"),
-				RepoPattern:    ruleK.String("match.repo_pattern"),
-				Remote:         ruleK.String("match.remote"),
-				BranchPattern:  ruleK.String("match.branch_pattern"),
-				FilePattern:    ruleK.String("match.file_pattern"),
-				ContentPattern: ruleK.String("match.content_pattern"),
-				CommandPattern: ruleK.String("match.command_pattern"),
-				ToolType:       ruleK.String("match.tool_type"),
-				EventType:      ruleK.String("match.event_type"),
+			rule.Match = &config.RuleMatchConfig{}
+
+			err := ruleK.UnmarshalWithConf("match", rule.Match, koanf.UnmarshalConf{Tag: "koanf"})
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid match section of rule %q", rule.Name)
 			}
 		}
 
@@ -254,16 +404,18 @@ func (l *KoanfLoader) extractRules() []config.RuleConfig {
 				Type:      ruleK.String("action.type"),
 				Message:   ruleK.String("action.message"),
 				Reference: ruleK.String("action.reference"),
+				FixHint:   ruleK.String("action.fix_hint"),
 			}
 		}
 
 		rules = append(rules, rule)
 	}
 
-	return rules
+	return rules, nil
 }
 
-// mergeRules merges global and project rules.
+// mergeRules merges global and project rules. It is applied per config file,
+// so each project config acts as "project" for the configs loaded before it.
 // Rules with the same name: project overrides global.
 // Rules with different names: combined (both included).
 func mergeRules(globalRules, projectRules []config.RuleConfig) []config.RuleConfig {
@@ -319,16 +471,17 @@ func mergeRules(globalRules, projectRules []config.RuleConfig) []config.RuleConf
 }
 
 // loadTOMLFile loads a TOML configuration file with security checks.
-func (l *KoanfLoader) loadTOMLFile(path string) error {
+// Returns the koanf state of the file alone.
+func (l *KoanfLoader) loadTOMLFile(path string) (*koanf.Koanf, error) {
 	// Check if file exists
 	info, err := os.Stat(path)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Security check: reject world-writable files
 	if info.Mode().Perm()&0o002 != 0 {
-		return errors.Wrapf(
+		return nil, errors.Wrapf(
 			ErrInvalidPermissions,
 			"%s is world-writable (mode: %s)",
 			path,
@@ -336,41 +489,107 @@ func (l *KoanfLoader) loadTOMLFile(path string) error {
 		)
 	}
 
-	return l.k.Load(file.Provider(path), tomlparser.Parser())
+	k, err := l.loadSource(file.Provider(path), tomlparser.Parser(), path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	l.files = append(l.files, path)
+
+	return k, nil
 }
 
 // envTransform transforms environment variable names to config paths.
 // KLAUDIUSH_VALIDATORS_GIT_COMMIT_ENABLED → validators.git.commit.enabled
-func (*KoanfLoader) envTransform(key, value string) (string, any) {
+func (l *KoanfLoader) envTransform(key, value string) (string, any) {
+	envName := key
+
 	key = strings.TrimPrefix(key, "KLAUDIUSH_")
 	key = strings.ToLower(key)
 	key = strings.ReplaceAll(key, "_", ".")
 
+	l.envNames[key] = envName
+
 	return key, value
 }
 
+// envName returns the environment variable a key was loaded from.
+func (l *KoanfLoader) envName(key string) string {
+	return l.envNames[key]
+}
+
 // GlobalConfigPath returns the path to the global configuration file.
 func (l *KoanfLoader) GlobalConfigPath() string {
 	return filepath.Join(l.homeDir, GlobalConfigDir, GlobalConfigFile)
 }
 
-// ProjectConfigPaths returns the paths to check for project configuration.
+// ProjectConfigPaths returns the paths to check for project configuration
+// in the working directory.
 func (l *KoanfLoader) ProjectConfigPaths() []string {
+	return projectConfigPathsIn(l.workDir)
+}
+
+// projectConfigPathsIn returns the project configuration paths of a directory,
+// in order of preference.
+func projectConfigPathsIn(dir string) []string {
 	return []string{
-		filepath.Join(l.workDir, ProjectConfigDir, ProjectConfigFile),
-		filepath.Join(l.workDir, ProjectConfigFileAlt),
+		filepath.Join(dir, ProjectConfigDir, ProjectConfigFile),
+		filepath.Join(dir, ProjectConfigFileAlt),
 	}
 }
 
-// findProjectConfig checks for project config files and returns the first found.
-func (l *KoanfLoader) findProjectConfig() string {
-	for _, path := range l.ProjectConfigPaths() {
-		if fileExists(path) {
-			return path
+// projectConfigDirs returns the directories searched for project configuration,
+// from the working directory up to the git repository root. Outside of a
+// repository, only the working directory is searched.
+func (l *KoanfLoader) projectConfigDirs() []string {
+	dirs := []string{l.workDir}
+
+	if pathutil.FindRepoRoot(l.workDir) == "" {
+		return dirs
+	}
+
+	for dir := l.workDir; !pathutil.IsRepoRoot(dir); {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+
+		dirs = append(dirs, parent)
+		dir = parent
+	}
+
+	return dirs
+}
+
+// findProjectConfigs returns the project config files found from the
+// repository root down to the working directory, one per directory, so that
+// later files take precedence.
+func (l *KoanfLoader) findProjectConfigs() []string {
+	var paths []string
+
+	for _, dir := range l.projectConfigDirs() {
+		for _, path := range projectConfigPathsIn(dir) {
+			if fileExists(path) {
+				paths = append(paths, path)
+
+				break
+			}
 		}
 	}
 
-	return ""
+	slices.Reverse(paths)
+
+	return paths
+}
+
+// findProjectConfig returns the project config file closest to the working directory.
+func (l *KoanfLoader) findProjectConfig() string {
+	paths := l.findProjectConfigs()
+	if len(paths) == 0 {
+		return ""
+	}
+
+	return paths[len(paths)-1]
 }
 
 // HasGlobalConfig checks if a global configuration file exists.
@@ -383,8 +602,14 @@ func (l *KoanfLoader) HasProjectConfig() bool {
 	return l.findProjectConfig() != ""
 }
 
-// FindProjectConfigPath returns the path to the project config file if one exists.
-// Returns empty string if no project config file is found.
+// FindProjectConfigPaths returns all project config files that apply to the
+// working directory, from the lowest to the highest precedence.
+func (l *KoanfLoader) FindProjectConfigPaths() []string {
+	return l.findProjectConfigs()
+}
+
+// FindProjectConfigPath returns the path to the project config file closest to
+// the working directory. Returns empty string if no project config file is found.
 func (l *KoanfLoader) FindProjectConfigPath() string {
 	return l.findProjectConfig()
 }
@@ -446,6 +671,12 @@ func (*KoanfLoader) flagsToConfig(flags map[string]any) map[string]any {
 				globalMap := ensureMapKey(result, "global")
 				globalMap["default_timeout"] = strVal
 			}
+
+		case "output-format":
+			if strVal, ok := value.(string); ok {
+				globalMap := ensureMapKey(result, "global")
+				globalMap["output_format"] = strVal
+			}
 		}
 	}
 
@@ -466,17 +697,23 @@ func ensureMapKey(cfg map[string]any, key string) map[string]any {
 // applyDisableFlags applies --disable flags to the config map.
 func applyDisableFlags(cfg map[string]any, validatorNames []string) {
 	validatorPaths := map[string][]string{
-		"commit":      {"git", "commit"},
-		"push":        {"git", "push"},
-		"add":         {"git", "add"},
-		"pr":          {"git", "pr"},
-		"branch":      {"git", "branch"},
-		"no_verify":   {"git", "no_verify"},
-		"markdown":    {"file", "markdown"},
-		"shellscript": {"file", "shellscript"},
-		"terraform":   {"file", "terraform"},
-		"workflow":    {"file", "workflow"},
-		"bell":        {"notification", "bell"},
+		"commit":            {"git", "commit"},
+		"push":              {"git", "push"},
+		"add":               {"git", "add"},
+		"pr":                {"git", "pr"},
+		"branch":            {"git", "branch"},
+		"no_verify":         {"git", "no_verify"},
+		"destructive":       {"git", "destructive"},
+		"shell_destructive": {"shell", "destructive"},
+		"markdown":          {"file", "markdown"},
+		"shellscript":       {"file", "shellscript"},
+		"terraform":         {"file", "terraform"},
+		"workflow":          {"file", "workflow"},
+		"sensitive_read":    {"file", "sensitive_read"},
+		"protected_paths":   {"file", "protected_paths"},
+		"bell":              {"notification", "bell"},
+		"file_lint":         {"post", "file_lint"},
+		"commit_signoff":    {"post", "commit_signoff"},
 	}
 
 	for _, name := range validatorNames {
@@ -551,8 +788,9 @@ func defaultSessionMap() map[string]any {
 
 func defaultGlobalMap() map[string]any {
 	return map[string]any{
-		"use_sdk_git":     true,
-		"default_timeout": defaultTimeoutStr,
+		"use_sdk_git":       true,
+		"default_timeout":   defaultTimeoutStr,
+		"max_nesting_depth": parser.DefaultMaxNestingDepth,
 	}
 }
 
@@ -561,27 +799,31 @@ func defaultValidatorsMap() map[string]any {
 		"git":          defaultGitValidatorsMap(),
 		"file":         defaultFileValidatorsMap(),
 		"notification": defaultNotificationValidatorsMap(),
+		"post":         defaultPostValidatorsMap(),
+		"shell":        defaultShellValidatorsMap(),
 	}
 }
 
 func defaultGitValidatorsMap() map[string]any {
 	return map[string]any{
-		"commit":    defaultCommitMap(),
-		"push":      defaultPushMap(),
-		"fetch":     defaultFetchMap(),
-		"add":       defaultAddMap(),
-		"pr":        defaultPRMap(),
-		"branch":    defaultBranchMap(),
-		"no_verify": defaultNoVerifyMap(),
+		"commit":      defaultCommitMap(),
+		"push":        defaultPushMap(),
+		"fetch":       defaultFetchMap(),
+		"add":         defaultAddMap(),
+		"pr":          defaultPRMap(),
+		"branch":      defaultBranchMap(),
+		"no_verify":   defaultNoVerifyMap(),
+		"destructive": defaultDestructiveMap(),
 	}
 }
 
 func defaultCommitMap() map[string]any {
 	return map[string]any{
-		"enabled":            true,
-		"severity":           "error",
-		"required_flags":     []string{"-s", "-S"},
-		"check_staging_area": true,
+		"enabled":              true,
+		"severity":             "error",
+		"required_flags":       []string{"-s", "-S"},
+		"check_staging_area":   true,
+		"check_staged_changes": true,
 		"message": map[string]any{
 			"enabled":                  true,
 			"title_max_length":         defaultTitleMaxLength,
@@ -600,10 +842,12 @@ func defaultCommitMap() map[string]any {
 
 func defaultPushMap() map[string]any {
 	return map[string]any{
-		"enabled":          true,
-		"severity":         "error",
-		"blocked_remotes":  []string{},
-		"require_tracking": true,
+		"enabled":                  true,
+		"severity":                 "error",
+		"blocked_remotes":          []string{},
+		"require_tracking":         true,
+		"protected_branches":       []string{"main", "master"},
+		"require_force_with_lease": true,
 	}
 }
 
@@ -654,12 +898,31 @@ func defaultNoVerifyMap() map[string]any {
 	}
 }
 
+func defaultDestructiveMap() map[string]any {
+	return map[string]any{
+		"enabled":  true,
+		"severity": "error",
+	}
+}
+
 func defaultFileValidatorsMap() map[string]any {
 	return map[string]any{
 		"markdown":    defaultMarkdownMap(),
 		"shellscript": defaultShellscriptMap(),
 		"terraform":   defaultTerraformMap(),
 		"workflow":    defaultWorkflowMap(),
+		"sensitive_read": map[string]any{
+			"enabled":  true,
+			"severity": "error",
+			"patterns": defaultSensitiveReadPatterns,
+			"allow":    defaultSensitiveReadAllow,
+			"action":   "block",
+		},
+		"protected_paths": map[string]any{
+			"enabled":  false,
+			"severity": "error",
+			"paths":    defaultProtectedPaths,
+		},
 	}
 }
 
@@ -722,6 +985,29 @@ func defaultNotificationValidatorsMap() map[string]any {
 	}
 }
 
+func defaultPostValidatorsMap() map[string]any {
+	return map[string]any{
+		"file_lint": map[string]any{
+			"enabled":  true,
+			"severity": "error",
+		},
+		"commit_signoff": map[string]any{
+			"enabled":  true,
+			"severity": "error",
+		},
+	}
+}
+
+func defaultShellValidatorsMap() map[string]any {
+	return map[string]any{
+		"destructive": map[string]any{
+			"enabled":      true,
+			"severity":     "error",
+			"safe_targets": config.DefaultShellSafeTargets,
+		},
+	}
+}
+
 // fileExists checks if a file exists and is not a directory.
 func fileExists(path string) bool {
 	info, err := os.Stat(path)
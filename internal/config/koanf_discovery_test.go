@@ -0,0 +1,121 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("project config discovery", func() {
+	var (
+		homeDir  string
+		repoRoot string
+		nested   string
+	)
+
+	writeConfig := func(path, content string) {
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		homeDir = GinkgoT().TempDir()
+		repoRoot = GinkgoT().TempDir()
+		nested = filepath.Join(repoRoot, "services", "api")
+
+		Expect(os.MkdirAll(filepath.Join(repoRoot, ".git"), 0o755)).To(Succeed())
+		Expect(os.MkdirAll(nested, 0o755)).To(Succeed())
+	})
+
+	It("should find project configs from the repository root down to the working directory", func() {
+		rootConfig := filepath.Join(repoRoot, ProjectConfigDir, ProjectConfigFile)
+		servicesConfig := filepath.Join(repoRoot, "services", ProjectConfigFileAlt)
+		apiConfig := filepath.Join(nested, ProjectConfigDir, ProjectConfigFile)
+
+		writeConfig(rootConfig, "")
+		writeConfig(servicesConfig, "")
+		writeConfig(apiConfig, "")
+
+		loader, err := NewKoanfLoaderWithDirs(homeDir, nested)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(loader.FindProjectConfigPaths()).To(Equal([]string{
+			rootConfig,
+			servicesConfig,
+			apiConfig,
+		}))
+		Expect(loader.FindProjectConfigPath()).To(Equal(apiConfig))
+	})
+
+	It("should not search above the working directory outside of a repository", func() {
+		outside := GinkgoT().TempDir()
+		sub := filepath.Join(outside, "sub")
+
+		writeConfig(filepath.Join(outside, ProjectConfigDir, ProjectConfigFile), "")
+		Expect(os.MkdirAll(sub, 0o755)).To(Succeed())
+
+		loader, err := NewKoanfLoaderWithDirs(homeDir, sub)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loader.HasProjectConfig()).To(BeFalse())
+	})
+
+	It("should let the closest config win and merge rules by name", func() {
+		rootConfig := filepath.Join(repoRoot, ProjectConfigDir, ProjectConfigFile)
+		apiConfig := filepath.Join(nested, ProjectConfigDir, ProjectConfigFile)
+
+		writeConfig(rootConfig, `
+[validators.git.commit.message]
+title_max_length = 60
+
+[validators.git.push]
+enabled = false
+
+[[rules.rules]]
+name = "root-only"
+[rules.rules.match]
+validator_type = "git.push"
+
+[[rules.rules]]
+name = "shared"
+[rules.rules.match]
+validator_type = "git.commit"
+[rules.rules.action]
+type = "block"
+`)
+		writeConfig(apiConfig, `
+[validators.git.commit.message]
+title_max_length = 70
+
+[[rules.rules]]
+name = "shared"
+[rules.rules.match]
+validator_type = "git.commit"
+[rules.rules.action]
+type = "warn"
+`)
+
+		loader, err := NewKoanfLoaderWithDirs(homeDir, nested)
+		Expect(err).NotTo(HaveOccurred())
+
+		cfg, err := loader.Load(nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(cfg.Validators.Git.Commit.Message.TitleMaxLength).To(HaveValue(Equal(70)))
+		Expect(cfg.Validators.Git.Push.IsEnabled()).To(BeFalse())
+
+		Expect(cfg.Rules.Rules).To(HaveLen(2))
+		Expect(cfg.Rules.Rules[0].Name).To(Equal("root-only"))
+		Expect(cfg.Rules.Rules[1].Name).To(Equal("shared"))
+		Expect(cfg.Rules.Rules[1].Action.Type).To(Equal("warn"))
+
+		sources := loader.Sources()
+		Expect(sources).To(HaveKeyWithValue("validators.git.commit.message.title_max_length", apiConfig))
+		Expect(sources).To(HaveKeyWithValue("validators.git.push.enabled", rootConfig))
+		Expect(sources).To(HaveKeyWithValue("validators.git.commit.message.body_max_line_length", SourceDefaults))
+		Expect(sources).To(HaveKeyWithValue("rules.rules.root-only", rootConfig))
+		Expect(sources).To(HaveKeyWithValue("rules.rules.shared", apiConfig))
+		Expect(loader.LoadedFiles()).To(Equal([]string{rootConfig, apiConfig}))
+	})
+})
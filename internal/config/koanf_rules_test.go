@@ -177,6 +177,7 @@ remote = "origin"
 [rules.rules.action]
 type = "block"
 message = "Don't push to origin"
+fix_hint = "Push {{.Git.Branch}} to your fork"
 `
 			err := os.WriteFile(
 				filepath.Join(globalDir, GlobalConfigFile),
@@ -193,6 +194,7 @@ message = "Don't push to origin"
 			Expect(cfg.Rules.Rules[0].Priority).To(Equal(100))
 			Expect(cfg.Rules.Rules[0].Match.Remote).To(Equal("origin"))
 			Expect(cfg.Rules.Rules[0].Action.Type).To(Equal("block"))
+			Expect(cfg.Rules.Rules[0].Action.FixHint).To(Equal("Push {{.Git.Branch}} to your fork"))
 		})
 
 		It("should load rules from project config", func() {
@@ -308,6 +310,44 @@ message = "project message"
 			Expect(rulesByName["shared-rule"].Action.Type).To(Equal("allow"))
 		})
 
+		It("should load lists, flags and counts of match sections", func() {
+			projectDir := filepath.Join(workDir, ProjectConfigDir)
+			Expect(os.MkdirAll(projectDir, 0o755)).To(Succeed())
+
+			projectConfig := `
+[[rules.rules]]
+name = "unreviewed-migrations"
+[rules.rules.match]
+validator_type = "git.commit"
+staged_patterns = ["**/migrations/**", "!CHANGELOG.md"]
+pattern_mode = "all"
+dirty = true
+head_signed = false
+min_ahead = 2
+case_insensitive = true
+[rules.rules.action]
+type = "warn"
+`
+			err := os.WriteFile(
+				filepath.Join(projectDir, ProjectConfigFile),
+				[]byte(projectConfig),
+				0o600,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			cfg, err := loader.Load(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Rules.Rules).To(HaveLen(1))
+
+			match := cfg.Rules.Rules[0].Match
+			Expect(match.StagedPatterns).To(Equal([]string{"**/migrations/**", "!CHANGELOG.md"}))
+			Expect(match.GetPatternMode()).To(Equal("all"))
+			Expect(match.Dirty).To(HaveValue(BeTrue()))
+			Expect(match.HeadSigned).To(HaveValue(BeFalse()))
+			Expect(match.MinAhead).To(Equal(2))
+			Expect(match.IsCaseInsensitive()).To(BeTrue())
+		})
+
 		It("should respect rules.enabled setting", func() {
 			projectDir := filepath.Join(workDir, ProjectConfigDir)
 			Expect(os.MkdirAll(projectDir, 0o755)).To(Succeed())
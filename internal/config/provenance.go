@@ -0,0 +1,88 @@
+package config
+
+import (
+	"slices"
+	"strings"
+)
+
+// ValueOrigin is a configuration layer that set a key.
+type ValueOrigin struct {
+	// Source is a config file path, SourceDefaults, SourceEnv or SourceFlags.
+	Source string `json:"source"`
+
+	// Name is the environment variable or CLI flag that set the value.
+	Name string `json:"name,omitempty"`
+
+	// Value is the value set by this layer.
+	Value any `json:"value"`
+}
+
+// KeyProvenance describes where the effective value of a configuration key
+// came from and which lower-precedence values it overrides.
+type KeyProvenance struct {
+	// Key is the configuration key path, e.g. "validators.git.push.enabled".
+	// Rules are reported per rule name as "rules.rules.<name>".
+	Key string `json:"key"`
+
+	ValueOrigin
+
+	// Overridden lists the values of lower-precedence layers, highest first.
+	Overridden []ValueOrigin `json:"overridden,omitempty"`
+}
+
+// flagNames maps configuration keys to the CLI flags that set them.
+var flagNames = map[string]string{
+	"global.use_sdk_git":     "--use-sdk-git",
+	"global.default_timeout": "--timeout",
+	"global.output_format":   "--output-format",
+}
+
+// flagName returns the CLI flag that sets a configuration key.
+func flagName(key string) string {
+	if name, ok := flagNames[key]; ok {
+		return name
+	}
+
+	if strings.HasPrefix(key, "validators.") {
+		return "--disable"
+	}
+
+	return ""
+}
+
+// recordOrigin records a layer that set a configuration key.
+func (l *KoanfLoader) recordOrigin(key string, origin ValueOrigin) {
+	l.origins[key] = append(l.origins[key], origin)
+}
+
+// Explain returns the provenance of every configuration key of the last load,
+// sorted by key.
+func (l *KoanfLoader) Explain() []KeyProvenance {
+	keys := make([]string, 0, len(l.origins))
+
+	for key, origins := range l.origins {
+		if len(origins) > 0 {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	result := make([]KeyProvenance, 0, len(keys))
+
+	for _, key := range keys {
+		origins := l.origins[key]
+		effective := origins[len(origins)-1]
+
+		overridden := slices.Clone(origins[:len(origins)-1])
+		slices.Reverse(overridden)
+
+		result = append(result, KeyProvenance{
+			Key:         key,
+			ValueOrigin: effective,
+			Overridden:  overridden,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("config provenance", func() {
+	var (
+		homeDir    string
+		workDir    string
+		globalPath string
+	)
+
+	findKey := func(entries []KeyProvenance, key string) *KeyProvenance {
+		for i := range entries {
+			if entries[i].Key == key {
+				return &entries[i]
+			}
+		}
+
+		return nil
+	}
+
+	BeforeEach(func() {
+		homeDir = GinkgoT().TempDir()
+		workDir = GinkgoT().TempDir()
+		globalPath = filepath.Join(homeDir, GlobalConfigDir, GlobalConfigFile)
+
+		Expect(os.MkdirAll(filepath.Dir(globalPath), 0o755)).To(Succeed())
+		Expect(os.WriteFile(globalPath, []byte(`
+[validators.git.commit.message]
+title_max_length = 60
+
+[validators.git.push]
+enabled = false
+
+[[rules.rules]]
+name = "no-force-push"
+[rules.rules.match]
+validator_type = "git.push"
+[rules.rules.action]
+type = "warn"
+`), 0o600)).To(Succeed())
+	})
+
+	It("should report the effective source and the overridden values", func() {
+		GinkgoT().Setenv("KLAUDIUSH_VALIDATORS_GIT_PUSH_SEVERITY", "warning")
+
+		loader, err := NewKoanfLoaderWithDirs(homeDir, workDir)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = loader.LoadWithoutValidation(map[string]any{"disable": []string{"push"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		entries := loader.Explain()
+
+		titleMax := findKey(entries, "validators.git.commit.message.title_max_length")
+		Expect(titleMax).NotTo(BeNil())
+		Expect(titleMax.Source).To(Equal(globalPath))
+		Expect(titleMax.Value).To(BeEquivalentTo(60))
+		Expect(titleMax.Overridden).To(HaveLen(1))
+		Expect(titleMax.Overridden[0].Source).To(Equal(SourceDefaults))
+		Expect(titleMax.Overridden[0].Value).To(BeEquivalentTo(defaultTitleMaxLength))
+
+		pushEnabled := findKey(entries, "validators.git.push.enabled")
+		Expect(pushEnabled).NotTo(BeNil())
+		Expect(pushEnabled.Source).To(Equal(SourceFlags))
+		Expect(pushEnabled.Name).To(Equal("--disable"))
+		Expect(pushEnabled.Overridden).To(HaveLen(2))
+		Expect(pushEnabled.Overridden[0].Source).To(Equal(globalPath))
+		Expect(pushEnabled.Overridden[1].Source).To(Equal(SourceDefaults))
+
+		severity := findKey(entries, "validators.git.push.severity")
+		Expect(severity).NotTo(BeNil())
+		Expect(severity.Source).To(Equal(SourceEnv))
+		Expect(severity.Name).To(Equal("KLAUDIUSH_VALIDATORS_GIT_PUSH_SEVERITY"))
+		Expect(severity.Value).To(Equal("warning"))
+
+		rule := findKey(entries, "rules.rules.no-force-push")
+		Expect(rule).NotTo(BeNil())
+		Expect(rule.Source).To(Equal(globalPath))
+		Expect(rule.Value).To(Equal("warn"))
+		Expect(findKey(entries, "rules.rules")).To(BeNil())
+	})
+
+	It("should keep entries sorted by key and match Sources", func() {
+		loader, err := NewKoanfLoaderWithDirs(homeDir, workDir)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = loader.LoadWithoutValidation(nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		entries := loader.Explain()
+		sources := loader.Sources()
+
+		Expect(entries).To(HaveLen(len(sources)))
+
+		for i, entry := range entries {
+			if i > 0 {
+				Expect(entries[i-1].Key < entry.Key).To(BeTrue())
+			}
+
+			Expect(sources[entry.Key]).To(Equal(entry.Source))
+		}
+	})
+})
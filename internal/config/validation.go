@@ -3,10 +3,16 @@ package config
 
 import (
 	"fmt"
+	"maps"
+	"path"
+	"path/filepath"
+	"regexp"
 	"slices"
 
+	"github.com/bmatcuk/doublestar/v4"
 	"github.com/cockroachdb/errors"
 
+	"github.com/smykla-labs/klaudiush/internal/rules"
 	"github.com/smykla-labs/klaudiush/pkg/config"
 	"github.com/smykla-labs/klaudiush/pkg/stringutil"
 )
@@ -87,8 +93,41 @@ func (v *Validator) Validate(cfg *config.Config) error {
 }
 
 // validateGlobalConfig validates global configuration.
-func (*Validator) validateGlobalConfig(*config.GlobalConfig) error {
-	// No specific validation needed for global config currently
+func (*Validator) validateGlobalConfig(cfg *config.GlobalConfig) error {
+	if cfg.OutputFormat != "" && !slices.Contains(config.ValidOutputFormats, cfg.OutputFormat) {
+		return errors.Wrapf(
+			ErrInvalidOption,
+			"global.output_format must be one of %v, got %q",
+			config.ValidOutputFormats,
+			cfg.OutputFormat,
+		)
+	}
+
+	workers := map[string]*int{
+		"max_cpu_workers": cfg.MaxCPUWorkers,
+		"max_io_workers":  cfg.MaxIOWorkers,
+		"max_git_workers": cfg.MaxGitWorkers,
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(workers)) {
+		if value := workers[key]; value != nil && *value < 1 {
+			return errors.Wrapf(
+				ErrInvalidOption,
+				"global.%s must be at least 1, got %d",
+				key,
+				*value,
+			)
+		}
+	}
+
+	if cfg.MaxNestingDepth != nil && *cfg.MaxNestingDepth < 0 {
+		return errors.Wrapf(
+			ErrInvalidOption,
+			"global.max_nesting_depth must not be negative, got %d",
+			*cfg.MaxNestingDepth,
+		)
+	}
+
 	return nil
 }
 
@@ -114,6 +153,25 @@ func (v *Validator) validateValidatorsConfig(cfg *config.ValidatorsConfig) error
 		}
 	}
 
+	if cfg.Post != nil {
+		if err := v.validatePostConfig(cfg.Post); err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+	}
+
+	if cfg.Shell != nil {
+		if err := v.validateShellConfig(cfg.Shell); err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+	}
+
+	if cfg.Secrets != nil && cfg.Secrets.Secrets != nil {
+		if err := v.validateSecretsConfig(cfg.Secrets.Secrets); err != nil {
+			validationErrors = append(validationErrors,
+				errors.Wrap(err, "validators.secrets.secrets"))
+		}
+	}
+
 	if len(validationErrors) > 0 {
 		return combineErrors(validationErrors)
 	}
@@ -170,6 +228,15 @@ func (v *Validator) validateGitConfig(cfg *config.GitConfig) error {
 		}
 	}
 
+	if cfg.Destructive != nil {
+		if err := v.validateBaseConfig(&cfg.Destructive.ValidatorConfig); err != nil {
+			validationErrors = append(
+				validationErrors,
+				errors.Wrap(err, "validators.git.destructive"),
+			)
+		}
+	}
+
 	if len(validationErrors) > 0 {
 		return combineErrors(validationErrors)
 	}
@@ -217,6 +284,24 @@ func (v *Validator) validateFileConfig(cfg *config.FileConfig) error {
 		}
 	}
 
+	if cfg.SensitiveRead != nil {
+		if err := v.validateSensitiveReadConfig(cfg.SensitiveRead); err != nil {
+			validationErrors = append(
+				validationErrors,
+				errors.Wrap(err, "validators.file.sensitive_read"),
+			)
+		}
+	}
+
+	if cfg.ProtectedPaths != nil {
+		if err := v.validateProtectedPathsConfig(cfg.ProtectedPaths); err != nil {
+			validationErrors = append(
+				validationErrors,
+				errors.Wrap(err, "validators.file.protected_paths"),
+			)
+		}
+	}
+
 	if len(validationErrors) > 0 {
 		return combineErrors(validationErrors)
 	}
@@ -235,6 +320,63 @@ func (v *Validator) validateNotificationConfig(cfg *config.NotificationConfig) e
 	return nil
 }
 
+// validatePostConfig validates post validators configuration.
+func (v *Validator) validatePostConfig(cfg *config.PostConfig) error {
+	if cfg.FileLint != nil {
+		if err := v.validateBaseConfig(&cfg.FileLint.ValidatorConfig); err != nil {
+			return errors.Wrap(err, "validators.post.file_lint")
+		}
+	}
+
+	if cfg.CommitSignoff != nil {
+		if err := v.validateBaseConfig(&cfg.CommitSignoff.ValidatorConfig); err != nil {
+			return errors.Wrap(err, "validators.post.commit_signoff")
+		}
+	}
+
+	return nil
+}
+
+// validateShellConfig validates shell validators configuration.
+func (v *Validator) validateShellConfig(cfg *config.ShellConfig) error {
+	if cfg.Destructive != nil {
+		if err := v.validateShellDestructiveConfig(cfg.Destructive); err != nil {
+			return errors.Wrap(err, "validators.shell.destructive")
+		}
+	}
+
+	return nil
+}
+
+// validateSecretsConfig validates secrets validator configuration.
+func (v *Validator) validateSecretsConfig(cfg *config.SecretsValidatorConfig) error {
+	if err := v.validateBaseConfig(&cfg.ValidatorConfig); err != nil {
+		return err
+	}
+
+	if filepath.IsAbs(cfg.BaselineFile) {
+		return errors.Wrapf(
+			ErrInvalidOption,
+			"baseline_file must be relative to the project root, got %q",
+			cfg.BaselineFile,
+		)
+	}
+
+	if cfg.Entropy == nil {
+		return nil
+	}
+
+	if cfg.Entropy.Base64Threshold < 0 || cfg.Entropy.HexThreshold < 0 {
+		return errors.WithMessage(ErrInvalidOption, "entropy thresholds must not be negative")
+	}
+
+	if cfg.Entropy.MinLength < 0 {
+		return errors.WithMessage(ErrInvalidLength, "entropy.min_length")
+	}
+
+	return nil
+}
+
 // validateCommitConfig validates commit validator configuration.
 func (v *Validator) validateCommitConfig(cfg *config.CommitValidatorConfig) error {
 	if err := v.validateBaseConfig(&cfg.ValidatorConfig); err != nil {
@@ -305,7 +447,143 @@ func (*Validator) validateCommitMessageConfig(cfg *config.CommitMessageConfig) e
 
 // validatePushConfig validates push validator configuration.
 func (v *Validator) validatePushConfig(cfg *config.PushValidatorConfig) error {
-	return v.validateBaseConfig(&cfg.ValidatorConfig)
+	if err := v.validateBaseConfig(&cfg.ValidatorConfig); err != nil {
+		return err
+	}
+
+	for _, pattern := range cfg.ProtectedBranches {
+		if pattern == "" {
+			return errors.WithMessage(ErrEmptyValue, "protected_branches")
+		}
+
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.Wrapf(
+				ErrInvalidOption,
+				"protected_branches contains invalid pattern %q",
+				pattern,
+			)
+		}
+	}
+
+	return nil
+}
+
+// validateShellDestructiveConfig validates destructive shell command validator configuration.
+func (v *Validator) validateShellDestructiveConfig(
+	cfg *config.ShellDestructiveValidatorConfig,
+) error {
+	if err := v.validateBaseConfig(&cfg.ValidatorConfig); err != nil {
+		return err
+	}
+
+	for _, pattern := range cfg.SafeTargets {
+		if pattern == "" {
+			return errors.WithMessage(ErrEmptyValue, "safe_targets")
+		}
+
+		if !doublestar.ValidatePattern(pattern) {
+			return errors.Wrapf(
+				ErrInvalidOption,
+				"safe_targets contains invalid pattern %q",
+				pattern,
+			)
+		}
+	}
+
+	return nil
+}
+
+// validateSensitiveReadConfig validates sensitive file read validator configuration.
+func (v *Validator) validateSensitiveReadConfig(cfg *config.SensitiveReadValidatorConfig) error {
+	if err := v.validateBaseConfig(&cfg.ValidatorConfig); err != nil {
+		return err
+	}
+
+	if err := validateGlobPatterns("patterns", cfg.Patterns); err != nil {
+		return err
+	}
+
+	if err := validateGlobPatterns("allow", cfg.Allow); err != nil {
+		return err
+	}
+
+	if cfg.Action != "" && !slices.Contains(config.ValidSensitiveReadActions, cfg.Action) {
+		return errors.Wrapf(
+			ErrInvalidOption,
+			"action must be one of %v, got %q",
+			config.ValidSensitiveReadActions,
+			cfg.Action,
+		)
+	}
+
+	return nil
+}
+
+// validateProtectedPathsConfig validates protected paths validator configuration.
+func (v *Validator) validateProtectedPathsConfig(
+	cfg *config.ProtectedPathsValidatorConfig,
+) error {
+	if err := v.validateBaseConfig(&cfg.ValidatorConfig); err != nil {
+		return err
+	}
+
+	for i := range cfg.Paths {
+		if err := validateProtectedPath(&cfg.Paths[i]); err != nil {
+			return errors.Wrapf(err, "paths[%d]", i)
+		}
+	}
+
+	return nil
+}
+
+// validateProtectedPath validates a single protected path entry.
+func validateProtectedPath(entry *config.ProtectedPathConfig) error {
+	switch {
+	case entry.Pattern == "" && entry.Regex == "":
+		return errors.WithMessage(ErrEmptyValue, "pattern or regex")
+	case entry.Pattern != "" && entry.Regex != "":
+		return errors.Wrap(ErrInvalidOption, "pattern and regex are mutually exclusive")
+	case entry.Pattern != "":
+		if err := validateGlobPatterns("pattern", []string{entry.Pattern}); err != nil {
+			return err
+		}
+	default:
+		if _, err := regexp.Compile(entry.Regex); err != nil {
+			return errors.Wrapf(ErrInvalidOption, "invalid regex %q: %v", entry.Regex, err)
+		}
+	}
+
+	if entry.Action != "" && !slices.Contains(config.ValidProtectedPathActions, entry.Action) {
+		return errors.Wrapf(
+			ErrInvalidOption,
+			"action must be one of %v, got %q",
+			config.ValidProtectedPathActions,
+			entry.Action,
+		)
+	}
+
+	return nil
+}
+
+// validateGlobPatterns checks that a list of doublestar patterns has no empty or
+// invalid entries.
+func validateGlobPatterns(field string, patterns []string) error {
+	for _, pattern := range patterns {
+		if pattern == "" {
+			return errors.WithMessage(ErrEmptyValue, field)
+		}
+
+		if !doublestar.ValidatePattern(pattern) {
+			return errors.Wrapf(
+				ErrInvalidOption,
+				"%s contains invalid pattern %q",
+				field,
+				pattern,
+			)
+		}
+	}
+
+	return nil
 }
 
 // validateAddConfig validates add validator configuration.
@@ -468,11 +746,11 @@ func (*Validator) validateBaseConfig(cfg *config.ValidatorConfig) error {
 
 // validateRulesConfig validates the rules configuration.
 func (v *Validator) validateRulesConfig(cfg *config.RulesConfig) error {
-	if cfg == nil || len(cfg.Rules) == 0 {
+	if cfg == nil {
 		return nil
 	}
 
-	var validationErrors []error
+	validationErrors := v.validateFreezes(cfg.Freezes)
 
 	for i := range cfg.Rules {
 		// Skip validation for disabled rules
@@ -485,6 +763,14 @@ func (v *Validator) validateRulesConfig(cfg *config.RulesConfig) error {
 		if err := v.validateRule(&cfg.Rules[i], ruleID); err != nil {
 			validationErrors = append(validationErrors, err)
 		}
+
+		if cfg.Rules[i].Match == nil {
+			continue
+		}
+
+		if err := v.validateRuleSchedule(cfg, cfg.Rules[i].Match.Schedule, ruleID); err != nil {
+			validationErrors = append(validationErrors, err)
+		}
 	}
 
 	if len(validationErrors) > 0 {
@@ -494,6 +780,74 @@ func (v *Validator) validateRulesConfig(cfg *config.RulesConfig) error {
 	return nil
 }
 
+// validateFreezes validates the freeze calendar.
+func (*Validator) validateFreezes(freezes []config.FreezeConfig) []error {
+	var validationErrors []error
+
+	seen := make(map[string]bool, len(freezes))
+
+	for i, f := range freezes {
+		if f.Name == "" {
+			validationErrors = append(
+				validationErrors,
+				errors.Wrapf(ErrInvalidRule, "freezes[%d] has no name", i),
+			)
+
+			continue
+		}
+
+		if seen[f.Name] {
+			validationErrors = append(
+				validationErrors,
+				errors.Wrapf(ErrInvalidRule, "duplicate freeze %q", f.Name),
+			)
+		}
+
+		seen[f.Name] = true
+
+		if _, err := rules.NewFreeze(f.Name, f.Start, f.End, f.Reason, f.Timezone); err != nil {
+			validationErrors = append(validationErrors, errors.Wrapf(ErrInvalidRule, "%v", err))
+		}
+	}
+
+	return validationErrors
+}
+
+// validateRuleSchedule validates the schedule of a rule and the freezes it references.
+func (*Validator) validateRuleSchedule(
+	cfg *config.RulesConfig,
+	schedule *config.ScheduleConfig,
+	ruleID string,
+) error {
+	if schedule == nil {
+		return nil
+	}
+
+	if len(schedule.Windows) == 0 && len(schedule.Dates) == 0 && len(schedule.Freezes) == 0 {
+		return errors.Wrapf(
+			ErrInvalidRule,
+			"%s has an empty schedule (set windows, dates or freezes)",
+			ruleID,
+		)
+	}
+
+	if _, unknown := cfg.ResolveFreezes(schedule.Freezes); len(unknown) > 0 {
+		return errors.Wrapf(ErrInvalidRule, "%s references unknown freezes %v", ruleID, unknown)
+	}
+
+	compiled := &rules.Schedule{Windows: schedule.Windows, Timezone: schedule.Timezone}
+
+	for _, d := range schedule.Dates {
+		compiled.Dates = append(compiled.Dates, rules.DateRange{Start: d.Start, End: d.End})
+	}
+
+	if _, err := rules.NewScheduleMatcher(compiled); err != nil {
+		return errors.Wrapf(ErrInvalidRule, "%s: %v", ruleID, err)
+	}
+
+	return nil
+}
+
 // getRuleIdentifier returns a human-readable identifier for a rule.
 func (*Validator) getRuleIdentifier(rule config.RuleConfig, index int) string {
 	if rule.Name != "" {
@@ -585,6 +939,34 @@ func (*Validator) validateRuleMatchFields(match *config.RuleMatchConfig, ruleID
 		}
 	}
 
+	// Validate ahead/behind minimums
+	if match.MinAhead < 0 || match.MinBehind < 0 {
+		validationErrors = append(
+			validationErrors,
+			errors.Wrapf(
+				ErrInvalidRule,
+				"%s has negative min_ahead or min_behind (%d/%d)",
+				ruleID,
+				match.MinAhead,
+				match.MinBehind,
+			),
+		)
+	}
+
+	// Validate when expression if specified
+	if match.When != "" {
+		_, err := rules.NewExpressionMatcherWithOpts(
+			match.When,
+			rules.PatternOptions{CaseInsensitive: match.IsCaseInsensitive()},
+		)
+		if err != nil {
+			validationErrors = append(
+				validationErrors,
+				errors.Wrapf(ErrInvalidRule, "%s: %v", ruleID, err),
+			)
+		}
+	}
+
 	if len(validationErrors) > 0 {
 		return combineErrors(validationErrors)
 	}
@@ -610,6 +992,11 @@ func (*Validator) validateRuleAction(action *config.RuleActionConfig, ruleID str
 		)
 	}
 
+	// Validate message and fix hint templates
+	if err := rules.ValidateActionTemplates(action.Message, action.FixHint); err != nil {
+		return errors.Wrapf(ErrInvalidRule, "%s: %v", ruleID, err)
+	}
+
 	return nil
 }
 
@@ -74,6 +74,21 @@ var _ = Describe("Validator", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 
+			It("should pass for rule with mcp_server and MCP tool_type", func() {
+				err := validator.validateRulesConfig(&config.RulesConfig{
+					Rules: []config.RuleConfig{
+						{
+							Name: "valid-mcp-rule",
+							Match: &config.RuleMatchConfig{
+								ToolType:  "mcp",
+								MCPServer: "github",
+							},
+						},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
 			It("should pass for rule with file_pattern", func() {
 				err := validator.validateRulesConfig(&config.RulesConfig{
 					Rules: []config.RuleConfig{
@@ -106,7 +121,7 @@ var _ = Describe("Validator", func() {
 			})
 
 			It("should accept all valid action types", func() {
-				for _, actionType := range []string{"allow", "block", "warn"} {
+				for _, actionType := range []string{"allow", "ask", "block", "warn"} {
 					err := validator.validateRulesConfig(&config.RulesConfig{
 						Rules: []config.RuleConfig{
 							{
@@ -232,6 +247,142 @@ var _ = Describe("Validator", func() {
 				Expect(err.Error()).To(ContainSubstring("InvalidEvent"))
 			})
 
+			It("should fail when when expression is invalid", func() {
+				err := validator.validateRulesConfig(&config.RulesConfig{
+					Rules: []config.RuleConfig{
+						{
+							Name: "invalid-when-rule",
+							Match: &config.RuleMatchConfig{
+								When: `git.branch == "main" &&`,
+							},
+						},
+					},
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid when expression"))
+			})
+
+			It("should pass for valid when expression", func() {
+				err := validator.validateRulesConfig(&config.RulesConfig{
+					Rules: []config.RuleConfig{
+						{
+							Name: "when-rule",
+							Match: &config.RuleMatchConfig{
+								When: `git.branch == "main" && command.argv.contains("--force")`,
+							},
+						},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should fail when min_behind is negative", func() {
+				err := validator.validateRulesConfig(&config.RulesConfig{
+					Rules: []config.RuleConfig{
+						{
+							Name: "negative-behind-rule",
+							Match: &config.RuleMatchConfig{
+								ValidatorType: "git.push",
+								MinBehind:     -1,
+							},
+						},
+					},
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("negative min_ahead or min_behind"))
+			})
+
+			It("should fail when schedule is invalid", func() {
+				err := validator.validateRulesConfig(&config.RulesConfig{
+					Rules: []config.RuleConfig{
+						{
+							Name: "invalid-schedule-rule",
+							Match: &config.RuleMatchConfig{
+								ValidatorType: "git.push",
+								Schedule: &config.ScheduleConfig{
+									Windows: []string{"* 9-17 * * weekdays"},
+								},
+							},
+						},
+					},
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(`invalid day of week "weekdays"`))
+			})
+
+			It("should fail when schedule is empty", func() {
+				err := validator.validateRulesConfig(&config.RulesConfig{
+					Rules: []config.RuleConfig{
+						{
+							Name: "empty-schedule-rule",
+							Match: &config.RuleMatchConfig{
+								Schedule: &config.ScheduleConfig{Timezone: "UTC"},
+							},
+						},
+					},
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("has an empty schedule"))
+			})
+
+			It("should fail when schedule references unknown freezes", func() {
+				err := validator.validateRulesConfig(&config.RulesConfig{
+					Freezes: []config.FreezeConfig{
+						{Name: "q4", Start: "2026-12-20", End: "2027-01-03"},
+					},
+					Rules: []config.RuleConfig{
+						{
+							Name: "freeze-rule",
+							Match: &config.RuleMatchConfig{
+								Schedule: &config.ScheduleConfig{Freezes: []string{"q4", "q1"}},
+							},
+						},
+					},
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("references unknown freezes [q1]"))
+			})
+
+			It("should fail when freezes are invalid", func() {
+				err := validator.validateRulesConfig(&config.RulesConfig{
+					Freezes: []config.FreezeConfig{
+						{Name: "q4", Start: "2026-12-20", End: "2027-01-03"},
+						{Name: "q4", Start: "2026-12-20", End: "2027-01-03"},
+						{Start: "2026-12-20", End: "2027-01-03"},
+						{Name: "reversed", Start: "2027-01-03", End: "2026-12-20"},
+					},
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(`duplicate freeze "q4"`))
+				Expect(err.Error()).To(ContainSubstring("freezes[2] has no name"))
+				Expect(err.Error()).To(ContainSubstring(`freeze "reversed"`))
+			})
+
+			It("should pass for valid schedule and freezes", func() {
+				err := validator.validateRulesConfig(&config.RulesConfig{
+					Freezes: []config.FreezeConfig{
+						{Name: "q4", Start: "2026-12-20", End: "2027-01-03", Timezone: "UTC"},
+					},
+					Rules: []config.RuleConfig{
+						{
+							Name: "schedule-rule",
+							Match: &config.RuleMatchConfig{
+								ValidatorType: "git.push",
+								Schedule: &config.ScheduleConfig{
+									Windows: []string{"* 18-23 * * fri", "* * * * sat,sun"},
+									Dates: []config.DateRangeConfig{
+										{Start: "2026-12-24", End: "2026-12-26"},
+									},
+									Freezes:  []string{"*"},
+									Timezone: "Europe/Berlin",
+								},
+							},
+						},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
 			It("should fail when action type is invalid", func() {
 				err := validator.validateRulesConfig(&config.RulesConfig{
 					Rules: []config.RuleConfig{
@@ -251,6 +402,25 @@ var _ = Describe("Validator", func() {
 				Expect(err.Error()).To(ContainSubstring("invalid-action"))
 			})
 
+			It("should fail when fix hint template is invalid", func() {
+				err := validator.validateRulesConfig(&config.RulesConfig{
+					Rules: []config.RuleConfig{
+						{
+							Name: "invalid-template-rule",
+							Match: &config.RuleMatchConfig{
+								ValidatorType: "git.push",
+							},
+							Action: &config.RuleActionConfig{
+								Message: "Push to {{.Git.Branch}} blocked",
+								FixHint: "Use {{.Git.Remot",
+							},
+						},
+					},
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("template: fix_hint:1"))
+			})
+
 			It("should report multiple errors", func() {
 				err := validator.validateRulesConfig(&config.RulesConfig{
 					Rules: []config.RuleConfig{
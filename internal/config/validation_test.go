@@ -44,6 +44,43 @@ var _ = Describe("Validator", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("should accept a valid output format", func() {
+			cfg := &config.Config{
+				Global: &config.GlobalConfig{OutputFormat: config.OutputFormatJSON},
+			}
+			err := validator.Validate(cfg)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject an unknown output format", func() {
+			cfg := &config.Config{
+				Global: &config.GlobalConfig{OutputFormat: "yaml"},
+			}
+			err := validator.Validate(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, ErrInvalidConfig)).To(BeTrue())
+		})
+
+		It("should reject non-positive worker limits", func() {
+			zero := 0
+			cfg := &config.Config{
+				Global: &config.GlobalConfig{MaxGitWorkers: &zero},
+			}
+			err := validator.Validate(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, ErrInvalidConfig)).To(BeTrue())
+		})
+
+		It("should reject a negative nesting depth", func() {
+			negative := -1
+			cfg := &config.Config{
+				Global: &config.GlobalConfig{MaxNestingDepth: &negative},
+			}
+			err := validator.Validate(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, ErrInvalidConfig)).To(BeTrue())
+		})
+
 		It("should collect multiple validation errors", func() {
 			negativeLength := -1
 			cfg := &config.Config{
@@ -98,6 +135,174 @@ var _ = Describe("Validator", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("should fail with invalid protected branch pattern in push config", func() {
+			cfg := &config.Config{
+				Validators: &config.ValidatorsConfig{
+					Git: &config.GitConfig{
+						Push: &config.PushValidatorConfig{
+							ProtectedBranches: []string{"main", "release/["},
+						},
+					},
+				},
+			}
+			err := validator.Validate(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, ErrInvalidConfig)).To(BeTrue())
+		})
+
+		It("should fail with empty protected branch in push config", func() {
+			cfg := &config.Config{
+				Validators: &config.ValidatorsConfig{
+					Git: &config.GitConfig{
+						Push: &config.PushValidatorConfig{
+							ProtectedBranches: []string{""},
+						},
+					},
+				},
+			}
+			err := validator.Validate(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, ErrInvalidConfig)).To(BeTrue())
+		})
+
+		It("should fail with invalid safe target pattern in shell destructive config", func() {
+			cfg := &config.Config{
+				Validators: &config.ValidatorsConfig{
+					Shell: &config.ShellConfig{
+						Destructive: &config.ShellDestructiveValidatorConfig{
+							SafeTargets: []string{"node_modules", "dist/["},
+						},
+					},
+				},
+			}
+			err := validator.Validate(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, ErrInvalidConfig)).To(BeTrue())
+		})
+
+		It("should fail with invalid pattern in sensitive read config", func() {
+			cfg := &config.Config{
+				Validators: &config.ValidatorsConfig{
+					File: &config.FileConfig{
+						SensitiveRead: &config.SensitiveReadValidatorConfig{
+							Allow: []string{"fixtures/["},
+						},
+					},
+				},
+			}
+			err := validator.Validate(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, ErrInvalidConfig)).To(BeTrue())
+		})
+
+		It("should fail with unknown sensitive read action", func() {
+			cfg := &config.Config{
+				Validators: &config.ValidatorsConfig{
+					File: &config.FileConfig{
+						SensitiveRead: &config.SensitiveReadValidatorConfig{
+							Action: "warn",
+						},
+					},
+				},
+			}
+			err := validator.Validate(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, ErrInvalidConfig)).To(BeTrue())
+		})
+
+		It("should fail with protected path without pattern or regex", func() {
+			cfg := &config.Config{
+				Validators: &config.ValidatorsConfig{
+					File: &config.FileConfig{
+						ProtectedPaths: &config.ProtectedPathsValidatorConfig{
+							Paths: []config.ProtectedPathConfig{{Message: "no target"}},
+						},
+					},
+				},
+			}
+			err := validator.Validate(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, ErrInvalidConfig)).To(BeTrue())
+		})
+
+		It("should fail with protected path with both pattern and regex", func() {
+			cfg := &config.Config{
+				Validators: &config.ValidatorsConfig{
+					File: &config.FileConfig{
+						ProtectedPaths: &config.ProtectedPathsValidatorConfig{
+							Paths: []config.ProtectedPathConfig{{Pattern: "/etc", Regex: "^/etc/"}},
+						},
+					},
+				},
+			}
+			err := validator.Validate(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, ErrInvalidConfig)).To(BeTrue())
+		})
+
+		It("should fail with invalid protected path regex", func() {
+			cfg := &config.Config{
+				Validators: &config.ValidatorsConfig{
+					File: &config.FileConfig{
+						ProtectedPaths: &config.ProtectedPathsValidatorConfig{
+							Paths: []config.ProtectedPathConfig{
+								{Pattern: "/etc"},
+								{Regex: "vendor/(["},
+							},
+						},
+					},
+				},
+			}
+			err := validator.Validate(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, ErrInvalidConfig)).To(BeTrue())
+		})
+
+		It("should fail with unknown protected path action", func() {
+			cfg := &config.Config{
+				Validators: &config.ValidatorsConfig{
+					File: &config.FileConfig{
+						ProtectedPaths: &config.ProtectedPathsValidatorConfig{
+							Paths: []config.ProtectedPathConfig{{Pattern: "/etc", Action: "deny"}},
+						},
+					},
+				},
+			}
+			err := validator.Validate(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, ErrInvalidConfig)).To(BeTrue())
+		})
+
+		It("should fail with absolute secrets baseline file", func() {
+			cfg := &config.Config{
+				Validators: &config.ValidatorsConfig{
+					Secrets: &config.SecretsConfig{
+						Secrets: &config.SecretsValidatorConfig{
+							BaselineFile: "/etc/secrets-baseline.json",
+						},
+					},
+				},
+			}
+			err := validator.Validate(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, ErrInvalidConfig)).To(BeTrue())
+		})
+
+		It("should fail with negative entropy threshold", func() {
+			cfg := &config.Config{
+				Validators: &config.ValidatorsConfig{
+					Secrets: &config.SecretsConfig{
+						Secrets: &config.SecretsValidatorConfig{
+							Entropy: &config.SecretsEntropyConfig{HexThreshold: -1},
+						},
+					},
+				},
+			}
+			err := validator.Validate(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, ErrInvalidConfig)).To(BeTrue())
+		})
+
 		It("should validate add config", func() {
 			cfg := &config.Config{
 				Validators: &config.ValidatorsConfig{
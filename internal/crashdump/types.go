@@ -52,7 +52,7 @@ type RuntimeInfo struct {
 
 // ContextInfo contains the hook context at the time of crash.
 type ContextInfo struct {
-	// EventType is the type of hook event (PreToolUse, PostToolUse, Notification).
+	// EventType is the type of hook event (PreToolUse, PostToolUse, Stop, ...).
 	EventType string `json:"event_type"`
 
 	// ToolName is the name of the tool being invoked.
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -66,6 +67,9 @@ type ValidationError struct {
 	// ShouldBlock indicates whether this error should block the operation.
 	ShouldBlock bool
 
+	// ShouldAsk indicates whether this error should escalate to the user for approval.
+	ShouldAsk bool
+
 	// Reference is the URL that uniquely identifies this error type.
 	// Format: https://klaudiu.sh/{CODE} (e.g., https://klaudiu.sh/GIT001).
 	Reference validator.Reference
@@ -96,6 +100,8 @@ type Dispatcher struct {
 	exceptionChecker   ExceptionChecker
 	sessionTracker     SessionTracker
 	sessionAuditLogger SessionAuditLogger
+	trace              *Trace
+	parserOpts         []parser.BashParserOption
 }
 
 // NewDispatcher creates a new Dispatcher with sequential execution.
@@ -150,6 +156,14 @@ func WithSessionAuditLogger(auditLogger SessionAuditLogger) DispatcherOption {
 	}
 }
 
+// WithMaxNestingDepth sets how many wrapper levels are unwrapped when parsing
+// Bash commands for file writes. Deeper commands ask for approval.
+func WithMaxNestingDepth(depth int) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.parserOpts = []parser.BashParserOption{parser.WithMaxNestingDepth(depth)}
+	}
+}
+
 // NewDispatcherWithOptions creates a new Dispatcher with options.
 func NewDispatcherWithOptions(
 	registry *validator.Registry,
@@ -178,6 +192,8 @@ func (d *Dispatcher) Dispatch(ctx context.Context, hookCtx *hook.Context) []*Val
 		"tool", hookCtx.ToolName,
 	)
 
+	var validationErrors []*ValidationError
+
 	// Check if session tracking is enabled and session is poisoned
 	if d.sessionTracker != nil && d.sessionTracker.IsEnabled() && hookCtx.HasSessionID() {
 		if poisoned, info := d.sessionTracker.IsPoisoned(hookCtx.SessionID); poisoned {
@@ -186,24 +202,39 @@ func (d *Dispatcher) Dispatch(ctx context.Context, hookCtx *hook.Context) []*Val
 				"poison_codes", info.PoisonCodes,
 			)
 
-			// Check for unpoison acknowledgment token
-			if !d.checkUnpoisonAcknowledgment(hookCtx, info) {
-				return []*ValidationError{createPoisonedSessionError(info)}
-			}
+			switch poisonHandlingFor(hookCtx) {
+			case poisonBlock:
+				// Check for unpoison acknowledgment token
+				if !d.checkUnpoisonAcknowledgment(hookCtx, info) {
+					d.traceSession(SessionEffectBlocked, info.PoisonCodes)
 
-			d.logger.Info("session unpoisoned via acknowledgment",
-				"session_id", hookCtx.SessionID,
-			)
+					return []*ValidationError{createPoisonedSessionError(info)}
+				}
+
+				d.traceSession(SessionEffectUnpoisoned, info.PoisonCodes)
+
+				d.logger.Info("session unpoisoned via acknowledgment",
+					"session_id", hookCtx.SessionID,
+				)
+			case poisonInform:
+				if notice := createPoisonedSessionNotice(info); notice != nil {
+					d.traceSession(SessionEffectInformed, info.PoisonCodes)
+
+					validationErrors = append(validationErrors, notice)
+				}
+			case poisonIgnore:
+			}
 		}
 	}
 
 	// Run validators on the main context
-	validationErrors := d.runValidators(ctx, hookCtx)
+	validationErrors = append(validationErrors, d.runValidators(ctx, hookCtx, "")...)
 
-	// If this is a Bash PreToolUse, also validate synthetic Write contexts for file writes
+	// If this is a Bash PreToolUse, also check wrapper nesting and validate synthetic
+	// Write contexts for file writes
 	if hookCtx.EventType == hook.EventTypePreToolUse && hookCtx.ToolName == hook.ToolTypeBash {
-		syntheticErrors := d.validateBashFileWrites(ctx, hookCtx)
-		validationErrors = append(validationErrors, syntheticErrors...)
+		bashErrors := d.validateBashCommand(ctx, hookCtx)
+		validationErrors = append(validationErrors, bashErrors...)
 	}
 
 	// Poison session if there are blocking errors, otherwise record command
@@ -218,6 +249,7 @@ func (d *Dispatcher) Dispatch(ctx context.Context, hookCtx *hook.Context) []*Val
 			)
 
 			d.sessionTracker.Poison(hookCtx.SessionID, codes, message)
+			d.traceSession(SessionEffectPoisoned, codes)
 
 			// Log audit entry for poison
 			d.logSessionAuditEntry(
@@ -227,9 +259,10 @@ func (d *Dispatcher) Dispatch(ctx context.Context, hookCtx *hook.Context) []*Val
 				"", // no source for poison (it's from validation failure)
 				message,
 			)
-		} else {
+		} else if !hookCtx.EventType.IsLifecycleEvent() {
 			// Record command when validation passes or only has warnings (no blocking errors)
 			d.sessionTracker.RecordCommand(hookCtx.SessionID)
+			d.traceSession(SessionEffectRecorded, nil)
 		}
 	}
 
@@ -237,7 +270,12 @@ func (d *Dispatcher) Dispatch(ctx context.Context, hookCtx *hook.Context) []*Val
 }
 
 // runValidators runs validators on a context and returns validation errors.
-func (d *Dispatcher) runValidators(ctx context.Context, hookCtx *hook.Context) []*ValidationError {
+// target is the file path of a synthetic Write context, empty otherwise.
+func (d *Dispatcher) runValidators(
+	ctx context.Context,
+	hookCtx *hook.Context,
+	target string,
+) []*ValidationError {
 	validators := d.registry.FindValidators(hookCtx)
 
 	if len(validators) == 0 {
@@ -246,6 +284,10 @@ func (d *Dispatcher) runValidators(ctx context.Context, hookCtx *hook.Context) [
 			"tool", hookCtx.ToolName,
 		)
 
+		if d.trace != nil {
+			d.trace.addValidators(hookCtx, target, d.registry.Registrations(), nil)
+		}
+
 		return nil
 	}
 
@@ -256,6 +298,10 @@ func (d *Dispatcher) runValidators(ctx context.Context, hookCtx *hook.Context) [
 	// Use executor to run validators (sequential or parallel)
 	validationErrors := d.executor.Execute(ctx, hookCtx, validators)
 
+	if d.trace != nil {
+		d.trace.addValidators(hookCtx, target, d.registry.Registrations(), validationErrors)
+	}
+
 	// Apply exception checking to blocking errors
 	validationErrors = d.applyExceptionChecking(hookCtx, validationErrors)
 
@@ -263,12 +309,18 @@ func (d *Dispatcher) runValidators(ctx context.Context, hookCtx *hook.Context) [
 	for _, verr := range validationErrors {
 		name := shortName(verr.Validator)
 
-		if verr.ShouldBlock {
+		switch {
+		case verr.ShouldBlock:
 			d.logger.Error("validator failed",
 				"validator", name,
 				"message", verr.Message,
 			)
-		} else {
+		case verr.ShouldAsk:
+			d.logger.Info("validator asked for approval",
+				"validator", name,
+				"message", verr.Message,
+			)
+		default:
 			d.logger.Info("validator warned",
 				"validator", name,
 				"message", verr.Message,
@@ -293,6 +345,10 @@ func (d *Dispatcher) applyExceptionChecking(
 	for _, verr := range errors {
 		modifiedErr, bypassed := d.exceptionChecker.CheckException(hookCtx, verr)
 
+		if d.trace != nil && verr.ShouldBlock {
+			d.trace.addException(verr, bypassed)
+		}
+
 		if bypassed {
 			d.logger.Info("validation error bypassed via exception",
 				"validator", verr.Validator,
@@ -309,24 +365,44 @@ func (d *Dispatcher) applyExceptionChecking(
 	return result
 }
 
-// validateBashFileWrites parses Bash commands for file writes and validates them
-// as synthetic Write operations.
-func (d *Dispatcher) validateBashFileWrites(
+// validateBashCommand parses a Bash command, asks for approval when its wrappers
+// nest too deep to be unwrapped and validates its file writes.
+func (d *Dispatcher) validateBashCommand(
 	ctx context.Context,
 	bashCtx *hook.Context,
 ) []*ValidationError {
 	// Parse the bash command
-	bashParser := parser.NewBashParser()
+	bashParser := parser.NewBashParser(d.parserOpts...)
 
 	result, err := bashParser.Parse(bashCtx.GetCommand())
 	if err != nil {
-		d.logger.Debug("failed to parse bash command for file writes",
+		d.logger.Debug("failed to parse bash command",
 			"error", err,
 		)
 
 		return nil
 	}
 
+	var validationErrors []*ValidationError
+
+	// Validators never see the commands below the nesting limit, so a passing
+	// result would not cover them
+	if result.Truncated {
+		d.logger.Info("bash command exceeds max nesting depth")
+
+		validationErrors = append(validationErrors, createNestingDepthError())
+	}
+
+	return append(validationErrors, d.validateBashFileWrites(ctx, bashCtx, result)...)
+}
+
+// validateBashFileWrites validates the file writes of a parsed Bash command as
+// synthetic Write operations.
+func (d *Dispatcher) validateBashFileWrites(
+	ctx context.Context,
+	bashCtx *hook.Context,
+	result *parser.ParseResult,
+) []*ValidationError {
 	// No file writes found
 	if len(result.FileWrites) == 0 {
 		return nil
@@ -343,9 +419,10 @@ func (d *Dispatcher) validateBashFileWrites(
 		syntheticCtx := &hook.Context{
 			EventType: hook.EventTypePreToolUse,
 			ToolName:  hook.ToolTypeWrite,
+			Cwd:       bashCtx.Cwd,
 			ToolInput: hook.ToolInput{
 				FilePath: fw.Path,
-				Content:  fw.Content,
+				Content:  d.fileWriteContent(&fw, bashCtx.Cwd),
 			},
 		}
 
@@ -355,13 +432,39 @@ func (d *Dispatcher) validateBashFileWrites(
 		)
 
 		// Run validators on the synthetic context
-		errors := d.runValidators(ctx, syntheticCtx)
+		errors := d.runValidators(ctx, syntheticCtx, fw.Path)
 		allErrors = append(allErrors, errors...)
 	}
 
 	return allErrors
 }
 
+// fileWriteContent returns the content a Bash file write produces. For sed -i
+// with simple substitutions the edit is applied to the file on disk, so linters
+// see the post-edit content.
+func (d *Dispatcher) fileWriteContent(fw *parser.FileWrite, cwd string) string {
+	if fw.InPlaceEdit == nil {
+		return fw.Content
+	}
+
+	path := fw.Path
+	if !filepath.IsAbs(path) && cwd != "" {
+		path = filepath.Join(cwd, path)
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // Path comes from the Bash command being validated
+	if err != nil {
+		d.logger.Debug("failed to read file for in-place edit",
+			"file", path,
+			"error", err,
+		)
+
+		return fw.Content
+	}
+
+	return fw.InPlaceEdit.Apply(string(data))
+}
+
 // checkUnpoisonAcknowledgment checks if the current command contains an unpoison token
 // that acknowledges all poison codes. If all codes are acknowledged, it unpoisons
 // the session and returns true. Otherwise, returns false.
@@ -448,6 +551,13 @@ func (d *Dispatcher) checkUnpoisonAcknowledgment(
 	return true
 }
 
+// traceSession records a session effect if tracing is enabled.
+func (d *Dispatcher) traceSession(effect string, codes []string) {
+	if d.trace != nil {
+		d.trace.addSession(effect, codes)
+	}
+}
+
 // logSessionAuditEntry logs a session audit entry if audit logging is enabled.
 func (d *Dispatcher) logSessionAuditEntry(
 	hookCtx *hook.Context,
@@ -501,20 +611,36 @@ func ShouldBlock(errors []*ValidationError) bool {
 	return false
 }
 
-// categorizeErrors separates validation errors into blocking errors and warnings.
-func categorizeErrors(errors []*ValidationError) (blocking, warnings []*ValidationError) {
+// ShouldAsk returns true if any non-blocking validation error asks for user approval.
+func ShouldAsk(errors []*ValidationError) bool {
+	for _, err := range errors {
+		if err.ShouldAsk && !err.ShouldBlock {
+			return true
+		}
+	}
+
+	return false
+}
+
+// categorizeErrors separates validation errors into blocking errors, approval
+// requests and warnings.
+func categorizeErrors(errors []*ValidationError) (blocking, asks, warnings []*ValidationError) {
 	blockingErrors := make([]*ValidationError, 0)
+	askErrors := make([]*ValidationError, 0)
 	warningErrors := make([]*ValidationError, 0)
 
 	for _, err := range errors {
-		if err.ShouldBlock {
+		switch {
+		case err.ShouldBlock:
 			blockingErrors = append(blockingErrors, err)
-		} else {
+		case err.ShouldAsk:
+			askErrors = append(askErrors, err)
+		default:
 			warningErrors = append(warningErrors, err)
 		}
 	}
 
-	return blockingErrors, warningErrors
+	return blockingErrors, askErrors, warningErrors
 }
 
 // formatErrorList formats a list of errors with a header.
@@ -598,9 +724,10 @@ func FormatErrors(errors []*ValidationError) string {
 		return ""
 	}
 
-	blockingErrors, warnings := categorizeErrors(errors)
+	blockingErrors, asks, warnings := categorizeErrors(errors)
 
 	result := formatErrorList("❌ Validation Failed:", blockingErrors)
+	result += formatErrorList("❓ Approval Required:", asks)
 	result += formatErrorList("⚠️  Warnings:", warnings)
 
 	return result
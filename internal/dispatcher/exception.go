@@ -67,7 +67,8 @@ func (c *DefaultExceptionChecker) CheckException(
 		return verr, false
 	}
 
-	// Only check blocking errors
+	// Only check blocking errors. Approval requests are never bypassed, since
+	// the exception token is written by the agent the user is asked to approve.
 	if !verr.ShouldBlock {
 		return verr, false
 	}
@@ -198,6 +198,7 @@ func toValidationError(v validator.Validator, result *validator.Result) *Validat
 		Message:     result.Message,
 		Details:     result.Details,
 		ShouldBlock: result.ShouldBlock,
+		ShouldAsk:   result.ShouldAsk,
 		Reference:   result.Reference,
 		FixHint:     result.FixHint,
 	}
@@ -0,0 +1,90 @@
+package dispatcher_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/dispatcher"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// writeRecorder is a test validator that records the synthetic Write contexts it sees.
+type writeRecorder struct {
+	writes []hook.ToolInput
+}
+
+func (*writeRecorder) Name() string {
+	return "validate-write-recorder"
+}
+
+func (v *writeRecorder) Validate(_ context.Context, hookCtx *hook.Context) *validator.Result {
+	v.writes = append(v.writes, hookCtx.ToolInput)
+
+	return validator.Pass()
+}
+
+func (*writeRecorder) Category() validator.ValidatorCategory {
+	return validator.CategoryCPU
+}
+
+var _ = Describe("Bash file writes", func() {
+	var (
+		recorder *writeRecorder
+		disp     *dispatcher.Dispatcher
+		dir      string
+	)
+
+	BeforeEach(func() {
+		log := logger.NewNoOpLogger()
+		reg := validator.NewRegistry()
+		recorder = &writeRecorder{}
+		reg.Register(recorder, validator.ToolTypeIs(hook.ToolTypeWrite))
+
+		disp = dispatcher.NewDispatcherWithOptions(reg, log, dispatcher.NewSequentialExecutor(log))
+		dir = GinkgoT().TempDir()
+	})
+
+	dispatch := func(command string) {
+		disp.Dispatch(context.Background(), &hook.Context{
+			EventType: hook.EventTypePreToolUse,
+			ToolName:  hook.ToolTypeBash,
+			ToolInput: hook.ToolInput{Command: command},
+			Cwd:       dir,
+		})
+	}
+
+	It("validates in-place edits as synthetic writes", func() {
+		dispatch("perl -pi -e 's/a/b/' notes.md && ln -sf notes.md README.md")
+
+		Expect(recorder.writes).To(HaveLen(2))
+		Expect(recorder.writes[0].FilePath).To(Equal("notes.md"))
+		Expect(recorder.writes[1].FilePath).To(Equal("README.md"))
+	})
+
+	It("passes the post-edit content of sed -i substitutions", func() {
+		Expect(os.WriteFile(
+			filepath.Join(dir, "README.md"),
+			[]byte("# Title\nold text\n"),
+			0o600,
+		)).To(Succeed())
+
+		dispatch("sed -i 's/old/new/' README.md")
+
+		Expect(recorder.writes).To(HaveLen(1))
+		Expect(recorder.writes[0].FilePath).To(Equal("README.md"))
+		Expect(recorder.writes[0].Content).To(Equal("# Title\nnew text\n"))
+	})
+
+	It("leaves the content empty when the file cannot be read", func() {
+		dispatch("sed -i 's/old/new/' missing.md")
+
+		Expect(recorder.writes).To(HaveLen(1))
+		Expect(recorder.writes[0].Content).To(BeEmpty())
+	})
+})
@@ -0,0 +1,24 @@
+package dispatcher
+
+import (
+	"github.com/smykla-labs/klaudiush/internal/validator"
+)
+
+const (
+	// nestingDepthValidator is the validator name for commands nested too deep.
+	nestingDepthValidator = "nesting-depth"
+)
+
+// createNestingDepthError creates a validation error for a Bash command whose
+// wrappers nest deeper than global.max_nesting_depth. The innermost commands
+// were not validated, so the user is asked to approve the command.
+func createNestingDepthError() *ValidationError {
+	return &ValidationError{
+		Validator: nestingDepthValidator,
+		Message: "Command nests wrappers such as bash -c, eval or sudo deeper than " +
+			"global.max_nesting_depth, so the innermost commands were not validated",
+		ShouldAsk: true,
+		Reference: validator.RefShellNestingDepth,
+		FixHint:   validator.GetSuggestion(validator.RefShellNestingDepth),
+	}
+}
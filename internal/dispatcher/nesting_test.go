@@ -0,0 +1,52 @@
+package dispatcher_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/dispatcher"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+var _ = Describe("Nesting depth", func() {
+	const nested = `bash -c "bash -c 'bash -c \"bash -c \\\"rm -rf /\\\"\"'"`
+
+	dispatch := func(command string, opts ...dispatcher.DispatcherOption) []*dispatcher.ValidationError {
+		log := logger.NewNoOpLogger()
+		disp := dispatcher.NewDispatcherWithOptions(
+			validator.NewRegistry(),
+			log,
+			dispatcher.NewSequentialExecutor(log),
+			opts...,
+		)
+
+		return disp.Dispatch(context.Background(), &hook.Context{
+			EventType: hook.EventTypePreToolUse,
+			ToolName:  hook.ToolTypeBash,
+			ToolInput: hook.ToolInput{Command: command},
+		})
+	}
+
+	It("asks for approval when wrappers nest deeper than the limit", func() {
+		errs := dispatch(nested)
+
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].ShouldAsk).To(BeTrue())
+		Expect(errs[0].ShouldBlock).To(BeFalse())
+		Expect(errs[0].Reference).To(Equal(validator.RefShellNestingDepth))
+		Expect(dispatcher.ShouldAsk(errs)).To(BeTrue())
+	})
+
+	It("passes wrappers within the limit", func() {
+		Expect(dispatch(nested, dispatcher.WithMaxNestingDepth(4))).To(BeEmpty())
+		Expect(dispatch(`sudo bash -c "git status"`)).To(BeEmpty())
+	})
+
+	It("passes wrappers when unwrapping is disabled", func() {
+		Expect(dispatch(nested, dispatcher.WithMaxNestingDepth(0))).To(BeEmpty())
+	})
+})
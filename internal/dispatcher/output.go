@@ -0,0 +1,213 @@
+package dispatcher
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+)
+
+// PermissionDecision is the PreToolUse permission decision reported to Claude Code.
+type PermissionDecision string
+
+const (
+	// PermissionAllow bypasses the Claude Code permission system.
+	// Never emitted automatically: passing validation defers to the normal permission flow.
+	PermissionAllow PermissionDecision = "allow"
+
+	// PermissionDeny prevents the tool call and shows the reason to Claude.
+	PermissionDeny PermissionDecision = "deny"
+
+	// PermissionAsk asks the user to confirm the tool call in the UI.
+	PermissionAsk PermissionDecision = "ask"
+)
+
+// decisionBlock is the top-level decision used by events without permission decisions.
+const decisionBlock = "block"
+
+// HookOutput is the JSON document written to stdout for Claude Code's hook protocol.
+type HookOutput struct {
+	// Continue controls whether Claude should continue after the hook runs.
+	// Nil leaves the default (continue) in place.
+	Continue *bool `json:"continue,omitempty"`
+
+	// StopReason is shown to the user when Continue is false.
+	StopReason string `json:"stopReason,omitempty"`
+
+	// SuppressOutput hides stdout from the transcript.
+	SuppressOutput bool `json:"suppressOutput,omitempty"`
+
+	// SystemMessage is an optional warning shown to the user.
+	SystemMessage string `json:"systemMessage,omitempty"`
+
+	// Decision is the top-level decision for events other than PreToolUse ("block" or empty).
+	Decision string `json:"decision,omitempty"`
+
+	// Reason explains the top-level decision to Claude.
+	Reason string `json:"reason,omitempty"`
+
+	// HookSpecificOutput carries event-specific fields.
+	HookSpecificOutput *HookSpecificOutput `json:"hookSpecificOutput,omitempty"`
+}
+
+// HookSpecificOutput contains event-specific hook output fields.
+type HookSpecificOutput struct {
+	// HookEventName is the name of the event this output belongs to.
+	HookEventName string `json:"hookEventName"`
+
+	// PermissionDecision is the PreToolUse permission decision.
+	PermissionDecision PermissionDecision `json:"permissionDecision,omitempty"`
+
+	// PermissionDecisionReason explains the decision (shown to Claude for deny, to the user for ask).
+	PermissionDecisionReason string `json:"permissionDecisionReason,omitempty"`
+
+	// AdditionalContext is added to Claude's context without affecting the decision.
+	AdditionalContext string `json:"additionalContext,omitempty"`
+}
+
+// BuildHookOutput converts validation errors into a hook output document.
+// Blocking errors map to deny, approval requests to ask and warnings to
+// additional context. Returns an output without a decision when errors is empty.
+func BuildHookOutput(eventType hook.EventType, errors []*ValidationError) *HookOutput {
+	blocking, asks, warnings := categorizeErrors(errors)
+
+	switch eventType {
+	case hook.EventTypePreToolUse:
+		return buildPermissionOutput(eventType, blocking, asks, warnings)
+	case hook.EventTypeSessionStart, hook.EventTypeUserPromptSubmit:
+		return buildContextOutput(eventType, blocking, asks, warnings)
+	default:
+		return buildGenericOutput(blocking, asks, warnings)
+	}
+}
+
+// buildPermissionOutput builds output for events with permission decisions.
+func buildPermissionOutput(
+	eventType hook.EventType,
+	blocking, asks, warnings []*ValidationError,
+) *HookOutput {
+	specific := &HookSpecificOutput{
+		HookEventName:     eventType.String(),
+		AdditionalContext: formatContext(warnings),
+	}
+
+	switch {
+	case len(blocking) > 0:
+		specific.PermissionDecision = PermissionDeny
+		specific.PermissionDecisionReason = formatReason(blocking, asks)
+	case len(asks) > 0:
+		specific.PermissionDecision = PermissionAsk
+		specific.PermissionDecisionReason = formatReason(asks, nil)
+	}
+
+	if specific.PermissionDecision == "" && specific.AdditionalContext == "" {
+		return &HookOutput{}
+	}
+
+	return &HookOutput{HookSpecificOutput: specific}
+}
+
+// buildGenericOutput builds output for events that have no permission decision.
+// Approval requests cannot be expressed there, so they are treated as blocking.
+func buildGenericOutput(blocking, asks, warnings []*ValidationError) *HookOutput {
+	output := &HookOutput{}
+
+	if len(blocking) > 0 || len(asks) > 0 {
+		output.Decision = decisionBlock
+		output.Reason = formatReason(blocking, asks)
+	}
+
+	output.SystemMessage = formatContext(warnings)
+
+	return output
+}
+
+// buildContextOutput builds output for events that can add context for Claude.
+// SessionStart cannot be blocked, so all findings become context there.
+// UserPromptSubmit blocks the prompt on blocking errors and approval requests.
+func buildContextOutput(
+	eventType hook.EventType,
+	blocking, asks, warnings []*ValidationError,
+) *HookOutput {
+	if eventType == hook.EventTypeSessionStart {
+		all := make([]*ValidationError, 0, len(blocking)+len(asks)+len(warnings))
+		all = append(all, blocking...)
+		all = append(all, asks...)
+		warnings = append(all, warnings...)
+		blocking, asks = nil, nil
+	}
+
+	output := buildGenericOutput(blocking, asks, nil)
+
+	if context := formatContext(warnings); context != "" {
+		output.HookSpecificOutput = &HookSpecificOutput{
+			HookEventName:     eventType.String(),
+			AdditionalContext: context,
+		}
+	}
+
+	return output
+}
+
+// InjectsContext returns true if stdout of a passing hook is added to Claude's
+// context for the event, rather than only being shown in the transcript.
+func InjectsContext(eventType hook.EventType) bool {
+	return eventType == hook.EventTypeSessionStart ||
+		eventType == hook.EventTypeUserPromptSubmit
+}
+
+// IsFeedbackEvent returns true if the event runs after the tool call has
+// completed, so failures cannot block it and are reported to Claude as
+// "decision": "block" feedback JSON regardless of the output format.
+func IsFeedbackEvent(eventType hook.EventType) bool {
+	return eventType == hook.EventTypePostToolUse
+}
+
+// formatReason formats the primary errors followed by any secondary errors.
+func formatReason(primary, secondary []*ValidationError) string {
+	combined := make([]*ValidationError, 0, len(primary)+len(secondary))
+	combined = append(combined, primary...)
+	combined = append(combined, secondary...)
+
+	return strings.TrimSpace(FormatErrors(combined))
+}
+
+// formatContext formats warnings as plain text for additional context.
+func formatContext(warnings []*ValidationError) string {
+	return strings.TrimSpace(FormatErrors(warnings))
+}
+
+// WriteHookOutput encodes the hook output as JSON and writes it to w.
+// Empty outputs are skipped so that passing validations print nothing.
+func WriteHookOutput(w io.Writer, output *HookOutput) error {
+	if output == nil || output.IsEmpty() {
+		return nil
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode hook output")
+	}
+
+	data = append(data, '\n')
+
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "failed to write hook output")
+	}
+
+	return nil
+}
+
+// IsEmpty returns true if the output carries no decision, message or context.
+func (o *HookOutput) IsEmpty() bool {
+	return o.Continue == nil &&
+		o.StopReason == "" &&
+		!o.SuppressOutput &&
+		o.SystemMessage == "" &&
+		o.Decision == "" &&
+		o.Reason == "" &&
+		o.HookSpecificOutput == nil
+}
@@ -0,0 +1,184 @@
+package dispatcher_test
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/dispatcher"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+)
+
+var _ = Describe("HookOutput", func() {
+	var (
+		blockErr = &dispatcher.ValidationError{
+			Validator:   "git.commit",
+			Message:     "missing signoff",
+			ShouldBlock: true,
+			Reference:   validator.RefGitNoSignoff,
+		}
+		askErr = &dispatcher.ValidationError{
+			Validator: "git.push",
+			Message:   "force push to release branch",
+			ShouldAsk: true,
+		}
+		warnErr = &dispatcher.ValidationError{
+			Validator: "file.markdown",
+			Message:   "line too long",
+		}
+	)
+
+	Describe("BuildHookOutput", func() {
+		It("returns an empty output when there are no errors", func() {
+			output := dispatcher.BuildHookOutput(hook.EventTypePreToolUse, nil)
+			Expect(output.IsEmpty()).To(BeTrue())
+		})
+
+		It("maps blocking errors to deny", func() {
+			output := dispatcher.BuildHookOutput(
+				hook.EventTypePreToolUse,
+				[]*dispatcher.ValidationError{blockErr},
+			)
+
+			Expect(output.HookSpecificOutput).NotTo(BeNil())
+			Expect(output.HookSpecificOutput.HookEventName).To(Equal("PreToolUse"))
+			Expect(output.HookSpecificOutput.PermissionDecision).To(Equal(dispatcher.PermissionDeny))
+			Expect(output.HookSpecificOutput.PermissionDecisionReason).To(ContainSubstring("missing signoff"))
+			Expect(output.HookSpecificOutput.PermissionDecisionReason).To(ContainSubstring("GIT001"))
+		})
+
+		It("maps ask errors to ask", func() {
+			output := dispatcher.BuildHookOutput(
+				hook.EventTypePreToolUse,
+				[]*dispatcher.ValidationError{askErr},
+			)
+
+			Expect(output.HookSpecificOutput.PermissionDecision).To(Equal(dispatcher.PermissionAsk))
+			Expect(output.HookSpecificOutput.PermissionDecisionReason).
+				To(ContainSubstring("force push to release branch"))
+		})
+
+		It("prefers deny over ask and includes both reasons", func() {
+			output := dispatcher.BuildHookOutput(
+				hook.EventTypePreToolUse,
+				[]*dispatcher.ValidationError{askErr, blockErr},
+			)
+
+			Expect(output.HookSpecificOutput.PermissionDecision).To(Equal(dispatcher.PermissionDeny))
+			Expect(output.HookSpecificOutput.PermissionDecisionReason).To(ContainSubstring("missing signoff"))
+			Expect(output.HookSpecificOutput.PermissionDecisionReason).
+				To(ContainSubstring("force push to release branch"))
+		})
+
+		It("maps warnings to additional context without a decision", func() {
+			output := dispatcher.BuildHookOutput(
+				hook.EventTypePreToolUse,
+				[]*dispatcher.ValidationError{warnErr},
+			)
+
+			Expect(output.HookSpecificOutput.PermissionDecision).To(BeEmpty())
+			Expect(output.HookSpecificOutput.AdditionalContext).To(ContainSubstring("line too long"))
+		})
+
+		It("uses a top-level block decision for other events", func() {
+			output := dispatcher.BuildHookOutput(
+				hook.EventTypePostToolUse,
+				[]*dispatcher.ValidationError{askErr, warnErr},
+			)
+
+			Expect(output.HookSpecificOutput).To(BeNil())
+			Expect(output.Decision).To(Equal("block"))
+			Expect(output.Reason).To(ContainSubstring("force push to release branch"))
+			Expect(output.SystemMessage).To(ContainSubstring("line too long"))
+		})
+	})
+
+	Describe("BuildHookOutput for context events", func() {
+		It("adds SessionStart findings as context without a decision", func() {
+			output := dispatcher.BuildHookOutput(
+				hook.EventTypeSessionStart,
+				[]*dispatcher.ValidationError{blockErr, warnErr},
+			)
+
+			Expect(output.Decision).To(BeEmpty())
+			Expect(output.HookSpecificOutput).NotTo(BeNil())
+			Expect(output.HookSpecificOutput.HookEventName).To(Equal("SessionStart"))
+			Expect(output.HookSpecificOutput.AdditionalContext).To(ContainSubstring("missing signoff"))
+			Expect(output.HookSpecificOutput.AdditionalContext).To(ContainSubstring("line too long"))
+		})
+
+		It("blocks UserPromptSubmit and keeps warnings as context", func() {
+			output := dispatcher.BuildHookOutput(
+				hook.EventTypeUserPromptSubmit,
+				[]*dispatcher.ValidationError{blockErr, warnErr},
+			)
+
+			Expect(output.Decision).To(Equal("block"))
+			Expect(output.Reason).To(ContainSubstring("missing signoff"))
+			Expect(output.HookSpecificOutput.HookEventName).To(Equal("UserPromptSubmit"))
+			Expect(output.HookSpecificOutput.AdditionalContext).To(ContainSubstring("line too long"))
+		})
+
+		It("returns an empty output when there are no errors", func() {
+			output := dispatcher.BuildHookOutput(hook.EventTypeSessionStart, nil)
+			Expect(output.IsEmpty()).To(BeTrue())
+		})
+	})
+
+	Describe("IsFeedbackEvent", func() {
+		It("returns true for PostToolUse only", func() {
+			Expect(dispatcher.IsFeedbackEvent(hook.EventTypePostToolUse)).To(BeTrue())
+			Expect(dispatcher.IsFeedbackEvent(hook.EventTypePreToolUse)).To(BeFalse())
+			Expect(dispatcher.IsFeedbackEvent(hook.EventTypeStop)).To(BeFalse())
+		})
+	})
+
+	Describe("WriteHookOutput", func() {
+		It("writes nothing for empty output", func() {
+			var buf bytes.Buffer
+
+			Expect(dispatcher.WriteHookOutput(&buf, &dispatcher.HookOutput{})).To(Succeed())
+			Expect(buf.String()).To(BeEmpty())
+		})
+
+		It("writes the Claude Code JSON protocol fields", func() {
+			var buf bytes.Buffer
+
+			output := dispatcher.BuildHookOutput(
+				hook.EventTypePreToolUse,
+				[]*dispatcher.ValidationError{blockErr},
+			)
+			Expect(dispatcher.WriteHookOutput(&buf, output)).To(Succeed())
+
+			var decoded map[string]any
+			Expect(json.Unmarshal(buf.Bytes(), &decoded)).To(Succeed())
+
+			specific, ok := decoded["hookSpecificOutput"].(map[string]any)
+			Expect(ok).To(BeTrue())
+			Expect(specific["hookEventName"]).To(Equal("PreToolUse"))
+			Expect(specific["permissionDecision"]).To(Equal("deny"))
+			Expect(specific).To(HaveKey("permissionDecisionReason"))
+		})
+	})
+
+	Describe("ShouldAsk", func() {
+		It("returns true for non-blocking ask errors", func() {
+			Expect(dispatcher.ShouldAsk([]*dispatcher.ValidationError{askErr})).To(BeTrue())
+		})
+
+		It("returns false for warnings only", func() {
+			Expect(dispatcher.ShouldAsk([]*dispatcher.ValidationError{warnErr})).To(BeFalse())
+		})
+	})
+
+	Describe("FormatErrors", func() {
+		It("lists approval requests in their own section", func() {
+			msg := dispatcher.FormatErrors([]*dispatcher.ValidationError{askErr})
+			Expect(msg).To(ContainSubstring("Approval Required"))
+			Expect(msg).NotTo(ContainSubstring("Validation Failed"))
+		})
+	})
+})
@@ -6,6 +6,7 @@ import (
 
 	"github.com/smykla-labs/klaudiush/internal/session"
 	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
 )
 
 const (
@@ -66,6 +67,54 @@ func createPoisonedSessionError(info *session.SessionInfo) *ValidationError {
 	}
 }
 
+// poisonHandling describes how a poisoned session affects an event.
+type poisonHandling int
+
+const (
+	// poisonBlock blocks the event until the violations are acknowledged.
+	poisonBlock poisonHandling = iota
+
+	// poisonInform adds the poisoned state to Claude's context without blocking.
+	poisonInform
+
+	// poisonIgnore lets the event through unchanged.
+	poisonIgnore
+)
+
+// poisonHandlingFor returns how a poisoned session affects the event.
+// Prompts and session starts cannot be meaningfully blocked, so the poisoned
+// state is injected as context instead. Stops are blocked so Claude has to
+// address the violations, except when Claude is already continuing because
+// of a stop hook, which would otherwise loop forever.
+func poisonHandlingFor(hookCtx *hook.Context) poisonHandling {
+	switch {
+	case hookCtx.EventType == hook.EventTypeSessionStart,
+		hookCtx.EventType == hook.EventTypeUserPromptSubmit:
+		return poisonInform
+	case hookCtx.EventType == hook.EventTypeSessionEnd,
+		hookCtx.EventType == hook.EventTypePreCompact:
+		return poisonIgnore
+	case hookCtx.EventType.IsStopEvent() && hookCtx.StopHookActive:
+		return poisonIgnore
+	default:
+		return poisonBlock
+	}
+}
+
+// createPoisonedSessionNotice creates a non-blocking notice describing a
+// poisoned session, used to inject session state into Claude's context.
+func createPoisonedSessionNotice(info *session.SessionInfo) *ValidationError {
+	notice := createPoisonedSessionError(info)
+	if notice == nil {
+		return nil
+	}
+
+	notice.Message = strings.Replace(notice.Message, "Blocked: session", "Session", 1)
+	notice.ShouldBlock = false
+
+	return notice
+}
+
 // extractSessionPoisonCodes extracts all error codes from blocking validation errors.
 // Returns a slice of codes from all blocking errors with references.
 func extractSessionPoisonCodes(errors []*ValidationError) []string {
@@ -187,6 +187,35 @@ var _ = Describe("Dispatcher Session Integration", func() {
 			Expect(poisoned).To(BeFalse())
 		})
 
+		It("should not poison session for approval requests", func() {
+			reg.Register(
+				&mockAskValidator{name: "test-ask"},
+				validator.And(
+					validator.EventTypeIs(hook.EventTypePreToolUse),
+					validator.ToolTypeIs(hook.ToolTypeWrite),
+				),
+			)
+
+			hookCtx := &hook.Context{
+				EventType: hook.EventTypePreToolUse,
+				ToolName:  hook.ToolTypeWrite,
+				SessionID: "test-session-ask",
+				ToolInput: hook.ToolInput{
+					FilePath: "test.txt",
+					Content:  "test",
+				},
+			}
+
+			errs := disp.Dispatch(ctx, hookCtx)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].ShouldAsk).To(BeTrue())
+			Expect(errs[0].ShouldBlock).To(BeFalse())
+
+			// Session should not be poisoned
+			poisoned, _ := tracker.IsPoisoned("test-session-ask")
+			Expect(poisoned).To(BeFalse())
+		})
+
 		It("should record command count for clean sessions", func() {
 			sessionID := "test-session-6"
 
@@ -526,6 +555,66 @@ var _ = Describe("Dispatcher Session Integration", func() {
 		})
 	})
 
+	Context("lifecycle events in a poisoned session", func() {
+		const sessionID = "test-session-lifecycle"
+
+		BeforeEach(func() {
+			disp = dispatcher.NewDispatcherWithOptions(
+				reg,
+				log,
+				dispatcher.NewSequentialExecutor(log),
+				dispatcher.WithSessionTracker(tracker),
+			)
+
+			tracker.Poison(sessionID, []string{"GIT001"}, "commit error")
+		})
+
+		It("should block Stop", func() {
+			errs := disp.Dispatch(ctx, &hook.Context{
+				EventType: hook.EventTypeStop,
+				SessionID: sessionID,
+			})
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].ShouldBlock).To(BeTrue())
+			Expect(errs[0].Reference.Code()).To(Equal("SESS001"))
+		})
+
+		It("should not block Stop when a stop hook is already active", func() {
+			errs := disp.Dispatch(ctx, &hook.Context{
+				EventType:      hook.EventTypeSubagentStop,
+				SessionID:      sessionID,
+				StopHookActive: true,
+			})
+
+			Expect(errs).To(BeEmpty())
+		})
+
+		It("should inform at SessionStart without blocking", func() {
+			errs := disp.Dispatch(ctx, &hook.Context{
+				EventType: hook.EventTypeSessionStart,
+				SessionID: sessionID,
+				Source:    "resume",
+			})
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].ShouldBlock).To(BeFalse())
+			Expect(errs[0].Message).To(HavePrefix("Session poisoned by GIT001"))
+
+			poisoned, _ := tracker.IsPoisoned(sessionID)
+			Expect(poisoned).To(BeTrue())
+		})
+
+		It("should ignore PreCompact", func() {
+			errs := disp.Dispatch(ctx, &hook.Context{
+				EventType: hook.EventTypePreCompact,
+				SessionID: sessionID,
+			})
+
+			Expect(errs).To(BeEmpty())
+		})
+	})
+
 	Context("lenient fallback for complex commands", func() {
 		BeforeEach(func() {
 			// Create dispatcher with session tracker (no validators needed for this test)
@@ -1057,3 +1146,20 @@ func (m *mockSessionAuditLogger) Log(entry *session.AuditEntry) error {
 func (m *mockSessionAuditLogger) IsEnabled() bool {
 	return m.enabled
 }
+
+// mockAskValidator is a test validator that always asks for approval.
+type mockAskValidator struct {
+	name string
+}
+
+func (v *mockAskValidator) Name() string {
+	return v.name
+}
+
+func (*mockAskValidator) Validate(_ context.Context, _ *hook.Context) *validator.Result {
+	return validator.Ask("needs approval")
+}
+
+func (*mockAskValidator) Category() validator.ValidatorCategory {
+	return validator.CategoryCPU
+}
@@ -0,0 +1,262 @@
+package dispatcher
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+)
+
+// Validator outcomes recorded in a Trace.
+const (
+	// OutcomePass means the validator passed.
+	OutcomePass = "pass"
+
+	// OutcomeWarn means the validator returned a non-blocking warning.
+	OutcomeWarn = "warn"
+
+	// OutcomeAsk means the validator asked for user approval.
+	OutcomeAsk = "ask"
+
+	// OutcomeBlock means the validator blocked the operation.
+	OutcomeBlock = "block"
+)
+
+// Session effects recorded in a Trace.
+const (
+	// SessionEffectBlocked means the session was poisoned and the operation blocked.
+	SessionEffectBlocked = "blocked"
+
+	// SessionEffectInformed means the session was poisoned and a notice was added.
+	SessionEffectInformed = "informed"
+
+	// SessionEffectUnpoisoned means an acknowledgment token unpoisoned the session.
+	SessionEffectUnpoisoned = "unpoisoned"
+
+	// SessionEffectPoisoned means a blocking error poisoned the session.
+	SessionEffectPoisoned = "poisoned"
+
+	// SessionEffectRecorded means the command was counted for the session.
+	SessionEffectRecorded = "recorded"
+)
+
+// ValidatorTrace records how a registered validator handled a context.
+type ValidatorTrace struct {
+	// Validator is the validator name.
+	Validator string `json:"validator"`
+
+	// Category is the validator category (cpu, io, git).
+	Category string `json:"category"`
+
+	// Target is the file path of a synthetic Write context created for a Bash
+	// file write. Empty for the hook context itself.
+	Target string `json:"target,omitempty"`
+
+	// Matched is true if the validator predicate matched the context.
+	Matched bool `json:"matched"`
+
+	// Outcome is the validator result, empty if the predicate did not match.
+	Outcome string `json:"outcome,omitempty"`
+
+	// Message is the validator message.
+	Message string `json:"message,omitempty"`
+
+	// Reference is the error reference URL.
+	Reference string `json:"reference,omitempty"`
+}
+
+// RuleTrace records a rule that matched during validation.
+type RuleTrace struct {
+	// Rule is the rule name.
+	Rule string `json:"rule"`
+
+	// ValidatorType is the validator type the rule was evaluated for.
+	ValidatorType string `json:"validator_type"`
+
+	// Action is the rule action.
+	Action string `json:"action"`
+
+	// Message is the rule message.
+	Message string `json:"message,omitempty"`
+}
+
+// ExceptionTrace records an exception check of a blocking error.
+type ExceptionTrace struct {
+	// Validator is the validator whose error was checked.
+	Validator string `json:"validator"`
+
+	// Reference is the error reference URL.
+	Reference string `json:"reference,omitempty"`
+
+	// Bypassed is true if an exception token bypassed the error.
+	Bypassed bool `json:"bypassed"`
+}
+
+// SessionTrace records an effect of session tracking.
+type SessionTrace struct {
+	// Effect is one of the SessionEffect* constants.
+	Effect string `json:"effect"`
+
+	// Codes are the poison codes involved, if any.
+	Codes []string `json:"codes,omitempty"`
+}
+
+// Trace records how a dispatch reached its decision: every validator consulted,
+// the rules that matched and the exception and session effects. It is filled
+// by a Dispatcher created with WithTrace, e.g. to explain a decision offline.
+type Trace struct {
+	mu sync.Mutex
+
+	validators []ValidatorTrace
+	rules      []RuleTrace
+	exceptions []ExceptionTrace
+	session    []SessionTrace
+}
+
+// NewTrace creates an empty Trace.
+func NewTrace() *Trace {
+	return &Trace{}
+}
+
+// WithTrace records dispatch decisions in the given trace.
+func WithTrace(trace *Trace) DispatcherOption {
+	return func(d *Dispatcher) {
+		if trace != nil {
+			d.trace = trace
+		}
+	}
+}
+
+// Validators returns the recorded validators in registration order, grouped
+// by context.
+func (t *Trace) Validators() []ValidatorTrace {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return slices.Clone(t.validators)
+}
+
+// Rules returns the recorded rule matches.
+func (t *Trace) Rules() []RuleTrace {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return slices.Clone(t.rules)
+}
+
+// Exceptions returns the recorded exception checks.
+func (t *Trace) Exceptions() []ExceptionTrace {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return slices.Clone(t.exceptions)
+}
+
+// Session returns the recorded session effects.
+func (t *Trace) Session() []SessionTrace {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return slices.Clone(t.session)
+}
+
+// AddRule records a rule match. Rules are evaluated inside validators, so the
+// rule engine reports matches through this method.
+func (t *Trace) AddRule(rule RuleTrace) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.rules = append(t.rules, rule)
+}
+
+// addValidators records the registered validators for a context, with the
+// results of the matched ones.
+func (t *Trace) addValidators(
+	hookCtx *hook.Context,
+	target string,
+	registrations []validator.Registration,
+	errs []*ValidationError,
+) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, reg := range registrations {
+		entry := ValidatorTrace{
+			Validator: reg.Validator.Name(),
+			Category:  reg.Validator.Category().String(),
+			Target:    target,
+			Matched:   reg.Predicate(hookCtx),
+		}
+
+		if entry.Matched {
+			entry.Outcome = OutcomePass
+
+			if verr := findValidationError(errs, entry.Validator); verr != nil {
+				entry.Outcome = outcomeOf(verr)
+				entry.Message = verr.Message
+				entry.Reference = string(verr.Reference)
+			}
+		}
+
+		t.validators = append(t.validators, entry)
+	}
+}
+
+// addException records an exception check.
+func (t *Trace) addException(verr *ValidationError, bypassed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.exceptions = append(t.exceptions, ExceptionTrace{
+		Validator: verr.Validator,
+		Reference: string(verr.Reference),
+		Bypassed:  bypassed,
+	})
+}
+
+// addSession records a session effect.
+func (t *Trace) addSession(effect string, codes []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.session = append(t.session, SessionTrace{Effect: effect, Codes: codes})
+}
+
+// findValidationError returns the error of the named validator, if any.
+func findValidationError(errs []*ValidationError, name string) *ValidationError {
+	for _, verr := range errs {
+		if verr.Validator == name {
+			return verr
+		}
+	}
+
+	return nil
+}
+
+// outcomeOf returns the outcome of a validation error.
+func outcomeOf(verr *ValidationError) string {
+	switch {
+	case verr.ShouldBlock:
+		return OutcomeBlock
+	case verr.ShouldAsk:
+		return OutcomeAsk
+	default:
+		return OutcomeWarn
+	}
+}
+
+// Decision returns the overall outcome of validation errors: block, ask, warn
+// or pass.
+func Decision(errs []*ValidationError) string {
+	switch {
+	case ShouldBlock(errs):
+		return OutcomeBlock
+	case ShouldAsk(errs):
+		return OutcomeAsk
+	case len(errs) > 0:
+		return OutcomeWarn
+	default:
+		return OutcomePass
+	}
+}
@@ -0,0 +1,134 @@
+package dispatcher_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/dispatcher"
+	"github.com/smykla-labs/klaudiush/internal/session"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+var _ = Describe("Trace", func() {
+	var (
+		reg   *validator.Registry
+		log   logger.Logger
+		trace *dispatcher.Trace
+	)
+
+	bashCtx := func(command string) *hook.Context {
+		return &hook.Context{
+			EventType: hook.EventTypePreToolUse,
+			ToolName:  hook.ToolTypeBash,
+			SessionID: "trace-session",
+			ToolInput: hook.ToolInput{Command: command},
+		}
+	}
+
+	BeforeEach(func() {
+		log = logger.NewNoOpLogger()
+		reg = validator.NewRegistry()
+		trace = dispatcher.NewTrace()
+
+		reg.Register(
+			&mockWarningValidator{name: "validate-warner"},
+			validator.CommandContains("warn"),
+		)
+		reg.Register(
+			&mockBlockingValidator{name: "validate-blocker", reference: validator.RefGitNoSignoff},
+			validator.CommandContains("block"),
+		)
+		reg.Register(
+			newTestValidator("validate-passer", validator.CategoryIO, validator.Pass()),
+			validator.ToolTypeIs(hook.ToolTypeBash),
+		)
+	})
+
+	It("should record every registered validator with its predicate match and outcome", func() {
+		disp := dispatcher.NewDispatcherWithOptions(
+			reg, log, dispatcher.NewSequentialExecutor(log),
+			dispatcher.WithTrace(trace),
+		)
+
+		errs := disp.Dispatch(context.Background(), bashCtx("echo warn"))
+		Expect(dispatcher.Decision(errs)).To(Equal(dispatcher.OutcomeWarn))
+
+		Expect(trace.Validators()).To(Equal([]dispatcher.ValidatorTrace{
+			{
+				Validator: "validate-warner",
+				Category:  "CPU",
+				Matched:   true,
+				Outcome:   dispatcher.OutcomeWarn,
+				Message:   "validation warning",
+			},
+			{Validator: "validate-blocker", Category: "CPU"},
+			{
+				Validator: "validate-passer",
+				Category:  "IO",
+				Matched:   true,
+				Outcome:   dispatcher.OutcomePass,
+			},
+		}))
+	})
+
+	It("should record the target of synthetic Write contexts", func() {
+		disp := dispatcher.NewDispatcherWithOptions(
+			reg, log, dispatcher.NewSequentialExecutor(log),
+			dispatcher.WithTrace(trace),
+		)
+
+		disp.Dispatch(context.Background(), bashCtx("echo hi > out.txt"))
+
+		var targets []string
+
+		for _, entry := range trace.Validators() {
+			targets = append(targets, entry.Target)
+		}
+
+		Expect(targets).To(Equal([]string{"", "", "", "out.txt", "out.txt", "out.txt"}))
+	})
+
+	It("should record session effects", func() {
+		enabled := true
+		tracker := session.NewTracker(
+			&config.SessionConfig{Enabled: &enabled},
+			session.WithLogger(log),
+		)
+
+		disp := dispatcher.NewDispatcherWithOptions(
+			reg, log, dispatcher.NewSequentialExecutor(log),
+			dispatcher.WithSessionTracker(tracker),
+			dispatcher.WithTrace(trace),
+		)
+
+		errs := disp.Dispatch(context.Background(), bashCtx("echo ok"))
+		Expect(dispatcher.Decision(errs)).To(Equal(dispatcher.OutcomePass))
+
+		errs = disp.Dispatch(context.Background(), bashCtx("echo block"))
+		Expect(dispatcher.Decision(errs)).To(Equal(dispatcher.OutcomeBlock))
+
+		disp.Dispatch(context.Background(), bashCtx("echo ok"))
+
+		Expect(trace.Session()).To(Equal([]dispatcher.SessionTrace{
+			{Effect: dispatcher.SessionEffectRecorded},
+			{Effect: dispatcher.SessionEffectPoisoned, Codes: []string{"GIT001"}},
+			{Effect: dispatcher.SessionEffectBlocked, Codes: []string{"GIT001"}},
+		}))
+	})
+
+	It("should record added rule matches", func() {
+		trace.AddRule(dispatcher.RuleTrace{
+			Rule:          "no-force-push",
+			ValidatorType: "git.push",
+			Action:        "block",
+		})
+
+		Expect(trace.Rules()).To(HaveLen(1))
+		Expect(trace.Rules()[0].Rule).To(Equal("no-force-push"))
+	})
+})
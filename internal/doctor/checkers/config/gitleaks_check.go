@@ -0,0 +1,80 @@
+package configchecker
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	internalconfig "github.com/smykla-labs/klaudiush/internal/config"
+	"github.com/smykla-labs/klaudiush/internal/doctor"
+	"github.com/smykla-labs/klaudiush/internal/validators/secrets"
+	"github.com/smykla-labs/klaudiush/pkg/pathutil"
+)
+
+// GitleaksChecker checks that the gitleaks config of the secrets validator can
+// be loaded. Without it, secrets detection falls back to the built-in patterns.
+type GitleaksChecker struct {
+	loader  ConfigLoader
+	workDir string
+}
+
+// NewGitleaksChecker creates a new gitleaks config checker
+func NewGitleaksChecker() *GitleaksChecker {
+	loader, _ := internalconfig.NewKoanfLoader()
+	workDir, _ := os.Getwd()
+
+	return &GitleaksChecker{
+		loader:  loader,
+		workDir: workDir,
+	}
+}
+
+// NewGitleaksCheckerWithLoader creates a GitleaksChecker with a custom loader
+// and working directory (for testing).
+func NewGitleaksCheckerWithLoader(loader ConfigLoader, workDir string) *GitleaksChecker {
+	return &GitleaksChecker{
+		loader:  loader,
+		workDir: workDir,
+	}
+}
+
+// Name returns the name of the check
+func (*GitleaksChecker) Name() string {
+	return "Gitleaks config"
+}
+
+// Category returns the category of the check
+func (*GitleaksChecker) Category() doctor.Category {
+	return doctor.CategoryConfig
+}
+
+// Check loads the gitleaks config, resolved from the project root
+func (c *GitleaksChecker) Check(_ context.Context) doctor.CheckResult {
+	cfg, err := c.loader.Load(nil)
+	if err != nil {
+		// Config loading errors are handled by the config checkers
+		return doctor.Skip("Gitleaks config", "Config load failed (see config check)")
+	}
+
+	if cfg.Validators == nil || cfg.Validators.Secrets == nil {
+		return doctor.Skip("Gitleaks config", "Not configured")
+	}
+
+	secretsCfg := cfg.Validators.Secrets.Secrets
+
+	path := secretsCfg.GetGitleaksConfigPath(pathutil.FindProjectRoot(c.workDir))
+	if path == "" {
+		return doctor.Skip("Gitleaks config", "Not configured")
+	}
+
+	if _, err := secrets.LoadGitleaksConfig(path); err != nil {
+		return doctor.FailError("Gitleaks config", "Failed to load").
+			WithDetails(
+				"File: "+path,
+				fmt.Sprintf("Error: %v", err),
+				"Secrets detection falls back to the built-in patterns",
+			)
+	}
+
+	return doctor.Pass("Gitleaks config", "Loaded "+path)
+}
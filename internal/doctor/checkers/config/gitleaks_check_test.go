@@ -0,0 +1,103 @@
+package configchecker_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/cockroachdb/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smykla-labs/klaudiush/internal/doctor"
+	configchecker "github.com/smykla-labs/klaudiush/internal/doctor/checkers/config"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+)
+
+var _ = Describe("GitleaksChecker", func() {
+	var (
+		ctrl       *gomock.Controller
+		mockLoader *configchecker.MockConfigLoader
+		checker    *configchecker.GitleaksChecker
+		ctx        context.Context
+		root       string
+	)
+
+	withGitleaksConfig := func(path string) *config.Config {
+		return &config.Config{
+			Validators: &config.ValidatorsConfig{
+				Secrets: &config.SecretsConfig{
+					Secrets: &config.SecretsValidatorConfig{GitleaksConfig: path},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockLoader = configchecker.NewMockConfigLoader(ctrl)
+		ctx = context.Background()
+
+		root = GinkgoT().TempDir()
+		Expect(os.MkdirAll(filepath.Join(root, ".git"), 0o755)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(root, "src"), 0o755)).To(Succeed())
+
+		checker = configchecker.NewGitleaksCheckerWithLoader(mockLoader, filepath.Join(root, "src"))
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should return the name and category", func() {
+		Expect(checker.Name()).To(Equal("Gitleaks config"))
+		Expect(checker.Category()).To(Equal(doctor.CategoryConfig))
+	})
+
+	It("should skip when no gitleaks config is set", func() {
+		mockLoader.EXPECT().Load(nil).Return(&config.Config{}, nil)
+
+		result := checker.Check(ctx)
+		Expect(result.Status).To(Equal(doctor.StatusSkipped))
+	})
+
+	It("should skip when the config does not load", func() {
+		mockLoader.EXPECT().Load(nil).Return(nil, errors.New("broken"))
+
+		result := checker.Check(ctx)
+		Expect(result.Status).To(Equal(doctor.StatusSkipped))
+	})
+
+	It("should resolve the path from the project root", func() {
+		Expect(os.WriteFile(
+			filepath.Join(root, ".gitleaks.toml"),
+			[]byte("[[rules]]\nid = \"token\"\nregex = '''tok_[a-z]{8}'''\n"),
+			0o600,
+		)).To(Succeed())
+		mockLoader.EXPECT().Load(nil).Return(withGitleaksConfig(".gitleaks.toml"), nil)
+
+		result := checker.Check(ctx)
+		Expect(result.Status).To(Equal(doctor.StatusPass))
+		Expect(result.Message).To(ContainSubstring(filepath.Join(root, ".gitleaks.toml")))
+	})
+
+	It("should fail when the file is missing", func() {
+		mockLoader.EXPECT().Load(nil).Return(withGitleaksConfig(".gitleaks.toml"), nil)
+
+		result := checker.Check(ctx)
+		Expect(result.Status).To(Equal(doctor.StatusFail))
+		Expect(result.Severity).To(Equal(doctor.SeverityError))
+		Expect(result.Details).To(ContainElement("File: " + filepath.Join(root, ".gitleaks.toml")))
+	})
+
+	It("should fail when the rules are invalid", func() {
+		path := filepath.Join(root, "gitleaks.toml")
+		Expect(os.WriteFile(path, []byte("[[rules]]\nid = \"bad\"\nregex = '''(['''\n"), 0o600)).
+			To(Succeed())
+		mockLoader.EXPECT().Load(nil).Return(withGitleaksConfig(path), nil)
+
+		result := checker.Check(ctx)
+		Expect(result.Status).To(Equal(doctor.StatusFail))
+	})
+})
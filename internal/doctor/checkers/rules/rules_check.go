@@ -9,6 +9,7 @@ import (
 
 	internalconfig "github.com/smykla-labs/klaudiush/internal/config"
 	"github.com/smykla-labs/klaudiush/internal/doctor"
+	"github.com/smykla-labs/klaudiush/internal/rules"
 	"github.com/smykla-labs/klaudiush/pkg/config"
 	"github.com/smykla-labs/klaudiush/pkg/stringutil"
 )
@@ -109,6 +110,7 @@ func (c *RulesChecker) Check(_ context.Context) doctor.CheckResult {
 		enabledCount++
 
 		c.validateRule(i, &cfg.Rules.Rules[i])
+		c.validateSchedule(i, cfg.Rules, &cfg.Rules.Rules[i])
 	}
 
 	if len(c.issues) == 0 {
@@ -207,17 +209,92 @@ func (c *RulesChecker) validateRule(index int, rule *config.RuleConfig) {
 		}
 	}
 
-	// Check for invalid action type
-	if rule.Action != nil && rule.Action.Type != "" {
-		if !slices.Contains(config.ValidActionTypes, rule.Action.Type) {
+	// Check for invalid when expression
+	if rule.Match.When != "" {
+		_, err := rules.NewExpressionMatcherWithOpts(
+			rule.Match.When,
+			rules.PatternOptions{CaseInsensitive: rule.Match.IsCaseInsensitive()},
+		)
+		if err != nil {
 			c.issues = append(c.issues, RuleIssue{
 				RuleIndex: index,
 				RuleName:  ruleName,
-				IssueType: "invalid_action_type",
-				Message: fmt.Sprintf("invalid action type %q (valid: %s)",
-					rule.Action.Type, strings.Join(config.ValidActionTypes, ", ")),
-				Fixable: true,
+				IssueType: "invalid_when",
+				Message:   err.Error(),
+				Fixable:   true,
 			})
 		}
 	}
+
+	c.validateAction(index, rule)
+}
+
+// validateAction validates the action of a rule and records issues.
+func (c *RulesChecker) validateAction(index int, rule *config.RuleConfig) {
+	if rule.Action == nil {
+		return
+	}
+
+	// Check for invalid action type
+	if rule.Action.Type != "" && !slices.Contains(config.ValidActionTypes, rule.Action.Type) {
+		c.issues = append(c.issues, RuleIssue{
+			RuleIndex: index,
+			RuleName:  rule.Name,
+			IssueType: "invalid_action_type",
+			Message: fmt.Sprintf("invalid action type %q (valid: %s)",
+				rule.Action.Type, strings.Join(config.ValidActionTypes, ", ")),
+			Fixable: true,
+		})
+	}
+
+	// Check for invalid message or fix hint templates
+	if err := rules.ValidateActionTemplates(rule.Action.Message, rule.Action.FixHint); err != nil {
+		c.issues = append(c.issues, RuleIssue{
+			RuleIndex: index,
+			RuleName:  rule.Name,
+			IssueType: "invalid_template",
+			Message:   err.Error(),
+			Fixable:   true,
+		})
+	}
+}
+
+// validateSchedule validates the schedule of a rule against the freeze calendar
+// and records issues.
+func (c *RulesChecker) validateSchedule(
+	index int,
+	rulesCfg *config.RulesConfig,
+	rule *config.RuleConfig,
+) {
+	if rule.Match == nil || rule.Match.Schedule == nil {
+		return
+	}
+
+	schedule := rule.Match.Schedule
+
+	message := ""
+
+	if _, unknown := rulesCfg.ResolveFreezes(schedule.Freezes); len(unknown) > 0 {
+		message = fmt.Sprintf("schedule references unknown freezes %v", unknown)
+	} else {
+		compiled := &rules.Schedule{Windows: schedule.Windows, Timezone: schedule.Timezone}
+
+		for _, d := range schedule.Dates {
+			compiled.Dates = append(compiled.Dates, rules.DateRange{Start: d.Start, End: d.End})
+		}
+
+		if _, err := rules.NewScheduleMatcher(compiled); err != nil {
+			message = err.Error()
+		}
+	}
+
+	if message != "" {
+		c.issues = append(c.issues, RuleIssue{
+			RuleIndex: index,
+			RuleName:  rule.Name,
+			IssueType: "invalid_schedule",
+			Message:   message,
+			Fixable:   true,
+		})
+	}
 }
@@ -214,6 +214,92 @@ var _ = Describe("RulesChecker", func() {
 				Expect(result.Details).To(ContainElement(ContainSubstring("invalid event_type")))
 			})
 
+			It("should fail when when expression is invalid", func() {
+				mockLoader.EXPECT().HasProjectConfig().Return(true)
+				mockLoader.EXPECT().LoadWithoutValidation(nil).Return(&config.Config{
+					Rules: &config.RulesConfig{
+						Rules: []config.RuleConfig{
+							{
+								Name: "invalid-when",
+								Match: &config.RuleMatchConfig{
+									When: `git.branchh == "main"`,
+								},
+							},
+						},
+					},
+				}, nil)
+
+				result := checker.Check(ctx)
+
+				Expect(result.Status).To(Equal(doctor.StatusFail))
+				Expect(result.Details).To(ContainElement(ContainSubstring(
+					`column 1: unknown field "git.branchh"`,
+				)))
+				Expect(checker.GetIssues()).To(ContainElement(
+					HaveField("IssueType", "invalid_when"),
+				))
+			})
+
+			It("should fail when schedule references unknown freezes", func() {
+				mockLoader.EXPECT().HasProjectConfig().Return(true)
+				mockLoader.EXPECT().LoadWithoutValidation(nil).Return(&config.Config{
+					Rules: &config.RulesConfig{
+						Freezes: []config.FreezeConfig{
+							{Name: "q4", Start: "2026-12-20", End: "2027-01-03"},
+						},
+						Rules: []config.RuleConfig{
+							{
+								Name: "freeze-rule",
+								Match: &config.RuleMatchConfig{
+									ValidatorType: "git.push",
+									Schedule: &config.ScheduleConfig{
+										Freezes: []string{"q4", "q1"},
+									},
+								},
+							},
+						},
+					},
+				}, nil)
+
+				result := checker.Check(ctx)
+
+				Expect(result.Status).To(Equal(doctor.StatusFail))
+				Expect(result.Details).To(ContainElement(
+					ContainSubstring("schedule references unknown freezes [q1]"),
+				))
+				Expect(checker.GetIssues()).To(ContainElement(
+					HaveField("IssueType", "invalid_schedule"),
+				))
+			})
+
+			It("should fail when message template is invalid", func() {
+				mockLoader.EXPECT().HasProjectConfig().Return(true)
+				mockLoader.EXPECT().LoadWithoutValidation(nil).Return(&config.Config{
+					Rules: &config.RulesConfig{
+						Rules: []config.RuleConfig{
+							{
+								Name: "invalid-template",
+								Match: &config.RuleMatchConfig{
+									ValidatorType: "git.push",
+								},
+								Action: &config.RuleActionConfig{
+									Type:    "block",
+									Message: "Push to {{.Git.Branch blocked",
+								},
+							},
+						},
+					},
+				}, nil)
+
+				result := checker.Check(ctx)
+
+				Expect(result.Status).To(Equal(doctor.StatusFail))
+				Expect(result.Details).To(ContainElement(ContainSubstring("template: message:1")))
+				Expect(checker.GetIssues()).To(ContainElement(
+					HaveField("IssueType", "invalid_template"),
+				))
+			})
+
 			It("should fail when action type is invalid", func() {
 				mockLoader.EXPECT().HasProjectConfig().Return(true)
 				mockLoader.EXPECT().LoadWithoutValidation(nil).Return(&config.Config{
@@ -359,4 +445,9 @@ var _ = Describe("RuleMatchConfig.HasMatchConditions", func() {
 		match := &config.RuleMatchConfig{CommandPattern: "git push*"}
 		Expect(match.HasMatchConditions()).To(BeTrue())
 	})
+
+	It("should return true when When is set", func() {
+		match := &config.RuleMatchConfig{When: `tool.name == "Bash"`}
+		Expect(match.HasMatchConditions()).To(BeTrue())
+	})
 })
@@ -56,6 +56,11 @@ func (a *RepositoryAdapter) GetUntrackedFiles() ([]string, error) {
 	return a.repo.GetUntrackedFiles()
 }
 
+// GetIgnoredFiles returns the untracked files matched by ignore rules
+func (a *RepositoryAdapter) GetIgnoredFiles() ([]string, error) {
+	return a.repo.GetIgnoredFiles()
+}
+
 // GetRepoRoot returns the git repository root directory
 func (a *RepositoryAdapter) GetRepoRoot() (string, error) {
 	return a.repo.GetRoot()
@@ -80,3 +85,39 @@ func (a *RepositoryAdapter) GetBranchRemote(branch string) (string, error) {
 func (a *RepositoryAdapter) GetRemotes() (map[string]string, error) {
 	return a.repo.GetRemotes()
 }
+
+// GetUnmergedCommits returns the commits reachable from ref but from no other ref
+func (a *RepositoryAdapter) GetUnmergedCommits(ref string) ([]string, error) {
+	return a.repo.GetUnmergedCommits(ref)
+}
+
+// GetStagedChanges returns the added or modified staged files with their added lines
+func (a *RepositoryAdapter) GetStagedChanges() ([]StagedChange, error) {
+	return a.repo.GetStagedChanges()
+}
+
+// GetWorktreeChanges returns the modified and untracked files of the worktree
+// with their added lines
+func (a *RepositoryAdapter) GetWorktreeChanges(maxSize int64) ([]StagedChange, error) {
+	return a.repo.GetWorktreeChanges(maxSize)
+}
+
+// GetStashes returns the stash entries, newest first
+func (a *RepositoryAdapter) GetStashes() ([]string, error) {
+	return a.repo.GetStashes()
+}
+
+// GetUpstream returns the upstream of the given branch
+func (a *RepositoryAdapter) GetUpstream(branch string) (string, error) {
+	return a.repo.GetUpstream(branch)
+}
+
+// GetAheadBehind returns how many commits HEAD is ahead of and behind upstream
+func (a *RepositoryAdapter) GetAheadBehind(upstream string) (ahead, behind int, err error) {
+	return a.repo.GetAheadBehind(upstream)
+}
+
+// GetHeadCommit returns the author and signature status of the HEAD commit
+func (a *RepositoryAdapter) GetHeadCommit() (*HeadCommit, error) {
+	return a.repo.GetHeadCommit()
+}
@@ -172,6 +172,39 @@ type mockRepository struct {
 	remotes          map[string]string
 	remotesErr       error
 	getRemotesCalled bool
+
+	// GetUnmergedCommits
+	unmergedCommits    []string
+	unmergedCommitsErr error
+
+	// GetIgnoredFiles
+	ignoredFiles    []string
+	ignoredFilesErr error
+
+	// GetStashes
+	stashes    []string
+	stashesErr error
+
+	// GetStagedChanges
+	stagedChanges    []internalgit.StagedChange
+	stagedChangesErr error
+
+	// GetWorktreeChanges
+	worktreeChanges    []internalgit.StagedChange
+	worktreeChangesErr error
+
+	// GetUpstream
+	upstream    string
+	upstreamErr error
+
+	// GetAheadBehind
+	ahead          int
+	behind         int
+	aheadBehindErr error
+
+	// GetHeadCommit
+	headCommit    *internalgit.HeadCommit
+	headCommitErr error
 }
 
 func (m *mockRepository) IsInRepo() bool {
@@ -231,6 +264,38 @@ func (m *mockRepository) GetRemotes() (map[string]string, error) {
 	return m.remotes, m.remotesErr
 }
 
+func (m *mockRepository) GetUnmergedCommits(_ string) ([]string, error) {
+	return m.unmergedCommits, m.unmergedCommitsErr
+}
+
+func (m *mockRepository) GetIgnoredFiles() ([]string, error) {
+	return m.ignoredFiles, m.ignoredFilesErr
+}
+
+func (m *mockRepository) GetStashes() ([]string, error) {
+	return m.stashes, m.stashesErr
+}
+
+func (m *mockRepository) GetStagedChanges() ([]internalgit.StagedChange, error) {
+	return m.stagedChanges, m.stagedChangesErr
+}
+
+func (m *mockRepository) GetWorktreeChanges(int64) ([]internalgit.StagedChange, error) {
+	return m.worktreeChanges, m.worktreeChangesErr
+}
+
+func (m *mockRepository) GetUpstream(string) (string, error) {
+	return m.upstream, m.upstreamErr
+}
+
+func (m *mockRepository) GetAheadBehind(string) (ahead, behind int, err error) {
+	return m.ahead, m.behind, m.aheadBehindErr
+}
+
+func (m *mockRepository) GetHeadCommit() (*internalgit.HeadCommit, error) {
+	return m.headCommit, m.headCommitErr
+}
+
 var _ = Describe("NewSDKRunnerForPath", func() {
 	var (
 		tempDir string
@@ -223,5 +223,53 @@ func (c *CachedRunner) GetRemotes() (map[string]string, error) {
 	return c.remotes, c.remotesErr
 }
 
+// GetUnmergedCommits returns the commits reachable only from the given ref.
+// Not cached, as it is only queried before destructive operations.
+func (c *CachedRunner) GetUnmergedCommits(ref string) ([]string, error) {
+	return c.delegate.GetUnmergedCommits(ref)
+}
+
+// GetStagedChanges returns the added or modified staged files with their added lines.
+// Not cached, as it is only queried before commits.
+func (c *CachedRunner) GetStagedChanges() ([]StagedChange, error) {
+	return c.delegate.GetStagedChanges()
+}
+
+// GetWorktreeChanges returns the modified and untracked files of the worktree
+// with their added lines. Not cached, as it is only queried before commits.
+func (c *CachedRunner) GetWorktreeChanges(maxSize int64) ([]StagedChange, error) {
+	return c.delegate.GetWorktreeChanges(maxSize)
+}
+
+// GetIgnoredFiles returns the untracked files matched by ignore rules.
+// Not cached, as it is only queried before destructive operations.
+func (c *CachedRunner) GetIgnoredFiles() ([]string, error) {
+	return c.delegate.GetIgnoredFiles()
+}
+
+// GetStashes returns the stash entries.
+// Not cached, as it is only queried before destructive operations.
+func (c *CachedRunner) GetStashes() ([]string, error) {
+	return c.delegate.GetStashes()
+}
+
+// GetUpstream returns the upstream of the given branch.
+// Not cached, as rules query it through RepoState, which computes it once.
+func (c *CachedRunner) GetUpstream(branch string) (string, error) {
+	return c.delegate.GetUpstream(branch)
+}
+
+// GetAheadBehind returns how many commits HEAD is ahead of and behind upstream.
+// Not cached, as rules query it through RepoState, which computes it once.
+func (c *CachedRunner) GetAheadBehind(upstream string) (ahead, behind int, err error) {
+	return c.delegate.GetAheadBehind(upstream)
+}
+
+// GetHeadCommit returns the author and signature status of the HEAD commit.
+// Not cached, as rules query it through RepoState, which computes it once.
+func (c *CachedRunner) GetHeadCommit() (*HeadCommit, error) {
+	return c.delegate.GetHeadCommit()
+}
+
 // Ensure CachedRunner implements Runner.
 var _ Runner = (*CachedRunner)(nil)
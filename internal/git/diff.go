@@ -0,0 +1,185 @@
+package git
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v6/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// DiffLine is a line added by a change
+type DiffLine struct {
+	// Number is the 1-based line number in the new version of the file
+	Number int
+
+	// Text is the content of the line, without the trailing newline
+	Text string
+}
+
+// StagedChange is a file added or modified in the staging area, with the lines
+// it adds relative to HEAD, or a file modified in the worktree, with the lines
+// it adds relative to the staging area. Binary files have no added lines.
+type StagedChange struct {
+	// Path is the path of the file, relative to the repository root
+	Path string
+
+	// Untracked is set for worktree files that are not tracked yet
+	Untracked bool
+
+	// Added holds the added lines, in order
+	Added []DiffLine
+}
+
+// ParseUnifiedDiff parses the output of "git diff" into the changed files and
+// their added lines. Deleted files are skipped. Paths must use the default
+// a/ and b/ prefixes.
+func ParseUnifiedDiff(output string) []StagedChange {
+	var (
+		changes []StagedChange
+		current *StagedChange
+		deleted bool
+		inHunk  bool
+		number  int
+	)
+
+	flush := func() {
+		if current != nil && !deleted {
+			changes = append(changes, *current)
+		}
+	}
+
+	for line := range strings.SplitSeq(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			flush()
+
+			current = &StagedChange{Path: diffHeaderPath(line)}
+			deleted = false
+			inHunk = false
+		case current == nil:
+			continue
+		case strings.HasPrefix(line, "@@ "):
+			inHunk = true
+			number = hunkNewStart(line)
+		case !inHunk:
+			// Extended headers: the +++ line holds the exact new path
+			if strings.HasPrefix(line, "deleted file mode") {
+				deleted = true
+			} else if path, ok := strings.CutPrefix(line, "+++ "); ok && path != "/dev/null" {
+				current.Path = diffPath(path)
+			}
+		case strings.HasPrefix(line, "+"):
+			current.Added = append(current.Added, DiffLine{Number: number, Text: line[1:]})
+			number++
+		case strings.HasPrefix(line, " "):
+			number++
+		}
+	}
+
+	flush()
+
+	return changes
+}
+
+// diffHeaderPath returns the path of a "diff --git a/<path> b/<path>" header.
+// Renames are not detected, so both paths are the same.
+func diffHeaderPath(line string) string {
+	paths := strings.TrimPrefix(line, "diff --git ")
+
+	if quoted, err := strconv.QuotedPrefix(paths); err == nil {
+		return diffPath(quoted)
+	}
+
+	// "a/" + path + " b/" + path
+	const prefixesLen = 5
+
+	if len(paths) < prefixesLen || (len(paths)-prefixesLen)%2 != 0 {
+		return diffPath(paths)
+	}
+
+	return diffPath(paths[:2+(len(paths)-prefixesLen)/2])
+}
+
+// diffPath returns a path of a diff without the C-style quoting git applies to
+// unusual paths and without its a/ or b/ prefix.
+func diffPath(path string) string {
+	if unquoted, err := strconv.Unquote(path); err == nil && strings.HasPrefix(path, `"`) {
+		path = unquoted
+	}
+
+	if trimmed, ok := strings.CutPrefix(path, "a/"); ok {
+		return trimmed
+	}
+
+	return strings.TrimPrefix(path, "b/")
+}
+
+// hunkNewStart returns the first line number of the new file in a hunk header
+// ("@@ -<old> +<start>[,<count>] @@").
+func hunkNewStart(line string) int {
+	for field := range strings.FieldsSeq(line) {
+		if rest, ok := strings.CutPrefix(field, "+"); ok {
+			start, _, _ := strings.Cut(rest, ",")
+
+			number, err := strconv.Atoi(start)
+			if err != nil {
+				return 1
+			}
+
+			return number
+		}
+	}
+
+	return 1
+}
+
+// NewUntrackedChange returns the change of an untracked file, adding all of its
+// lines. Binary files have no added lines.
+func NewUntrackedChange(path, content string) StagedChange {
+	change := StagedChange{Path: path, Untracked: true}
+
+	if !strings.Contains(content, "\x00") {
+		change.Added = diffAddedLines("", content)
+	}
+
+	return change
+}
+
+// diffAddedLines returns the lines dst adds to src.
+func diffAddedLines(src, dst string) []DiffLine {
+	var added []DiffLine
+
+	number := 1
+
+	for _, d := range diff.Do(src, dst) {
+		lines := splitDiffLines(d.Text)
+
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			for _, line := range lines {
+				added = append(added, DiffLine{Number: number, Text: line})
+				number++
+			}
+		case diffmatchpatch.DiffEqual:
+			number += len(lines)
+		case diffmatchpatch.DiffDelete:
+		}
+	}
+
+	return added
+}
+
+// splitDiffLines splits text into lines without their trailing newlines.
+func splitDiffLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\n")
+	}
+
+	return lines
+}
@@ -0,0 +1,92 @@
+package git_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	internalgit "github.com/smykla-labs/klaudiush/internal/git"
+)
+
+var _ = Describe("ParseUnifiedDiff", func() {
+	It("returns the added lines of each file", func() {
+		diff := `diff --git a/app.go b/app.go
+index 1111111..2222222 100644
+--- a/app.go
++++ b/app.go
+@@ -2 +2 @@ package app
+-old
++new
+@@ -10,0 +11,2 @@ func main() {
++++counter
++last
+diff --git a/docs/new file.md b/docs/new file.md
+new file mode 100644
+index 0000000..3333333
+--- /dev/null
++++ b/docs/new file.md
+@@ -0,0 +1 @@
++# Title
+\ No newline at end of file
+`
+
+		Expect(internalgit.ParseUnifiedDiff(diff)).To(Equal([]internalgit.StagedChange{
+			{
+				Path: "app.go",
+				Added: []internalgit.DiffLine{
+					{Number: 2, Text: "new"},
+					{Number: 11, Text: "++counter"},
+					{Number: 12, Text: "last"},
+				},
+			},
+			{
+				Path:  "docs/new file.md",
+				Added: []internalgit.DiffLine{{Number: 1, Text: "# Title"}},
+			},
+		}))
+	})
+
+	It("counts context lines", func() {
+		diff := `diff --git a/a.txt b/a.txt
+--- a/a.txt
++++ b/a.txt
+@@ -1,2 +1,3 @@
+ one
++two
+ three
+`
+
+		changes := internalgit.ParseUnifiedDiff(diff)
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Added).To(Equal([]internalgit.DiffLine{{Number: 2, Text: "two"}}))
+	})
+
+	It("skips deleted files and keeps binary files", func() {
+		diff := `diff --git a/old.txt b/old.txt
+deleted file mode 100644
+index 1111111..0000000
+--- a/old.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-gone
+diff --git a/image.png b/image.png
+new file mode 100644
+index 0000000..4444444
+Binary files /dev/null and b/image.png differ
+diff --git "a/tab\there.txt" "b/tab\there.txt"
+new file mode 100644
+--- /dev/null
++++ "b/tab\there.txt"
+@@ -0,0 +1 @@
++x
+`
+
+		Expect(internalgit.ParseUnifiedDiff(diff)).To(Equal([]internalgit.StagedChange{
+			{Path: "image.png"},
+			{Path: "tab\there.txt", Added: []internalgit.DiffLine{{Number: 1, Text: "x"}}},
+		}))
+	})
+
+	It("returns nothing for an empty diff", func() {
+		Expect(internalgit.ParseUnifiedDiff("")).To(BeEmpty())
+	})
+})
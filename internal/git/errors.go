@@ -20,4 +20,7 @@ var (
 
 	// ErrNoTracking is returned when a branch has no tracking configuration
 	ErrNoTracking = errors.New("branch has no tracking remote")
+
+	// ErrRefNotFound is returned when the specified ref does not exist
+	ErrRefNotFound = errors.New("ref not found")
 )
@@ -4,15 +4,24 @@ package git
 // This is a struct-based fake (not a mock) that allows tests to set state directly.
 // For expectation-based testing, use the generated MockRunner from runner_mock.go.
 type FakeRunner struct {
-	InRepo         bool
-	StagedFiles    []string
-	ModifiedFiles  []string
-	UntrackedFiles []string
-	RepoRoot       string
-	Remotes        map[string]string
-	CurrentBranch  string
-	BranchRemotes  map[string]string
-	Err            error
+	InRepo          bool
+	StagedFiles     []string
+	ModifiedFiles   []string
+	UntrackedFiles  []string
+	IgnoredFiles    []string
+	RepoRoot        string
+	Remotes         map[string]string
+	CurrentBranch   string
+	BranchRemotes   map[string]string
+	UnmergedCommits map[string][]string
+	Stashes         []string
+	StagedChanges   []StagedChange
+	WorktreeChanges []StagedChange
+	Upstreams       map[string]string
+	Ahead           int
+	Behind          int
+	HeadCommit      *HeadCommit
+	Err             error
 }
 
 // NewFakeRunner creates a new FakeRunner instance with sensible defaults.
@@ -67,6 +76,15 @@ func (f *FakeRunner) GetUntrackedFiles() ([]string, error) {
 	return f.UntrackedFiles, nil
 }
 
+// GetIgnoredFiles returns the untracked files matched by ignore rules.
+func (f *FakeRunner) GetIgnoredFiles() ([]string, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	return f.IgnoredFiles, nil
+}
+
 // GetRepoRoot returns the git repository root directory.
 func (f *FakeRunner) GetRepoRoot() (string, error) {
 	if f.Err != nil {
@@ -120,6 +138,78 @@ func (f *FakeRunner) GetRemotes() (map[string]string, error) {
 	return f.Remotes, nil
 }
 
+// GetUnmergedCommits returns the commits reachable only from the given ref.
+func (f *FakeRunner) GetUnmergedCommits(ref string) ([]string, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	return f.UnmergedCommits[ref], nil
+}
+
+// GetStashes returns the stash entries.
+func (f *FakeRunner) GetStashes() ([]string, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	return f.Stashes, nil
+}
+
+// GetStagedChanges returns the added or modified staged files with their added lines.
+func (f *FakeRunner) GetStagedChanges() ([]StagedChange, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	return f.StagedChanges, nil
+}
+
+// GetWorktreeChanges returns the modified and untracked files of the worktree
+// with their added lines.
+func (f *FakeRunner) GetWorktreeChanges(int64) ([]StagedChange, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	return f.WorktreeChanges, nil
+}
+
+// GetUpstream returns the upstream of the given branch.
+func (f *FakeRunner) GetUpstream(branch string) (string, error) {
+	if f.Err != nil {
+		return "", f.Err
+	}
+
+	if upstream, ok := f.Upstreams[branch]; ok {
+		return upstream, nil
+	}
+
+	return "", &FakeRunnerError{Msg: "upstream not found"}
+}
+
+// GetAheadBehind returns how many commits HEAD is ahead of and behind upstream.
+func (f *FakeRunner) GetAheadBehind(string) (ahead, behind int, err error) {
+	if f.Err != nil {
+		return 0, 0, f.Err
+	}
+
+	return f.Ahead, f.Behind, nil
+}
+
+// GetHeadCommit returns the author and signature status of the HEAD commit.
+func (f *FakeRunner) GetHeadCommit() (*HeadCommit, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	if f.HeadCommit == nil {
+		return nil, &FakeRunnerError{Msg: "no HEAD commit"}
+	}
+
+	return f.HeadCommit, nil
+}
+
 // FakeRunnerError is a simple error type for testing.
 type FakeRunnerError struct {
 	Msg string
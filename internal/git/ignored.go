@@ -0,0 +1,91 @@
+package git
+
+import (
+	"path"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-git/go-billy/v6"
+	"github.com/go-git/go-git/v6/plumbing/format/gitignore"
+)
+
+// GetIgnoredFiles returns the untracked files matched by .gitignore files and
+// info/exclude, which "git clean -x" and "git clean -X" remove. Ignored
+// directories without tracked files are returned as a single "dir/" entry,
+// like "git ls-files --others --ignored --exclude-standard --directory".
+func (r *SDKRepository) GetIgnoredFiles() ([]string, error) {
+	worktree, err := r.repo.Worktree()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get worktree")
+	}
+
+	patterns, err := gitignore.ReadPatterns(worktree.Filesystem, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read ignore patterns")
+	}
+
+	tracked, err := r.GetTrackedFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	walk := &ignoredWalk{
+		fs:      worktree.Filesystem,
+		matcher: gitignore.NewMatcher(append(patterns, worktree.Excludes...)),
+		tracked: make(map[string]bool, len(tracked)),
+	}
+
+	for _, file := range tracked {
+		walk.tracked[file] = true
+
+		// Mark parent directories, which must be descended into
+		for dir := path.Dir(file); dir != "."; dir = path.Dir(dir) {
+			walk.tracked[dir+"/"] = true
+		}
+	}
+
+	if err := walk.dir(nil); err != nil {
+		return nil, err
+	}
+
+	return walk.ignored, nil
+}
+
+// ignoredWalk collects the ignored untracked files of a worktree
+type ignoredWalk struct {
+	fs      billy.Filesystem
+	matcher gitignore.Matcher
+	tracked map[string]bool
+	ignored []string
+}
+
+// dir walks the worktree directory at the given path
+func (w *ignoredWalk) dir(parts []string) error {
+	entries, err := w.fs.ReadDir(w.fs.Join(parts...))
+	if err != nil {
+		return errors.Wrapf(err, "failed to read directory %q", path.Join(parts...))
+	}
+
+	for _, entry := range entries {
+		if len(parts) == 0 && entry.Name() == ".git" {
+			continue
+		}
+
+		entryParts := append(append([]string(nil), parts...), entry.Name())
+		name := strings.Join(entryParts, "/")
+		isDir := entry.IsDir()
+
+		switch {
+		case isDir && w.matcher.Match(entryParts, true) && !w.tracked[name+"/"]:
+			w.ignored = append(w.ignored, name+"/")
+		case isDir:
+			if err := w.dir(entryParts); err != nil {
+				return err
+			}
+		case !w.tracked[name] && w.matcher.Match(entryParts, false):
+			w.ignored = append(w.ignored, name)
+		}
+	}
+
+	return nil
+}
@@ -2,12 +2,28 @@
 package git
 
 import (
+	"bufio"
+	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strings"
 	"sync"
 
 	"github.com/cockroachdb/errors"
+	"github.com/go-git/go-billy/v6"
 	"github.com/go-git/go-git/v6"
 	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/go-git/go-git/v6/plumbing/object"
+	"github.com/go-git/go-git/v6/storage/filesystem"
+)
+
+const (
+	// shortHashLength is the length of abbreviated commit hashes
+	shortHashLength = 7
+
+	// stashReflogPath is the stash reflog, relative to the git directory
+	stashReflogPath = "logs/refs/stash"
 )
 
 // gitEnvVarsToUnset lists git environment variables that must be cleared before
@@ -49,12 +65,23 @@ type Repository interface {
 	// GetStagedFiles returns the list of staged files
 	GetStagedFiles() ([]string, error)
 
+	// GetStagedChanges returns the added or modified staged files with their added lines
+	GetStagedChanges() ([]StagedChange, error)
+
+	// GetWorktreeChanges returns the modified and untracked files of the worktree
+	// with the lines they add relative to the staging area. Lines of files
+	// larger than maxSize are not read.
+	GetWorktreeChanges(maxSize int64) ([]StagedChange, error)
+
 	// GetModifiedFiles returns the list of modified but unstaged files
 	GetModifiedFiles() ([]string, error)
 
 	// GetUntrackedFiles returns the list of untracked files
 	GetUntrackedFiles() ([]string, error)
 
+	// GetIgnoredFiles returns the untracked files matched by ignore rules
+	GetIgnoredFiles() ([]string, error)
+
 	// GetCurrentBranch returns the current branch name
 	GetCurrentBranch() (string, error)
 
@@ -66,6 +93,21 @@ type Repository interface {
 
 	// GetRemotes returns the list of all remotes with their URLs
 	GetRemotes() (map[string]string, error)
+
+	// GetUnmergedCommits returns the commits reachable from ref but from no other ref
+	GetUnmergedCommits(ref string) ([]string, error)
+
+	// GetStashes returns the stash entries, newest first
+	GetStashes() ([]string, error)
+
+	// GetUpstream returns the upstream of the given branch, e.g. "origin/main"
+	GetUpstream(branch string) (string, error)
+
+	// GetAheadBehind returns how many commits HEAD is ahead of and behind upstream
+	GetAheadBehind(upstream string) (ahead, behind int, err error)
+
+	// GetHeadCommit returns the author and signature status of the HEAD commit
+	GetHeadCommit() (*HeadCommit, error)
 }
 
 // SDKRepository implements Repository using go-git SDK
@@ -165,8 +207,9 @@ func (r *SDKRepository) GetStagedFiles() ([]string, error) {
 	var staged []string
 
 	for file, fileStatus := range status {
-		// A file is staged if the staging area differs from HEAD
-		if fileStatus.Staging != git.Unmodified {
+		// A file is staged if the staging area differs from HEAD. Untracked files
+		// report Untracked for both the staging area and the worktree.
+		if fileStatus.Staging != git.Unmodified && fileStatus.Staging != git.Untracked {
 			staged = append(staged, file)
 		}
 	}
@@ -222,6 +265,230 @@ func (r *SDKRepository) GetUntrackedFiles() ([]string, error) {
 	return untracked, nil
 }
 
+// GetTrackedFiles returns the list of files in the index
+func (r *SDKRepository) GetTrackedFiles() ([]string, error) {
+	index, err := r.repo.Storer.Index()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read index")
+	}
+
+	tracked := make([]string, 0, len(index.Entries))
+
+	for _, entry := range index.Entries {
+		tracked = append(tracked, entry.Name)
+	}
+
+	return tracked, nil
+}
+
+// GetStagedChanges returns the files added or modified in the staging area with
+// the lines they add relative to HEAD, sorted by path
+func (r *SDKRepository) GetStagedChanges() ([]StagedChange, error) {
+	worktree, err := r.repo.Worktree()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get worktree")
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get status")
+	}
+
+	index, err := r.repo.Storer.Index()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read index")
+	}
+
+	headTree, err := r.headTree()
+	if err != nil && !errors.Is(err, ErrNoHead) {
+		return nil, err
+	}
+
+	changes := make([]StagedChange, 0, len(status))
+
+	for path, fileStatus := range status {
+		if fileStatus.Staging == git.Unmodified || fileStatus.Staging == git.Untracked ||
+			fileStatus.Staging == git.Deleted {
+			continue
+		}
+
+		entry, err := index.Entry(path)
+		if err != nil {
+			continue
+		}
+
+		staged, err := r.blobContents(entry.Hash)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read staged %s", path)
+		}
+
+		change := StagedChange{Path: path}
+
+		// Binary files have no lines to report
+		if !strings.Contains(staged, "\x00") {
+			var previous string
+
+			if headTree != nil {
+				if file, err := headTree.File(path); err == nil {
+					if previous, err = file.Contents(); err != nil {
+						return nil, errors.Wrapf(err, "failed to read %s at HEAD", path)
+					}
+				}
+			}
+
+			change.Added = diffAddedLines(previous, staged)
+		}
+
+		changes = append(changes, change)
+	}
+
+	slices.SortFunc(changes, func(a, b StagedChange) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return changes, nil
+}
+
+// GetWorktreeChanges returns the tracked files modified in the worktree and the
+// untracked files, with the lines they add relative to the staging area, sorted
+// by path. Lines of files larger than maxSize are not read.
+func (r *SDKRepository) GetWorktreeChanges(maxSize int64) ([]StagedChange, error) {
+	worktree, err := r.repo.Worktree()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get worktree")
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get status")
+	}
+
+	index, err := r.repo.Storer.Index()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read index")
+	}
+
+	changes := make([]StagedChange, 0, len(status))
+
+	for path, fileStatus := range status {
+		if fileStatus.Worktree == git.Unmodified || fileStatus.Worktree == git.Deleted {
+			continue
+		}
+
+		current, ok := ReadWorktreeFile(worktree.Filesystem, path, maxSize)
+		if !ok {
+			continue
+		}
+
+		if fileStatus.Worktree == git.Untracked {
+			changes = append(changes, NewUntrackedChange(path, current))
+
+			continue
+		}
+
+		change := StagedChange{Path: path}
+
+		// Binary files have no lines to report
+		if current != "" && !strings.Contains(current, "\x00") {
+			var previous string
+
+			if entry, err := index.Entry(path); err == nil {
+				if previous, err = r.blobContents(entry.Hash); err != nil {
+					return nil, errors.Wrapf(err, "failed to read staged %s", path)
+				}
+			}
+
+			change.Added = diffAddedLines(previous, current)
+		}
+
+		changes = append(changes, change)
+	}
+
+	slices.SortFunc(changes, func(a, b StagedChange) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return changes, nil
+}
+
+// ReadWorktreeFile returns the contents of a worktree file to scan. Returns
+// false for files git does not commit, such as FIFOs and sockets. Symlinks,
+// files larger than maxSize and unreadable files have no contents to scan.
+func ReadWorktreeFile(fs billy.Filesystem, path string, maxSize int64) (string, bool) {
+	info, err := fs.Lstat(path)
+	if err != nil {
+		return "", false
+	}
+
+	switch mode := info.Mode(); {
+	case mode&os.ModeSymlink != 0:
+		return "", true
+	case !mode.IsRegular():
+		return "", false
+	case info.Size() > maxSize:
+		return "", true
+	}
+
+	file, err := fs.Open(path)
+	if err != nil {
+		return "", true
+	}
+	defer file.Close()
+
+	// The file may grow after Lstat
+	data, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil || int64(len(data)) > maxSize {
+		return "", true
+	}
+
+	return string(data), true
+}
+
+// headTree returns the tree of the HEAD commit, or ErrNoHead before the first commit
+func (r *SDKRepository) headTree() (*object.Tree, error) {
+	head, err := r.repo.Head()
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil, ErrNoHead
+		}
+
+		return nil, errors.Wrap(err, "failed to get HEAD")
+	}
+
+	commit, err := r.repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get HEAD commit")
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get HEAD tree")
+	}
+
+	return tree, nil
+}
+
+// blobContents returns the contents of a blob
+func (r *SDKRepository) blobContents(hash plumbing.Hash) (string, error) {
+	blob, err := r.repo.BlobObject(hash)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get blob")
+	}
+
+	reader, err := blob.Reader()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to open blob")
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read blob")
+	}
+
+	return string(data), nil
+}
+
 // GetCurrentBranch returns the current branch name
 func (r *SDKRepository) GetCurrentBranch() (string, error) {
 	head, err := r.repo.Head()
@@ -304,3 +571,239 @@ func (r *SDKRepository) GetRemotes() (map[string]string, error) {
 
 	return result, nil
 }
+
+// GetUnmergedCommits returns the commits reachable from ref but from no other
+// ref (branches, remote-tracking branches, tags, stash and a detached HEAD).
+// These are the commits that become unreachable when ref is deleted.
+func (r *SDKRepository) GetUnmergedCommits(ref string) ([]string, error) {
+	name := plumbing.ReferenceName(ref)
+
+	target, err := r.repo.Reference(name, true)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil, errors.Wrapf(ErrRefNotFound, "ref %q", ref)
+		}
+
+		return nil, errors.Wrap(err, "failed to lookup ref")
+	}
+
+	targetCommit, err := r.peelToCommit(target.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	others, err := r.otherTips(name)
+	if err != nil {
+		return nil, err
+	}
+
+	unmerged, err := newUnmergedWalk(targetCommit, others).run()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to walk ref history")
+	}
+
+	commits := make([]string, 0, len(unmerged))
+	for _, commit := range unmerged {
+		commits = append(commits, formatCommit(commit))
+	}
+
+	return commits, nil
+}
+
+// otherTips returns the commits all refs except the given one point to
+func (r *SDKRepository) otherTips(name plumbing.ReferenceName) ([]*object.Commit, error) {
+	refs, err := r.repo.References()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list refs")
+	}
+
+	var tips []*object.Commit
+
+	err = refs.ForEach(func(other *plumbing.Reference) error {
+		if other.Name() == name || other.Type() != plumbing.HashReference {
+			return nil
+		}
+
+		commit, peelErr := r.peelToCommit(other.Hash())
+		if peelErr != nil {
+			// Refs to non-commit objects (e.g., tagged blobs) reach no history
+			return nil //nolint:nilerr // Skip refs that don't point to commits
+		}
+
+		tips = append(tips, commit)
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to walk refs")
+	}
+
+	return tips, nil
+}
+
+// GetStashes returns the stash entries, newest first, read from the stash reflog
+func (r *SDKRepository) GetStashes() ([]string, error) {
+	storage, ok := r.repo.Storer.(*filesystem.Storage)
+	if !ok {
+		return nil, errors.New("repository storage does not support reflogs")
+	}
+
+	file, err := storage.Filesystem().Open(stashReflogPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to open stash reflog")
+	}
+	defer file.Close()
+
+	var messages []string
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// <old> <new> <committer> <timestamp> <tz>\t<message>
+		if _, message, found := strings.Cut(scanner.Text(), "\t"); found {
+			messages = append(messages, message)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read stash reflog")
+	}
+
+	stashes := make([]string, 0, len(messages))
+	for i := len(messages) - 1; i >= 0; i-- {
+		stashes = append(stashes, fmt.Sprintf("stash@{%d}: %s", len(messages)-1-i, messages[i]))
+	}
+
+	return stashes, nil
+}
+
+// GetUpstream returns the upstream of the given branch from its tracking
+// configuration, e.g. "origin/main", or the local branch it tracks
+func (r *SDKRepository) GetUpstream(branch string) (string, error) {
+	cfg, err := r.repo.Config()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get config")
+	}
+
+	branchCfg, ok := cfg.Branches[branch]
+	if !ok || branchCfg.Remote == "" || branchCfg.Merge == "" {
+		return "", errors.Wrapf(ErrNoTracking, "branch %q", branch)
+	}
+
+	if branchCfg.Remote == "." {
+		return branchCfg.Merge.Short(), nil
+	}
+
+	return branchCfg.Remote + "/" + branchCfg.Merge.Short(), nil
+}
+
+// GetAheadBehind returns how many commits HEAD is ahead of and behind upstream,
+// like "git rev-list --left-right --count HEAD...<upstream>"
+func (r *SDKRepository) GetAheadBehind(upstream string) (ahead, behind int, err error) {
+	head, err := r.repo.Head()
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return 0, 0, ErrNoHead
+		}
+
+		return 0, 0, errors.Wrap(err, "failed to get HEAD")
+	}
+
+	headCommit, err := r.peelToCommit(head.Hash())
+	if err != nil {
+		return 0, 0, err
+	}
+
+	upstreamHash, err := r.repo.ResolveRevision(plumbing.Revision(upstream))
+	if err != nil {
+		return 0, 0, errors.Wrapf(ErrRefNotFound, "upstream %q", upstream)
+	}
+
+	upstreamCommit, err := r.peelToCommit(*upstreamHash)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if ahead, err = countUnreachable(headCommit, upstreamCommit); err != nil {
+		return 0, 0, err
+	}
+
+	if behind, err = countUnreachable(upstreamCommit, headCommit); err != nil {
+		return 0, 0, err
+	}
+
+	return ahead, behind, nil
+}
+
+// GetHeadCommit returns the author and signature status of the HEAD commit
+func (r *SDKRepository) GetHeadCommit() (*HeadCommit, error) {
+	head, err := r.repo.Head()
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil, ErrNoHead
+		}
+
+		return nil, errors.Wrap(err, "failed to get HEAD")
+	}
+
+	commit, err := r.peelToCommit(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	return &HeadCommit{
+		AuthorName:  commit.Author.Name,
+		AuthorEmail: commit.Author.Email,
+		Signed:      commit.PGPSignature != "",
+	}, nil
+}
+
+// countUnreachable counts the commits reachable from from but not from other
+func countUnreachable(from, other *object.Commit) (int, error) {
+	reachable := make(map[plumbing.Hash]bool)
+
+	err := object.NewCommitPreorderIter(other, nil, nil).ForEach(func(c *object.Commit) error {
+		reachable[c.Hash] = true
+
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to walk history")
+	}
+
+	count := 0
+
+	err = object.NewCommitPreorderIter(from, reachable, nil).ForEach(func(*object.Commit) error {
+		count++
+
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to walk history")
+	}
+
+	return count, nil
+}
+
+// peelToCommit returns the commit a hash points to, following annotated tags
+func (r *SDKRepository) peelToCommit(hash plumbing.Hash) (*object.Commit, error) {
+	commit, err := r.repo.CommitObject(hash)
+	if err == nil {
+		return commit, nil
+	}
+
+	tag, tagErr := r.repo.TagObject(hash)
+	if tagErr != nil {
+		return nil, errors.Wrapf(err, "object %s is not a commit", hash)
+	}
+
+	commit, err = tag.Commit()
+	if err != nil {
+		return nil, errors.Wrapf(err, "tag %s does not point to a commit", tag.Name)
+	}
+
+	return commit, nil
+}
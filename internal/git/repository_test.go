@@ -266,6 +266,16 @@ var _ = Describe("SDKRepository", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(files).To(ConsistOf("test.txt"))
 			})
+
+			It("should not return untracked files", func() {
+				untracked := filepath.Join(tempDir, "untracked.txt")
+				err := os.WriteFile(untracked, []byte("new"), 0o644) //nolint:govet // shadow
+				Expect(err).NotTo(HaveOccurred())
+
+				files, err := sdkRepo.GetStagedFiles()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files).To(ConsistOf("test.txt"))
+			})
 		})
 
 		Context("when multiple files are staged", func() {
@@ -366,6 +376,140 @@ var _ = Describe("SDKRepository", func() {
 		})
 	})
 
+	Describe("GetIgnoredFiles", func() {
+		BeforeEach(func() {
+			sdkRepo, err = internalgit.DiscoverRepository()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should return empty list without ignore rules", func() {
+			Expect(os.WriteFile(filepath.Join(tempDir, ".env"), []byte("KEY=1"), 0o644)).
+				To(Succeed())
+
+			files, err := sdkRepo.GetIgnoredFiles() //nolint:govet // shadow
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(BeEmpty())
+		})
+
+		It("should return ignored untracked files and directories", func() {
+			writeFile := func(name, content string) {
+				path := filepath.Join(tempDir, name)
+				Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+				Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+			}
+
+			writeFile(".gitignore", ".env\nbuild/\n*.log\n")
+			writeFile(".env", "KEY=1")
+			writeFile("build/out.bin", "binary")
+			writeFile("build/sub/more.bin", "binary")
+			writeFile("logs/debug.log", "debug")
+			writeFile("kept.log", "tracked")
+			writeFile("notes.md", "untracked")
+
+			worktree, err := repo.Worktree() //nolint:govet // shadow
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = worktree.Add("kept.log")
+			Expect(err).NotTo(HaveOccurred())
+
+			files, err := sdkRepo.GetIgnoredFiles()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(ConsistOf(".env", "build/", "logs/debug.log"))
+		})
+	})
+
+	Describe("GetTrackedFiles", func() {
+		BeforeEach(func() {
+			sdkRepo, err = internalgit.DiscoverRepository()
+			Expect(err).NotTo(HaveOccurred())
+
+			worktree, err := repo.Worktree() //nolint:govet // shadow
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, filename := range []string{"tracked.txt", "untracked.txt"} {
+				testFile := filepath.Join(tempDir, filename)
+				err := os.WriteFile(testFile, []byte("content"), 0o644)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			_, err = worktree.Add("tracked.txt")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should return the files in the index", func() {
+			files, err := sdkRepo.GetTrackedFiles() //nolint:govet // shadow
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(ConsistOf("tracked.txt"))
+		})
+	})
+
+	Describe("GetStagedChanges", func() {
+		var worktree *git.Worktree
+
+		writeAndAdd := func(filename, content string) {
+			err := os.WriteFile(filepath.Join(tempDir, filename), []byte(content), 0o644)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = worktree.Add(filename)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			sdkRepo, err = internalgit.DiscoverRepository()
+			Expect(err).NotTo(HaveOccurred())
+
+			worktree, err = repo.Worktree()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should return all lines of files staged before the first commit", func() {
+			writeAndAdd("new.txt", "one\ntwo\n")
+
+			changes, err := sdkRepo.GetStagedChanges() //nolint:govet // shadow
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(Equal([]internalgit.StagedChange{{
+				Path: "new.txt",
+				Added: []internalgit.DiffLine{
+					{Number: 1, Text: "one"},
+					{Number: 2, Text: "two"},
+				},
+			}}))
+		})
+
+		It("should return the lines added relative to HEAD", func() {
+			writeAndAdd("changed.txt", "one\ntwo\nthree\n")
+			writeAndAdd("removed.txt", "gone\n")
+
+			_, err := worktree.Commit("Initial commit", &git.CommitOptions{ //nolint:govet // shadow
+				Author: testAuthor,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			writeAndAdd("changed.txt", "one\nTWO\nthree\nfour\n")
+			writeAndAdd("binary.bin", "\x00\x01")
+
+			_, err = worktree.Remove("removed.txt")
+			Expect(err).NotTo(HaveOccurred())
+
+			// Unstaged changes are not part of the staged diff
+			err = os.WriteFile(filepath.Join(tempDir, "changed.txt"), []byte("five\n"), 0o644)
+			Expect(err).NotTo(HaveOccurred())
+
+			changes, err := sdkRepo.GetStagedChanges()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(Equal([]internalgit.StagedChange{
+				{Path: "binary.bin"},
+				{
+					Path: "changed.txt",
+					Added: []internalgit.DiffLine{
+						{Number: 2, Text: "TWO"},
+						{Number: 4, Text: "four"},
+					},
+				},
+			}))
+		})
+	})
+
 	Describe("GetCurrentBranch", func() {
 		BeforeEach(func() {
 			sdkRepo, err = internalgit.DiscoverRepository()
@@ -549,6 +693,246 @@ var _ = Describe("SDKRepository", func() {
 			})
 		})
 	})
+
+	Describe("GetUnmergedCommits", func() {
+		commitFile := func(name, message string) plumbing.Hash {
+			err := os.WriteFile(filepath.Join(tempDir, name), []byte(message), 0o644)
+			Expect(err).NotTo(HaveOccurred())
+
+			worktree, err := repo.Worktree()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = worktree.Add(name)
+			Expect(err).NotTo(HaveOccurred())
+
+			hash, err := worktree.Commit(message, &git.CommitOptions{Author: testAuthor})
+			Expect(err).NotTo(HaveOccurred())
+
+			return hash
+		}
+
+		BeforeEach(func() {
+			sdkRepo, err = internalgit.DiscoverRepository()
+			Expect(err).NotTo(HaveOccurred())
+
+			commitFile("initial.txt", "Initial commit")
+
+			worktree, err := repo.Worktree() //nolint:govet // shadow
+			Expect(err).NotTo(HaveOccurred())
+
+			err = worktree.Checkout(&git.CheckoutOptions{
+				Branch: plumbing.NewBranchReferenceName("feature"),
+				Create: true,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			commitFile("feature.txt", "Add feature\n\nWith a body")
+
+			err = worktree.Checkout(&git.CheckoutOptions{
+				Branch: plumbing.NewBranchReferenceName("master"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should return commits only reachable from the ref", func() {
+			commits, err := sdkRepo.GetUnmergedCommits("refs/heads/feature") //nolint:govet // shadow
+			Expect(err).NotTo(HaveOccurred())
+			Expect(commits).To(HaveLen(1))
+			Expect(commits[0]).To(MatchRegexp(`^[0-9a-f]{7} Add feature$`))
+		})
+
+		It("should return no commits for a merged ref", func() {
+			commits, err := sdkRepo.GetUnmergedCommits("refs/heads/master") //nolint:govet // shadow
+			Expect(err).NotTo(HaveOccurred())
+			Expect(commits).To(BeEmpty())
+		})
+
+		It("should treat commits reachable from tags as saved", func() {
+			featureRef := plumbing.NewBranchReferenceName("feature")
+
+			feature, err := repo.Reference(featureRef, true) //nolint:govet // shadow
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.CreateTag("v1.0.0", feature.Hash(), &git.CreateTagOptions{
+				Tagger:  testAuthor,
+				Message: "Release",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			commits, err := sdkRepo.GetUnmergedCommits("refs/heads/feature")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(commits).To(BeEmpty())
+		})
+
+		It("should return unmerged commits newest first", func() {
+			worktree, err := repo.Worktree() //nolint:govet // shadow
+			Expect(err).NotTo(HaveOccurred())
+
+			err = worktree.Checkout(&git.CheckoutOptions{
+				Branch: plumbing.NewBranchReferenceName("feature"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			commitFile("second.txt", "Extend feature")
+
+			commits, err := sdkRepo.GetUnmergedCommits("refs/heads/feature")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(commits).To(HaveLen(2))
+			Expect(commits[0]).To(HaveSuffix(" Extend feature"))
+			Expect(commits[1]).To(HaveSuffix(" Add feature"))
+		})
+
+		It("should treat commits merged into another branch as saved", func() {
+			feature, err := repo.Reference( //nolint:govet // shadow
+				plumbing.NewBranchReferenceName("feature"),
+				true,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			master, err := repo.Head()
+			Expect(err).NotTo(HaveOccurred())
+
+			worktree, err := repo.Worktree()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = worktree.Commit("Merge feature", &git.CommitOptions{
+				Author:            testAuthor,
+				Parents:           []plumbing.Hash{master.Hash(), feature.Hash()},
+				AllowEmptyCommits: true,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			commits, err := sdkRepo.GetUnmergedCommits("refs/heads/feature")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(commits).To(BeEmpty())
+		})
+
+		It("should return ErrRefNotFound for unknown refs", func() {
+			_, err := sdkRepo.GetUnmergedCommits("refs/heads/missing") //nolint:govet // shadow
+			Expect(err).To(MatchError(internalgit.ErrRefNotFound))
+		})
+	})
+
+	Describe("GetStashes", func() {
+		BeforeEach(func() {
+			sdkRepo, err = internalgit.DiscoverRepository()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should return empty list without stashes", func() {
+			stashes, err := sdkRepo.GetStashes() //nolint:govet // shadow
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stashes).To(BeEmpty())
+		})
+
+		It("should return stash entries newest first", func() {
+			zero := "0000000000000000000000000000000000000000"
+			reflog := zero + " 1111111111111111111111111111111111111111 " +
+				"Test User <test@klaudiu.sh> 1700000000 +0000\tWIP on master: abc1234 first\n" +
+				"1111111111111111111111111111111111111111 " +
+				"2222222222222222222222222222222222222222 " +
+				"Test User <test@klaudiu.sh> 1700000100 +0000\tOn master: second\n"
+
+			logDir := filepath.Join(tempDir, ".git", "logs", "refs")
+			Expect(os.MkdirAll(logDir, 0o755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(logDir, "stash"), []byte(reflog), 0o644)).
+				To(Succeed())
+
+			stashes, err := sdkRepo.GetStashes() //nolint:govet // shadow
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stashes).To(Equal([]string{
+				"stash@{0}: On master: second",
+				"stash@{1}: WIP on master: abc1234 first",
+			}))
+		})
+	})
+
+	Describe("upstream state", func() {
+		commitFile := func(name string) {
+			err := os.WriteFile(filepath.Join(tempDir, name), []byte(name), 0o644)
+			Expect(err).NotTo(HaveOccurred())
+
+			worktree, err := repo.Worktree()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = worktree.Add(name)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = worktree.Commit("Add "+name, &git.CommitOptions{Author: testAuthor})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		trackBranch := func(remote, merge string) {
+			cfg, err := repo.Config()
+			Expect(err).NotTo(HaveOccurred())
+
+			cfg.Branches["master"] = &config.Branch{
+				Name:   "master",
+				Remote: remote,
+				Merge:  plumbing.NewBranchReferenceName(merge),
+			}
+
+			Expect(repo.SetConfig(cfg)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			sdkRepo, err = internalgit.DiscoverRepository()
+			Expect(err).NotTo(HaveOccurred())
+
+			commitFile("initial.txt")
+
+			head, err := repo.Head() //nolint:govet // shadow
+			Expect(err).NotTo(HaveOccurred())
+
+			err = repo.Storer.SetReference(
+				plumbing.NewHashReference(plumbing.NewBranchReferenceName("base"), head.Hash()),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			commitFile("first.txt")
+			commitFile("second.txt")
+		})
+
+		It("should return the upstream of a branch tracking a remote", func() {
+			trackBranch("origin", "main")
+
+			upstream, err := sdkRepo.GetUpstream("master") //nolint:govet // shadow
+			Expect(err).NotTo(HaveOccurred())
+			Expect(upstream).To(Equal("origin/main"))
+		})
+
+		It("should return the upstream of a branch tracking a local branch", func() {
+			trackBranch(".", "base")
+
+			upstream, err := sdkRepo.GetUpstream("master") //nolint:govet // shadow
+			Expect(err).NotTo(HaveOccurred())
+			Expect(upstream).To(Equal("base"))
+		})
+
+		It("should return ErrNoTracking without upstream", func() {
+			_, err := sdkRepo.GetUpstream("master") //nolint:govet // shadow
+			Expect(err).To(MatchError(internalgit.ErrNoTracking))
+		})
+
+		It("should count commits ahead of and behind the upstream", func() {
+			ahead, behind, err := sdkRepo.GetAheadBehind("base") //nolint:govet // shadow
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ahead).To(Equal(2))
+			Expect(behind).To(BeZero())
+		})
+
+		It("should return ErrRefNotFound for unknown upstreams", func() {
+			_, _, err := sdkRepo.GetAheadBehind("origin/missing") //nolint:govet // shadow
+			Expect(err).To(MatchError(internalgit.ErrRefNotFound))
+		})
+
+		It("should return the HEAD commit author", func() {
+			head, err := sdkRepo.GetHeadCommit() //nolint:govet // shadow
+			Expect(err).NotTo(HaveOccurred())
+			Expect(head.Author()).To(Equal("Test User <test@klaudiu.sh>"))
+			Expect(head.Signed).To(BeFalse())
+		})
+	})
 })
 
 var _ = Describe("DiscoverRepository with linked worktrees", func() {
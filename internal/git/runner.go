@@ -10,12 +10,26 @@ type Runner interface {
 	// GetStagedFiles returns the list of staged files
 	GetStagedFiles() ([]string, error)
 
+	// GetStagedChanges returns the files added or modified in the staging area with
+	// the lines they add relative to HEAD, as shown by "git diff --cached"
+	GetStagedChanges() ([]StagedChange, error)
+
+	// GetWorktreeChanges returns the tracked files modified in the worktree and
+	// the untracked files, with the lines they add relative to the staging area,
+	// as "git add -A" would stage them. Lines of symlinks, of unreadable files
+	// and of files larger than maxSize are not read.
+	GetWorktreeChanges(maxSize int64) ([]StagedChange, error)
+
 	// GetModifiedFiles returns the list of modified but unstaged files
 	GetModifiedFiles() ([]string, error)
 
 	// GetUntrackedFiles returns the list of untracked files
 	GetUntrackedFiles() ([]string, error)
 
+	// GetIgnoredFiles returns the untracked files matched by ignore rules. Ignored
+	// directories are returned as a single "dir/" entry.
+	GetIgnoredFiles() ([]string, error)
+
 	// GetRepoRoot returns the git repository root directory
 	GetRepoRoot() (string, error)
 
@@ -30,4 +44,21 @@ type Runner interface {
 
 	// GetRemotes returns the list of all remotes with their URLs
 	GetRemotes() (map[string]string, error)
+
+	// GetUnmergedCommits returns the commits reachable from the given full ref name
+	// (e.g., refs/heads/feature) but from no other ref, as "<short hash> <subject>"
+	GetUnmergedCommits(ref string) ([]string, error)
+
+	// GetStashes returns the stash entries, newest first, as "stash@{N}: <message>"
+	GetStashes() ([]string, error)
+
+	// GetUpstream returns the upstream of the given branch, e.g. "origin/main"
+	GetUpstream(branch string) (string, error)
+
+	// GetAheadBehind returns how many commits HEAD is ahead of and behind the given
+	// upstream, e.g. "origin/main"
+	GetAheadBehind(upstream string) (ahead, behind int, err error)
+
+	// GetHeadCommit returns the author and signature status of the HEAD commit
+	GetHeadCommit() (*HeadCommit, error)
 }
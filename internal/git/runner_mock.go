@@ -39,6 +39,22 @@ func (m *MockRunner) EXPECT() *MockRunnerMockRecorder {
 	return m.recorder
 }
 
+// GetAheadBehind mocks base method.
+func (m *MockRunner) GetAheadBehind(upstream string) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAheadBehind", upstream)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAheadBehind indicates an expected call of GetAheadBehind.
+func (mr *MockRunnerMockRecorder) GetAheadBehind(upstream any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAheadBehind", reflect.TypeOf((*MockRunner)(nil).GetAheadBehind), upstream)
+}
+
 // GetBranchRemote mocks base method.
 func (m *MockRunner) GetBranchRemote(branch string) (string, error) {
 	m.ctrl.T.Helper()
@@ -69,6 +85,36 @@ func (mr *MockRunnerMockRecorder) GetCurrentBranch() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentBranch", reflect.TypeOf((*MockRunner)(nil).GetCurrentBranch))
 }
 
+// GetHeadCommit mocks base method.
+func (m *MockRunner) GetHeadCommit() (*HeadCommit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeadCommit")
+	ret0, _ := ret[0].(*HeadCommit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeadCommit indicates an expected call of GetHeadCommit.
+func (mr *MockRunnerMockRecorder) GetHeadCommit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeadCommit", reflect.TypeOf((*MockRunner)(nil).GetHeadCommit))
+}
+
+// GetIgnoredFiles mocks base method.
+func (m *MockRunner) GetIgnoredFiles() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIgnoredFiles")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIgnoredFiles indicates an expected call of GetIgnoredFiles.
+func (mr *MockRunnerMockRecorder) GetIgnoredFiles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIgnoredFiles", reflect.TypeOf((*MockRunner)(nil).GetIgnoredFiles))
+}
+
 // GetModifiedFiles mocks base method.
 func (m *MockRunner) GetModifiedFiles() ([]string, error) {
 	m.ctrl.T.Helper()
@@ -129,6 +175,21 @@ func (mr *MockRunnerMockRecorder) GetRepoRoot() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepoRoot", reflect.TypeOf((*MockRunner)(nil).GetRepoRoot))
 }
 
+// GetStagedChanges mocks base method.
+func (m *MockRunner) GetStagedChanges() ([]StagedChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStagedChanges")
+	ret0, _ := ret[0].([]StagedChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStagedChanges indicates an expected call of GetStagedChanges.
+func (mr *MockRunnerMockRecorder) GetStagedChanges() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStagedChanges", reflect.TypeOf((*MockRunner)(nil).GetStagedChanges))
+}
+
 // GetStagedFiles mocks base method.
 func (m *MockRunner) GetStagedFiles() ([]string, error) {
 	m.ctrl.T.Helper()
@@ -144,6 +205,36 @@ func (mr *MockRunnerMockRecorder) GetStagedFiles() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStagedFiles", reflect.TypeOf((*MockRunner)(nil).GetStagedFiles))
 }
 
+// GetStashes mocks base method.
+func (m *MockRunner) GetStashes() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStashes")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStashes indicates an expected call of GetStashes.
+func (mr *MockRunnerMockRecorder) GetStashes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStashes", reflect.TypeOf((*MockRunner)(nil).GetStashes))
+}
+
+// GetUnmergedCommits mocks base method.
+func (m *MockRunner) GetUnmergedCommits(ref string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnmergedCommits", ref)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnmergedCommits indicates an expected call of GetUnmergedCommits.
+func (mr *MockRunnerMockRecorder) GetUnmergedCommits(ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnmergedCommits", reflect.TypeOf((*MockRunner)(nil).GetUnmergedCommits), ref)
+}
+
 // GetUntrackedFiles mocks base method.
 func (m *MockRunner) GetUntrackedFiles() ([]string, error) {
 	m.ctrl.T.Helper()
@@ -159,6 +250,36 @@ func (mr *MockRunnerMockRecorder) GetUntrackedFiles() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUntrackedFiles", reflect.TypeOf((*MockRunner)(nil).GetUntrackedFiles))
 }
 
+// GetUpstream mocks base method.
+func (m *MockRunner) GetUpstream(branch string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpstream", branch)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpstream indicates an expected call of GetUpstream.
+func (mr *MockRunnerMockRecorder) GetUpstream(branch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpstream", reflect.TypeOf((*MockRunner)(nil).GetUpstream), branch)
+}
+
+// GetWorktreeChanges mocks base method.
+func (m *MockRunner) GetWorktreeChanges(maxSize int64) ([]StagedChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorktreeChanges", maxSize)
+	ret0, _ := ret[0].([]StagedChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorktreeChanges indicates an expected call of GetWorktreeChanges.
+func (mr *MockRunnerMockRecorder) GetWorktreeChanges(maxSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorktreeChanges", reflect.TypeOf((*MockRunner)(nil).GetWorktreeChanges), maxSize)
+}
+
 // IsInRepo mocks base method.
 func (m *MockRunner) IsInRepo() bool {
 	m.ctrl.T.Helper()
@@ -0,0 +1,168 @@
+package git
+
+import (
+	"strings"
+	"sync"
+)
+
+// HeadCommit describes the commit HEAD points to
+type HeadCommit struct {
+	// AuthorName is the name of the commit author
+	AuthorName string
+
+	// AuthorEmail is the email address of the commit author
+	AuthorEmail string
+
+	// Signed reports whether the commit carries a GPG, SSH or X.509 signature.
+	// The signature is not verified.
+	Signed bool
+}
+
+// Author returns the commit author as "Name <email>"
+func (c *HeadCommit) Author() string {
+	return c.AuthorName + " <" + c.AuthorEmail + ">"
+}
+
+// ParseCommitHeader parses the raw commit object printed by "git cat-file commit"
+// into the author and signature status of the commit.
+func ParseCommitHeader(raw string) *HeadCommit {
+	commit := &HeadCommit{}
+
+	for line := range strings.SplitSeq(raw, "\n") {
+		// Headers end at the first empty line, before the message
+		if line == "" {
+			break
+		}
+
+		key, value, _ := strings.Cut(line, " ")
+
+		switch key {
+		case "author":
+			// author <name> <<email>> <timestamp> <timezone>
+			name, rest, found := strings.Cut(value, " <")
+			if !found {
+				continue
+			}
+
+			commit.AuthorName = name
+			commit.AuthorEmail, _, _ = strings.Cut(rest, ">")
+		case "gpgsig", "gpgsig-sha256":
+			commit.Signed = true
+		}
+	}
+
+	return commit
+}
+
+// RepoState computes repository state for rule matching on first use. Each
+// value is queried from the runner at most once, and query errors, such as a
+// branch without upstream, result in zero values.
+type RepoState struct {
+	runner Runner
+	branch string
+
+	stagedOnce sync.Once
+	staged     []string
+
+	dirtyOnce sync.Once
+	dirty     bool
+
+	upstreamOnce sync.Once
+	upstream     string
+
+	aheadBehindOnce sync.Once
+	ahead           int
+	behind          int
+
+	headOnce sync.Once
+	head     *HeadCommit
+}
+
+// NewRepoState creates a RepoState for the given branch, which may be empty
+// for a detached HEAD.
+func NewRepoState(runner Runner, branch string) *RepoState {
+	return &RepoState{runner: runner, branch: branch}
+}
+
+// StagedFiles returns the paths of the staged files, relative to the repository root.
+func (s *RepoState) StagedFiles() []string {
+	s.stagedOnce.Do(func() {
+		s.staged, _ = s.runner.GetStagedFiles()
+	})
+
+	return s.staged
+}
+
+// IsDirty reports whether there are staged, modified or untracked files.
+func (s *RepoState) IsDirty() bool {
+	s.dirtyOnce.Do(func() {
+		if len(s.StagedFiles()) > 0 {
+			s.dirty = true
+			return
+		}
+
+		if modified, _ := s.runner.GetModifiedFiles(); len(modified) > 0 {
+			s.dirty = true
+			return
+		}
+
+		untracked, _ := s.runner.GetUntrackedFiles()
+		s.dirty = len(untracked) > 0
+	})
+
+	return s.dirty
+}
+
+// Upstream returns the upstream of the current branch, e.g. "origin/main", or
+// an empty string if it has none.
+func (s *RepoState) Upstream() string {
+	s.upstreamOnce.Do(func() {
+		if s.branch != "" {
+			s.upstream, _ = s.runner.GetUpstream(s.branch)
+		}
+	})
+
+	return s.upstream
+}
+
+// AheadBehind returns how many commits HEAD is ahead of and behind the upstream.
+func (s *RepoState) AheadBehind() (ahead, behind int) {
+	s.aheadBehindOnce.Do(func() {
+		if upstream := s.Upstream(); upstream != "" {
+			var err error
+
+			s.ahead, s.behind, err = s.runner.GetAheadBehind(upstream)
+			if err != nil {
+				s.ahead, s.behind = 0, 0
+			}
+		}
+	})
+
+	return s.ahead, s.behind
+}
+
+// HeadAuthor returns the author of the HEAD commit as "Name <email>", or an
+// empty string if there are no commits.
+func (s *RepoState) HeadAuthor() string {
+	if head := s.headCommit(); head != nil {
+		return head.Author()
+	}
+
+	return ""
+}
+
+// HeadSigned reports whether the HEAD commit is signed.
+func (s *RepoState) HeadSigned() bool {
+	head := s.headCommit()
+
+	return head != nil && head.Signed
+}
+
+// headCommit returns the HEAD commit, or nil if it cannot be read.
+func (s *RepoState) headCommit() *HeadCommit {
+	s.headOnce.Do(func() {
+		s.head, _ = s.runner.GetHeadCommit()
+	})
+
+	return s.head
+}
@@ -0,0 +1,141 @@
+package git_test
+
+import (
+	"github.com/cockroachdb/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smykla-labs/klaudiush/internal/git"
+)
+
+var _ = Describe("ParseCommitHeader", func() {
+	It("should parse the author", func() {
+		commit := git.ParseCommitHeader("tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+			"author Jane Doe <jane@example.com> 1700000000 +0100\n" +
+			"committer Jane Doe <jane@example.com> 1700000000 +0100\n" +
+			"\n" +
+			"gpgsig in the message is not a header\n")
+
+		Expect(commit.AuthorName).To(Equal("Jane Doe"))
+		Expect(commit.AuthorEmail).To(Equal("jane@example.com"))
+		Expect(commit.Author()).To(Equal("Jane Doe <jane@example.com>"))
+		Expect(commit.Signed).To(BeFalse())
+	})
+
+	DescribeTable("should detect signatures",
+		func(header string) {
+			commit := git.ParseCommitHeader("tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+				"author Jane Doe <jane@example.com> 1700000000 +0100\n" +
+				header + " -----BEGIN SSH SIGNATURE-----\n" +
+				" U1NIU0lHAAAAAQ==\n" +
+				" -----END SSH SIGNATURE-----\n" +
+				"\n" +
+				"Signed commit\n")
+
+			Expect(commit.Signed).To(BeTrue())
+			Expect(commit.AuthorName).To(Equal("Jane Doe"))
+		},
+		Entry("sha1 object format", "gpgsig"),
+		Entry("sha256 object format", "gpgsig-sha256"),
+	)
+})
+
+var _ = Describe("RepoState", func() {
+	var runner *git.FakeRunner
+
+	BeforeEach(func() {
+		runner = git.NewFakeRunner()
+	})
+
+	Describe("IsDirty", func() {
+		It("should be clean without changes", func() {
+			Expect(git.NewRepoState(runner, "main").IsDirty()).To(BeFalse())
+		})
+
+		DescribeTable("should be dirty with changes",
+			func(setup func()) {
+				setup()
+				Expect(git.NewRepoState(runner, "main").IsDirty()).To(BeTrue())
+			},
+			Entry("staged files", func() { runner.StagedFiles = []string{"a.go"} }),
+			Entry("modified files", func() { runner.ModifiedFiles = []string{"a.go"} }),
+			Entry("untracked files", func() { runner.UntrackedFiles = []string{"a.go"} }),
+		)
+	})
+
+	Describe("Upstream and AheadBehind", func() {
+		It("should return the upstream and counts", func() {
+			runner.Upstreams = map[string]string{"main": "origin/main"}
+			runner.Ahead, runner.Behind = 2, 1
+
+			state := git.NewRepoState(runner, "main")
+			Expect(state.Upstream()).To(Equal("origin/main"))
+
+			ahead, behind := state.AheadBehind()
+			Expect(ahead).To(Equal(2))
+			Expect(behind).To(Equal(1))
+		})
+
+		It("should return zero values without upstream", func() {
+			runner.Ahead = 2
+
+			state := git.NewRepoState(runner, "main")
+			Expect(state.Upstream()).To(BeEmpty())
+
+			ahead, behind := state.AheadBehind()
+			Expect(ahead).To(BeZero())
+			Expect(behind).To(BeZero())
+		})
+
+		It("should not look up an upstream for a detached HEAD", func() {
+			runner.Upstreams = map[string]string{"": "origin/main"}
+
+			Expect(git.NewRepoState(runner, "").Upstream()).To(BeEmpty())
+		})
+	})
+
+	Describe("HEAD commit", func() {
+		It("should return the author and signature", func() {
+			runner.HeadCommit = &git.HeadCommit{
+				AuthorName:  "Jane Doe",
+				AuthorEmail: "jane@example.com",
+				Signed:      true,
+			}
+
+			state := git.NewRepoState(runner, "main")
+			Expect(state.HeadAuthor()).To(Equal("Jane Doe <jane@example.com>"))
+			Expect(state.HeadSigned()).To(BeTrue())
+		})
+
+		It("should return zero values without commits", func() {
+			state := git.NewRepoState(runner, "main")
+			Expect(state.HeadAuthor()).To(BeEmpty())
+			Expect(state.HeadSigned()).To(BeFalse())
+		})
+	})
+
+	It("should query each value once", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		mockRunner := git.NewMockRunner(ctrl)
+
+		mockRunner.EXPECT().GetStagedFiles().Return(nil, nil).Times(1)
+		mockRunner.EXPECT().GetModifiedFiles().Return(nil, nil).Times(1)
+		mockRunner.EXPECT().GetUntrackedFiles().Return(nil, nil).Times(1)
+		mockRunner.EXPECT().GetUpstream("main").Return("", errors.New("no upstream")).Times(1)
+		mockRunner.EXPECT().GetHeadCommit().Return(nil, git.ErrNoHead).Times(1)
+
+		state := git.NewRepoState(mockRunner, "main")
+
+		for range 2 {
+			Expect(state.IsDirty()).To(BeFalse())
+			Expect(state.StagedFiles()).To(BeEmpty())
+			Expect(state.Upstream()).To(BeEmpty())
+
+			ahead, behind := state.AheadBehind()
+			Expect(ahead + behind).To(BeZero())
+			Expect(state.HeadAuthor()).To(BeEmpty())
+			Expect(state.HeadSigned()).To(BeFalse())
+		}
+	})
+})
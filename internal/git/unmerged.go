@@ -0,0 +1,163 @@
+package git
+
+import (
+	"container/heap"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/go-git/go-git/v6/plumbing/object"
+)
+
+// Flags of commits visited by unmergedWalk
+const (
+	// fromTarget marks commits reachable from the ref being deleted
+	fromTarget uint8 = 1 << iota
+
+	// fromOther marks commits reachable from any other ref
+	fromOther
+)
+
+// commitQueue is a priority queue of commits, newest committer date first
+type commitQueue []*object.Commit
+
+func (q commitQueue) Len() int { return len(q) }
+
+func (q commitQueue) Less(i, j int) bool {
+	return q[i].Committer.When.After(q[j].Committer.When)
+}
+
+func (q commitQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *commitQueue) Push(x any) { *q = append(*q, x.(*object.Commit)) }
+
+func (q *commitQueue) Pop() any {
+	old := *q
+	commit := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return commit
+}
+
+// unmergedWalk finds the commits reachable from a target commit but from none
+// of the other tips. Like "git log target --not others", it walks all tips
+// together, newest first, and stops as soon as every queued commit is
+// reachable from another tip. History below the merge bases is never visited.
+type unmergedWalk struct {
+	flags  map[plumbing.Hash]uint8
+	queue  commitQueue
+	queued map[plumbing.Hash]bool
+
+	// pending counts queued commits not reachable from another tip
+	pending int
+}
+
+// newUnmergedWalk creates a walk from the target commit and the other tips
+func newUnmergedWalk(target *object.Commit, others []*object.Commit) *unmergedWalk {
+	w := &unmergedWalk{
+		flags:  make(map[plumbing.Hash]uint8),
+		queued: make(map[plumbing.Hash]bool),
+	}
+
+	w.mark(target, fromTarget)
+
+	for _, other := range others {
+		w.mark(other, fromOther)
+	}
+
+	return w
+}
+
+// mark adds flags to a commit and queues it if they need to be passed on to
+// its parents
+func (w *unmergedWalk) mark(commit *object.Commit, flags uint8) {
+	old, seen := w.flags[commit.Hash]
+	if seen && old|flags == old {
+		return
+	}
+
+	w.flags[commit.Hash] = old | flags
+
+	switch {
+	case w.queued[commit.Hash]:
+		// A queued commit that becomes reachable from another tip no longer
+		// keeps the walk going
+		if old&fromOther == 0 && flags&fromOther != 0 {
+			w.pending--
+		}
+	case !seen || flags&fromOther != 0:
+		// Visited commits are queued again when they turn out to be reachable
+		// from another tip, so their parents are marked as well
+		w.push(commit, old|flags)
+	}
+}
+
+// push queues a commit
+func (w *unmergedWalk) push(commit *object.Commit, flags uint8) {
+	heap.Push(&w.queue, commit)
+	w.queued[commit.Hash] = true
+
+	if flags&fromOther == 0 {
+		w.pending++
+	}
+}
+
+// reaches reports whether the newest queued commit is not older than date.
+// Such commits can still mark visited commits as reachable from another tip.
+func (w *unmergedWalk) reaches(date time.Time) bool {
+	return !date.IsZero() && len(w.queue) > 0 && !w.queue[0].Committer.When.Before(date)
+}
+
+// run walks the history and returns the unmerged commits, newest first
+func (w *unmergedWalk) run() ([]*object.Commit, error) {
+	var (
+		visited []*object.Commit
+		oldest  time.Time
+	)
+
+	for w.pending > 0 || w.reaches(oldest) {
+		commit := heap.Pop(&w.queue).(*object.Commit)
+		delete(w.queued, commit.Hash)
+
+		flags := w.flags[commit.Hash]
+
+		if flags&fromOther == 0 {
+			w.pending--
+
+			visited = append(visited, commit)
+
+			if oldest.IsZero() || commit.Committer.When.Before(oldest) {
+				oldest = commit.Committer.When
+			}
+		}
+
+		err := commit.Parents().ForEach(func(parent *object.Commit) error {
+			w.mark(parent, flags)
+
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read parents of %s", commit.Hash)
+		}
+	}
+
+	// Commits visited before another tip reached them, e.g. with skewed
+	// committer dates, are dropped here
+	commits := make([]*object.Commit, 0, len(visited))
+
+	for _, commit := range visited {
+		if w.flags[commit.Hash]&fromOther == 0 {
+			commits = append(commits, commit)
+		}
+	}
+
+	return commits, nil
+}
+
+// formatCommit formats a commit as "<short hash> <subject>"
+func formatCommit(commit *object.Commit) string {
+	subject, _, _ := strings.Cut(commit.Message, "\n")
+
+	return commit.Hash.String()[:shortHashLength] + " " + subject
+}
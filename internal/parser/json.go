@@ -2,6 +2,7 @@
 package parser
 
 import (
+	"cmp"
 	"encoding/json"
 	"io"
 	"os"
@@ -29,6 +30,28 @@ type JSONInput struct {
 	SessionID        string          `json:"session_id,omitempty"`
 	ToolUseID        string          `json:"tool_use_id,omitempty"`
 	TranscriptPath   string          `json:"transcript_path,omitempty"`
+	Cwd              string          `json:"cwd,omitempty"`
+	HookEventName    string          `json:"hook_event_name,omitempty"`
+	Prompt           string          `json:"prompt,omitempty"`
+	StopHookActive   bool            `json:"stop_hook_active,omitempty"`
+	Source           string          `json:"source,omitempty"`
+	Trigger          string          `json:"trigger,omitempty"`
+	Reason           string          `json:"reason,omitempty"`
+	ToolResponse     json.RawMessage `json:"tool_response,omitempty"`
+}
+
+// toolResponseInput represents the known fields of a tool_response object.
+// Claude Code uses camelCase for file tools, so both spellings are accepted.
+type toolResponseInput struct {
+	Stdout      string `json:"stdout,omitempty"`
+	Stderr      string `json:"stderr,omitempty"`
+	ExitCode    *int   `json:"exit_code,omitempty"`
+	ExitCodeAlt *int   `json:"exitCode,omitempty"`
+	Interrupted bool   `json:"interrupted,omitempty"`
+	FilePath    string `json:"filePath,omitempty"`
+	FilePathAlt string `json:"file_path,omitempty"`
+	Success     *bool  `json:"success,omitempty"`
+	Error       string `json:"error,omitempty"`
 }
 
 // JSONParser parses JSON input from stdin or environment variable.
@@ -44,6 +67,8 @@ func NewJSONParser(reader io.Reader) *JSONParser {
 }
 
 // Parse parses the JSON input and extracts the hook context.
+// When eventType is EventTypeUnknown, the event type is taken from the
+// hook_event_name field of the input, defaulting to EventTypePreToolUse.
 func (p *JSONParser) Parse(eventType hook.EventType) (*hook.Context, error) {
 	// Try reading from stdin
 	jsonBytes, err := io.ReadAll(p.reader)
@@ -94,16 +119,79 @@ func (p *JSONParser) Parse(eventType hook.EventType) (*hook.Context, error) {
 		parsedToolType = hook.ToolTypeUnknown
 	}
 
+	// MCP tools are named mcp__<server>__<tool>
+	mcpServer, mcpTool, isMCP := hook.ParseMCPToolName(toolName)
+	if isMCP {
+		parsedToolType = hook.ToolTypeMCP
+	}
+
+	if eventType == hook.EventTypeUnknown {
+		eventType = resolveEventType(input.HookEventName)
+	}
+
 	ctx := &hook.Context{
 		EventType:        eventType,
 		ToolName:         parsedToolType,
 		ToolInput:        toolInput,
+		MCPServer:        mcpServer,
+		MCPTool:          mcpTool,
 		NotificationType: input.NotificationType,
+		Prompt:           input.Prompt,
+		StopHookActive:   input.StopHookActive,
+		Source:           input.Source,
+		Trigger:          input.Trigger,
+		Reason:           input.Reason,
+		ToolResponse:     parseToolResponse(input.ToolResponse),
 		RawJSON:          string(jsonBytes),
 		SessionID:        input.SessionID,
 		ToolUseID:        input.ToolUseID,
 		TranscriptPath:   input.TranscriptPath,
+		Cwd:              input.Cwd,
 	}
 
 	return ctx, nil
 }
+
+// resolveEventType parses the hook_event_name field, defaulting to PreToolUse
+// when it is missing or unknown.
+func resolveEventType(name string) hook.EventType {
+	eventType, err := hook.EventTypeString(name)
+	if err != nil || eventType == hook.EventTypeUnknown {
+		return hook.EventTypePreToolUse
+	}
+
+	return eventType
+}
+
+// parseToolResponse parses the tool_response field. Returns nil when it is
+// missing. String responses (e.g. tool errors) are stored in Error, and
+// responses of other shapes are only kept as raw JSON.
+func parseToolResponse(raw json.RawMessage) *hook.ToolResponse {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+
+	response := &hook.ToolResponse{Raw: string(raw)}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		response.Error = text
+
+		return response
+	}
+
+	var input toolResponseInput
+	if err := json.Unmarshal(raw, &input); err != nil {
+		return response
+	}
+
+	response.Stdout = input.Stdout
+	response.Stderr = input.Stderr
+	response.ExitCode = cmp.Or(input.ExitCode, input.ExitCodeAlt)
+	response.Interrupted = input.Interrupted
+	response.FilePath = cmp.Or(input.FilePath, input.FilePathAlt)
+	response.Success = input.Success
+	response.Error = input.Error
+
+	return response
+}
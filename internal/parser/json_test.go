@@ -93,6 +93,7 @@ var _ = Describe("JSONParser", func() {
 			Expect(
 				ctx.TranscriptPath,
 			).To(Equal("/Users/test/projects/klaudiush/d267099c-6c3a-45ed-997c-2fa4c8ec9b39.jsonl"))
+			Expect(ctx.Cwd).To(Equal("/Users/test/projects/klaudiush"))
 			Expect(ctx.HasSessionID()).To(BeTrue())
 			Expect(ctx.SessionID).To(Equal("d267099c-6c3a-45ed-997c-2fa4c8ec9b39"))
 
@@ -103,6 +104,236 @@ var _ = Describe("JSONParser", func() {
 		})
 	})
 
+	Describe("Parse with lifecycle event fields", func() {
+		It("parses the UserPromptSubmit prompt", func() {
+			input := `{"hook_event_name": "UserPromptSubmit", "prompt": "deploy to prod"}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypeUserPromptSubmit)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.EventType).To(Equal(hook.EventTypeUserPromptSubmit))
+			Expect(ctx.Prompt).To(Equal("deploy to prod"))
+		})
+
+		It("parses stop_hook_active, source, trigger and reason", func() {
+			input := `{
+				"stop_hook_active": true,
+				"source": "resume",
+				"trigger": "auto",
+				"reason": "logout"
+			}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypeStop)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.StopHookActive).To(BeTrue())
+			Expect(ctx.Source).To(Equal("resume"))
+			Expect(ctx.Trigger).To(Equal("auto"))
+			Expect(ctx.Reason).To(Equal("logout"))
+		})
+
+		It("resolves the event type from hook_event_name when unknown", func() {
+			input := `{"hook_event_name": "SessionStart", "source": "startup"}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypeUnknown)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.EventType).To(Equal(hook.EventTypeSessionStart))
+		})
+
+		It("defaults to PreToolUse when hook_event_name is missing", func() {
+			input := `{"tool_name": "Bash", "tool_input": {"command": "ls"}}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypeUnknown)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.EventType).To(Equal(hook.EventTypePreToolUse))
+		})
+
+		It("prefers the explicit event type over hook_event_name", func() {
+			input := `{"hook_event_name": "Stop"}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypeSubagentStop)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.EventType).To(Equal(hook.EventTypeSubagentStop))
+		})
+	})
+
+	Describe("Parse with MultiEdit input", func() {
+		It("parses the edits array", func() {
+			input := `{
+				"tool_name": "MultiEdit",
+				"tool_input": {
+					"file_path": "README.md",
+					"edits": [
+						{"old_string": "foo", "new_string": "bar"},
+						{"old_string": "baz", "new_string": "qux", "replace_all": true}
+					]
+				}
+			}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypePreToolUse)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.ToolName).To(Equal(hook.ToolTypeMultiEdit))
+			Expect(ctx.ToolInput.Edits).To(Equal([]hook.Edit{
+				{OldString: "foo", NewString: "bar"},
+				{OldString: "baz", NewString: "qux", ReplaceAll: true},
+			}))
+		})
+	})
+
+	Describe("Parse with MCP, web and notebook tools", func() {
+		It("splits MCP tool names into server and tool", func() {
+			input := `{
+				"tool_name": "mcp__github__create_pull_request",
+				"tool_input": {"title": "Add feature"}
+			}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypePreToolUse)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.ToolName).To(Equal(hook.ToolTypeMCP))
+			Expect(ctx.MCPServer).To(Equal("github"))
+			Expect(ctx.MCPTool).To(Equal("create_pull_request"))
+		})
+
+		It("keeps underscores in MCP tool names", func() {
+			server, tool, ok := hook.ParseMCPToolName("mcp__my_server__list__items")
+
+			Expect(ok).To(BeTrue())
+			Expect(server).To(Equal("my_server"))
+			Expect(tool).To(Equal("list__items"))
+
+			_, _, ok = hook.ParseMCPToolName("mcp__server")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("parses the WebFetch URL", func() {
+			input := `{
+				"tool_name": "WebFetch",
+				"tool_input": {"url": "https://pkg.go.dev/net/url", "prompt": "Summarize"}
+			}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypePreToolUse)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.ToolName).To(Equal(hook.ToolTypeWebFetch))
+			Expect(ctx.GetURL()).To(Equal("https://pkg.go.dev/net/url"))
+			Expect(ctx.GetURLDomain()).To(Equal("pkg.go.dev"))
+			Expect(ctx.ToolInput.Prompt).To(Equal("Summarize"))
+		})
+
+		It("parses the NotebookEdit path and cell source", func() {
+			input := `{
+				"tool_name": "NotebookEdit",
+				"tool_input": {
+					"notebook_path": "/work/analysis.ipynb",
+					"cell_id": "abc123",
+					"new_source": "print('hello')",
+					"edit_mode": "replace"
+				}
+			}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypePreToolUse)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.ToolName).To(Equal(hook.ToolTypeNotebookEdit))
+			Expect(ctx.GetFilePath()).To(Equal("/work/analysis.ipynb"))
+			Expect(ctx.GetContent()).To(Equal("print('hello')"))
+			Expect(ctx.ToolInput.CellID).To(Equal("abc123"))
+		})
+
+		It("recognizes WebSearch, Task and TodoWrite", func() {
+			for name, expected := range map[string]hook.ToolType{
+				"WebSearch": hook.ToolTypeWebSearch,
+				"Task":      hook.ToolTypeTask,
+				"TodoWrite": hook.ToolTypeTodoWrite,
+			} {
+				input := `{"tool_name": "` + name + `", "tool_input": {}}`
+
+				p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+				ctx, err := p.Parse(hook.EventTypePreToolUse)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ctx.ToolName).To(Equal(expected))
+			}
+		})
+	})
+
+	Describe("Parse with tool_response", func() {
+		It("parses Bash stdout, stderr, exit code and interrupted", func() {
+			input := `{
+				"tool_name": "Bash",
+				"tool_input": {"command": "make test"},
+				"tool_response": {
+					"stdout": "ok",
+					"stderr": "warning",
+					"exit_code": 2,
+					"interrupted": false
+				}
+			}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypePostToolUse)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.HasToolResponse()).To(BeTrue())
+			Expect(ctx.ToolResponse.Stdout).To(Equal("ok"))
+			Expect(ctx.ToolResponse.Stderr).To(Equal("warning"))
+			Expect(ctx.ToolResponse.ExitCode).To(HaveValue(Equal(2)))
+			Expect(ctx.ToolResponse.Succeeded()).To(BeFalse())
+		})
+
+		It("parses the file path of Write and Edit responses", func() {
+			input := `{
+				"tool_name": "Edit",
+				"tool_input": {"file_path": "README.md"},
+				"tool_response": {"filePath": "/repo/README.md", "success": true}
+			}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypePostToolUse)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.ToolResponse.FilePath).To(Equal("/repo/README.md"))
+			Expect(ctx.GetResultFilePath()).To(Equal("/repo/README.md"))
+			Expect(ctx.ToolResponse.Succeeded()).To(BeTrue())
+		})
+
+		It("stores string responses as errors", func() {
+			input := `{"tool_name": "Bash", "tool_response": "Command timed out"}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypePostToolUse)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.ToolResponse.Error).To(Equal("Command timed out"))
+			Expect(ctx.ToolResponse.Succeeded()).To(BeFalse())
+		})
+
+		It("leaves the tool response nil when it is missing", func() {
+			input := `{"tool_name": "Write", "tool_input": {"file_path": "a.md"}}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypePostToolUse)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.HasToolResponse()).To(BeFalse())
+			Expect(ctx.GetResultFilePath()).To(Equal("a.md"))
+		})
+	})
+
 	Describe("Backward compatibility", func() {
 		It("works with inputs without session fields", func() {
 			input := `{
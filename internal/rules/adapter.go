@@ -148,8 +148,20 @@ func (a *RuleValidatorAdapter) CheckRulesWithContext(
 	return a.convertResult(result)
 }
 
-// convertResult converts a RuleResult to a validator.Result.
+// convertResult converts a RuleResult to a validator.Result. The fix hint of
+// the rule replaces the suggestion of its reference.
 func (*RuleValidatorAdapter) convertResult(result *RuleResult) *validator.Result {
+	converted := newValidatorResult(result)
+
+	if converted != nil && !converted.Passed && result.FixHint != "" {
+		converted.FixHint = result.FixHint
+	}
+
+	return converted
+}
+
+// newValidatorResult creates the validator.Result of a rule action.
+func newValidatorResult(result *RuleResult) *validator.Result {
 	switch result.Action {
 	case ActionBlock:
 		if result.Reference != "" {
@@ -174,6 +186,16 @@ func (*RuleValidatorAdapter) convertResult(result *RuleResult) *validator.Result
 	case ActionAllow:
 		return validator.Pass()
 
+	case ActionAsk:
+		if result.Reference != "" {
+			return validator.AskWithRef(
+				validator.Reference(result.Reference),
+				result.Message,
+			)
+		}
+
+		return validator.Ask(result.Message)
+
 	default:
 		return nil
 	}
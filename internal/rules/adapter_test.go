@@ -160,6 +160,50 @@ var _ = Describe("RuleValidatorAdapter", func() {
 			})
 		})
 
+		Context("with ask rule", func() {
+			BeforeEach(func() {
+				ruleList := []*rules.Rule{
+					{
+						Name:    "ask-release",
+						Enabled: true,
+						Match: &rules.RuleMatch{
+							BranchPattern: "release/*",
+						},
+						Action: &rules.RuleAction{
+							Type:      rules.ActionAsk,
+							Message:   "push to release branch needs approval",
+							Reference: "GIT022",
+						},
+					},
+				}
+
+				var err error
+				engine, err = rules.NewRuleEngine(ruleList)
+				Expect(err).NotTo(HaveOccurred())
+
+				adapter = rules.NewRuleValidatorAdapter(
+					engine,
+					rules.ValidatorGitPush,
+				)
+			})
+
+			It("should return ask result when rule asks", func() {
+				adapter.GitContextProvider = func() *rules.GitContext {
+					return &rules.GitContext{
+						Branch: "release/1.0",
+					}
+				}
+
+				result := adapter.CheckRules(ctx, &hook.Context{})
+				Expect(result).NotTo(BeNil())
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.ShouldBlock).To(BeFalse())
+				Expect(result.ShouldAsk).To(BeTrue())
+				Expect(string(result.Reference)).To(Equal("GIT022"))
+				Expect(result.String()).To(Equal("ASK"))
+			})
+		})
+
 		Context("with nil engine", func() {
 			BeforeEach(func() {
 				adapter = rules.NewRuleValidatorAdapter(
@@ -425,6 +469,44 @@ var _ = Describe("RuleValidatorAdapter", func() {
 		})
 	})
 
+	Describe("Fix hint", func() {
+		It("should replace the suggestion of the reference with the rendered fix hint", func() {
+			ruleList := []*rules.Rule{
+				{
+					Name:    "block-with-hint",
+					Enabled: true,
+					Match: &rules.RuleMatch{
+						Remote: "upstream",
+					},
+					Action: &rules.RuleAction{
+						Type:      rules.ActionBlock,
+						Message:   "Push of {{.Git.Branch}} blocked",
+						Reference: "GIT019",
+						FixHint:   "Push to your fork instead of {{.Git.Remote}}",
+					},
+				},
+			}
+
+			engine, _ = rules.NewRuleEngine(ruleList)
+			adapter = rules.NewRuleValidatorAdapter(
+				engine,
+				rules.ValidatorGitPush,
+				rules.WithGitContextProvider(func() *rules.GitContext {
+					return &rules.GitContext{
+						Remote: "upstream",
+						Branch: "main",
+					}
+				}),
+			)
+
+			result := adapter.CheckRules(ctx, &hook.Context{})
+			Expect(result).NotTo(BeNil())
+			Expect(result.Message).To(Equal("Push of main blocked"))
+			Expect(string(result.Reference)).To(Equal("GIT019"))
+			Expect(result.FixHint).To(Equal("Push to your fork instead of upstream"))
+		})
+	})
+
 	Describe("Block without reference", func() {
 		It("should return fail result without reference", func() {
 			ruleList := []*rules.Rule{
@@ -2,9 +2,11 @@ package rules
 
 import (
 	"context"
+	"time"
 
 	"github.com/smykla-labs/klaudiush/pkg/hook"
 	"github.com/smykla-labs/klaudiush/pkg/logger"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
 )
 
 // RuleEngine is the main implementation of the Engine interface.
@@ -16,8 +18,24 @@ type RuleEngine struct {
 	// Configuration options.
 	stopOnFirstMatch bool
 	defaultAction    ActionType
+
+	// observer is notified of every rule match.
+	observer MatchObserver
+
+	// gitContextProvider supplies the git context of match contexts without one.
+	gitContextProvider func() *GitContext
+
+	// now returns the current time for schedule conditions.
+	now func() time.Time
+
+	// parserOpts configure how commands are parsed for command patterns.
+	parserOpts []parser.BashParserOption
 }
 
+// MatchObserver is notified when a rule matches, e.g. to explain a decision.
+// It may be called concurrently by validators running in parallel.
+type MatchObserver func(matchCtx *MatchContext, result *RuleResult)
+
 // EngineOption configures a RuleEngine.
 type EngineOption func(*RuleEngine)
 
@@ -42,12 +60,45 @@ func WithEngineDefaultAction(action ActionType) EngineOption {
 	}
 }
 
+// WithMatchObserver sets a function notified of every rule match.
+func WithMatchObserver(observer MatchObserver) EngineOption {
+	return func(e *RuleEngine) {
+		e.observer = observer
+	}
+}
+
+// WithEngineGitContextProvider sets the provider of the git context used for
+// match contexts that have none.
+func WithEngineGitContextProvider(provider func() *GitContext) EngineOption {
+	return func(e *RuleEngine) {
+		e.gitContextProvider = provider
+	}
+}
+
+// WithEngineTimeFunc sets a custom time function for schedule conditions, for testing.
+func WithEngineTimeFunc(fn func() time.Time) EngineOption {
+	return func(e *RuleEngine) {
+		if fn != nil {
+			e.now = fn
+		}
+	}
+}
+
+// WithEngineMaxNestingDepth sets how many wrapper levels are unwrapped when
+// matching commands.
+func WithEngineMaxNestingDepth(depth int) EngineOption {
+	return func(e *RuleEngine) {
+		e.parserOpts = []parser.BashParserOption{parser.WithMaxNestingDepth(depth)}
+	}
+}
+
 // NewRuleEngine creates a new RuleEngine with the given rules.
 func NewRuleEngine(rules []*Rule, opts ...EngineOption) (*RuleEngine, error) {
 	engine := &RuleEngine{
 		registry:         NewRegistry(),
 		stopOnFirstMatch: true,
 		defaultAction:    ActionAllow,
+		now:              time.Now,
 	}
 
 	// Apply options.
@@ -77,6 +128,18 @@ func NewRuleEngine(rules []*Rule, opts ...EngineOption) (*RuleEngine, error) {
 
 // Evaluate evaluates rules against the given match context.
 func (e *RuleEngine) Evaluate(_ context.Context, matchCtx *MatchContext) *RuleResult {
+	if matchCtx.GitContext == nil && e.gitContextProvider != nil {
+		matchCtx.GitContext = e.gitContextProvider()
+	}
+
+	if matchCtx.Now.IsZero() {
+		matchCtx.Now = e.now()
+	}
+
+	if matchCtx.parserOpts == nil {
+		matchCtx.parserOpts = e.parserOpts
+	}
+
 	result := e.evaluator.Evaluate(matchCtx)
 
 	if result.Matched {
@@ -85,11 +148,27 @@ func (e *RuleEngine) Evaluate(_ context.Context, matchCtx *MatchContext) *RuleRe
 			"action", result.Action,
 			"validator", matchCtx.ValidatorType,
 		)
+
+		if e.observer != nil {
+			e.observer(matchCtx, result)
+		}
 	}
 
 	return result
 }
 
+// SetMatchObserver sets a function notified of every rule match, replacing
+// any previous observer. Pass nil to remove it.
+func (e *RuleEngine) SetMatchObserver(observer MatchObserver) {
+	e.observer = observer
+}
+
+// SetGitContextProvider sets the provider of the git context used for match
+// contexts that have none, replacing any previous provider. Pass nil to remove it.
+func (e *RuleEngine) SetGitContextProvider(provider func() *GitContext) {
+	e.gitContextProvider = provider
+}
+
 // EvaluateHook evaluates rules for a hook context with additional git/file context.
 // This is a convenience method that builds the match context from hook context.
 func (e *RuleEngine) EvaluateHook(
@@ -373,5 +373,93 @@ var _ = Describe("RuleEngine", func() {
 			Expect(result.Matched).To(BeFalse())
 			Expect(result.Action).To(Equal(rules.ActionBlock))
 		})
+
+		It("should notify the match observer of matches only", func() {
+			var observed []string
+
+			engine, err := rules.NewRuleEngine(
+				[]*rules.Rule{
+					{
+						Name:    "block-push",
+						Enabled: true,
+						Match: &rules.RuleMatch{
+							ValidatorType: rules.ValidatorGitPush,
+						},
+						Action: &rules.RuleAction{Type: rules.ActionBlock},
+					},
+				},
+				rules.WithMatchObserver(func(matchCtx *rules.MatchContext, result *rules.RuleResult) {
+					observed = append(observed, string(matchCtx.ValidatorType)+":"+result.Rule.Name)
+				}),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			engine.Evaluate(ctx, &rules.MatchContext{ValidatorType: rules.ValidatorGitCommit})
+			Expect(observed).To(BeEmpty())
+
+			engine.Evaluate(ctx, &rules.MatchContext{ValidatorType: rules.ValidatorGitPush})
+			Expect(observed).To(Equal([]string{"git.push:block-push"}))
+
+			engine.SetMatchObserver(nil)
+			engine.Evaluate(ctx, &rules.MatchContext{ValidatorType: rules.ValidatorGitPush})
+			Expect(observed).To(HaveLen(1))
+		})
+
+		It("should use the git context provider for contexts without git context", func() {
+			calls := 0
+
+			engine, err := rules.NewRuleEngine(
+				[]*rules.Rule{
+					{
+						Name:    "block-main",
+						Enabled: true,
+						Match:   &rules.RuleMatch{BranchPattern: "main"},
+						Action:  &rules.RuleAction{Type: rules.ActionBlock},
+					},
+				},
+				rules.WithEngineGitContextProvider(func() *rules.GitContext {
+					calls++
+
+					return &rules.GitContext{Branch: "main", IsInRepo: true}
+				}),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(engine.Evaluate(ctx, &rules.MatchContext{}).Matched).To(BeTrue())
+			Expect(calls).To(Equal(1))
+
+			explicit := &rules.MatchContext{GitContext: &rules.GitContext{Branch: "feat/x"}}
+			Expect(engine.Evaluate(ctx, explicit).Matched).To(BeFalse())
+			Expect(calls).To(Equal(1))
+
+			engine.SetGitContextProvider(nil)
+			Expect(engine.Evaluate(ctx, &rules.MatchContext{}).Matched).To(BeFalse())
+		})
+
+		It("should unwrap commands up to the configured nesting depth", func() {
+			newEngine := func(opts ...rules.EngineOption) *rules.RuleEngine {
+				engine, err := rules.NewRuleEngine([]*rules.Rule{
+					{
+						Name:    "block-push",
+						Enabled: true,
+						Match:   &rules.RuleMatch{CommandPattern: "git push*"},
+						Action:  &rules.RuleAction{Type: rules.ActionBlock},
+					},
+				}, opts...)
+				Expect(err).NotTo(HaveOccurred())
+
+				return engine
+			}
+
+			nested := func() *rules.MatchContext {
+				return &rules.MatchContext{Command: `sudo bash -c "git push"`}
+			}
+
+			Expect(newEngine().Evaluate(ctx, nested()).Matched).To(BeTrue())
+			Expect(newEngine(rules.WithEngineMaxNestingDepth(1)).Evaluate(ctx, nested()).Matched).
+				To(BeFalse())
+			Expect(newEngine(rules.WithEngineMaxNestingDepth(0)).Evaluate(ctx, nested()).Matched).
+				To(BeFalse())
+		})
 	})
 })
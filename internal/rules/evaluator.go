@@ -1,5 +1,7 @@
 package rules
 
+import "strings"
+
 // Evaluator evaluates compiled rules against a match context.
 type Evaluator struct {
 	// registry contains all compiled rules.
@@ -66,13 +68,7 @@ func (e *Evaluator) Evaluate(ctx *MatchContext) *RuleResult {
 	// Rules are already sorted by priority (highest first).
 	for _, compiled := range rules {
 		if compiled.Matcher.Match(ctx) {
-			return &RuleResult{
-				Matched:   true,
-				Rule:      compiled.Rule,
-				Action:    compiled.Rule.Action.Type,
-				Message:   compiled.Rule.Action.Message,
-				Reference: compiled.Rule.Action.Reference,
-			}
+			return newMatchResult(compiled, ctx)
 		}
 	}
 
@@ -83,6 +79,38 @@ func (e *Evaluator) Evaluate(ctx *MatchContext) *RuleResult {
 	}
 }
 
+// newMatchResult creates the result of a matching rule with its message and
+// fix hint rendered. If the rule schedule has an active freeze, its notice is
+// appended to the message.
+func newMatchResult(compiled *CompiledRule, ctx *MatchContext) *RuleResult {
+	rule := compiled.Rule
+
+	result := &RuleResult{
+		Matched:   true,
+		Rule:      rule,
+		Action:    rule.Action.Type,
+		Reference: rule.Action.Reference,
+	}
+
+	result.Message, result.FixHint = compiled.renderAction(ctx)
+
+	if rule.Match == nil {
+		return result
+	}
+
+	if result.Freeze = rule.Match.Schedule.ActiveFreeze(ctx.now()); result.Freeze != nil {
+		notice := result.Freeze.Notice()
+
+		if result.Message == "" {
+			result.Message = strings.ToUpper(notice[:1]) + notice[1:]
+		} else {
+			result.Message += " (" + notice + ")"
+		}
+	}
+
+	return result
+}
+
 // EvaluateAll evaluates all enabled rules and returns all matching results.
 // Results are ordered by priority (highest first).
 func (e *Evaluator) EvaluateAll(ctx *MatchContext) []*RuleResult {
@@ -99,13 +127,7 @@ func (e *Evaluator) EvaluateAll(ctx *MatchContext) []*RuleResult {
 
 	for _, compiled := range rules {
 		if compiled.Matcher.Match(ctx) {
-			results = append(results, &RuleResult{
-				Matched:   true,
-				Rule:      compiled.Rule,
-				Action:    compiled.Rule.Action.Type,
-				Message:   compiled.Rule.Action.Message,
-				Reference: compiled.Rule.Action.Reference,
-			})
+			results = append(results, newMatchResult(compiled, ctx))
 		}
 	}
 
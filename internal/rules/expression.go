@@ -0,0 +1,667 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	goparser "go/parser"
+	"go/scanner"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
+)
+
+// ErrInvalidExpression is returned when a when expression cannot be compiled.
+var ErrInvalidExpression = errors.New("invalid when expression")
+
+// exprType is the type of a value in a when expression.
+type exprType int
+
+const (
+	exprBool exprType = iota
+	exprString
+	exprInt
+	exprList
+)
+
+// String returns the name of the type used in error messages.
+func (t exprType) String() string {
+	switch t {
+	case exprBool:
+		return "bool"
+	case exprString:
+		return "string"
+	case exprInt:
+		return "int"
+	case exprList:
+		return "list"
+	default:
+		return "unknown"
+	}
+}
+
+// exprEnv is the data a when expression is evaluated against. The command is
+// parsed on first use, so expressions not referring to it do not parse it.
+type exprEnv struct {
+	ctx *MatchContext
+}
+
+// exprNode is a compiled expression. Only the function of its type is set.
+type exprNode struct {
+	typ     exprType
+	boolFn  func(*exprEnv) bool
+	strFn   func(*exprEnv) string
+	intFn   func(*exprEnv) int
+	listFn  func(*exprEnv) []string
+	literal *string
+}
+
+// exprFields are the fields available in when expressions, by their path.
+var exprFields = map[string]*exprNode{
+	"validator": strField(func(e *exprEnv) string { return string(e.ctx.ValidatorType) }),
+
+	"git.repo":   strField(func(e *exprEnv) string { return e.git().RepoRoot }),
+	"git.remote": strField(func(e *exprEnv) string { return e.git().Remote }),
+	"git.branch": strField(func(e *exprEnv) string { return e.git().Branch }),
+	"git.repo_name": strField(func(e *exprEnv) string {
+		return baseName(e.git().RepoRoot)
+	}),
+	"git.in_repo": {typ: exprBool, boolFn: func(e *exprEnv) bool { return e.git().IsInRepo }},
+
+	"git.dirty":       boolStateField(GitState.IsDirty),
+	"git.staged":      listStateField(GitState.StagedFiles),
+	"git.upstream":    strStateField(GitState.Upstream),
+	"git.head_author": strStateField(GitState.HeadAuthor),
+	"git.head_signed": boolStateField(GitState.HeadSigned),
+	"git.ahead": intStateField(func(s GitState) int {
+		ahead, _ := s.AheadBehind()
+		return ahead
+	}),
+	"git.behind": intStateField(func(s GitState) int {
+		_, behind := s.AheadBehind()
+		return behind
+	}),
+
+	"file.path":    strField((*exprEnv).filePath),
+	"file.name":    strField(func(e *exprEnv) string { return baseName(e.filePath()) }),
+	"file.ext":     strField(func(e *exprEnv) string { return filepath.Ext(e.filePath()) }),
+	"file.content": strField((*exprEnv).fileContent),
+
+	"command.raw":  strField((*exprEnv).command),
+	"command.name": strField(func(e *exprEnv) string { return e.firstCommand().Name }),
+	"command.argv": listField(func(e *exprEnv) []string {
+		if cmd := e.firstCommand(); cmd.Name != "" {
+			return cmd.FullCommand()
+		}
+
+		return nil
+	}),
+	"command.names": listField(func(e *exprEnv) []string {
+		names := make([]string, 0, len(e.parsedCommands()))
+		for _, cmd := range e.parsedCommands() {
+			names = append(names, cmd.Name)
+		}
+
+		return names
+	}),
+	"command.args": listField(func(e *exprEnv) []string {
+		var args []string
+		for _, cmd := range e.parsedCommands() {
+			args = append(args, cmd.Args...)
+		}
+
+		return args
+	}),
+
+	"tool.name":       hookField(func(h *hook.Context) string { return h.ToolName.String() }),
+	"tool.mcp_server": hookField(func(h *hook.Context) string { return h.MCPServer }),
+	"tool.mcp_tool":   hookField(func(h *hook.Context) string { return h.MCPTool }),
+	"tool.url_domain": hookField((*hook.Context).GetURLDomain),
+
+	"event.name": hookField(func(h *hook.Context) string { return h.EventType.String() }),
+	"event.notification_type": hookField(func(h *hook.Context) string {
+		return h.NotificationType
+	}),
+	"event.prompt":  hookField(func(h *hook.Context) string { return h.Prompt }),
+	"event.source":  hookField(func(h *hook.Context) string { return h.Source }),
+	"event.trigger": hookField(func(h *hook.Context) string { return h.Trigger }),
+	"event.reason":  hookField(func(h *hook.Context) string { return h.Reason }),
+
+	"session.id":              hookField(func(h *hook.Context) string { return h.SessionID }),
+	"session.cwd":             hookField(func(h *hook.Context) string { return h.Cwd }),
+	"session.transcript_path": hookField(func(h *hook.Context) string { return h.TranscriptPath }),
+}
+
+// exprFieldNames returns the sorted paths of the fields available in when
+// expressions, such as "git.branch" or "command.argv".
+func exprFieldNames() []string {
+	fields := make([]string, 0, len(exprFields))
+	for path := range exprFields {
+		fields = append(fields, path)
+	}
+
+	slices.Sort(fields)
+
+	return fields
+}
+
+// ExpressionMatcher matches a when expression, such as
+// git.branch.matches("release/*") && !command.argv.contains("--dry-run").
+type ExpressionMatcher struct {
+	expr string
+	eval func(*exprEnv) bool
+}
+
+// NewExpressionMatcher compiles a when expression.
+func NewExpressionMatcher(expr string) (*ExpressionMatcher, error) {
+	return NewExpressionMatcherWithOpts(expr, PatternOptions{})
+}
+
+// NewExpressionMatcherWithOpts compiles a when expression. The options apply
+// to the patterns passed to matches().
+func NewExpressionMatcherWithOpts(expr string, opts PatternOptions) (*ExpressionMatcher, error) {
+	fset := token.NewFileSet()
+
+	parsed, err := goparser.ParseExprFrom(fset, "", expr, 0)
+	if err != nil {
+		var list scanner.ErrorList
+		if errors.As(err, &list) && len(list) > 0 {
+			return nil, errors.Wrapf(
+				ErrInvalidExpression,
+				"column %d: %s",
+				list[0].Pos.Column,
+				list[0].Msg,
+			)
+		}
+
+		return nil, errors.Wrap(ErrInvalidExpression, err.Error())
+	}
+
+	c := &exprCompiler{fset: fset, opts: opts}
+
+	node, err := c.compile(parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	if node.typ != exprBool {
+		return nil, errors.Wrapf(
+			ErrInvalidExpression,
+			"expression must be a bool, got %s",
+			node.typ,
+		)
+	}
+
+	return &ExpressionMatcher{expr: expr, eval: node.boolFn}, nil
+}
+
+// Match returns true if the expression evaluates to true.
+func (m *ExpressionMatcher) Match(ctx *MatchContext) bool {
+	return m.eval(&exprEnv{ctx: ctx})
+}
+
+// Name returns the matcher name.
+func (m *ExpressionMatcher) Name() string {
+	return "when:" + m.expr
+}
+
+// exprCompiler compiles the syntax tree of an expression.
+type exprCompiler struct {
+	fset *token.FileSet
+	opts PatternOptions
+}
+
+// errorf returns an ErrInvalidExpression error for the given position.
+func (c *exprCompiler) errorf(pos token.Pos, format string, args ...any) error {
+	return errors.Wrapf(
+		ErrInvalidExpression,
+		"column %d: %s",
+		c.fset.Position(pos).Column,
+		fmt.Sprintf(format, args...),
+	)
+}
+
+// compile compiles an expression node.
+func (c *exprCompiler) compile(node ast.Expr) (*exprNode, error) {
+	switch n := node.(type) {
+	case *ast.ParenExpr:
+		return c.compile(n.X)
+	case *ast.BasicLit:
+		return c.compileLiteral(n)
+	case *ast.Ident:
+		switch n.Name {
+		case "true", "false":
+			value := n.Name == "true"
+			return &exprNode{typ: exprBool, boolFn: func(*exprEnv) bool { return value }}, nil
+		}
+
+		return c.compileField(n, n.Name)
+	case *ast.SelectorExpr:
+		path, ok := selectorPath(n)
+		if !ok {
+			return nil, c.errorf(n.Pos(), "unsupported field access")
+		}
+
+		return c.compileField(n, path)
+	case *ast.UnaryExpr:
+		return c.compileUnary(n)
+	case *ast.BinaryExpr:
+		return c.compileBinary(n)
+	case *ast.CallExpr:
+		return c.compileCall(n)
+	default:
+		return nil, c.errorf(node.Pos(), "unsupported expression")
+	}
+}
+
+// compileLiteral compiles a string or integer literal.
+func (c *exprCompiler) compileLiteral(lit *ast.BasicLit) (*exprNode, error) {
+	if lit.Kind == token.INT {
+		value, err := strconv.Atoi(lit.Value)
+		if err != nil {
+			return nil, c.errorf(lit.Pos(), "invalid integer %s", lit.Value)
+		}
+
+		return &exprNode{typ: exprInt, intFn: func(*exprEnv) int { return value }}, nil
+	}
+
+	if lit.Kind != token.STRING {
+		return nil, c.errorf(
+			lit.Pos(),
+			"unsupported literal %s, only strings and integers are supported",
+			lit.Value,
+		)
+	}
+
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return nil, c.errorf(lit.Pos(), "invalid string %s", lit.Value)
+	}
+
+	return &exprNode{
+		typ:     exprString,
+		strFn:   func(*exprEnv) string { return value },
+		literal: &value,
+	}, nil
+}
+
+// compileField compiles a reference to a field of the match context.
+func (c *exprCompiler) compileField(node ast.Expr, path string) (*exprNode, error) {
+	field, ok := exprFields[path]
+	if !ok {
+		return nil, c.errorf(
+			node.Pos(),
+			"unknown field %q (valid: %s)",
+			path,
+			strings.Join(exprFieldNames(), ", "),
+		)
+	}
+
+	return field, nil
+}
+
+// compileUnary compiles the negation of a bool.
+func (c *exprCompiler) compileUnary(n *ast.UnaryExpr) (*exprNode, error) {
+	if n.Op != token.NOT {
+		return nil, c.errorf(n.OpPos, "unsupported operator %s", n.Op)
+	}
+
+	operand, err := c.compileTyped(n.X, exprBool)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exprNode{
+		typ:    exprBool,
+		boolFn: func(e *exprEnv) bool { return !operand.boolFn(e) },
+	}, nil
+}
+
+// compileBinary compiles logical operators and comparisons.
+func (c *exprCompiler) compileBinary(n *ast.BinaryExpr) (*exprNode, error) {
+	switch n.Op { //nolint:exhaustive // other operators are rejected below
+	case token.LAND, token.LOR:
+		left, err := c.compileTyped(n.X, exprBool)
+		if err != nil {
+			return nil, err
+		}
+
+		right, err := c.compileTyped(n.Y, exprBool)
+		if err != nil {
+			return nil, err
+		}
+
+		if n.Op == token.LAND {
+			return &exprNode{
+				typ:    exprBool,
+				boolFn: func(e *exprEnv) bool { return left.boolFn(e) && right.boolFn(e) },
+			}, nil
+		}
+
+		return &exprNode{
+			typ:    exprBool,
+			boolFn: func(e *exprEnv) bool { return left.boolFn(e) || right.boolFn(e) },
+		}, nil
+	case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		return c.compileComparison(n)
+	default:
+		return nil, c.errorf(n.OpPos, "unsupported operator %s", n.Op)
+	}
+}
+
+// compileComparison compiles == and != between values of the same type, and
+// <, <=, > and >= between integers.
+func (c *exprCompiler) compileComparison(n *ast.BinaryExpr) (*exprNode, error) {
+	left, err := c.compile(n.X)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := c.compile(n.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	if left.typ != right.typ || left.typ == exprList {
+		return nil, c.errorf(
+			n.OpPos,
+			"cannot compare %s with %s",
+			left.typ,
+			right.typ,
+		)
+	}
+
+	if left.typ == exprInt {
+		return c.compileIntComparison(n.Op, left, right), nil
+	}
+
+	if n.Op != token.EQL && n.Op != token.NEQ {
+		return nil, c.errorf(n.OpPos, "operator %s is only supported for int", n.Op)
+	}
+
+	equal := func(e *exprEnv) bool { return left.strFn(e) == right.strFn(e) }
+	if left.typ == exprBool {
+		equal = func(e *exprEnv) bool { return left.boolFn(e) == right.boolFn(e) }
+	}
+
+	if n.Op == token.NEQ {
+		return &exprNode{typ: exprBool, boolFn: func(e *exprEnv) bool { return !equal(e) }}, nil
+	}
+
+	return &exprNode{typ: exprBool, boolFn: equal}, nil
+}
+
+// compileIntComparison compiles a comparison between integers.
+func (*exprCompiler) compileIntComparison(op token.Token, left, right *exprNode) *exprNode {
+	var compare func(a, b int) bool
+
+	switch op { //nolint:exhaustive // only comparison operators reach here
+	case token.EQL:
+		compare = func(a, b int) bool { return a == b }
+	case token.NEQ:
+		compare = func(a, b int) bool { return a != b }
+	case token.LSS:
+		compare = func(a, b int) bool { return a < b }
+	case token.LEQ:
+		compare = func(a, b int) bool { return a <= b }
+	case token.GTR:
+		compare = func(a, b int) bool { return a > b }
+	default:
+		compare = func(a, b int) bool { return a >= b }
+	}
+
+	return &exprNode{typ: exprBool, boolFn: func(e *exprEnv) bool {
+		return compare(left.intFn(e), right.intFn(e))
+	}}
+}
+
+// compileCall compiles a method call on a string or list, such as
+// file.path.endsWith(".tf") or command.argv.contains("apply").
+func (c *exprCompiler) compileCall(n *ast.CallExpr) (*exprNode, error) {
+	fun, ok := n.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, c.errorf(
+			n.Pos(),
+			"unsupported function call, use methods such as x.matches(\"...\")",
+		)
+	}
+
+	receiver, err := c.compile(fun.X)
+	if err != nil {
+		return nil, err
+	}
+
+	method := fun.Sel.Name
+
+	if len(n.Args) != 1 {
+		return nil, c.errorf(n.Lparen, "%s() takes 1 argument, got %d", method, len(n.Args))
+	}
+
+	arg, err := c.compileTyped(n.Args[0], exprString)
+	if err != nil {
+		return nil, err
+	}
+
+	if receiver.typ == exprBool || receiver.typ == exprInt {
+		return nil, c.errorf(fun.Sel.Pos(), "%s has no method %s()", receiver.typ, method)
+	}
+
+	if method == "matches" {
+		return c.compileMatches(n, receiver, arg)
+	}
+
+	if receiver.typ == exprList {
+		if method != "contains" {
+			return nil, c.errorf(
+				fun.Sel.Pos(),
+				"unknown list method %s() (valid: contains, matches)",
+				method,
+			)
+		}
+
+		return &exprNode{typ: exprBool, boolFn: func(e *exprEnv) bool {
+			return slices.Contains(receiver.listFn(e), arg.strFn(e))
+		}}, nil
+	}
+
+	var fn func(s, substr string) bool
+
+	switch method {
+	case "contains":
+		fn = strings.Contains
+	case "startsWith":
+		fn = strings.HasPrefix
+	case "endsWith":
+		fn = strings.HasSuffix
+	default:
+		return nil, c.errorf(
+			fun.Sel.Pos(),
+			"unknown string method %s() (valid: contains, endsWith, matches, startsWith)",
+			method,
+		)
+	}
+
+	return &exprNode{typ: exprBool, boolFn: func(e *exprEnv) bool {
+		return fn(receiver.strFn(e), arg.strFn(e))
+	}}, nil
+}
+
+// compileMatches compiles matches(), which takes a pattern literal with the same
+// syntax as the match fields, and for lists matches when any element matches.
+func (c *exprCompiler) compileMatches(
+	n *ast.CallExpr,
+	receiver *exprNode,
+	arg *exprNode,
+) (*exprNode, error) {
+	if arg.literal == nil {
+		return nil, c.errorf(n.Args[0].Pos(), "matches() takes a string literal")
+	}
+
+	pattern, err := CompilePatternWithOptions(*arg.literal, c.opts)
+	if err != nil {
+		return nil, c.errorf(n.Args[0].Pos(), "invalid pattern %q: %v", *arg.literal, err)
+	}
+
+	if receiver.typ == exprList {
+		return &exprNode{typ: exprBool, boolFn: func(e *exprEnv) bool {
+			return slices.ContainsFunc(receiver.listFn(e), pattern.Match)
+		}}, nil
+	}
+
+	return &exprNode{typ: exprBool, boolFn: func(e *exprEnv) bool {
+		return pattern.Match(receiver.strFn(e))
+	}}, nil
+}
+
+// compileTyped compiles an expression that must be of the given type.
+func (c *exprCompiler) compileTyped(node ast.Expr, typ exprType) (*exprNode, error) {
+	compiled, err := c.compile(node)
+	if err != nil {
+		return nil, err
+	}
+
+	if compiled.typ != typ {
+		return nil, c.errorf(node.Pos(), "expected %s, got %s", typ, compiled.typ)
+	}
+
+	return compiled, nil
+}
+
+// selectorPath returns the dotted path of a field selector such as git.branch.
+func selectorPath(n *ast.SelectorExpr) (string, bool) {
+	switch x := n.X.(type) {
+	case *ast.Ident:
+		return x.Name + "." + n.Sel.Name, true
+	case *ast.SelectorExpr:
+		prefix, ok := selectorPath(x)
+		if !ok {
+			return "", false
+		}
+
+		return prefix + "." + n.Sel.Name, true
+	default:
+		return "", false
+	}
+}
+
+// strField returns a string field node.
+func strField(fn func(*exprEnv) string) *exprNode {
+	return &exprNode{typ: exprString, strFn: fn}
+}
+
+// listField returns a list field node.
+func listField(fn func(*exprEnv) []string) *exprNode {
+	return &exprNode{typ: exprList, listFn: fn}
+}
+
+// strStateField returns a string field node read from the repository state.
+func strStateField(fn func(GitState) string) *exprNode {
+	return strField(func(e *exprEnv) string { return stateValue(e, fn) })
+}
+
+// boolStateField returns a bool field node read from the repository state.
+func boolStateField(fn func(GitState) bool) *exprNode {
+	return &exprNode{typ: exprBool, boolFn: func(e *exprEnv) bool { return stateValue(e, fn) }}
+}
+
+// intStateField returns an int field node read from the repository state.
+func intStateField(fn func(GitState) int) *exprNode {
+	return &exprNode{typ: exprInt, intFn: func(e *exprEnv) int { return stateValue(e, fn) }}
+}
+
+// listStateField returns a list field node read from the repository state.
+func listStateField(fn func(GitState) []string) *exprNode {
+	return listField(func(e *exprEnv) []string { return stateValue(e, fn) })
+}
+
+// hookField returns a string field node read from the hook context.
+func hookField(fn func(*hook.Context) string) *exprNode {
+	return strField(func(e *exprEnv) string { return e.hook(fn) })
+}
+
+// baseName returns the last element of a path, or an empty string for an
+// empty path.
+func baseName(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	return filepath.Base(path)
+}
+
+// git returns the git context, or an empty one.
+func (e *exprEnv) git() *GitContext {
+	if e.ctx.GitContext == nil {
+		return &GitContext{}
+	}
+
+	return e.ctx.GitContext
+}
+
+// stateValue returns a value of the repository state, or zero without one.
+func stateValue[T any](e *exprEnv, fn func(GitState) T) T {
+	if state := gitState(e.ctx); state != nil {
+		return fn(state)
+	}
+
+	var zero T
+
+	return zero
+}
+
+// hook returns a value of the hook context, or an empty string without one.
+func (e *exprEnv) hook(fn func(*hook.Context) string) string {
+	if e.ctx.HookContext == nil {
+		return ""
+	}
+
+	return fn(e.ctx.HookContext)
+}
+
+// filePath returns the file path, falling back to the hook context.
+func (e *exprEnv) filePath() string {
+	if e.ctx.FileContext != nil && e.ctx.FileContext.Path != "" {
+		return e.ctx.FileContext.Path
+	}
+
+	return e.hook((*hook.Context).GetFilePath)
+}
+
+// fileContent returns the file content, falling back to the hook context.
+func (e *exprEnv) fileContent() string {
+	if e.ctx.FileContext != nil && e.ctx.FileContext.Content != "" {
+		return e.ctx.FileContext.Content
+	}
+
+	return e.hook((*hook.Context).GetContent)
+}
+
+// command returns the Bash command, falling back to the hook context.
+func (e *exprEnv) command() string {
+	if e.ctx.Command != "" {
+		return e.ctx.Command
+	}
+
+	return e.hook((*hook.Context).GetCommand)
+}
+
+// parsedCommands returns the commands of the Bash command, including the ones
+// unwrapped from wrappers such as bash -c or sudo.
+func (e *exprEnv) parsedCommands() []parser.Command {
+	return e.ctx.commands()
+}
+
+// firstCommand returns the first command of the Bash command, or an empty one.
+func (e *exprEnv) firstCommand() parser.Command {
+	if commands := e.parsedCommands(); len(commands) > 0 {
+		return commands[0]
+	}
+
+	return parser.Command{}
+}
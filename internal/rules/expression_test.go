@@ -0,0 +1,173 @@
+package rules_test
+
+import (
+	"github.com/cockroachdb/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+)
+
+var _ = Describe("ExpressionMatcher", func() {
+	var ctx *rules.MatchContext
+
+	BeforeEach(func() {
+		ctx = &rules.MatchContext{
+			HookContext: &hook.Context{
+				EventType: hook.EventTypePreToolUse,
+				ToolName:  hook.ToolTypeBash,
+				SessionID: "session-1",
+				Cwd:       "/home/user/src/app",
+			},
+			GitContext: &rules.GitContext{
+				RepoRoot: "/home/user/src/app",
+				Remote:   "origin",
+				Branch:   "release/1.2",
+				IsInRepo: true,
+			},
+			ValidatorType: rules.ValidatorGitPush,
+			Command:       "git push --force origin release/1.2 && echo done",
+		}
+	})
+
+	match := func(expr string) bool {
+		m, err := rules.NewExpressionMatcher(expr)
+		Expect(err).NotTo(HaveOccurred())
+
+		return m.Match(ctx)
+	}
+
+	Describe("fields", func() {
+		It("should expose git fields", func() {
+			Expect(match(`git.branch == "release/1.2"`)).To(BeTrue())
+			Expect(match(`git.remote == "origin" && git.in_repo`)).To(BeTrue())
+			Expect(match(`git.repo_name == "app"`)).To(BeTrue())
+			Expect(match(`git.repo.startsWith("/home/user")`)).To(BeTrue())
+		})
+
+		It("should expose command fields", func() {
+			Expect(match(`command.name == "git"`)).To(BeTrue())
+			Expect(match(`command.argv.contains("--force")`)).To(BeTrue())
+			Expect(match(`command.names.contains("echo")`)).To(BeTrue())
+			Expect(match(`command.args.contains("done")`)).To(BeTrue())
+			Expect(match(`command.raw.contains("&&")`)).To(BeTrue())
+		})
+
+		It("should expose tool, event, session and validator fields", func() {
+			Expect(match(`tool.name == "Bash"`)).To(BeTrue())
+			Expect(match(`event.name == "PreToolUse"`)).To(BeTrue())
+			Expect(match(`session.id == "session-1"`)).To(BeTrue())
+			Expect(match(`session.cwd.endsWith("/app")`)).To(BeTrue())
+			Expect(match(`validator == "git.push"`)).To(BeTrue())
+		})
+
+		It("should fall back to the hook context for file fields", func() {
+			ctx.HookContext.ToolName = hook.ToolTypeWrite
+			ctx.HookContext.ToolInput = hook.ToolInput{
+				FilePath: "infra/main.tf",
+				Content:  "resource {}",
+			}
+
+			Expect(match(`file.path == "infra/main.tf"`)).To(BeTrue())
+			Expect(match(`file.name == "main.tf" && file.ext == ".tf"`)).To(BeTrue())
+			Expect(match(`file.content.contains("resource")`)).To(BeTrue())
+		})
+
+		It("should evaluate to empty values without contexts", func() {
+			ctx = &rules.MatchContext{}
+
+			Expect(match(`git.branch == ""`)).To(BeTrue())
+			Expect(match(`!git.in_repo`)).To(BeTrue())
+			Expect(match(`tool.name == ""`)).To(BeTrue())
+			Expect(match(`command.argv.contains("git")`)).To(BeFalse())
+		})
+	})
+
+	Describe("operators", func() {
+		It("should combine conditions", func() {
+			Expect(match(`git.branch == "main" || command.argv.contains("--force")`)).To(BeTrue())
+			Expect(match(`git.branch != "main" && !command.argv.contains("--dry-run")`)).
+				To(BeTrue())
+			Expect(match(`!(git.branch == "main" || git.branch == "master")`)).To(BeTrue())
+			Expect(match(`git.in_repo == true`)).To(BeTrue())
+			Expect(match(`false`)).To(BeFalse())
+		})
+	})
+
+	Describe("matches", func() {
+		It("should use the rule pattern syntax", func() {
+			Expect(match(`git.branch.matches("release/*")`)).To(BeTrue())
+			Expect(match(`git.branch.matches("^release/\\d+\\.\\d+$")`)).To(BeTrue())
+			Expect(match("git.branch.matches(`^release/\\d+`)")).To(BeTrue())
+			Expect(match(`git.branch.matches("!release/*")`)).To(BeFalse())
+		})
+
+		It("should match any element of a list", func() {
+			Expect(match(`command.argv.matches("--force*")`)).To(BeTrue())
+			Expect(match(`command.argv.matches("--force-with-lease")`)).To(BeFalse())
+		})
+
+		It("should honour case-insensitive matching", func() {
+			m, err := rules.NewExpressionMatcherWithOpts(
+				`git.branch.matches("RELEASE/*")`,
+				rules.PatternOptions{CaseInsensitive: true},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.Match(ctx)).To(BeTrue())
+		})
+	})
+
+	Describe("compile errors", func() {
+		DescribeTable("should reject invalid expressions",
+			func(expr, message string) {
+				_, err := rules.NewExpressionMatcher(expr)
+				Expect(err).To(HaveOccurred())
+				Expect(errors.Is(err, rules.ErrInvalidExpression)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring(message))
+			},
+			Entry("syntax error", `git.branch ==`, "column 14"),
+			Entry("unknown field", `git.brnch == "main"`, `unknown field "git.brnch"`),
+			Entry("non-bool result", `git.branch`, "expression must be a bool, got string"),
+			Entry("type mismatch", `git.in_repo == "true"`, "cannot compare bool with string"),
+			Entry("string in logic", `git.branch && true`, "expected bool, got string"),
+			Entry("unknown method", `git.branch.lower("x")`, "unknown string method lower()"),
+			Entry("unknown list method", `command.argv.startsWith("git")`,
+				"unknown list method startsWith()"),
+			Entry("wrong argument count", `git.branch.contains()`, "takes 1 argument, got 0"),
+			Entry("non-literal pattern", `git.branch.matches(git.remote)`,
+				"matches() takes a string literal"),
+			Entry("invalid pattern", `git.branch.matches("[")`, "invalid pattern"),
+			Entry("float literal", `git.ahead > 1.5`, "only strings and integers are supported"),
+			Entry("ordering strings", `git.branch < "main"`, "operator < is only supported for int"),
+			Entry("int method", `git.ahead.contains("1")`, "int has no method contains()"),
+			Entry("unsupported operator", `git.branch + "x" == "y"`, "unsupported operator +"),
+		)
+	})
+
+	Describe("Registry", func() {
+		It("should compile when expressions when adding rules", func() {
+			registry := rules.NewRegistry()
+
+			err := registry.Add(&rules.Rule{
+				Name:   "bad-when",
+				Match:  &rules.RuleMatch{When: `git.branch ==`},
+				Action: &rules.RuleAction{Type: rules.ActionBlock},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, rules.ErrInvalidExpression)).To(BeTrue())
+		})
+
+		It("should combine when with other conditions", func() {
+			m, err := rules.BuildMatcher(&rules.RuleMatch{
+				ValidatorType: rules.ValidatorGitPush,
+				When:          `command.argv.contains("--force")`,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.Match(ctx)).To(BeTrue())
+
+			ctx.ValidatorType = rules.ValidatorGitCommit
+			Expect(m.Match(ctx)).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,285 @@
+package rules
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// gitState returns the repository state of the match context, or nil.
+//
+//nolint:ireturn // interface for polymorphism
+func gitState(ctx *MatchContext) GitState {
+	if ctx.GitContext == nil {
+		return nil
+	}
+
+	return ctx.GitContext.State
+}
+
+// DirtyMatcher matches whether the working tree has uncommitted changes.
+type DirtyMatcher struct {
+	dirty bool
+}
+
+// NewDirtyMatcher creates a matcher for the dirty state of the working tree.
+func NewDirtyMatcher(dirty bool) *DirtyMatcher {
+	return &DirtyMatcher{dirty: dirty}
+}
+
+// Match returns true if the dirty state equals the expected one.
+func (m *DirtyMatcher) Match(ctx *MatchContext) bool {
+	state := gitState(ctx)
+	if state == nil {
+		return false
+	}
+
+	return state.IsDirty() == m.dirty
+}
+
+// Name returns the matcher name.
+func (m *DirtyMatcher) Name() string {
+	return "dirty:" + strconv.FormatBool(m.dirty)
+}
+
+// stagedPattern is a staged file pattern with its negation stripped.
+type stagedPattern struct {
+	pattern Pattern
+	negated bool
+}
+
+// StagedFilesMatcher matches against the staged files. A pattern matches when
+// any staged file matches it, and a negated pattern when no staged file matches
+// it, so ["migrations/**", "!CHANGELOG.md"] in all mode matches staged
+// migrations without a changelog entry.
+type StagedFilesMatcher struct {
+	patterns []stagedPattern
+	mode     MultiPatternMode
+	repr     string
+}
+
+// NewStagedFilesMatcher creates a matcher for staged file patterns.
+func NewStagedFilesMatcher(
+	patterns []string,
+	mode MultiPatternMode,
+	opts PatternOptions,
+) (*StagedFilesMatcher, error) {
+	if len(patterns) == 0 {
+		return nil, nil //nolint:nilnil // no patterns is valid
+	}
+
+	m := &StagedFilesMatcher{
+		patterns: make([]stagedPattern, 0, len(patterns)),
+		mode:     mode,
+		repr:     strings.Join(patterns, ","),
+	}
+
+	for _, p := range patterns {
+		compiled, err := CompilePatternWithOptions(StripNegation(p), opts)
+		if err != nil {
+			return nil, err
+		}
+
+		m.patterns = append(m.patterns, stagedPattern{pattern: compiled, negated: IsNegated(p)})
+	}
+
+	return m, nil
+}
+
+// Match returns true if the staged files match the patterns.
+func (m *StagedFilesMatcher) Match(ctx *MatchContext) bool {
+	state := gitState(ctx)
+	if state == nil {
+		return false
+	}
+
+	staged := state.StagedFiles()
+
+	for _, p := range m.patterns {
+		matched := slices.ContainsFunc(staged, p.pattern.Match) != p.negated
+
+		if matched && m.mode == MultiPatternAny {
+			return true
+		}
+
+		if !matched && m.mode == MultiPatternAll {
+			return false
+		}
+	}
+
+	return m.mode == MultiPatternAll
+}
+
+// Name returns the matcher name.
+func (m *StagedFilesMatcher) Name() string {
+	return "staged_pattern:" + m.repr
+}
+
+// UpstreamPatternMatcher matches against the upstream of the current branch.
+type UpstreamPatternMatcher struct {
+	pattern Pattern
+}
+
+// NewUpstreamPatternMatcherWithOpts creates a matcher for upstream patterns.
+func NewUpstreamPatternMatcherWithOpts(
+	patternStr string,
+	opts PatternOptions,
+) (*UpstreamPatternMatcher, error) {
+	pattern, err := CompilePatternWithOptions(patternStr, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpstreamPatternMatcher{pattern: pattern}, nil
+}
+
+// Match returns true if the upstream matches the pattern.
+func (m *UpstreamPatternMatcher) Match(ctx *MatchContext) bool {
+	state := gitState(ctx)
+	if state == nil {
+		return false
+	}
+
+	upstream := state.Upstream()
+	if upstream == "" {
+		return false
+	}
+
+	return m.pattern.Match(upstream)
+}
+
+// Name returns the matcher name.
+func (m *UpstreamPatternMatcher) Name() string {
+	return "upstream_pattern:" + m.pattern.String()
+}
+
+// AheadBehindMatcher matches when HEAD is at least a number of commits ahead
+// of or behind the upstream.
+type AheadBehindMatcher struct {
+	minAhead  int
+	minBehind int
+}
+
+// NewAheadBehindMatcher creates a matcher for ahead/behind counts. A minimum
+// of 0 is not checked.
+func NewAheadBehindMatcher(minAhead, minBehind int) *AheadBehindMatcher {
+	return &AheadBehindMatcher{minAhead: minAhead, minBehind: minBehind}
+}
+
+// Match returns true if both counts reach their minimums.
+func (m *AheadBehindMatcher) Match(ctx *MatchContext) bool {
+	state := gitState(ctx)
+	if state == nil {
+		return false
+	}
+
+	ahead, behind := state.AheadBehind()
+
+	return ahead >= m.minAhead && behind >= m.minBehind
+}
+
+// Name returns the matcher name.
+func (m *AheadBehindMatcher) Name() string {
+	return "ahead_behind:" + strconv.Itoa(m.minAhead) + "/" + strconv.Itoa(m.minBehind)
+}
+
+// HeadSignedMatcher matches whether the HEAD commit is signed.
+type HeadSignedMatcher struct {
+	signed bool
+}
+
+// NewHeadSignedMatcher creates a matcher for the signature of the HEAD commit.
+func NewHeadSignedMatcher(signed bool) *HeadSignedMatcher {
+	return &HeadSignedMatcher{signed: signed}
+}
+
+// Match returns true if the signature state equals the expected one.
+func (m *HeadSignedMatcher) Match(ctx *MatchContext) bool {
+	state := gitState(ctx)
+	if state == nil {
+		return false
+	}
+
+	return state.HeadSigned() == m.signed
+}
+
+// Name returns the matcher name.
+func (m *HeadSignedMatcher) Name() string {
+	return "head_signed:" + strconv.FormatBool(m.signed)
+}
+
+// HeadAuthorPatternMatcher matches against the author of the HEAD commit.
+type HeadAuthorPatternMatcher struct {
+	pattern Pattern
+}
+
+// NewHeadAuthorPatternMatcherWithOpts creates a matcher for HEAD author patterns.
+func NewHeadAuthorPatternMatcherWithOpts(
+	patternStr string,
+	opts PatternOptions,
+) (*HeadAuthorPatternMatcher, error) {
+	pattern, err := CompilePatternWithOptions(patternStr, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HeadAuthorPatternMatcher{pattern: pattern}, nil
+}
+
+// Match returns true if the HEAD author matches the pattern.
+func (m *HeadAuthorPatternMatcher) Match(ctx *MatchContext) bool {
+	state := gitState(ctx)
+	if state == nil {
+		return false
+	}
+
+	author := state.HeadAuthor()
+	if author == "" {
+		return false
+	}
+
+	return m.pattern.Match(author)
+}
+
+// Name returns the matcher name.
+func (m *HeadAuthorPatternMatcher) Name() string {
+	return "head_author_pattern:" + m.pattern.String()
+}
+
+// addGitStateMatchers adds the matchers of the repository state conditions.
+func (b *matcherBuilder) addGitStateMatchers(match *RuleMatch) {
+	if match.Dirty != nil {
+		b.addSimple(NewDirtyMatcher(*match.Dirty))
+	}
+
+	if match.HeadSigned != nil {
+		b.addSimple(NewHeadSignedMatcher(*match.HeadSigned))
+	}
+
+	if match.MinAhead > 0 || match.MinBehind > 0 {
+		b.addSimple(NewAheadBehindMatcher(match.MinAhead, match.MinBehind))
+	}
+
+	b.addAdvancedPatternMatcher(match.StagedPattern, match.StagedPatterns,
+		wrapStagedMatcherWithOpts, wrapStagedMultiMatcher)
+	b.addPatternMatcher(match.UpstreamPattern, func(p string) (Matcher, error) {
+		return NewUpstreamPatternMatcherWithOpts(p, b.opts)
+	})
+	b.addPatternMatcher(match.HeadAuthorPattern, func(p string) (Matcher, error) {
+		return NewHeadAuthorPatternMatcherWithOpts(p, b.opts)
+	})
+}
+
+//nolint:ireturn // interface for polymorphism
+func wrapStagedMatcherWithOpts(p string, opts PatternOptions) (Matcher, error) {
+	return NewStagedFilesMatcher([]string{p}, MultiPatternAny, opts)
+}
+
+//nolint:ireturn // interface for polymorphism
+func wrapStagedMultiMatcher(
+	patterns []string,
+	mode MultiPatternMode,
+	opts PatternOptions,
+) (Matcher, error) {
+	return NewStagedFilesMatcher(patterns, mode, opts)
+}
@@ -0,0 +1,203 @@
+package rules_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+)
+
+// fakeGitState is a GitState with fixed values.
+type fakeGitState struct {
+	staged   []string
+	dirty    bool
+	upstream string
+	ahead    int
+	behind   int
+	author   string
+	signed   bool
+}
+
+func (s *fakeGitState) StagedFiles() []string { return s.staged }
+
+func (s *fakeGitState) IsDirty() bool { return s.dirty }
+
+func (s *fakeGitState) Upstream() string { return s.upstream }
+
+func (s *fakeGitState) AheadBehind() (ahead, behind int) { return s.ahead, s.behind }
+
+func (s *fakeGitState) HeadAuthor() string { return s.author }
+
+func (s *fakeGitState) HeadSigned() bool { return s.signed }
+
+var _ = Describe("Git state matchers", func() {
+	var (
+		state *fakeGitState
+		ctx   *rules.MatchContext
+	)
+
+	BeforeEach(func() {
+		state = &fakeGitState{
+			staged:   []string{"db/migrations/001_init.sql", "main.go"},
+			dirty:    true,
+			upstream: "origin/main",
+			ahead:    3,
+			behind:   1,
+			author:   "Jane Doe <jane@example.com>",
+		}
+		ctx = &rules.MatchContext{
+			GitContext: &rules.GitContext{
+				Branch:   "main",
+				IsInRepo: true,
+				State:    state,
+			},
+		}
+	})
+
+	build := func(match *rules.RuleMatch) rules.Matcher {
+		m, err := rules.BuildMatcher(match)
+		Expect(err).NotTo(HaveOccurred())
+
+		return m
+	}
+
+	boolPtr := func(b bool) *bool { return &b }
+
+	Describe("DirtyMatcher", func() {
+		It("should match the dirty state", func() {
+			Expect(rules.NewDirtyMatcher(true).Match(ctx)).To(BeTrue())
+			Expect(rules.NewDirtyMatcher(false).Match(ctx)).To(BeFalse())
+
+			state.dirty = false
+			Expect(rules.NewDirtyMatcher(false).Match(ctx)).To(BeTrue())
+		})
+
+		It("should not match without repository state", func() {
+			ctx.GitContext.State = nil
+			Expect(rules.NewDirtyMatcher(false).Match(ctx)).To(BeFalse())
+
+			ctx.GitContext = nil
+			Expect(rules.NewDirtyMatcher(false).Match(ctx)).To(BeFalse())
+		})
+	})
+
+	Describe("StagedFilesMatcher", func() {
+		It("should match when any staged file matches", func() {
+			Expect(build(&rules.RuleMatch{StagedPattern: "**/migrations/**"}).Match(ctx)).
+				To(BeTrue())
+			Expect(build(&rules.RuleMatch{StagedPattern: "*.md"}).Match(ctx)).To(BeFalse())
+		})
+
+		It("should match a negated pattern when no staged file matches", func() {
+			Expect(build(&rules.RuleMatch{StagedPattern: "!CHANGELOG.md"}).Match(ctx)).
+				To(BeTrue())
+			Expect(build(&rules.RuleMatch{StagedPattern: "!main.go"}).Match(ctx)).To(BeFalse())
+		})
+
+		It("should combine patterns in all mode", func() {
+			m := build(&rules.RuleMatch{
+				StagedPatterns: []string{"**/migrations/**", "!CHANGELOG.md"},
+				PatternMode:    "all",
+			})
+			Expect(m.Match(ctx)).To(BeTrue())
+
+			state.staged = append(state.staged, "CHANGELOG.md")
+			Expect(m.Match(ctx)).To(BeFalse())
+		})
+
+		It("should combine patterns in any mode", func() {
+			m := build(&rules.RuleMatch{StagedPatterns: []string{"*.md", "*.go"}})
+			Expect(m.Match(ctx)).To(BeTrue())
+
+			state.staged = nil
+			Expect(m.Match(ctx)).To(BeFalse())
+		})
+	})
+
+	Describe("UpstreamPatternMatcher", func() {
+		It("should match the upstream", func() {
+			Expect(build(&rules.RuleMatch{UpstreamPattern: "origin/*"}).Match(ctx)).To(BeTrue())
+			Expect(build(&rules.RuleMatch{UpstreamPattern: "fork/*"}).Match(ctx)).To(BeFalse())
+		})
+
+		It("should not match without upstream", func() {
+			state.upstream = ""
+			Expect(build(&rules.RuleMatch{UpstreamPattern: "!fork/*"}).Match(ctx)).To(BeFalse())
+		})
+	})
+
+	Describe("AheadBehindMatcher", func() {
+		DescribeTable("should check the minimums",
+			func(minAhead, minBehind int, expected bool) {
+				Expect(rules.NewAheadBehindMatcher(minAhead, minBehind).Match(ctx)).
+					To(Equal(expected))
+			},
+			Entry("ahead reached", 3, 0, true),
+			Entry("ahead not reached", 4, 0, false),
+			Entry("behind reached", 0, 1, true),
+			Entry("both reached", 2, 1, true),
+			Entry("behind not reached", 1, 2, false),
+		)
+	})
+
+	Describe("HEAD commit matchers", func() {
+		It("should match the signature state", func() {
+			Expect(build(&rules.RuleMatch{HeadSigned: boolPtr(false)}).Match(ctx)).To(BeTrue())
+			Expect(build(&rules.RuleMatch{HeadSigned: boolPtr(true)}).Match(ctx)).To(BeFalse())
+		})
+
+		It("should match the author", func() {
+			Expect(build(&rules.RuleMatch{HeadAuthorPattern: "*@example.com>"}).Match(ctx)).
+				To(BeTrue())
+			Expect(build(&rules.RuleMatch{HeadAuthorPattern: "!Jane *"}).Match(ctx)).To(BeFalse())
+		})
+
+		It("should honour case-insensitive matching", func() {
+			m := build(&rules.RuleMatch{
+				HeadAuthorPattern: "jane doe *",
+				CaseInsensitive:   true,
+			})
+			Expect(m.Match(ctx)).To(BeTrue())
+		})
+	})
+
+	It("should combine git state conditions", func() {
+		m := build(&rules.RuleMatch{
+			BranchPattern: "main",
+			Dirty:         boolPtr(true),
+			MinAhead:      1,
+		})
+		Expect(m.Match(ctx)).To(BeTrue())
+
+		state.ahead = 0
+		Expect(m.Match(ctx)).To(BeFalse())
+	})
+
+	Describe("when expressions", func() {
+		match := func(expr string) bool {
+			m, err := rules.NewExpressionMatcher(expr)
+			Expect(err).NotTo(HaveOccurred())
+
+			return m.Match(ctx)
+		}
+
+		It("should expose git state fields", func() {
+			Expect(match(`git.dirty && git.upstream == "origin/main"`)).To(BeTrue())
+			Expect(match(`git.staged.matches("**/migrations/**")`)).To(BeTrue())
+			Expect(match(`git.head_author.endsWith("@example.com>")`)).To(BeTrue())
+			Expect(match(`!git.head_signed`)).To(BeTrue())
+		})
+
+		It("should compare counts", func() {
+			Expect(match(`git.ahead > 2 && git.behind == 1`)).To(BeTrue())
+			Expect(match(`git.ahead >= 4 || git.behind < 1`)).To(BeFalse())
+			Expect(match(`git.ahead <= 3 && git.behind != 0`)).To(BeTrue())
+		})
+
+		It("should evaluate to zero values without repository state", func() {
+			ctx.GitContext.State = nil
+
+			Expect(match(`!git.dirty && git.ahead == 0 && git.upstream == ""`)).To(BeTrue())
+		})
+	})
+})
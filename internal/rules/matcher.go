@@ -1,6 +1,7 @@
 package rules
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/smykla-labs/klaudiush/pkg/hook"
@@ -380,17 +381,19 @@ func NewCommandMultiPatternMatcher(
 	return &CommandPatternMatcher{pattern: pattern}, nil
 }
 
-// Match returns true if the command matches the pattern.
+// Match returns true if the command, or a command nested in it (e.g. the
+// git push in bash -c "git push"), matches the pattern.
 func (m *CommandPatternMatcher) Match(ctx *MatchContext) bool {
-	if ctx.Command != "" {
-		return m.pattern.Match(ctx.Command)
+	command := contextCommand(ctx)
+	if command == "" {
+		return false
 	}
 
-	if ctx.HookContext != nil {
-		return m.pattern.Match(ctx.HookContext.GetCommand())
+	if m.pattern.Match(command) {
+		return true
 	}
 
-	return false
+	return slices.ContainsFunc(ctx.nestedCommands(), m.pattern.Match)
 }
 
 // Name returns the matcher name.
@@ -489,6 +492,137 @@ func (m *EventTypeMatcher) Name() string {
 	return "event_type:" + m.eventType
 }
 
+// MCPServerMatcher matches against the MCP server name.
+type MCPServerMatcher struct {
+	pattern Pattern
+}
+
+// NewMCPServerMatcher creates a matcher for MCP server name patterns.
+func NewMCPServerMatcher(patternStr string) (*MCPServerMatcher, error) {
+	pattern, err := GetCachedPattern(patternStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MCPServerMatcher{pattern: pattern}, nil
+}
+
+// NewMCPServerMatcherWithOpts creates a matcher with pattern options.
+func NewMCPServerMatcherWithOpts(
+	patternStr string,
+	opts PatternOptions,
+) (*MCPServerMatcher, error) {
+	pattern, err := CompilePatternWithOptions(patternStr, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MCPServerMatcher{pattern: pattern}, nil
+}
+
+// Match returns true if the tool is an MCP tool of a matching server.
+func (m *MCPServerMatcher) Match(ctx *MatchContext) bool {
+	if ctx.HookContext == nil || !ctx.HookContext.IsMCPTool() {
+		return false
+	}
+
+	return m.pattern.Match(ctx.HookContext.MCPServer)
+}
+
+// Name returns the matcher name.
+func (m *MCPServerMatcher) Name() string {
+	return "mcp_server:" + m.pattern.String()
+}
+
+// MCPToolMatcher matches against the MCP tool name without the server prefix.
+type MCPToolMatcher struct {
+	pattern Pattern
+}
+
+// NewMCPToolMatcher creates a matcher for MCP tool name patterns.
+func NewMCPToolMatcher(patternStr string) (*MCPToolMatcher, error) {
+	pattern, err := GetCachedPattern(patternStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MCPToolMatcher{pattern: pattern}, nil
+}
+
+// NewMCPToolMatcherWithOpts creates a matcher with pattern options.
+func NewMCPToolMatcherWithOpts(
+	patternStr string,
+	opts PatternOptions,
+) (*MCPToolMatcher, error) {
+	pattern, err := CompilePatternWithOptions(patternStr, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MCPToolMatcher{pattern: pattern}, nil
+}
+
+// Match returns true if the tool is an MCP tool with a matching name.
+func (m *MCPToolMatcher) Match(ctx *MatchContext) bool {
+	if ctx.HookContext == nil || !ctx.HookContext.IsMCPTool() {
+		return false
+	}
+
+	return m.pattern.Match(ctx.HookContext.MCPTool)
+}
+
+// Name returns the matcher name.
+func (m *MCPToolMatcher) Name() string {
+	return "mcp_tool:" + m.pattern.String()
+}
+
+// URLDomainMatcher matches against the host name of the WebFetch URL.
+type URLDomainMatcher struct {
+	pattern Pattern
+}
+
+// NewURLDomainMatcher creates a matcher for URL domain patterns.
+func NewURLDomainMatcher(patternStr string) (*URLDomainMatcher, error) {
+	pattern, err := GetCachedPattern(patternStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &URLDomainMatcher{pattern: pattern}, nil
+}
+
+// NewURLDomainMatcherWithOpts creates a matcher with pattern options.
+func NewURLDomainMatcherWithOpts(
+	patternStr string,
+	opts PatternOptions,
+) (*URLDomainMatcher, error) {
+	pattern, err := CompilePatternWithOptions(patternStr, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &URLDomainMatcher{pattern: pattern}, nil
+}
+
+// Match returns true if the URL host matches the pattern.
+func (m *URLDomainMatcher) Match(ctx *MatchContext) bool {
+	if ctx.HookContext == nil {
+		return false
+	}
+
+	domain := ctx.HookContext.GetURLDomain()
+	if domain == "" {
+		return false
+	}
+
+	return m.pattern.Match(domain)
+}
+
+// Name returns the matcher name.
+func (m *URLDomainMatcher) Name() string {
+	return "url_domain:" + m.pattern.String()
+}
+
 // CompositeOp represents the operation for composite matchers.
 type CompositeOp int
 
@@ -697,6 +831,18 @@ func wrapContentMatcher(p string) (Matcher, error) { return NewContentPatternMat
 //nolint:ireturn // interface for polymorphism
 func wrapCommandMatcher(p string) (Matcher, error) { return NewCommandPatternMatcher(p) }
 
+//nolint:ireturn // interface for polymorphism
+func wrapMCPServerMatcher(p string) (Matcher, error) { return NewMCPServerMatcher(p) }
+
+//nolint:ireturn // interface for polymorphism
+func wrapMCPToolMatcher(p string) (Matcher, error) { return NewMCPToolMatcher(p) }
+
+//nolint:ireturn // interface for polymorphism
+func wrapURLDomainMatcher(p string) (Matcher, error) { return NewURLDomainMatcher(p) }
+
+//nolint:ireturn // interface for polymorphism
+func wrapExpressionMatcher(p string) (Matcher, error) { return NewExpressionMatcher(p) }
+
 // Advanced pattern matcher factory wrappers.
 //
 //nolint:ireturn // interface for polymorphism
@@ -799,7 +945,8 @@ func BuildMatcher(match *RuleMatch) (Matcher, error) {
 		len(match.BranchPatterns) > 0 ||
 		len(match.FilePatterns) > 0 ||
 		len(match.ContentPatterns) > 0 ||
-		len(match.CommandPatterns) > 0
+		len(match.CommandPatterns) > 0 ||
+		len(match.StagedPatterns) > 0
 
 	// Use legacy builder for simple cases (backward compatibility).
 	if !useAdvanced {
@@ -839,6 +986,12 @@ func buildMatcherLegacy(match *RuleMatch) (Matcher, error) {
 	b.addPatternMatcher(match.FilePattern, wrapFileMatcher)
 	b.addPatternMatcher(match.ContentPattern, wrapContentMatcher)
 	b.addPatternMatcher(match.CommandPattern, wrapCommandMatcher)
+	b.addPatternMatcher(match.MCPServer, wrapMCPServerMatcher)
+	b.addPatternMatcher(match.MCPTool, wrapMCPToolMatcher)
+	b.addPatternMatcher(match.URLDomain, wrapURLDomainMatcher)
+	b.addScheduleMatcher(match.Schedule)
+	b.addGitStateMatchers(match)
+	b.addPatternMatcher(match.When, wrapExpressionMatcher)
 
 	return b.result()
 }
@@ -883,6 +1036,22 @@ func buildMatcherAdvanced(match *RuleMatch) (Matcher, error) {
 	b.addAdvancedPatternMatcher(match.CommandPattern, match.CommandPatterns,
 		wrapCommandMatcherWithOpts, wrapCommandMultiMatcher)
 
+	// Tool name matchers only take single patterns.
+	b.addPatternMatcher(match.MCPServer, func(p string) (Matcher, error) {
+		return NewMCPServerMatcherWithOpts(p, opts)
+	})
+	b.addPatternMatcher(match.MCPTool, func(p string) (Matcher, error) {
+		return NewMCPToolMatcherWithOpts(p, opts)
+	})
+	b.addPatternMatcher(match.URLDomain, func(p string) (Matcher, error) {
+		return NewURLDomainMatcherWithOpts(p, opts)
+	})
+	b.addScheduleMatcher(match.Schedule)
+	b.addGitStateMatchers(match)
+	b.addPatternMatcher(match.When, func(p string) (Matcher, error) {
+		return NewExpressionMatcherWithOpts(p, opts)
+	})
+
 	return b.result()
 }
 
@@ -923,6 +1092,9 @@ var (
 	_ Matcher = (*ValidatorTypeMatcher)(nil)
 	_ Matcher = (*ToolTypeMatcher)(nil)
 	_ Matcher = (*EventTypeMatcher)(nil)
+	_ Matcher = (*MCPServerMatcher)(nil)
+	_ Matcher = (*MCPToolMatcher)(nil)
+	_ Matcher = (*URLDomainMatcher)(nil)
 	_ Matcher = (*CompositeMatcher)(nil)
 	_ Matcher = (*AlwaysMatcher)(nil)
 	_ Matcher = (*NeverMatcher)(nil)
@@ -613,6 +613,21 @@ var _ = Describe("Matcher", func() {
 			Expect(matcher.Match(ctx)).To(BeTrue())
 		})
 
+		It("should match commands nested in wrappers", func() {
+			matcher, err := rules.NewCommandPatternMatcher("git push*")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(matcher.Match(&rules.MatchContext{
+				Command: `bash -c "git push --force"`,
+			})).To(BeTrue())
+			Expect(matcher.Match(&rules.MatchContext{
+				Command: "sudo -u deploy git push origin main",
+			})).To(BeTrue())
+			Expect(matcher.Match(&rules.MatchContext{
+				Command: `echo "git push"`,
+			})).To(BeFalse())
+		})
+
 		It("should return false when no command available", func() {
 			matcher, err := rules.NewCommandPatternMatcher("git*")
 			Expect(err).NotTo(HaveOccurred())
@@ -747,6 +762,55 @@ var _ = Describe("Matcher", func() {
 		})
 	})
 
+	Describe("MCPServerMatcher and MCPToolMatcher", func() {
+		mcpCtx := &rules.MatchContext{
+			HookContext: &hook.Context{
+				ToolName:  hook.ToolTypeMCP,
+				MCPServer: "github",
+				MCPTool:   "create_issue",
+			},
+		}
+
+		It("should match the MCP server and tool names", func() {
+			serverMatcher, err := rules.NewMCPServerMatcher("github")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serverMatcher.Match(mcpCtx)).To(BeTrue())
+			Expect(serverMatcher.Name()).To(Equal("mcp_server:github"))
+
+			toolMatcher, err := rules.NewMCPToolMatcher("create_*")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(toolMatcher.Match(mcpCtx)).To(BeTrue())
+		})
+
+		It("should not match non-MCP tools", func() {
+			matcher, err := rules.NewMCPServerMatcher("*")
+			Expect(err).NotTo(HaveOccurred())
+
+			ctx := &rules.MatchContext{
+				HookContext: &hook.Context{ToolName: hook.ToolTypeBash},
+			}
+			Expect(matcher.Match(ctx)).To(BeFalse())
+		})
+	})
+
+	Describe("URLDomainMatcher", func() {
+		It("should match the WebFetch URL host", func() {
+			matcher, err := rules.NewURLDomainMatcher("*.github.com")
+			Expect(err).NotTo(HaveOccurred())
+
+			ctx := &rules.MatchContext{
+				HookContext: &hook.Context{
+					ToolName:  hook.ToolTypeWebFetch,
+					ToolInput: hook.ToolInput{URL: "https://api.github.com/repos"},
+				},
+			}
+			Expect(matcher.Match(ctx)).To(BeTrue())
+
+			ctx.HookContext.ToolInput.URL = "https://github.com.evil.test/"
+			Expect(matcher.Match(ctx)).To(BeFalse())
+		})
+	})
+
 	Describe("CompositeMatcher", func() {
 		Describe("AND", func() {
 			It("should match when all conditions match", func() {
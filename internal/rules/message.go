@@ -0,0 +1,280 @@
+package rules
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/smykla-labs/klaudiush/internal/templates"
+)
+
+// ErrInvalidTemplate is returned when a message or fix hint template cannot be
+// parsed.
+var ErrInvalidTemplate = errors.New("invalid message template")
+
+// Template names of rule actions, used in parse errors.
+const (
+	messageTemplateName = "message"
+	fixHintTemplateName = "fix_hint"
+)
+
+// MessageData is the data rule messages and fix hints are rendered with, e.g.
+// "Push to {{.Git.Branch}} blocked" or "Reference {{.Captures.ticket}}".
+type MessageData struct {
+	// Rule is the name of the matching rule.
+	Rule string
+
+	// Validator is the validator type, e.g. "git.push".
+	Validator string
+
+	// Git contains the repository root, remote and branch.
+	Git GitContext
+
+	// File contains the file path and content.
+	File FileContext
+
+	// Command is the bash command.
+	Command string
+
+	// Tool is the name of the hook tool, e.g. "Bash".
+	Tool string
+
+	// Captures are the named groups of the regex patterns of the rule, such as
+	// ticket in "(?P<ticket>[A-Z]+-\d+)". Groups that did not match are empty.
+	Captures map[string]string
+}
+
+// parseMessageTemplate parses a rule message or fix hint as a Go text/template.
+// Returns nil for text without actions, which is used as is.
+func parseMessageTemplate(name, text string) (*template.Template, error) {
+	if !strings.Contains(text, "{{") {
+		return nil, nil //nolint:nilnil // plain text needs no template
+	}
+
+	tmpl, err := templates.New(name, text)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidTemplate, "%v", err)
+	}
+
+	// Captures of other patterns are empty instead of "<no value>"
+	return tmpl.Option("missingkey=zero"), nil
+}
+
+// compileAction parses the message and fix hint templates of a rule action.
+func compileAction(action *RuleAction) (*template.Template, *template.Template, error) {
+	message, err := parseMessageTemplate(messageTemplateName, action.Message)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fixHint, err := parseMessageTemplate(fixHintTemplateName, action.FixHint)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return message, fixHint, nil
+}
+
+// ValidateActionTemplates returns an error if the message or fix hint of a rule
+// action is not a valid template.
+func ValidateActionTemplates(message, fixHint string) error {
+	_, _, err := compileAction(&RuleAction{Message: message, FixHint: fixHint})
+
+	return err
+}
+
+// renderAction renders the message and fix hint of the rule. A template that
+// fails to render falls back to its raw text.
+func (c *CompiledRule) renderAction(ctx *MatchContext) (string, string) {
+	message, fixHint := c.Rule.Action.Message, c.Rule.Action.FixHint
+
+	if c.message == nil && c.fixHint == nil {
+		return message, fixHint
+	}
+
+	data := c.messageData(ctx)
+
+	return renderTemplate(c.message, data, message), renderTemplate(c.fixHint, data, fixHint)
+}
+
+// renderTemplate renders a template, or returns the raw text if there is no
+// template or rendering fails.
+func renderTemplate(tmpl *template.Template, data *MessageData, raw string) string {
+	if tmpl == nil {
+		return raw
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return raw
+	}
+
+	return buf.String()
+}
+
+// messageData builds the template data of the rule for the match context.
+func (c *CompiledRule) messageData(ctx *MatchContext) *MessageData {
+	data := &MessageData{
+		Rule:      c.Rule.Name,
+		Validator: string(ctx.ValidatorType),
+		File:      FileContext{Path: contextFilePath(ctx), Content: contextFileContent(ctx)},
+		Command:   contextCommand(ctx),
+		Captures:  make(map[string]string),
+	}
+
+	if ctx.GitContext != nil {
+		data.Git = *ctx.GitContext
+	}
+
+	if ctx.HookContext != nil {
+		data.Tool = ctx.HookContext.ToolName.String()
+	}
+
+	for _, source := range c.captures {
+		source.capture(ctx, data.Captures)
+	}
+
+	return data
+}
+
+// captureSource is a regex pattern of a rule with named groups, and the
+// values of the match context it is matched against.
+type captureSource struct {
+	re     *regexp.Regexp
+	values func(*MatchContext) []string
+}
+
+// capture adds the named groups of the first matching value to captures,
+// keeping groups already captured by other patterns.
+func (s *captureSource) capture(ctx *MatchContext, captures map[string]string) {
+	for _, value := range s.values(ctx) {
+		groups := s.re.FindStringSubmatch(value)
+		if groups == nil {
+			continue
+		}
+
+		for i, name := range s.re.SubexpNames() {
+			if _, ok := captures[name]; name != "" && !ok {
+				captures[name] = groups[i]
+			}
+		}
+
+		return
+	}
+}
+
+// compileCaptures compiles the regex patterns of a match with named groups.
+// Negated patterns never capture.
+func compileCaptures(match *RuleMatch) []captureSource {
+	if match == nil {
+		return nil
+	}
+
+	var sources []captureSource
+
+	add := func(
+		single string,
+		multi []string,
+		alwaysRegex bool,
+		values func(*MatchContext) []string,
+	) {
+		for _, p := range append([]string{single}, multi...) {
+			if p == "" || IsNegated(p) {
+				continue
+			}
+
+			if !alwaysRegex && DetectPatternType(p) != PatternTypeRegex {
+				continue
+			}
+
+			if match.CaseInsensitive && !strings.HasPrefix(p, "(?i)") {
+				p = "(?i)" + p
+			}
+
+			re, err := regexp.Compile(p)
+			if err != nil || !hasNamedGroups(re) {
+				continue
+			}
+
+			sources = append(sources, captureSource{re: re, values: values})
+		}
+	}
+
+	add(match.RepoPattern, match.RepoPatterns, false, func(ctx *MatchContext) []string {
+		if ctx.GitContext == nil {
+			return nil
+		}
+
+		return []string{ctx.GitContext.RepoRoot}
+	})
+	add(match.BranchPattern, match.BranchPatterns, false, func(ctx *MatchContext) []string {
+		if ctx.GitContext == nil {
+			return nil
+		}
+
+		return []string{ctx.GitContext.Branch}
+	})
+	add(match.FilePattern, match.FilePatterns, false, func(ctx *MatchContext) []string {
+		return []string{contextFilePath(ctx)}
+	})
+	add(match.ContentPattern, match.ContentPatterns, true, func(ctx *MatchContext) []string {
+		return []string{contextFileContent(ctx)}
+	})
+	add(match.CommandPattern, match.CommandPatterns, false, func(ctx *MatchContext) []string {
+		return append([]string{contextCommand(ctx)}, ctx.nestedCommands()...)
+	})
+
+	return sources
+}
+
+// hasNamedGroups returns true if the regex has named capture groups.
+func hasNamedGroups(re *regexp.Regexp) bool {
+	for _, name := range re.SubexpNames() {
+		if name != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// contextFilePath returns the file path of the match context, falling back to
+// the hook context.
+func contextFilePath(ctx *MatchContext) string {
+	if ctx.FileContext != nil && ctx.FileContext.Path != "" {
+		return ctx.FileContext.Path
+	}
+
+	if ctx.HookContext != nil {
+		return ctx.HookContext.GetFilePath()
+	}
+
+	return ""
+}
+
+// contextFileContent returns the file content of the match context, falling
+// back to the hook context.
+func contextFileContent(ctx *MatchContext) string {
+	if ctx.FileContext != nil && ctx.FileContext.Content != "" {
+		return ctx.FileContext.Content
+	}
+
+	if ctx.HookContext != nil {
+		return ctx.HookContext.GetContent()
+	}
+
+	return ""
+}
+
+// contextCommand returns the bash command of the match context, falling back
+// to the hook context.
+func contextCommand(ctx *MatchContext) string {
+	if ctx.Command == "" && ctx.HookContext != nil {
+		return ctx.HookContext.GetCommand()
+	}
+
+	return ctx.Command
+}
@@ -0,0 +1,153 @@
+package rules_test
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+)
+
+var _ = Describe("Message templates", func() {
+	var ctx *rules.MatchContext
+
+	BeforeEach(func() {
+		ctx = &rules.MatchContext{
+			HookContext: &hook.Context{
+				EventType: hook.EventTypePreToolUse,
+				ToolName:  hook.ToolTypeBash,
+			},
+			GitContext: &rules.GitContext{
+				RepoRoot: "/home/user/src/app",
+				Remote:   "origin",
+				Branch:   "feat/PROJ-123-login",
+				IsInRepo: true,
+			},
+			ValidatorType: rules.ValidatorGitPush,
+			Command:       "git push origin feat/PROJ-123-login",
+		}
+	})
+
+	evaluate := func(match *rules.RuleMatch, action *rules.RuleAction) *rules.RuleResult {
+		engine, err := rules.NewRuleEngine([]*rules.Rule{
+			{Name: "templated", Enabled: true, Match: match, Action: action},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		result := engine.Evaluate(context.Background(), ctx)
+		Expect(result.Matched).To(BeTrue())
+
+		return result
+	}
+
+	It("should render match context fields", func() {
+		result := evaluate(
+			&rules.RuleMatch{ValidatorType: rules.ValidatorGitPush},
+			&rules.RuleAction{
+				Type:    rules.ActionBlock,
+				Message: "{{.Rule}}: push of {{.Git.Branch}} to {{.Git.Remote}} in {{.Tool}}",
+				FixHint: "Run: {{.Command}} --dry-run",
+			},
+		)
+
+		Expect(result.Message).To(Equal("templated: push of feat/PROJ-123-login to origin in Bash"))
+		Expect(result.FixHint).To(Equal("Run: git push origin feat/PROJ-123-login --dry-run"))
+	})
+
+	It("should fall back to the hook context for file fields", func() {
+		ctx.HookContext.ToolName = hook.ToolTypeWrite
+		ctx.HookContext.ToolInput = hook.ToolInput{FilePath: "infra/main.tf"}
+
+		result := evaluate(nil, &rules.RuleAction{
+			Type:    rules.ActionWarn,
+			Message: "Writing {{.File.Path}}",
+		})
+		Expect(result.Message).To(Equal("Writing infra/main.tf"))
+	})
+
+	It("should render named capture groups of regex patterns", func() {
+		result := evaluate(
+			&rules.RuleMatch{
+				BranchPattern:  `^feat/(?P<ticket>[A-Z]+-\d+)-`,
+				CommandPattern: `push (?P<remote>\w+) `,
+			},
+			&rules.RuleAction{
+				Type:    rules.ActionAsk,
+				Message: "Push {{.Captures.ticket}} to {{.Captures.remote}}?",
+			},
+		)
+
+		Expect(result.Message).To(Equal("Push PROJ-123 to origin?"))
+	})
+
+	It("should render captures of multiple and case-insensitive patterns", func() {
+		result := evaluate(
+			&rules.RuleMatch{
+				BranchPatterns: []string{
+					`^fix/(?P<ticket>[a-z]+-\d+)`,
+					`^feat/(?P<ticket>[a-z]+-\d+)`,
+				},
+				CaseInsensitive: true,
+			},
+			&rules.RuleAction{Type: rules.ActionWarn, Message: "Ticket {{.Captures.ticket}}"},
+		)
+
+		Expect(result.Message).To(Equal("Ticket PROJ-123"))
+	})
+
+	It("should render missing captures as empty", func() {
+		result := evaluate(nil, &rules.RuleAction{
+			Type:    rules.ActionWarn,
+			Message: "Ticket [{{.Captures.ticket}}]",
+		})
+
+		Expect(result.Message).To(Equal("Ticket []"))
+	})
+
+	It("should fall back to the raw message when rendering fails", func() {
+		result := evaluate(nil, &rules.RuleAction{
+			Type:    rules.ActionBlock,
+			Message: "Blocked {{.Git.State.Upstream}}",
+			FixHint: "Check {{.Git.Branch}}",
+		})
+
+		Expect(result.Message).To(Equal("Blocked {{.Git.State.Upstream}}"))
+		Expect(result.FixHint).To(Equal("Check feat/PROJ-123-login"))
+	})
+
+	It("should keep plain messages as is", func() {
+		result := evaluate(nil, &rules.RuleAction{
+			Type:    rules.ActionBlock,
+			Message: "Use {curly} braces freely",
+		})
+
+		Expect(result.Message).To(Equal("Use {curly} braces freely"))
+		Expect(result.FixHint).To(BeEmpty())
+	})
+
+	DescribeTable("should reject invalid templates",
+		func(message, fixHint, expected string) {
+			err := rules.ValidateActionTemplates(message, fixHint)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, rules.ErrInvalidTemplate)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(expected))
+
+			_, err = rules.NewRuleEngine([]*rules.Rule{{
+				Name:    "invalid",
+				Enabled: true,
+				Action: &rules.RuleAction{
+					Type:    rules.ActionBlock,
+					Message: message,
+					FixHint: fixHint,
+				},
+			}})
+			Expect(errors.Is(err, rules.ErrInvalidTemplate)).To(BeTrue())
+		},
+		Entry("unclosed action", "Push {{.Git.Branch", "", "template: message:1"),
+		Entry("unknown function", "", "{{lower .Git.Branch}}",
+			`template: fix_hint:1: function "lower"`),
+	)
+})
@@ -4,6 +4,7 @@ import (
 	"cmp"
 	"slices"
 	"sync"
+	"text/template"
 
 	"github.com/cockroachdb/errors"
 )
@@ -15,6 +16,13 @@ type CompiledRule struct {
 
 	// Matcher is the compiled matcher for this rule.
 	Matcher Matcher
+
+	// message and fixHint are the action templates (nil for plain text).
+	message *template.Template
+	fixHint *template.Template
+
+	// captures are the regex patterns of the rule with named groups.
+	captures []captureSource
 }
 
 // Registry stores compiled rules sorted by priority.
@@ -56,16 +64,27 @@ func (r *Registry) Add(rule *Rule) error {
 		matcher = &AlwaysMatcher{}
 	}
 
+	// Compile the message and fix hint templates.
+	message, fixHint, err := compileAction(rule.Action)
+	if err != nil {
+		return errors.Wrap(err, "failed to compile rule action")
+	}
+
+	compiled := &CompiledRule{
+		Rule:     rule,
+		Matcher:  matcher,
+		message:  message,
+		fixHint:  fixHint,
+		captures: compileCaptures(rule.Match),
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	// Check for duplicate name and update if exists.
 	for i, existing := range r.rules {
 		if existing.Rule.Name == rule.Name {
-			r.rules[i] = &CompiledRule{
-				Rule:    rule,
-				Matcher: matcher,
-			}
+			r.rules[i] = compiled
 
 			r.sortRulesLocked()
 
@@ -74,10 +93,7 @@ func (r *Registry) Add(rule *Rule) error {
 	}
 
 	// Add new rule.
-	r.rules = append(r.rules, &CompiledRule{
-		Rule:    rule,
-		Matcher: matcher,
-	})
+	r.rules = append(r.rules, compiled)
 
 	r.sortRulesLocked()
 
@@ -0,0 +1,440 @@
+package rules
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// ErrInvalidSchedule is returned when a schedule or freeze cannot be compiled.
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// freezeTimeLayout is the layout of freeze end times in rule messages.
+const freezeTimeLayout = "2006-01-02 15:04 MST"
+
+// dateLayout is the layout of date-only times, which cover the whole day.
+const dateLayout = "2006-01-02"
+
+// timeLayouts are the accepted layouts of times without offset, parsed in the
+// schedule or freeze time zone.
+var timeLayouts = []string{dateLayout, "2006-01-02T15:04", "2006-01-02 15:04"}
+
+// Schedule restricts a rule to points in time. It matches when the time falls
+// into any of its windows, date ranges or freezes.
+type Schedule struct {
+	// Windows are cron-like expressions of the minutes the rule applies in,
+	// as "minute hour day-of-month month day-of-week", e.g. "* * * * sat,sun".
+	Windows []string
+
+	// Dates are date ranges the rule applies in.
+	Dates []DateRange
+
+	// Freezes are the code freezes the rule applies during.
+	Freezes []*Freeze
+
+	// Timezone is the IANA time zone of windows and dates (default: local).
+	Timezone string
+}
+
+// DateRange is a range of dates or times. Date-only ends include the whole day.
+type DateRange struct {
+	// Start is the first date or time, e.g. "2026-12-20" or "2026-12-20T18:00".
+	Start string
+
+	// End is the last date, or the time the range ends.
+	End string
+}
+
+// ActiveFreeze returns the freeze of the schedule active at t, or nil.
+func (s *Schedule) ActiveFreeze(t time.Time) *Freeze {
+	if s == nil {
+		return nil
+	}
+
+	for _, f := range s.Freezes {
+		if f.Active(t) {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// Freeze is a named period, such as a release freeze, that rules can reference.
+type Freeze struct {
+	// Name identifies the freeze.
+	Name string
+
+	// Start is when the freeze starts.
+	Start time.Time
+
+	// End is when the freeze ends.
+	End time.Time
+
+	// Reason explains the freeze.
+	Reason string
+}
+
+// NewFreeze creates a freeze from start and end dates or times. Times without
+// offset are in the given IANA time zone (default: local), and a date-only end
+// includes the whole day.
+func NewFreeze(name, start, end, reason, timezone string) (*Freeze, error) {
+	loc, err := loadLocation(timezone)
+	if err != nil {
+		return nil, errors.Wrapf(err, "freeze %q", name)
+	}
+
+	startTime, endTime, err := parseRange(start, end, loc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "freeze %q", name)
+	}
+
+	return &Freeze{Name: name, Start: startTime, End: endTime, Reason: reason}, nil
+}
+
+// Active returns true if t is within the freeze.
+func (f *Freeze) Active(t time.Time) bool {
+	return !t.Before(f.Start) && t.Before(f.End)
+}
+
+// Notice describes the freeze and when it ends, for rule messages.
+func (f *Freeze) Notice() string {
+	notice := "code freeze " + strconv.Quote(f.Name) + " until " + f.End.Format(freezeTimeLayout)
+	if f.Reason != "" {
+		notice += ": " + f.Reason
+	}
+
+	return notice
+}
+
+// timeRange is a half-open range of times.
+type timeRange struct {
+	start time.Time
+	end   time.Time
+}
+
+// ScheduleMatcher matches the time of the match context against a schedule.
+type ScheduleMatcher struct {
+	schedule *Schedule
+	windows  []*cronWindow
+	dates    []timeRange
+	loc      *time.Location
+}
+
+// NewScheduleMatcher creates a matcher for a schedule. An empty schedule, such
+// as one referencing all freezes of an empty freeze calendar, never matches.
+func NewScheduleMatcher(schedule *Schedule) (*ScheduleMatcher, error) {
+	loc, err := loadLocation(schedule.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &ScheduleMatcher{schedule: schedule, loc: loc}
+
+	for _, expr := range schedule.Windows {
+		window, err := parseCronWindow(expr)
+		if err != nil {
+			return nil, err
+		}
+
+		m.windows = append(m.windows, window)
+	}
+
+	for _, d := range schedule.Dates {
+		start, end, err := parseRange(d.Start, d.End, loc)
+		if err != nil {
+			return nil, err
+		}
+
+		m.dates = append(m.dates, timeRange{start: start, end: end})
+	}
+
+	return m, nil
+}
+
+// Match returns true if the time falls into the schedule.
+func (m *ScheduleMatcher) Match(ctx *MatchContext) bool {
+	now := ctx.now()
+
+	if m.schedule.ActiveFreeze(now) != nil {
+		return true
+	}
+
+	for _, d := range m.dates {
+		if !now.Before(d.start) && now.Before(d.end) {
+			return true
+		}
+	}
+
+	local := now.In(m.loc)
+
+	return slices.ContainsFunc(m.windows, func(w *cronWindow) bool {
+		return w.match(local)
+	})
+}
+
+// Name returns the matcher name.
+func (m *ScheduleMatcher) Name() string {
+	parts := slices.Clone(m.schedule.Windows)
+
+	for _, d := range m.schedule.Dates {
+		parts = append(parts, d.Start+".."+d.End)
+	}
+
+	for _, f := range m.schedule.Freezes {
+		parts = append(parts, "freeze="+f.Name)
+	}
+
+	return "schedule:" + strings.Join(parts, ",")
+}
+
+// addScheduleMatcher adds a schedule matcher if the schedule is set.
+func (b *matcherBuilder) addScheduleMatcher(schedule *Schedule) {
+	if schedule == nil || b.err != nil {
+		return
+	}
+
+	m, err := NewScheduleMatcher(schedule)
+	if err != nil {
+		b.err = err
+
+		return
+	}
+
+	b.matchers = append(b.matchers, m)
+}
+
+// loadLocation loads an IANA time zone, defaulting to local time.
+func loadLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.Local, nil
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidSchedule, "unknown timezone %q", timezone)
+	}
+
+	return loc, nil
+}
+
+// parseRange parses the start and end of a range. A date-only end includes
+// the whole day.
+func parseRange(start, end string, loc *time.Location) (time.Time, time.Time, error) {
+	startTime, _, err := parseTime(start, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	endTime, dateOnly, err := parseTime(end, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if dateOnly {
+		endTime = endTime.AddDate(0, 0, 1)
+	}
+
+	if !endTime.After(startTime) {
+		return time.Time{}, time.Time{}, errors.Wrapf(
+			ErrInvalidSchedule,
+			"end %q is not after start %q",
+			end,
+			start,
+		)
+	}
+
+	return startTime, endTime, nil
+}
+
+// parseTime parses an RFC 3339 time, or a date or time without offset in loc.
+func parseTime(value string, loc *time.Location) (time.Time, bool, error) {
+	if value == "" {
+		return time.Time{}, false, errors.Wrap(ErrInvalidSchedule, "missing start or end")
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, layout == dateLayout, nil
+		}
+	}
+
+	return time.Time{}, false, errors.Wrapf(
+		ErrInvalidSchedule,
+		"invalid time %q (expected YYYY-MM-DD, YYYY-MM-DDTHH:MM or RFC 3339)",
+		value,
+	)
+}
+
+// cronField describes one field of a cron expression.
+type cronField struct {
+	name     string
+	minValue int
+	maxValue int
+	names    []string
+}
+
+var cronFields = []cronField{
+	{name: "minute", minValue: 0, maxValue: 59},
+	{name: "hour", minValue: 0, maxValue: 23},
+	{name: "day of month", minValue: 1, maxValue: 31},
+	{name: "month", minValue: 1, maxValue: 12, names: []string{
+		"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec",
+	}},
+	// 7 is Sunday as well, as in cron
+	{name: "day of week", minValue: 0, maxValue: 7, names: []string{
+		"sun", "mon", "tue", "wed", "thu", "fri", "sat",
+	}},
+}
+
+// cronWindow is a compiled cron expression. Each field is a bit set of the
+// values it allows.
+type cronWindow struct {
+	minute, hour, dom, month, dow uint64
+
+	// domAny and dowAny are set for "*" fields. As in cron, a time matches
+	// either day field if both are restricted.
+	domAny, dowAny bool
+}
+
+// parseCronWindow parses a "minute hour day-of-month month day-of-week"
+// expression. Fields support "*", values, ranges ("9-17"), lists ("1,15"),
+// steps ("*/15") and month and day names ("jan", "mon-fri").
+func parseCronWindow(expr string) (*cronWindow, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, errors.Wrapf(
+			ErrInvalidSchedule,
+			"window %q has %d fields, expected 5 (minute hour day-of-month month day-of-week)",
+			expr,
+			len(fields),
+		)
+	}
+
+	sets := make([]uint64, len(fields))
+
+	for i, field := range fields {
+		set, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "window %q", expr)
+		}
+
+		sets[i] = set
+	}
+
+	// Fold day 7 into Sunday
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+
+	return &cronWindow{
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		domAny: strings.HasPrefix(fields[2], "*"),
+		dowAny: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parseCronField parses a comma-separated list of cron items into a bit set.
+func parseCronField(field string, spec cronField) (uint64, error) {
+	var set uint64
+
+	for item := range strings.SplitSeq(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+
+		step := 1
+
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, errors.Wrapf(ErrInvalidSchedule, "invalid %s step %q", spec.name, item)
+			}
+
+			step = n
+		}
+
+		low, high := spec.minValue, spec.maxValue
+
+		if rangePart != "*" {
+			lowPart, highPart, isRange := strings.Cut(rangePart, "-")
+
+			var err error
+
+			if low, err = parseCronValue(lowPart, spec); err != nil {
+				return 0, err
+			}
+
+			high = low
+
+			switch {
+			case isRange:
+				if high, err = parseCronValue(highPart, spec); err != nil {
+					return 0, err
+				}
+			case hasStep:
+				// "5/15" runs from 5 to the maximum
+				high = spec.maxValue
+			}
+
+			if low > high {
+				return 0, errors.Wrapf(ErrInvalidSchedule, "invalid %s range %q", spec.name, item)
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			set |= 1 << v
+		}
+	}
+
+	return set, nil
+}
+
+// parseCronValue parses a number or name of a cron field.
+func parseCronValue(value string, spec cronField) (int, error) {
+	if i := slices.Index(spec.names, strings.ToLower(value)); i >= 0 {
+		// Names start at the minimum, e.g. jan is 1 and sun is 0
+		return spec.minValue + i, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < spec.minValue || n > spec.maxValue {
+		return 0, errors.Wrapf(
+			ErrInvalidSchedule,
+			"invalid %s %q (expected %d-%d)",
+			spec.name,
+			value,
+			spec.minValue,
+			spec.maxValue,
+		)
+	}
+
+	return n, nil
+}
+
+// match returns true if t, in the schedule time zone, is in the window.
+func (w *cronWindow) match(t time.Time) bool {
+	if w.minute&(1<<t.Minute()) == 0 ||
+		w.hour&(1<<t.Hour()) == 0 ||
+		w.month&(1<<int(t.Month())) == 0 {
+		return false
+	}
+
+	domMatch := w.dom&(1<<t.Day()) != 0
+	dowMatch := w.dow&(1<<int(t.Weekday())) != 0
+
+	if w.domAny || w.dowAny {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
@@ -0,0 +1,216 @@
+package rules_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+)
+
+var _ = Describe("Schedule", func() {
+	// Friday 2026-12-04 17:30 UTC
+	friday := time.Date(2026, time.December, 4, 17, 30, 0, 0, time.UTC)
+
+	matchAt := func(schedule *rules.Schedule, t time.Time) bool {
+		m, err := rules.NewScheduleMatcher(schedule)
+		Expect(err).NotTo(HaveOccurred())
+
+		return m.Match(&rules.MatchContext{Now: t})
+	}
+
+	windowAt := func(window string, t time.Time) bool {
+		return matchAt(&rules.Schedule{Windows: []string{window}, Timezone: "UTC"}, t)
+	}
+
+	Describe("windows", func() {
+		DescribeTable("should match cron-like windows",
+			func(window string, expected bool) {
+				Expect(windowAt(window, friday)).To(Equal(expected))
+			},
+			Entry("any time", "* * * * *", true),
+			Entry("hour range", "* 17-18 * * *", true),
+			Entry("hour outside range", "* 9-16 * * *", false),
+			Entry("minute step", "*/15 * * * *", true),
+			Entry("minute step miss", "*/20 * * * *", false),
+			Entry("minute list", "0,30 * * * *", true),
+			Entry("weekday names", "* * * * mon-fri", true),
+			Entry("weekend names", "* * * * sat,sun", false),
+			Entry("month name", "* * * dec *", true),
+			Entry("day of month", "* * 4 * *", true),
+			Entry("value with step", "30/10 * * * *", true),
+			Entry("either restricted day field", "* * 1 * fri", true),
+			Entry("neither restricted day field", "* * 1 * sat", false),
+		)
+
+		It("should treat 7 as Sunday", func() {
+			sunday := friday.AddDate(0, 0, 2)
+			Expect(windowAt("* * * * 7", sunday)).To(BeTrue())
+			Expect(windowAt("* * * * 0", sunday)).To(BeTrue())
+		})
+
+		It("should evaluate windows in the schedule time zone", func() {
+			// 17:30 UTC is 18:30 in Berlin
+			schedule := &rules.Schedule{
+				Windows:  []string{"* 18 * * *"},
+				Timezone: "Europe/Berlin",
+			}
+			Expect(matchAt(schedule, friday)).To(BeTrue())
+		})
+	})
+
+	Describe("dates", func() {
+		schedule := &rules.Schedule{
+			Dates:    []rules.DateRange{{Start: "2026-12-01", End: "2026-12-04"}},
+			Timezone: "UTC",
+		}
+
+		It("should include the whole end date", func() {
+			Expect(matchAt(schedule, friday)).To(BeTrue())
+			Expect(matchAt(schedule, friday.AddDate(0, 0, 1))).To(BeFalse())
+			Expect(matchAt(schedule, time.Date(2026, 11, 30, 23, 59, 0, 0, time.UTC))).
+				To(BeFalse())
+		})
+
+		It("should end at times exclusively", func() {
+			timed := &rules.Schedule{
+				Dates:    []rules.DateRange{{Start: "2026-12-04 09:00", End: "2026-12-04T17:30"}},
+				Timezone: "UTC",
+			}
+			Expect(matchAt(timed, friday.Add(-time.Minute))).To(BeTrue())
+			Expect(matchAt(timed, friday)).To(BeFalse())
+		})
+	})
+
+	Describe("freezes", func() {
+		It("should match during a freeze", func() {
+			freeze, err := rules.NewFreeze(
+				"release-2.0", "2026-12-01", "2026-12-04T18:00", "Release 2.0", "UTC",
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			schedule := &rules.Schedule{Freezes: []*rules.Freeze{freeze}}
+			Expect(matchAt(schedule, friday)).To(BeTrue())
+			Expect(matchAt(schedule, friday.Add(time.Hour))).To(BeFalse())
+			Expect(schedule.ActiveFreeze(friday)).To(Equal(freeze))
+			Expect(schedule.ActiveFreeze(friday.Add(time.Hour))).To(BeNil())
+		})
+
+		It("should describe when the freeze ends", func() {
+			freeze, err := rules.NewFreeze(
+				"q4", "2026-12-20", "2027-01-03", "Holidays", "Europe/Berlin",
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(freeze.Notice()).To(Equal(`code freeze "q4" until 2027-01-04 00:00 CET: Holidays`))
+		})
+
+		It("should never match without freezes", func() {
+			Expect(matchAt(&rules.Schedule{}, friday)).To(BeFalse())
+		})
+	})
+
+	DescribeTable("should reject invalid schedules",
+		func(schedule *rules.Schedule, message string) {
+			_, err := rules.NewScheduleMatcher(schedule)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, rules.ErrInvalidSchedule)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(message))
+		},
+		Entry("field count", &rules.Schedule{Windows: []string{"* * *"}}, "has 3 fields"),
+		Entry("out of range", &rules.Schedule{Windows: []string{"* 24 * * *"}},
+			`invalid hour "24" (expected 0-23)`),
+		Entry("unknown name", &rules.Schedule{Windows: []string{"* * * * weekend"}},
+			`invalid day of week "weekend"`),
+		Entry("reversed range", &rules.Schedule{Windows: []string{"* 18-9 * * *"}},
+			`invalid hour range "18-9"`),
+		Entry("zero step", &rules.Schedule{Windows: []string{"*/0 * * * *"}},
+			`invalid minute step "*/0"`),
+		Entry("timezone", &rules.Schedule{Windows: []string{"* * * * *"}, Timezone: "Mars/Base"},
+			`unknown timezone "Mars/Base"`),
+		Entry("date", &rules.Schedule{Dates: []rules.DateRange{{Start: "12/01/2026", End: "2026-12-04"}}},
+			`invalid time "12/01/2026"`),
+		Entry("missing end", &rules.Schedule{Dates: []rules.DateRange{{Start: "2026-12-01"}}},
+			"missing start or end"),
+		Entry("end before start", &rules.Schedule{
+			Dates: []rules.DateRange{{Start: "2026-12-04", End: "2026-12-01"}},
+		}, `end "2026-12-01" is not after start "2026-12-04"`),
+	)
+
+	Describe("RuleEngine", func() {
+		var freeze *rules.Freeze
+
+		BeforeEach(func() {
+			var err error
+
+			freeze, err = rules.NewFreeze(
+				"release-2.0", "2026-12-01", "2026-12-04T18:00", "Release 2.0", "UTC",
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		newEngine := func(message string, now time.Time) *rules.RuleEngine {
+			engine, err := rules.NewRuleEngine(
+				[]*rules.Rule{
+					{
+						Name:    "freeze-push",
+						Enabled: true,
+						Match: &rules.RuleMatch{
+							ValidatorType: rules.ValidatorGitPush,
+							Schedule: &rules.Schedule{
+								Windows:  []string{"* * * * sat,sun"},
+								Freezes:  []*rules.Freeze{freeze},
+								Timezone: "UTC",
+							},
+						},
+						Action: &rules.RuleAction{Type: rules.ActionBlock, Message: message},
+					},
+				},
+				rules.WithEngineTimeFunc(func() time.Time { return now }),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			return engine
+		}
+
+		evaluate := func(engine *rules.RuleEngine) *rules.RuleResult {
+			return engine.Evaluate(
+				context.Background(),
+				&rules.MatchContext{ValidatorType: rules.ValidatorGitPush},
+			)
+		}
+
+		It("should append when the freeze ends to the message", func() {
+			result := evaluate(newEngine("Pushes are frozen", friday))
+			Expect(result.Matched).To(BeTrue())
+			Expect(result.Freeze).To(Equal(freeze))
+			Expect(result.Message).To(Equal(
+				`Pushes are frozen (code freeze "release-2.0" until 2026-12-04 18:00 UTC: Release 2.0)`,
+			))
+		})
+
+		It("should use the freeze notice without a message", func() {
+			result := evaluate(newEngine("", friday))
+			Expect(result.Message).To(Equal(
+				`Code freeze "release-2.0" until 2026-12-04 18:00 UTC: Release 2.0`,
+			))
+		})
+
+		It("should keep the message when matching outside freezes", func() {
+			saturday := friday.AddDate(0, 0, 1)
+
+			result := evaluate(newEngine("No weekend pushes", saturday))
+			Expect(result.Matched).To(BeTrue())
+			Expect(result.Freeze).To(BeNil())
+			Expect(result.Message).To(Equal("No weekend pushes"))
+		})
+
+		It("should not match outside the schedule", func() {
+			monday := friday.AddDate(0, 0, 3)
+
+			Expect(evaluate(newEngine("", monday)).Matched).To(BeFalse())
+		})
+	})
+})
@@ -4,9 +4,12 @@ package rules
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/smykla-labs/klaudiush/internal/validator"
 	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
 )
 
 // ActionType represents the action to take when a rule matches.
@@ -21,6 +24,9 @@ const (
 
 	// ActionAllow explicitly allows the operation.
 	ActionAllow ActionType = "allow"
+
+	// ActionAsk escalates the operation to the user for explicit approval.
+	ActionAsk ActionType = "ask"
 )
 
 // ValidatorType identifies a specific validator or group of validators.
@@ -30,30 +36,36 @@ type ValidatorType string
 
 // Common validator type constants.
 const (
-	ValidatorGitPush        ValidatorType = "git.push"
-	ValidatorGitFetch       ValidatorType = "git.fetch"
-	ValidatorGitCommit      ValidatorType = "git.commit"
-	ValidatorGitAdd         ValidatorType = "git.add"
-	ValidatorGitPR          ValidatorType = "git.pr"
-	ValidatorGitMerge       ValidatorType = "git.merge"
-	ValidatorGitBranch      ValidatorType = "git.branch"
-	ValidatorGitNoVerify    ValidatorType = "git.no_verify"
-	ValidatorGitAll         ValidatorType = "git.*"
-	ValidatorGitHubIssue    ValidatorType = "github.issue"
-	ValidatorGitHubAll      ValidatorType = "github.*"
-	ValidatorFileMarkdown   ValidatorType = "file.markdown"
-	ValidatorFileShell      ValidatorType = "file.shell"
-	ValidatorFileTerraform  ValidatorType = "file.terraform"
-	ValidatorFileWorkflow   ValidatorType = "file.workflow"
-	ValidatorFileGofumpt    ValidatorType = "file.gofumpt"
-	ValidatorFilePython     ValidatorType = "file.python"
-	ValidatorFileJavaScript ValidatorType = "file.javascript"
-	ValidatorFileRust       ValidatorType = "file.rust"
-	ValidatorFileAll        ValidatorType = "file.*"
-	ValidatorSecrets        ValidatorType = "secrets.secrets"
-	ValidatorShellBacktick  ValidatorType = "shell.backtick"
-	ValidatorNotification   ValidatorType = "notification.bell"
-	ValidatorAll            ValidatorType = "*"
+	ValidatorGitPush            ValidatorType = "git.push"
+	ValidatorGitFetch           ValidatorType = "git.fetch"
+	ValidatorGitCommit          ValidatorType = "git.commit"
+	ValidatorGitAdd             ValidatorType = "git.add"
+	ValidatorGitPR              ValidatorType = "git.pr"
+	ValidatorGitMerge           ValidatorType = "git.merge"
+	ValidatorGitBranch          ValidatorType = "git.branch"
+	ValidatorGitNoVerify        ValidatorType = "git.no_verify"
+	ValidatorGitDestructive     ValidatorType = "git.destructive"
+	ValidatorGitAll             ValidatorType = "git.*"
+	ValidatorGitHubIssue        ValidatorType = "github.issue"
+	ValidatorGitHubAll          ValidatorType = "github.*"
+	ValidatorFileMarkdown       ValidatorType = "file.markdown"
+	ValidatorFileShell          ValidatorType = "file.shell"
+	ValidatorFileTerraform      ValidatorType = "file.terraform"
+	ValidatorFileWorkflow       ValidatorType = "file.workflow"
+	ValidatorFileGofumpt        ValidatorType = "file.gofumpt"
+	ValidatorFilePython         ValidatorType = "file.python"
+	ValidatorFileJavaScript     ValidatorType = "file.javascript"
+	ValidatorFileRust           ValidatorType = "file.rust"
+	ValidatorFileSensitiveRead  ValidatorType = "file.sensitive_read"
+	ValidatorFileProtectedPaths ValidatorType = "file.protected_paths"
+	ValidatorFileAll            ValidatorType = "file.*"
+	ValidatorSecrets            ValidatorType = "secrets.secrets"
+	ValidatorShellBacktick      ValidatorType = "shell.backtick"
+	ValidatorShellDestructive   ValidatorType = "shell.destructive"
+	ValidatorNotification       ValidatorType = "notification.bell"
+	ValidatorLifecycle          ValidatorType = "lifecycle.event"
+	ValidatorToolUse            ValidatorType = "tool.use"
+	ValidatorAll                ValidatorType = "*"
 )
 
 // Rule represents a single validation rule with match conditions and action.
@@ -122,6 +134,48 @@ type RuleMatch struct {
 	// EventType matches against the hook event type.
 	EventType string
 
+	// MCPServer matches against the MCP server name (supports patterns).
+	MCPServer string
+
+	// MCPTool matches against the MCP tool name without the server prefix (supports patterns).
+	MCPTool string
+
+	// URLDomain matches against the host name of the WebFetch URL (supports patterns).
+	URLDomain string
+
+	// Dirty matches whether the working tree has staged, modified or untracked files.
+	Dirty *bool
+
+	// StagedPattern matches when any staged file matches the pattern, or, if it
+	// is negated, when no staged file matches it.
+	StagedPattern string
+
+	// StagedPatterns is a list of staged file patterns, combined by PatternMode.
+	StagedPatterns []string
+
+	// UpstreamPattern matches against the upstream of the current branch (supports patterns).
+	UpstreamPattern string
+
+	// MinAhead matches when HEAD is at least this many commits ahead of the upstream.
+	MinAhead int
+
+	// MinBehind matches when HEAD is at least this many commits behind the upstream.
+	MinBehind int
+
+	// HeadSigned matches whether the HEAD commit is signed.
+	HeadSigned *bool
+
+	// HeadAuthorPattern matches against the HEAD commit author as "Name <email>"
+	// (supports patterns).
+	HeadAuthorPattern string
+
+	// Schedule restricts the rule to time windows, date ranges and freezes.
+	Schedule *Schedule
+
+	// When is an expression over the match context that must evaluate to true,
+	// e.g. `git.branch == "main" && command.argv.contains("--force")`.
+	When string
+
 	// CaseInsensitive enables case-insensitive pattern matching.
 	CaseInsensitive bool
 
@@ -131,14 +185,19 @@ type RuleMatch struct {
 
 // RuleAction specifies what happens when a rule matches.
 type RuleAction struct {
-	// Type is the action to take (block, warn, allow).
+	// Type is the action to take (block, warn, allow, ask).
 	Type ActionType
 
-	// Message is the human-readable message to display.
+	// Message is the human-readable message to display. Messages with actions
+	// are Go templates rendered with MessageData, e.g. "Push to {{.Git.Branch}}".
 	Message string
 
 	// Reference is an optional error reference code (e.g., "GIT019").
 	Reference string
+
+	// FixHint is an optional short suggestion for fixing the issue, rendered
+	// like Message. It replaces the suggestion of the reference.
+	FixHint string
 }
 
 // RuleResult represents the outcome of rule evaluation.
@@ -157,6 +216,13 @@ type RuleResult struct {
 
 	// Reference is the error reference code (if any).
 	Reference string
+
+	// FixHint is the rendered fix hint (if any).
+	FixHint string
+
+	// Freeze is the active freeze of the rule schedule (if any). Its notice
+	// is appended to the message.
+	Freeze *Freeze
 }
 
 // GitContext contains git-specific data for rule matching.
@@ -172,6 +238,33 @@ type GitContext struct {
 
 	// IsInRepo indicates whether we're inside a git repository.
 	IsInRepo bool
+
+	// State provides the repository state computed on first use (may be nil).
+	State GitState
+}
+
+// GitState provides repository state that is expensive to compute, such as
+// ahead/behind counts. Implementations compute each value on first use, so
+// rules that do not need it cost nothing.
+type GitState interface {
+	// StagedFiles returns the paths of the staged files, relative to the repository root.
+	StagedFiles() []string
+
+	// IsDirty reports whether there are staged, modified or untracked files.
+	IsDirty() bool
+
+	// Upstream returns the upstream of the current branch, e.g. "origin/main",
+	// or an empty string if it has none.
+	Upstream() string
+
+	// AheadBehind returns how many commits HEAD is ahead of and behind the upstream.
+	AheadBehind() (ahead, behind int)
+
+	// HeadAuthor returns the author of the HEAD commit as "Name <email>".
+	HeadAuthor() string
+
+	// HeadSigned reports whether the HEAD commit is signed.
+	HeadSigned() bool
 }
 
 // FileContext contains file-specific data for rule matching.
@@ -199,6 +292,64 @@ type MatchContext struct {
 
 	// Command is the bash command being executed (if applicable).
 	Command string
+
+	// Now is the time rules are evaluated at (zero means the current time).
+	Now time.Time
+
+	// parserOpts configure how the command is parsed, set by the engine.
+	parserOpts []parser.BashParserOption
+
+	// parsed caches the parsed command, so it is parsed once per context.
+	parsed *parsedCommand
+}
+
+// parsedCommand is a Bash command and the commands parsed from it.
+type parsedCommand struct {
+	command  string
+	commands []parser.Command
+}
+
+// commands returns the commands of the Bash command, including the ones
+// unwrapped from wrappers such as bash -c or sudo.
+func (c *MatchContext) commands() []parser.Command {
+	command := contextCommand(c)
+
+	if c.parsed != nil && c.parsed.command == command {
+		return c.parsed.commands
+	}
+
+	c.parsed = &parsedCommand{command: command}
+
+	if command != "" {
+		if result, err := parser.NewBashParser(c.parserOpts...).Parse(command); err == nil {
+			c.parsed.commands = result.Commands
+		}
+	}
+
+	return c.parsed.commands
+}
+
+// nestedCommands returns the commands unwrapped from wrappers such as bash -c,
+// sudo or xargs, joined with their arguments.
+func (c *MatchContext) nestedCommands() []string {
+	var nested []string
+
+	for _, cmd := range c.commands() {
+		if cmd.Depth > 0 {
+			nested = append(nested, strings.Join(cmd.FullCommand(), " "))
+		}
+	}
+
+	return nested
+}
+
+// now returns the time rules are evaluated at.
+func (c *MatchContext) now() time.Time {
+	if c.Now.IsZero() {
+		return time.Now()
+	}
+
+	return c.Now
 }
 
 // Engine is the main interface for the rule engine.
@@ -4,3 +4,53 @@ package templates
 type ShellCheckOutputData struct {
 	Output string
 }
+
+var (
+	// SensitiveReadTemplate formats error for reads of secret files
+	SensitiveReadTemplate = Parse(
+		"sensitive_read",
+		`🔒 Reading sensitive files is not allowed
+{{range .Violations}}
+  - {{.Source}}: '{{.Path}}' {{.Reason}}
+{{- end}}
+
+These files hold credentials or keys. Ask the user for the values you need instead.
+Files safe to read in this project can be allowed with [validators.file.sensitive_read] allow.`,
+	)
+
+	// ProtectedPathTemplate formats error for writes to protected paths
+	ProtectedPathTemplate = Parse(
+		"protected_path",
+		`🚫 Writing to protected path '{{.Path}}'
+{{- if ne .Path .Resolved}} ({{.Resolved}}){{end}}
+{{if .Message}}{{.Message}}{{else}}The path matches the protected {{.Entry}}.{{end}}
+{{- if .Replacement}}
+
+💡 Use a project-local path instead:
+   - Create: mkdir -p {{.ReplacementDir}}
+   - Use: {{.Replacement}}
+{{- end}}`,
+	)
+)
+
+// SensitiveReadViolation describes a single sensitive read
+type SensitiveReadViolation struct {
+	Source string
+	Path   string
+	Reason string
+}
+
+// SensitiveReadData holds data for SensitiveReadTemplate
+type SensitiveReadData struct {
+	Violations []SensitiveReadViolation
+}
+
+// ProtectedPathData holds data for ProtectedPathTemplate
+type ProtectedPathData struct {
+	Path           string
+	Resolved       string
+	Entry          string
+	Message        string
+	ReplacementDir string
+	Replacement    string
+}
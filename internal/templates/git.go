@@ -37,6 +37,31 @@ Did you forget to:
   • Use -a flag? Run 'git commit -a' to commit all modified files`,
 	)
 
+	// GitCommitBlockedFilesTemplate formats error message for staged files matching
+	// blocked patterns
+	GitCommitBlockedFilesTemplate = Parse(
+		"git_commit_blocked_files",
+		`Staged files match blocked patterns and would be committed
+
+Blocked files:
+{{range .Files}}  - {{.}}
+{{end}}
+Unstage them before committing:
+  git restore --staged{{range .Files}} {{.}}{{end}}`,
+	)
+
+	// GitCommitStagedSecretsTemplate formats error message for secrets in staged changes
+	GitCommitStagedSecretsTemplate = Parse(
+		"git_commit_staged_secrets",
+		`Lines added by the staged changes contain potential secrets
+
+Remove the secrets from the files and stage them again, then use
+environment variables or a secrets manager instead.
+
+Known findings, such as test fixtures, can be recorded with:
+  klaudiush secrets baseline update`,
+	)
+
 	// BranchSpaceErrorTemplate formats error for branch names with spaces
 	BranchSpaceErrorTemplate = Parse("branch_space_error", `Branch name appears to contain spaces
 
@@ -125,6 +150,58 @@ Suggested alternatives: [{{.SuggestedRemotesStr}}]
 Available remotes: [{{.AvailableRemotesStr}}]
 {{- end}}`,
 	)
+
+	// GitDestructiveTemplate formats error for git operations that would lose work
+	GitDestructiveTemplate = Parse(
+		"git_destructive",
+		`❌ '{{.Operation}}' would discard work that is not saved anywhere else
+{{- if .Files}}
+
+Uncommitted files that would be lost:
+{{- range .Files}}
+  - {{.}}
+{{- end}}
+{{- end}}
+{{- if .Commits}}
+
+Commits not reachable from any other branch or tag:
+{{- range .Commits}}
+  - {{.}}
+{{- end}}
+{{- end}}
+{{- if .Stashes}}
+
+Stash entries that would be dropped:
+{{- range .Stashes}}
+  - {{.}}
+{{- end}}
+{{- end}}
+
+Commit, stash or push this work first, or ask the user to run the command.`,
+	)
+
+	// PushForceProtectedTemplate formats error for force push to a protected branch
+	PushForceProtectedTemplate = Parse(
+		"push_force_protected",
+		`❌ Force push to protected branch '{{.Branch}}' is not allowed
+
+Protected branches: [{{.ProtectedBranchesStr}}]
+
+Rewriting the history of a protected branch is blocked even with --force-with-lease.
+Push to a feature branch and open a pull request instead.`,
+	)
+
+	// PushForceNoLeaseTemplate formats error for force push without --force-with-lease
+	PushForceNoLeaseTemplate = Parse(
+		"push_force_no_lease",
+		`❌ Force push to '{{.Branch}}' must use --force-with-lease
+
+{{.Flag}} overwrites the remote branch even if someone else pushed to it.
+--force-with-lease refuses to overwrite commits you have not fetched.
+
+Use:
+  git push --force-with-lease {{.Remote}} {{.Branch}}`,
+	)
 )
 
 // GitAddTmpFilesData holds data for GitAddTmpFilesTemplate
@@ -137,6 +214,11 @@ type GitCommitFlagsData struct {
 	ArgsStr string
 }
 
+// GitCommitBlockedFilesData holds data for GitCommitBlockedFilesTemplate
+type GitCommitBlockedFilesData struct {
+	Files []string
+}
+
 // GitCommitNoStagedData holds data for GitCommitNoStagedTemplate
 type GitCommitNoStagedData struct {
 	ModifiedCount  int
@@ -184,3 +266,24 @@ type PushBlockedRemoteData struct {
 	SuggestedRemotesStr string
 	AvailableRemotesStr string
 }
+
+// GitDestructiveData holds data for GitDestructiveTemplate
+type GitDestructiveData struct {
+	Operation string
+	Files     []string
+	Commits   []string
+	Stashes   []string
+}
+
+// PushForceProtectedData holds data for PushForceProtectedTemplate
+type PushForceProtectedData struct {
+	Branch               string
+	ProtectedBranchesStr string
+}
+
+// PushForceNoLeaseData holds data for PushForceNoLeaseTemplate
+type PushForceNoLeaseData struct {
+	Remote string
+	Branch string
+	Flag   string
+}
@@ -0,0 +1,30 @@
+package templates
+
+var (
+	// ShellDestructiveTemplate formats error for destructive commands on protected paths
+	ShellDestructiveTemplate = Parse(
+		"shell_destructive",
+		`❌ Destructive command targets paths it must not touch
+{{range .Violations}}
+  - {{.Command}}: '{{.Target}}' {{.Reason}}
+{{- end}}
+
+Project root: {{.ProjectRoot}}
+
+Only delete or rewrite explicit paths inside the project, or ask the user to run the command.
+Regenerable paths can be allowed with [validators.shell.destructive] safe_targets.`,
+	)
+)
+
+// ShellDestructiveViolation describes a single blocked target
+type ShellDestructiveViolation struct {
+	Command string
+	Target  string
+	Reason  string
+}
+
+// ShellDestructiveData holds data for ShellDestructiveTemplate
+type ShellDestructiveData struct {
+	Violations  []ShellDestructiveViolation
+	ProjectRoot string
+}
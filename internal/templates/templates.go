@@ -31,7 +31,12 @@ func MustExecute(tmpl *template.Template, data any) string {
 	return result
 }
 
-// Parse parses a template string with the funcMap
+// New parses a template string with the funcMap
+func New(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(funcMap).Parse(text)
+}
+
+// Parse parses a template string with the funcMap and panics on error
 func Parse(name, text string) *template.Template {
-	return template.Must(template.New(name).Funcs(funcMap).Parse(text))
+	return template.Must(New(name, text))
 }
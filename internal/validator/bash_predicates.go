@@ -0,0 +1,219 @@
+package validator
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
+)
+
+// defaultBashPredicates parse commands with the default parser options.
+var defaultBashPredicates = NewBashPredicates()
+
+// BashPredicates creates predicates that parse Bash commands, such as git
+// subcommand checks. The parser options, e.g. the configured nesting depth,
+// decide which wrapped commands the predicates see.
+type BashPredicates struct {
+	parserOpts []parser.BashParserOption
+}
+
+// NewBashPredicates creates BashPredicates parsing commands with the given options.
+func NewBashPredicates(opts ...parser.BashParserOption) *BashPredicates {
+	return &BashPredicates{parserOpts: opts}
+}
+
+// WritesFileWithExtension returns a predicate that matches if a Bash command
+// writes to a file with any of the given extensions.
+func (p *BashPredicates) WritesFileWithExtension(exts ...string) Predicate {
+	// Normalize extensions
+	normalized := make([]string, len(exts))
+
+	for i, ext := range exts {
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+
+		normalized[i] = ext
+	}
+
+	return func(ctx *hook.Context) bool {
+		// Only apply to Bash commands
+		if ctx.ToolName != hook.ToolTypeBash {
+			return false
+		}
+
+		// Parse the bash command
+		result, err := parser.NewBashParser(p.parserOpts...).Parse(ctx.GetCommand())
+		if err != nil {
+			return false
+		}
+
+		// Check if any file write has a matching extension
+		for _, fw := range result.FileWrites {
+			fileExt := filepath.Ext(fw.Path)
+			if slices.Contains(normalized, fileExt) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// GitSubcommandIs returns a predicate that matches if any git command in the chain
+// has the given subcommand. This properly handles command chains like "git add && git commit".
+func (p *BashPredicates) GitSubcommandIs(subcommand string) Predicate {
+	return func(ctx *hook.Context) bool {
+		gitCmds := p.gitCommands(ctx)
+
+		for _, gitCmd := range gitCmds {
+			if gitCmd.Subcommand == subcommand {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// GitSubcommandIn returns a predicate that matches if any git command in the chain
+// has any of the given subcommands.
+func (p *BashPredicates) GitSubcommandIn(subcommands ...string) Predicate {
+	return func(ctx *hook.Context) bool {
+		gitCmds := p.gitCommands(ctx)
+
+		for _, gitCmd := range gitCmds {
+			if slices.Contains(subcommands, gitCmd.Subcommand) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// GitHasFlag returns a predicate that matches if any git command in the chain has the given flag.
+func (p *BashPredicates) GitHasFlag(flag string) Predicate {
+	return func(ctx *hook.Context) bool {
+		gitCmds := p.gitCommands(ctx)
+
+		for _, gitCmd := range gitCmds {
+			if gitCmd.HasFlag(flag) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// GitHasAnyFlag returns a predicate that matches if any git command in the chain
+// has any of the given flags.
+func (p *BashPredicates) GitHasAnyFlag(flags ...string) Predicate {
+	return func(ctx *hook.Context) bool {
+		gitCmds := p.gitCommands(ctx)
+
+		for _, gitCmd := range gitCmds {
+			if slices.ContainsFunc(flags, gitCmd.HasFlag) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// GitSubcommandWithFlag returns a predicate that matches if any git command in the chain
+// has the given subcommand AND has the given flag.
+func (p *BashPredicates) GitSubcommandWithFlag(subcommand, flag string) Predicate {
+	return func(ctx *hook.Context) bool {
+		gitCmds := p.gitCommands(ctx)
+
+		for _, gitCmd := range gitCmds {
+			if gitCmd.Subcommand == subcommand && gitCmd.HasFlag(flag) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// GitSubcommandWithAnyFlag returns a predicate that matches if any git command in the chain
+// has the given subcommand AND has any of the given flags.
+func (p *BashPredicates) GitSubcommandWithAnyFlag(subcommand string, flags ...string) Predicate {
+	return func(ctx *hook.Context) bool {
+		gitCmds := p.gitCommands(ctx)
+
+		for _, gitCmd := range gitCmds {
+			if gitCmd.Subcommand == subcommand && slices.ContainsFunc(flags, gitCmd.HasFlag) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// GitSubcommandWithoutFlag returns a predicate that matches if any git command in the chain
+// has the given subcommand AND does NOT have the given flag.
+func (p *BashPredicates) GitSubcommandWithoutFlag(subcommand, flag string) Predicate {
+	return func(ctx *hook.Context) bool {
+		gitCmds := p.gitCommands(ctx)
+
+		for _, gitCmd := range gitCmds {
+			if gitCmd.Subcommand == subcommand && !gitCmd.HasFlag(flag) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// GitSubcommandWithoutAnyFlag returns a predicate that matches if any git command in the chain
+// has the given subcommand AND does NOT have any of the given flags.
+func (p *BashPredicates) GitSubcommandWithoutAnyFlag(subcommand string, flags ...string) Predicate {
+	return func(ctx *hook.Context) bool {
+		gitCmds := p.gitCommands(ctx)
+
+		for _, gitCmd := range gitCmds {
+			if gitCmd.Subcommand == subcommand && !slices.ContainsFunc(flags, gitCmd.HasFlag) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// gitCommands parses all git commands from a hook context.
+// Returns all git commands found in command chains like "git add && git commit".
+// Returns empty slice if no git commands are found or parsing fails.
+func (p *BashPredicates) gitCommands(ctx *hook.Context) []*parser.GitCommand {
+	if ctx.ToolName != hook.ToolTypeBash {
+		return nil
+	}
+
+	result, err := parser.NewBashParser(p.parserOpts...).Parse(ctx.GetCommand())
+	if err != nil {
+		return nil
+	}
+
+	var gitCmds []*parser.GitCommand
+
+	for _, cmd := range result.Commands {
+		if cmd.Name == "git" {
+			gitCmd, err := parser.ParseGitCommand(cmd)
+			if err != nil {
+				continue // Skip invalid git commands but continue processing
+			}
+
+			gitCmds = append(gitCmds, gitCmd)
+		}
+	}
+
+	return gitCmds
+}
@@ -9,7 +9,7 @@ type Reference string
 // ReferenceBaseURL is the base URL for error references.
 const ReferenceBaseURL = "https://klaudiu.sh"
 
-// Git-related references (GIT001-GIT025).
+// Git-related references (GIT001-GIT028).
 const (
 	// RefGitNoSignoff indicates missing -s/--signoff flag.
 	RefGitNoSignoff Reference = ReferenceBaseURL + "/GIT001"
@@ -85,9 +85,18 @@ const (
 
 	// RefGitBlockedRemote indicates push to a blocked remote.
 	RefGitBlockedRemote Reference = ReferenceBaseURL + "/GIT025"
+
+	// RefGitForcePushProtected indicates a force push to a protected branch.
+	RefGitForcePushProtected Reference = ReferenceBaseURL + "/GIT026"
+
+	// RefGitForcePushNoLease indicates a force push without --force-with-lease.
+	RefGitForcePushNoLease Reference = ReferenceBaseURL + "/GIT027"
+
+	// RefGitDestructive indicates a git operation that would discard unsaved work.
+	RefGitDestructive Reference = ReferenceBaseURL + "/GIT028"
 )
 
-// File-related references (FILE001-FILE009).
+// File-related references (FILE001-FILE011).
 const (
 	// RefShellcheck indicates shellcheck validation failure.
 	RefShellcheck Reference = ReferenceBaseURL + "/FILE001"
@@ -115,9 +124,15 @@ const (
 
 	// RefRustfmtCheck indicates rustfmt Rust code formatting failure.
 	RefRustfmtCheck Reference = ReferenceBaseURL + "/FILE009"
+
+	// RefSensitiveRead indicates a read of a file holding credentials or keys.
+	RefSensitiveRead Reference = ReferenceBaseURL + "/FILE010"
+
+	// RefProtectedPath indicates a write to a protected path.
+	RefProtectedPath Reference = ReferenceBaseURL + "/FILE011"
 )
 
-// Security-related references (SEC001-SEC005).
+// Security-related references (SEC001-SEC006).
 const (
 	// RefSecretsAPIKey indicates detected API key.
 	RefSecretsAPIKey Reference = ReferenceBaseURL + "/SEC001"
@@ -133,12 +148,22 @@ const (
 
 	// RefSecretsConnString indicates detected connection string with credentials.
 	RefSecretsConnString Reference = ReferenceBaseURL + "/SEC005"
+
+	// RefSecretsHighEntropy indicates a high-entropy string assigned to a secret-like name.
+	RefSecretsHighEntropy Reference = ReferenceBaseURL + "/SEC006"
 )
 
 // Shell-related references (SHELL001-SHELL005).
 const (
 	// RefShellBackticks indicates unescaped backticks in double-quoted strings.
 	RefShellBackticks Reference = ReferenceBaseURL + "/SHELL001"
+
+	// RefShellDestructive indicates a destructive command targeting a protected path or
+	// a path outside the project.
+	RefShellDestructive Reference = ReferenceBaseURL + "/SHELL002"
+
+	// RefShellNestingDepth indicates wrappers nested deeper than the maximum nesting depth.
+	RefShellNestingDepth Reference = ReferenceBaseURL + "/SHELL003"
 )
 
 // GitHub CLI-related references (GH001-GH005).
@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/smykla-labs/klaudiush/pkg/hook"
-	"github.com/smykla-labs/klaudiush/pkg/parser"
 )
 
 // Predicate determines if a validator should be applied to a context.
@@ -53,6 +52,11 @@ func (r *Registry) FindValidators(ctx *hook.Context) []Validator {
 	return validators
 }
 
+// Registrations returns all registrations in registration order.
+func (r *Registry) Registrations() []Registration {
+	return slices.Clone(r.registrations)
+}
+
 // Count returns the number of registered validators.
 func (r *Registry) Count() int {
 	return len(r.registrations)
@@ -67,6 +71,13 @@ func EventTypeIs(eventType hook.EventType) Predicate {
 	}
 }
 
+// EventTypeIn returns a predicate that matches any of the given event types.
+func EventTypeIn(eventTypes ...hook.EventType) Predicate {
+	return func(ctx *hook.Context) bool {
+		return slices.Contains(eventTypes, ctx.EventType)
+	}
+}
+
 // ToolTypeIs returns a predicate that matches the given tool type.
 func ToolTypeIs(toolType hook.ToolType) Predicate {
 	return func(ctx *hook.Context) bool {
@@ -155,40 +166,117 @@ func FileExtensionIn(exts ...string) Predicate {
 // BashWritesFileWithExtension returns a predicate that matches if a Bash command writes
 // to a file with any of the given extensions.
 func BashWritesFileWithExtension(exts ...string) Predicate {
-	// Normalize extensions
-	normalized := make([]string, len(exts))
+	return defaultBashPredicates.WritesFileWithExtension(exts...)
+}
 
-	for i, ext := range exts {
-		if !strings.HasPrefix(ext, ".") {
-			ext = "." + ext
-		}
+// MCP and Web Tool Predicates
 
-		normalized[i] = ext
+// MCPServerIs returns a predicate that matches MCP tools of the given server.
+func MCPServerIs(server string) Predicate {
+	return func(ctx *hook.Context) bool {
+		return ctx.IsMCPTool() && ctx.MCPServer == server
+	}
+}
+
+// MCPToolIs returns a predicate that matches the given MCP server tool.
+func MCPToolIs(server, tool string) Predicate {
+	return func(ctx *hook.Context) bool {
+		return ctx.IsMCPTool() && ctx.MCPServer == server && ctx.MCPTool == tool
 	}
+}
 
+// MCPToolMatches returns a predicate that matches MCP tools whose name matches
+// the glob pattern (e.g., "create_*").
+func MCPToolMatches(pattern string) Predicate {
 	return func(ctx *hook.Context) bool {
-		// Only apply to Bash commands
-		if ctx.ToolName != hook.ToolTypeBash {
+		if !ctx.IsMCPTool() {
 			return false
 		}
 
-		// Parse the bash command
-		bashParser := parser.NewBashParser()
+		matched, err := filepath.Match(pattern, ctx.MCPTool)
 
-		result, err := bashParser.Parse(ctx.GetCommand())
-		if err != nil {
+		return err == nil && matched
+	}
+}
+
+// URLDomainIs returns a predicate that matches if the WebFetch URL host is one
+// of the given domains or a subdomain of one of them.
+func URLDomainIs(domains ...string) Predicate {
+	return func(ctx *hook.Context) bool {
+		host := ctx.GetURLDomain()
+		if host == "" {
 			return false
 		}
 
-		// Check if any file write has a matching extension
-		for _, fw := range result.FileWrites {
-			fileExt := filepath.Ext(fw.Path)
-			if slices.Contains(normalized, fileExt) {
-				return true
-			}
-		}
+		return slices.ContainsFunc(domains, func(domain string) bool {
+			domain = strings.ToLower(domain)
 
-		return false
+			return host == domain || strings.HasSuffix(host, "."+domain)
+		})
+	}
+}
+
+// Lifecycle Event Predicates
+
+// LifecycleEvent returns a predicate that matches session lifecycle events
+// (UserPromptSubmit, Stop, SubagentStop, SessionStart, SessionEnd, PreCompact).
+func LifecycleEvent() Predicate {
+	return func(ctx *hook.Context) bool {
+		return ctx.EventType.IsLifecycleEvent()
+	}
+}
+
+// PromptMatches returns a predicate that matches if the submitted prompt matches the pattern.
+func PromptMatches(pattern string) Predicate {
+	re := regexp.MustCompile(pattern)
+
+	return func(ctx *hook.Context) bool {
+		return re.MatchString(ctx.Prompt)
+	}
+}
+
+// PromptContains returns a predicate that matches if the submitted prompt contains the substring.
+func PromptContains(substring string) Predicate {
+	return func(ctx *hook.Context) bool {
+		return strings.Contains(ctx.Prompt, substring)
+	}
+}
+
+// StopHookActive returns a predicate that matches if Claude is already continuing
+// because of a stop hook. Use Not(StopHookActive()) to avoid blocking stops forever.
+func StopHookActive() Predicate {
+	return func(ctx *hook.Context) bool {
+		return ctx.StopHookActive
+	}
+}
+
+// SessionSourceIs returns a predicate that matches the SessionStart source
+// (startup, resume, clear or compact).
+func SessionSourceIs(source string) Predicate {
+	return func(ctx *hook.Context) bool {
+		return ctx.Source == source
+	}
+}
+
+// CompactTriggerIs returns a predicate that matches the PreCompact trigger (manual or auto).
+func CompactTriggerIs(trigger string) Predicate {
+	return func(ctx *hook.Context) bool {
+		return ctx.Trigger == trigger
+	}
+}
+
+// SessionEndReasonIs returns a predicate that matches the SessionEnd reason.
+func SessionEndReasonIs(reason string) Predicate {
+	return func(ctx *hook.Context) bool {
+		return ctx.Reason == reason
+	}
+}
+
+// ToolSucceeded returns a predicate that matches PostToolUse events whose tool
+// response does not report a failure. Contexts without a response match.
+func ToolSucceeded() Predicate {
+	return func(ctx *hook.Context) bool {
+		return ctx.ToolResponse.Succeeded()
 	}
 }
 
@@ -246,157 +334,46 @@ func Never() Predicate {
 // GitSubcommandIs returns a predicate that matches if any git command in the chain
 // has the given subcommand. This properly handles command chains like "git add && git commit".
 func GitSubcommandIs(subcommand string) Predicate {
-	return func(ctx *hook.Context) bool {
-		gitCmds := parseAllGitFromContext(ctx)
-
-		for _, gitCmd := range gitCmds {
-			if gitCmd.Subcommand == subcommand {
-				return true
-			}
-		}
-
-		return false
-	}
+	return defaultBashPredicates.GitSubcommandIs(subcommand)
 }
 
 // GitSubcommandIn returns a predicate that matches if any git command in the chain
 // has any of the given subcommands.
 func GitSubcommandIn(subcommands ...string) Predicate {
-	return func(ctx *hook.Context) bool {
-		gitCmds := parseAllGitFromContext(ctx)
-
-		for _, gitCmd := range gitCmds {
-			if slices.Contains(subcommands, gitCmd.Subcommand) {
-				return true
-			}
-		}
-
-		return false
-	}
+	return defaultBashPredicates.GitSubcommandIn(subcommands...)
 }
 
 // GitHasFlag returns a predicate that matches if any git command in the chain has the given flag.
 func GitHasFlag(flag string) Predicate {
-	return func(ctx *hook.Context) bool {
-		gitCmds := parseAllGitFromContext(ctx)
-
-		for _, gitCmd := range gitCmds {
-			if gitCmd.HasFlag(flag) {
-				return true
-			}
-		}
-
-		return false
-	}
+	return defaultBashPredicates.GitHasFlag(flag)
 }
 
 // GitHasAnyFlag returns a predicate that matches if any git command in the chain
 // has any of the given flags.
 func GitHasAnyFlag(flags ...string) Predicate {
-	return func(ctx *hook.Context) bool {
-		gitCmds := parseAllGitFromContext(ctx)
-
-		for _, gitCmd := range gitCmds {
-			if slices.ContainsFunc(flags, gitCmd.HasFlag) {
-				return true
-			}
-		}
-
-		return false
-	}
+	return defaultBashPredicates.GitHasAnyFlag(flags...)
 }
 
 // GitSubcommandWithFlag returns a predicate that matches if any git command in the chain
 // has the given subcommand AND has the given flag.
 func GitSubcommandWithFlag(subcommand, flag string) Predicate {
-	return func(ctx *hook.Context) bool {
-		gitCmds := parseAllGitFromContext(ctx)
-
-		for _, gitCmd := range gitCmds {
-			if gitCmd.Subcommand == subcommand && gitCmd.HasFlag(flag) {
-				return true
-			}
-		}
-
-		return false
-	}
+	return defaultBashPredicates.GitSubcommandWithFlag(subcommand, flag)
 }
 
 // GitSubcommandWithAnyFlag returns a predicate that matches if any git command in the chain
 // has the given subcommand AND has any of the given flags.
 func GitSubcommandWithAnyFlag(subcommand string, flags ...string) Predicate {
-	return func(ctx *hook.Context) bool {
-		gitCmds := parseAllGitFromContext(ctx)
-
-		for _, gitCmd := range gitCmds {
-			if gitCmd.Subcommand == subcommand && slices.ContainsFunc(flags, gitCmd.HasFlag) {
-				return true
-			}
-		}
-
-		return false
-	}
+	return defaultBashPredicates.GitSubcommandWithAnyFlag(subcommand, flags...)
 }
 
 // GitSubcommandWithoutFlag returns a predicate that matches if any git command in the chain
 // has the given subcommand AND does NOT have the given flag.
 func GitSubcommandWithoutFlag(subcommand, flag string) Predicate {
-	return func(ctx *hook.Context) bool {
-		gitCmds := parseAllGitFromContext(ctx)
-
-		for _, gitCmd := range gitCmds {
-			if gitCmd.Subcommand == subcommand && !gitCmd.HasFlag(flag) {
-				return true
-			}
-		}
-
-		return false
-	}
+	return defaultBashPredicates.GitSubcommandWithoutFlag(subcommand, flag)
 }
 
 // GitSubcommandWithoutAnyFlag returns a predicate that matches if any git command in the chain
 // has the given subcommand AND does NOT have any of the given flags.
 func GitSubcommandWithoutAnyFlag(subcommand string, flags ...string) Predicate {
-	return func(ctx *hook.Context) bool {
-		gitCmds := parseAllGitFromContext(ctx)
-
-		for _, gitCmd := range gitCmds {
-			if gitCmd.Subcommand == subcommand && !slices.ContainsFunc(flags, gitCmd.HasFlag) {
-				return true
-			}
-		}
-
-		return false
-	}
-}
-
-// parseAllGitFromContext parses all git commands from a hook context.
-// Returns all git commands found in command chains like "git add && git commit".
-// Returns empty slice if no git commands are found or parsing fails.
-func parseAllGitFromContext(ctx *hook.Context) []*parser.GitCommand {
-	if ctx.ToolName != hook.ToolTypeBash {
-		return nil
-	}
-
-	bashParser := parser.NewBashParser()
-
-	result, err := bashParser.Parse(ctx.GetCommand())
-	if err != nil {
-		return nil
-	}
-
-	var gitCmds []*parser.GitCommand
-
-	for _, cmd := range result.Commands {
-		if cmd.Name == "git" {
-			gitCmd, err := parser.ParseGitCommand(cmd)
-			if err != nil {
-				continue // Skip invalid git commands but continue processing
-			}
-
-			gitCmds = append(gitCmds, gitCmd)
-		}
-	}
-
-	return gitCmds
+	return defaultBashPredicates.GitSubcommandWithoutAnyFlag(subcommand, flags...)
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/smykla-labs/klaudiush/internal/validator"
 	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
 )
 
 var _ = Describe("Git Predicates", func() {
@@ -42,6 +43,22 @@ var _ = Describe("Git Predicates", func() {
 			Expect(predicate(ctx)).To(BeTrue())
 		})
 
+		It("matches git checkout nested in wrappers", func() {
+			predicate := validator.GitSubcommandIs("checkout")
+
+			for _, command := range []string{
+				`bash -c "git checkout main"`,
+				"sudo -u dev git checkout main",
+				"echo main | xargs git checkout",
+			} {
+				ctx := &hook.Context{
+					ToolName:  hook.ToolTypeBash,
+					ToolInput: hook.ToolInput{Command: command},
+				}
+				Expect(predicate(ctx)).To(BeTrue(), command)
+			}
+		})
+
 		It("does not match different subcommand", func() {
 			ctx := &hook.Context{
 				ToolName:  hook.ToolTypeBash,
@@ -655,3 +672,160 @@ EOF
 		})
 	})
 })
+
+var _ = Describe("Lifecycle Event Predicates", func() {
+	Describe("EventTypeIn", func() {
+		It("matches any of the given event types", func() {
+			predicate := validator.EventTypeIn(hook.EventTypeStop, hook.EventTypeSubagentStop)
+
+			Expect(predicate(&hook.Context{EventType: hook.EventTypeSubagentStop})).To(BeTrue())
+			Expect(predicate(&hook.Context{EventType: hook.EventTypePreToolUse})).To(BeFalse())
+		})
+	})
+
+	Describe("LifecycleEvent", func() {
+		It("matches session lifecycle events only", func() {
+			predicate := validator.LifecycleEvent()
+
+			Expect(predicate(&hook.Context{EventType: hook.EventTypeSessionStart})).To(BeTrue())
+			Expect(predicate(&hook.Context{EventType: hook.EventTypePreCompact})).To(BeTrue())
+			Expect(predicate(&hook.Context{EventType: hook.EventTypeNotification})).To(BeFalse())
+		})
+	})
+
+	Describe("PromptMatches", func() {
+		It("matches the submitted prompt", func() {
+			predicate := validator.PromptMatches(`(?i)deploy\s+to\s+prod`)
+
+			Expect(predicate(&hook.Context{Prompt: "please Deploy to prod now"})).To(BeTrue())
+			Expect(predicate(&hook.Context{Prompt: "deploy to staging"})).To(BeFalse())
+		})
+	})
+
+	Describe("PromptContains", func() {
+		It("matches a substring of the prompt", func() {
+			predicate := validator.PromptContains("secret")
+
+			Expect(predicate(&hook.Context{Prompt: "show me the secret"})).To(BeTrue())
+			Expect(predicate(&hook.Context{Prompt: "show me the code"})).To(BeFalse())
+		})
+	})
+
+	Describe("StopHookActive", func() {
+		It("matches when a stop hook is already active", func() {
+			predicate := validator.StopHookActive()
+
+			Expect(predicate(&hook.Context{StopHookActive: true})).To(BeTrue())
+			Expect(predicate(&hook.Context{})).To(BeFalse())
+		})
+	})
+
+	Describe("SessionSourceIs", func() {
+		It("matches the session start source", func() {
+			predicate := validator.SessionSourceIs("resume")
+
+			Expect(predicate(&hook.Context{Source: "resume"})).To(BeTrue())
+			Expect(predicate(&hook.Context{Source: "startup"})).To(BeFalse())
+		})
+	})
+
+	Describe("CompactTriggerIs", func() {
+		It("matches the compaction trigger", func() {
+			predicate := validator.CompactTriggerIs("auto")
+
+			Expect(predicate(&hook.Context{Trigger: "auto"})).To(BeTrue())
+			Expect(predicate(&hook.Context{Trigger: "manual"})).To(BeFalse())
+		})
+	})
+
+	Describe("SessionEndReasonIs", func() {
+		It("matches the session end reason", func() {
+			predicate := validator.SessionEndReasonIs("logout")
+
+			Expect(predicate(&hook.Context{Reason: "logout"})).To(BeTrue())
+			Expect(predicate(&hook.Context{Reason: "clear"})).To(BeFalse())
+		})
+	})
+
+	Describe("ToolSucceeded", func() {
+		It("matches successful or missing tool responses", func() {
+			predicate := validator.ToolSucceeded()
+			exitCode := 1
+
+			Expect(predicate(&hook.Context{})).To(BeTrue())
+			Expect(predicate(&hook.Context{ToolResponse: &hook.ToolResponse{}})).To(BeTrue())
+			Expect(predicate(&hook.Context{
+				ToolResponse: &hook.ToolResponse{ExitCode: &exitCode},
+			})).To(BeFalse())
+			Expect(predicate(&hook.Context{
+				ToolResponse: &hook.ToolResponse{Interrupted: true},
+			})).To(BeFalse())
+		})
+	})
+
+	Describe("MCP and web tool predicates", func() {
+		mcpCtx := &hook.Context{
+			ToolName:  hook.ToolTypeMCP,
+			MCPServer: "github",
+			MCPTool:   "create_pull_request",
+		}
+
+		It("matches MCP tools by server", func() {
+			Expect(validator.MCPServerIs("github")(mcpCtx)).To(BeTrue())
+			Expect(validator.MCPServerIs("slack")(mcpCtx)).To(BeFalse())
+			Expect(validator.MCPServerIs("github")(&hook.Context{
+				ToolName:  hook.ToolTypeBash,
+				MCPServer: "github",
+			})).To(BeFalse())
+		})
+
+		It("matches MCP tools by server and tool name", func() {
+			Expect(validator.MCPToolIs("github", "create_pull_request")(mcpCtx)).To(BeTrue())
+			Expect(validator.MCPToolIs("github", "get_issue")(mcpCtx)).To(BeFalse())
+			Expect(validator.MCPToolMatches("create_*")(mcpCtx)).To(BeTrue())
+			Expect(validator.MCPToolMatches("get_*")(mcpCtx)).To(BeFalse())
+		})
+
+		It("matches WebFetch URLs by domain and subdomain", func() {
+			predicate := validator.URLDomainIs("example.com")
+
+			Expect(predicate(&hook.Context{
+				ToolName:  hook.ToolTypeWebFetch,
+				ToolInput: hook.ToolInput{URL: "https://docs.Example.com:8443/page"},
+			})).To(BeTrue())
+			Expect(predicate(&hook.Context{
+				ToolName:  hook.ToolTypeWebFetch,
+				ToolInput: hook.ToolInput{URL: "https://notexample.com"},
+			})).To(BeFalse())
+			Expect(predicate(&hook.Context{ToolName: hook.ToolTypeWebFetch})).To(BeFalse())
+		})
+	})
+})
+
+var _ = Describe("BashPredicates", func() {
+	bashCtx := func(command string) *hook.Context {
+		return &hook.Context{
+			ToolName:  hook.ToolTypeBash,
+			ToolInput: hook.ToolInput{Command: command},
+		}
+	}
+
+	It("sees commands up to the default nesting depth", func() {
+		predicate := validator.NewBashPredicates().GitSubcommandIs("commit")
+
+		Expect(predicate(bashCtx(`sudo bash -c "git commit -m fix"`))).To(BeTrue())
+		Expect(predicate(bashCtx(`sudo sudo sudo sudo git commit -m fix`))).To(BeFalse())
+	})
+
+	It("sees commands up to the given nesting depth", func() {
+		deep := validator.NewBashPredicates(parser.WithMaxNestingDepth(5))
+		Expect(deep.GitSubcommandIs("commit")(bashCtx(`sudo sudo sudo sudo git commit`))).
+			To(BeTrue())
+
+		flat := validator.NewBashPredicates(parser.WithMaxNestingDepth(0))
+		Expect(flat.GitSubcommandIs("commit")(bashCtx(`bash -c "git commit"`))).To(BeFalse())
+		Expect(flat.GitSubcommandIs("commit")(bashCtx(`git commit`))).To(BeTrue())
+		Expect(flat.WritesFileWithExtension(".go")(bashCtx(`bash -c "echo x > main.go"`))).
+			To(BeFalse())
+	})
+})
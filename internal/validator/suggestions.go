@@ -29,27 +29,35 @@ var DefaultSuggestions = map[Reference]string{
 	RefGitPRValidation:       "Fix PR title, body, markdown formatting, or labels per validation errors",
 	RefGitFetchNoRemote:      "Specify valid remote: git fetch <remote> (use 'git remote -v' to list remotes)",
 	RefGitBlockedRemote:      "Use an allowed remote instead (see error message for suggested alternatives)",
+	RefGitForcePushProtected: "Push to a feature branch and open a PR instead of rewriting a protected branch",
+	RefGitForcePushNoLease:   "Use --force-with-lease instead: git push --force-with-lease <remote> <branch>",
+	RefGitDestructive:        "Commit, stash or push the listed work first, then retry",
 
 	// File suggestions
-	RefShellcheck:   "Run 'shellcheck <file>' to see detailed errors",
-	RefTerraformFmt: "Run 'terraform fmt' or 'tofu fmt' to fix formatting",
-	RefTflint:       "Run 'tflint' to see detailed linting issues",
-	RefActionlint:   "Run 'actionlint' to see workflow issues",
-	RefMarkdownLint: "Check markdown formatting and structure",
-	RefGofumpt:      "Run 'gofumpt -w <file>' to auto-fix formatting",
-	RefRuffCheck:    "Run 'ruff check <file>' to see Python code quality issues",
-	RefOxlintCheck:  "Run 'oxlint <file>' to see JavaScript/TypeScript code quality issues",
-	RefRustfmtCheck: "Run 'rustfmt <file>' to auto-fix formatting",
+	RefShellcheck:    "Run 'shellcheck <file>' to see detailed errors",
+	RefTerraformFmt:  "Run 'terraform fmt' or 'tofu fmt' to fix formatting",
+	RefTflint:        "Run 'tflint' to see detailed linting issues",
+	RefActionlint:    "Run 'actionlint' to see workflow issues",
+	RefMarkdownLint:  "Check markdown formatting and structure",
+	RefGofumpt:       "Run 'gofumpt -w <file>' to auto-fix formatting",
+	RefRuffCheck:     "Run 'ruff check <file>' to see Python code quality issues",
+	RefOxlintCheck:   "Run 'oxlint <file>' to see JavaScript/TypeScript code quality issues",
+	RefRustfmtCheck:  "Run 'rustfmt <file>' to auto-fix formatting",
+	RefSensitiveRead: "Ask the user for the values you need, or add safe files to sensitive_read allow",
+	RefProtectedPath: "Write outside the protected paths, for example to a project-local tmp/ directory",
 
 	// Security suggestions
-	RefSecretsAPIKey:     "Remove API key and use environment variables or secret management",
-	RefSecretsPassword:   "Remove hardcoded password and use secret management",
-	RefSecretsPrivKey:    "Remove private key from code; use secure key storage",
-	RefSecretsToken:      "Remove token and use environment variables or secret management",
-	RefSecretsConnString: "Use environment variables for database connection strings",
+	RefSecretsAPIKey:      "Remove API key and use environment variables or secret management",
+	RefSecretsPassword:    "Remove hardcoded password and use secret management",
+	RefSecretsPrivKey:     "Remove private key from code; use secure key storage",
+	RefSecretsToken:       "Remove token and use environment variables or secret management",
+	RefSecretsConnString:  "Use environment variables for database connection strings",
+	RefSecretsHighEntropy: "Remove the secret, or record known fixtures with 'klaudiush secrets baseline update'",
 
 	// Shell suggestions
-	RefShellBackticks: "Use HEREDOC (git commit -m \"$(cat <<'EOF'\\n...\\nEOF\\n)\") or file-based input (--body-file)",
+	RefShellBackticks:    "Use HEREDOC (git commit -m \"$(cat <<'EOF'\\n...\\nEOF\\n)\") or file-based input (--body-file)",
+	RefShellDestructive:  "Target explicit paths inside the project, or add regenerable paths to safe_targets",
+	RefShellNestingDepth: "Run the innermost command directly instead of through nested wrappers",
 
 	// GitHub CLI suggestions
 	RefGHIssueValidation: "Fix markdown formatting in issue body (empty lines around headings, proper list spacing)",
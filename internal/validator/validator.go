@@ -7,6 +7,7 @@ import (
 
 	"github.com/smykla-labs/klaudiush/pkg/hook"
 	"github.com/smykla-labs/klaudiush/pkg/logger"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
 )
 
 // ValidatorCategory represents the type of workload a validator performs.
@@ -69,6 +70,10 @@ type Result struct {
 	// Some validators may only warn without blocking.
 	ShouldBlock bool
 
+	// ShouldAsk indicates that the operation needs explicit human approval.
+	// Ask results escalate to the user instead of blocking or allowing outright.
+	ShouldAsk bool
+
 	// Reference is the URL that uniquely identifies this error type.
 	// Format: https://klaudiu.sh/{CODE} (e.g., https://klaudiu.sh/GIT001).
 	Reference Reference
@@ -156,6 +161,29 @@ func WarnWithRef(ref Reference, message string) *Result {
 	}
 }
 
+// Ask creates a failing validation result that asks the user for approval.
+func Ask(message string) *Result {
+	return &Result{
+		Passed:      false,
+		Message:     message,
+		ShouldBlock: false,
+		ShouldAsk:   true,
+	}
+}
+
+// AskWithRef creates an ask validation result with a reference URL.
+// Automatically populates FixHint from the suggestions registry.
+func AskWithRef(ref Reference, message string) *Result {
+	return &Result{
+		Passed:      false,
+		Message:     message,
+		ShouldBlock: false,
+		ShouldAsk:   true,
+		Reference:   ref,
+		FixHint:     GetSuggestion(ref),
+	}
+}
+
 // AddDetail adds a detail to the result.
 func (r *Result) AddDetail(key, value string) *Result {
 	if r.Details == nil {
@@ -177,13 +205,18 @@ func (r *Result) String() string {
 		return "BLOCK"
 	}
 
+	if r.ShouldAsk {
+		return "ASK"
+	}
+
 	return "WARN"
 }
 
 // BaseValidator provides common validator functionality.
 type BaseValidator struct {
-	name   string
-	logger logger.Logger
+	name       string
+	logger     logger.Logger
+	parserOpts []parser.BashParserOption
 }
 
 // NewBaseValidator creates a new BaseValidator.
@@ -206,6 +239,17 @@ func (v *BaseValidator) Logger() logger.Logger {
 	return v.logger
 }
 
+// SetMaxNestingDepth sets how many wrapper levels the parsers returned by
+// BashParser unwrap.
+func (v *BaseValidator) SetMaxNestingDepth(depth int) {
+	v.parserOpts = []parser.BashParserOption{parser.WithMaxNestingDepth(depth)}
+}
+
+// BashParser returns a new Bash parser configured for the validator.
+func (v *BaseValidator) BashParser() *parser.BashParser {
+	return parser.NewBashParser(v.parserOpts...)
+}
+
 // Category returns the default category (CPU) for validators.
 // Validators that perform I/O or Git operations should override this.
 func (*BaseValidator) Category() ValidatorCategory {
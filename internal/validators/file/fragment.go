@@ -2,11 +2,34 @@ package file
 
 import (
 	"cmp"
+	"io/fs"
+	"os"
 	"strings"
 
+	"github.com/cockroachdb/errors"
+
+	"github.com/smykla-labs/klaudiush/pkg/hook"
 	"github.com/smykla-labs/klaudiush/pkg/logger"
 )
 
+// ErrEditNotFound is returned when the old_string of an edit is not found in the content.
+var ErrEditNotFound = errors.New("old_string not found in file content")
+
+// EditFragment is the changed region of a file after applying edits.
+type EditFragment struct {
+	// Content is the full file content with all edits applied.
+	Content string
+
+	// Fragment contains the changed lines plus surrounding context lines.
+	Fragment string
+
+	// StartLine is the line in Content where Fragment starts (0-indexed).
+	StartLine int
+
+	// EndsAtEOF is true if the changed region reaches the end of the file.
+	EndsAtEOF bool
+}
+
 // ExtractEditFragment extracts the edit region with surrounding context lines.
 // It finds the oldStr in content, replaces it with newStr, and returns a fragment
 // containing the edit plus contextLines before and after for proper linting context.
@@ -150,3 +173,141 @@ func EditReachesEOF(content, oldStr string) bool {
 
 	return trimmed == ""
 }
+
+// ApplyEdits applies MultiEdit edits in order, the way the MultiEdit tool does.
+// Each edit replaces the first occurrence of its old_string in the result of the
+// previous edits, or all occurrences when replace_all is set. An edit with an
+// empty old_string provides the content of a new (empty) file.
+func ApplyEdits(content string, edits []hook.Edit) (string, error) {
+	for i, edit := range edits {
+		if edit.OldString == "" {
+			if content != "" {
+				return "", errors.Wrapf(ErrEditNotFound, "edit %d has an empty old_string", i+1)
+			}
+
+			content = edit.NewString
+
+			continue
+		}
+
+		if !strings.Contains(content, edit.OldString) {
+			return "", errors.Wrapf(ErrEditNotFound, "edit %d", i+1)
+		}
+
+		if edit.ReplaceAll {
+			content = strings.ReplaceAll(content, edit.OldString, edit.NewString)
+		} else {
+			content = strings.Replace(content, edit.OldString, edit.NewString, 1)
+		}
+	}
+
+	return content, nil
+}
+
+// ExtractMultiEditFragment applies all edits to content and extracts the changed
+// region of the result with contextLines before and after. The edits are merged
+// into a single fragment spanning from the first to the last changed line, so
+// the linter sees them together with the unchanged lines in between.
+// Returns nil if the edits cannot be applied.
+func ExtractMultiEditFragment(
+	content string,
+	edits []hook.Edit,
+	contextLines int,
+	log logger.Logger,
+) *EditFragment {
+	result, err := ApplyEdits(content, edits)
+	if err != nil {
+		log.Debug("failed to apply edits", "error", err)
+		return nil
+	}
+
+	start, end := changedRange(content, result)
+
+	// Find the lines containing the start and the end of the changed region
+	startLine := strings.Count(result[:start], "\n")
+	endLine := startLine
+
+	if end > start {
+		endLine = strings.Count(result[:end-1], "\n")
+	}
+
+	lines := strings.Split(result, "\n")
+	contextStart := max(0, startLine-contextLines)
+	contextEnd := min(endLine+contextLines, len(lines)-1)
+
+	fragmentLines := trimTrailingEmptyLines(lines[contextStart : contextEnd+1])
+
+	return &EditFragment{
+		Content:   result,
+		Fragment:  strings.Join(fragmentLines, "\n"),
+		StartLine: contextStart,
+		EndsAtEOF: strings.TrimSpace(result[end:]) == "",
+	}
+}
+
+// changedRange returns the byte range of updated that differs from original,
+// after stripping their common prefix and suffix.
+func changedRange(original, updated string) (start, end int) {
+	limit := min(len(original), len(updated))
+
+	for start < limit && original[start] == updated[start] {
+		start++
+	}
+
+	suffix := 0
+
+	for suffix < limit-start &&
+		original[len(original)-1-suffix] == updated[len(updated)-1-suffix] {
+		suffix++
+	}
+
+	return start, len(updated) - suffix
+}
+
+// isMultiEdit returns true for MultiEdit operations in PreToolUse, whose content
+// has to be derived by applying the edits to the file on disk.
+func isMultiEdit(ctx *hook.Context) bool {
+	return ctx.EventType == hook.EventTypePreToolUse && ctx.ToolName == hook.ToolTypeMultiEdit
+}
+
+// readMultiEditFragment reads the file of a MultiEdit operation and extracts
+// the merged fragment of its edits. A missing file is treated as empty, since
+// MultiEdit can create new files.
+func readMultiEditFragment(
+	ctx *hook.Context,
+	contextLines int,
+	log logger.Logger,
+) (*EditFragment, error) {
+	if len(ctx.ToolInput.Edits) == 0 {
+		log.Debug("no edits in multi-edit operation")
+		return nil, errNoContent
+	}
+
+	filePath := ctx.GetFilePath()
+
+	//nolint:gosec // filePath is from Claude Code tool context, not user input
+	originalContent, err := os.ReadFile(filePath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Debug("failed to read file for multi-edit validation", "file", filePath, "error", err)
+		return nil, err
+	}
+
+	fragment := ExtractMultiEditFragment(
+		string(originalContent),
+		ctx.ToolInput.Edits,
+		contextLines,
+		log,
+	)
+	if fragment == nil {
+		log.Debug("could not apply multi-edit, skipping validation")
+		return nil, errNoContent
+	}
+
+	log.Debug("validating multi-edit fragment with context",
+		"edits", len(ctx.ToolInput.Edits),
+		"fragment_lines", len(strings.Split(fragment.Fragment, "\n")),
+		"fragment_start_line", fragment.StartLine,
+	)
+
+	return fragment, nil
+}
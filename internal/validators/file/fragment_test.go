@@ -5,6 +5,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/smykla-labs/klaudiush/internal/validators/file"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
 	"github.com/smykla-labs/klaudiush/pkg/logger"
 )
 
@@ -577,3 +578,102 @@ var _ = Describe("EditReachesEOF", func() {
 		})
 	})
 })
+
+var _ = Describe("ApplyEdits", func() {
+	It("applies edits in order on the result of previous edits", func() {
+		result, err := file.ApplyEdits("a b c", []hook.Edit{
+			{OldString: "a", NewString: "x"},
+			{OldString: "x b", NewString: "y"},
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal("y c"))
+	})
+
+	It("replaces only the first occurrence by default", func() {
+		result, err := file.ApplyEdits("foo foo", []hook.Edit{
+			{OldString: "foo", NewString: "bar"},
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal("bar foo"))
+	})
+
+	It("honors replace_all", func() {
+		result, err := file.ApplyEdits("foo foo", []hook.Edit{
+			{OldString: "foo", NewString: "bar", ReplaceAll: true},
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal("bar bar"))
+	})
+
+	It("creates the content of a new file from an empty old_string", func() {
+		result, err := file.ApplyEdits("", []hook.Edit{
+			{OldString: "", NewString: "# Title\n"},
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal("# Title\n"))
+	})
+
+	It("returns an error when an old_string is not found", func() {
+		_, err := file.ApplyEdits("foo", []hook.Edit{
+			{OldString: "missing", NewString: "bar"},
+		})
+
+		Expect(err).To(MatchError(file.ErrEditNotFound))
+	})
+})
+
+var _ = Describe("ExtractMultiEditFragment", func() {
+	var log logger.Logger
+
+	BeforeEach(func() {
+		log = logger.NewNoOpLogger()
+	})
+
+	content := `line 1
+line 2
+line 3
+line 4
+line 5
+line 6
+line 7
+line 8`
+
+	It("merges all edits into one fragment with context", func() {
+		fragment := file.ExtractMultiEditFragment(content, []hook.Edit{
+			{OldString: "line 3", NewString: "line 3 changed"},
+			{OldString: "line 5", NewString: "line 5 changed"},
+		}, 1, log)
+
+		Expect(fragment).NotTo(BeNil())
+		Expect(fragment.StartLine).To(Equal(1))
+		Expect(fragment.Fragment).To(Equal(`line 2
+line 3 changed
+line 4
+line 5 changed
+line 6`))
+		Expect(fragment.EndsAtEOF).To(BeFalse())
+		Expect(fragment.Content).To(ContainSubstring("line 3 changed\nline 4\nline 5 changed"))
+	})
+
+	It("reports edits that reach the end of the file", func() {
+		fragment := file.ExtractMultiEditFragment(content, []hook.Edit{
+			{OldString: "line 8", NewString: "line 8 changed"},
+		}, 1, log)
+
+		Expect(fragment).NotTo(BeNil())
+		Expect(fragment.Fragment).To(Equal("line 7\nline 8 changed"))
+		Expect(fragment.EndsAtEOF).To(BeTrue())
+	})
+
+	It("returns nil when the edits cannot be applied", func() {
+		fragment := file.ExtractMultiEditFragment(content, []hook.Edit{
+			{OldString: "line 9", NewString: "line 9 changed"},
+		}, 1, log)
+
+		Expect(fragment).To(BeNil())
+	})
+})
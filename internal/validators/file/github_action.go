@@ -215,6 +215,16 @@ func (v *WorkflowValidator) getContent(ctx *hook.Context) (string, error) {
 		return ctx.ToolInput.Content, nil
 	}
 
+	// For MultiEdit operations in PreToolUse, read file and apply all edits
+	if isMultiEdit(ctx) {
+		fragment, err := readMultiEditFragment(ctx, 0, log)
+		if err != nil {
+			return "", err
+		}
+
+		return fragment.Content, nil
+	}
+
 	// For Edit operations in PreToolUse, read file and apply edit
 	if ctx.EventType == hook.EventTypePreToolUse && ctx.ToolName == hook.ToolTypeEdit {
 		filePath := ctx.GetFilePath()
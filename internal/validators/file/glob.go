@@ -0,0 +1,394 @@
+package file
+
+import (
+	"strings"
+)
+
+// maxGlobAlternatives limits the patterns a brace expansion produces.
+const maxGlobAlternatives = 256
+
+// maxClassCandidates limits the characters of a bracket expression range
+// tried when intersecting globs.
+const maxClassCandidates = 64
+
+// globTokenKind is the kind of a glob pattern token.
+type globTokenKind int
+
+const (
+	// globLiteral matches one given character.
+	globLiteral globTokenKind = iota
+
+	// globAny matches one character but "/", as "?" does.
+	globAny
+
+	// globClass matches one character of a bracket expression such as [a-z].
+	globClass
+
+	// globStar matches any characters but "/", as "*" does.
+	globStar
+
+	// globStarStar matches any characters, as "**" does.
+	globStarStar
+)
+
+// runeRange is an inclusive range of characters of a bracket expression.
+type runeRange struct {
+	lo, hi rune
+}
+
+// globToken is a token of a glob pattern.
+type globToken struct {
+	kind   globTokenKind
+	char   rune
+	ranges []runeRange
+	negate bool
+}
+
+// isStar reports whether the token matches a sequence of characters.
+func (t globToken) isStar() bool {
+	return t.kind == globStar || t.kind == globStarStar
+}
+
+// matches reports whether the token matches the character c. With hidden,
+// wildcards do not match a dot at the start of a path segment, like in Bash.
+func (t globToken) matches(c rune, segmentStart, hidden bool) bool {
+	if hidden && segmentStart && c == '.' && t.kind != globLiteral && t.kind != globClass {
+		return false
+	}
+
+	switch t.kind {
+	case globLiteral:
+		return c == t.char
+	case globAny, globStar:
+		return c != '/'
+	case globStarStar:
+		return true
+	default:
+		return c != '/' && t.inRanges(c) != t.negate
+	}
+}
+
+// inRanges reports whether c is in the ranges of a bracket expression.
+func (t globToken) inRanges(c rune) bool {
+	for _, r := range t.ranges {
+		if c >= r.lo && c <= r.hi {
+			return true
+		}
+	}
+
+	return false
+}
+
+// candidates returns the characters worth trying for the token.
+func (t globToken) candidates() []rune {
+	if t.kind == globLiteral {
+		return []rune{t.char}
+	}
+
+	var chars []rune
+
+	for _, r := range t.ranges {
+		if r.hi-r.lo < maxClassCandidates {
+			for c := r.lo; c <= r.hi; c++ {
+				chars = append(chars, c)
+			}
+
+			continue
+		}
+
+		chars = append(chars, r.lo, r.hi)
+	}
+
+	return chars
+}
+
+// hasGlobMeta reports whether a path contains glob characters.
+func hasGlobMeta(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// parseGlob splits a glob pattern into tokens. A "[" without a closing "]"
+// is a literal character, as is any character escaped with a backslash.
+func parseGlob(pattern string) []globToken {
+	runes := []rune(pattern)
+	tokens := make([]globToken, 0, len(runes))
+
+	for i := 0; i < len(runes); i++ {
+		switch c := runes[i]; {
+		case c == '\\' && i+1 < len(runes):
+			i++
+			tokens = append(tokens, globToken{kind: globLiteral, char: runes[i]})
+		case c == '*':
+			kind := globStar
+
+			for i+1 < len(runes) && runes[i+1] == '*' {
+				kind = globStarStar
+				i++
+			}
+
+			tokens = append(tokens, globToken{kind: kind})
+		case c == '?':
+			tokens = append(tokens, globToken{kind: globAny})
+		case c == '[':
+			token, length, ok := parseGlobClass(runes[i:])
+			if !ok {
+				tokens = append(tokens, globToken{kind: globLiteral, char: c})
+				continue
+			}
+
+			tokens = append(tokens, token)
+			i += length - 1
+		default:
+			tokens = append(tokens, globToken{kind: globLiteral, char: c})
+		}
+	}
+
+	return tokens
+}
+
+// parseGlobClass parses a bracket expression at the start of runes and
+// returns it with its length.
+func parseGlobClass(runes []rune) (globToken, int, bool) {
+	token := globToken{kind: globClass}
+	i := 1
+
+	if i < len(runes) && (runes[i] == '!' || runes[i] == '^') {
+		token.negate = true
+		i++
+	}
+
+	// A "]" right after the opening bracket is a member
+	for first := true; i < len(runes); first = false {
+		c := runes[i]
+		if c == ']' && !first {
+			return token, i + 1, true
+		}
+
+		if c == '\\' && i+1 < len(runes) {
+			i++
+			c = runes[i]
+		}
+
+		hi := c
+		if i+2 < len(runes) && runes[i+1] == '-' && runes[i+2] != ']' {
+			hi = runes[i+2]
+			i += 2
+		}
+
+		token.ranges = append(token.ranges, runeRange{lo: c, hi: hi})
+		i++
+	}
+
+	return globToken{}, 0, false
+}
+
+// expandGlob returns the alternatives of a pattern: brace expansions such as
+// {a,b}, and "**" path segments matching no directory, as in "a/**/b"
+// matching "a/b". Sequences such as {1..3} become "*".
+func expandGlob(pattern string) []string {
+	var expanded []string
+
+	for _, alt := range expandBraces(pattern) {
+		expanded = append(expanded, expandStarStar(alt)...)
+	}
+
+	return expanded
+}
+
+// expandBraces expands the brace alternations of a pattern. Patterns with too
+// many alternatives get "**" in place of their braces instead.
+func expandBraces(pattern string) []string {
+	start, end, ok := findBraces(pattern)
+	if !ok {
+		return []string{pattern}
+	}
+
+	prefix, body, suffix := pattern[:start], pattern[start+1:end], pattern[end+1:]
+
+	parts := splitBraceBody(body)
+	if len(parts) < 2 { //nolint:mnd // A single part is not an alternation
+		if strings.Contains(body, "..") {
+			parts = []string{"*"}
+		} else {
+			parts = []string{"{" + body + "}"}
+		}
+	}
+
+	var expanded []string
+
+	for _, part := range parts {
+		for _, rest := range expandBraces(suffix) {
+			expanded = append(expanded, prefix+part+rest)
+		}
+
+		if len(expanded) > maxGlobAlternatives {
+			return []string{pattern[:start] + "**"}
+		}
+	}
+
+	return expanded
+}
+
+// findBraces returns the positions of the first brace pair of a pattern,
+// skipping escaped braces.
+func findBraces(pattern string) (int, int, bool) {
+	start, depth := -1, 0
+
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '{':
+			if depth == 0 {
+				start = i
+			}
+
+			depth++
+		case '}':
+			if depth == 0 {
+				continue
+			}
+
+			depth--
+			if depth == 0 {
+				return start, i, true
+			}
+		}
+	}
+
+	return 0, 0, false
+}
+
+// splitBraceBody splits the body of a brace alternation at its top level commas.
+func splitBraceBody(body string) []string {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, body[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, body[start:])
+}
+
+// expandStarStar returns the pattern and its variants where "**" segments
+// match no directory.
+func expandStarStar(pattern string) []string {
+	expanded := []string{pattern}
+
+	if i := strings.Index(pattern, "/**/"); i >= 0 {
+		for _, rest := range expandStarStar(pattern[i+3:]) {
+			expanded = append(expanded, pattern[:i]+rest)
+		}
+	}
+
+	if base, ok := strings.CutSuffix(pattern, "/**"); ok && !strings.Contains(base, "/**/") {
+		expanded = append(expanded, base)
+	}
+
+	return expanded
+}
+
+// globsIntersect reports whether some path matches both glob patterns. With
+// hidden, the wildcards of a do not match a dot at the start of a path
+// segment, as Bash globs do.
+func globsIntersect(a, b string, hidden bool) bool {
+	for _, altA := range expandGlob(a) {
+		for _, altB := range expandGlob(b) {
+			if tokensIntersect(parseGlob(altA), parseGlob(altB), hidden) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// intersectState is a position in both patterns while intersecting them.
+type intersectState struct {
+	i, j         int
+	segmentStart bool
+}
+
+// tokensIntersect walks both token lists together, looking for a character
+// sequence both of them match.
+func tokensIntersect(a, b []globToken, hidden bool) bool {
+	start := intersectState{segmentStart: true}
+	queue := []intersectState{start}
+	seen := map[intersectState]bool{start: true}
+
+	push := func(state intersectState) {
+		if !seen[state] {
+			seen[state] = true
+			queue = append(queue, state)
+		}
+	}
+
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+
+		if state.i == len(a) && state.j == len(b) {
+			return true
+		}
+
+		// Stars may match nothing
+		if state.i < len(a) && a[state.i].isStar() {
+			push(intersectState{state.i + 1, state.j, state.segmentStart})
+		}
+
+		if state.j < len(b) && b[state.j].isStar() {
+			push(intersectState{state.i, state.j + 1, state.segmentStart})
+		}
+
+		if state.i == len(a) || state.j == len(b) {
+			continue
+		}
+
+		ta, tb := a[state.i], b[state.j]
+
+		for _, c := range intersectCandidates(ta, tb) {
+			if !ta.matches(c, state.segmentStart, hidden) ||
+				!tb.matches(c, state.segmentStart, false) {
+				continue
+			}
+
+			next := intersectState{state.i, state.j, c == '/'}
+			if !ta.isStar() {
+				next.i++
+			}
+
+			if !tb.isStar() {
+				next.j++
+			}
+
+			push(next)
+		}
+	}
+
+	return false
+}
+
+// intersectCandidates returns the characters to try for a pair of tokens:
+// their own characters, a path separator, a dot and some ordinary characters.
+func intersectCandidates(a, b globToken) []rune {
+	chars := []rune{'/', '.', 'a', 'Z', '0', '_', '-', '~', 'é'}
+	chars = append(chars, a.candidates()...)
+
+	return append(chars, b.candidates()...)
+}
@@ -110,8 +110,9 @@ func (v *GofumptValidator) getContent(
 ) (string, error) {
 	log := v.Logger()
 
-	// For Edit operations, skip validation initially (no fragment support)
-	if ctx.EventType == hook.EventTypePreToolUse && ctx.ToolName == hook.ToolTypeEdit {
+	// For Edit and MultiEdit operations, skip validation initially (no fragment support)
+	if ctx.EventType == hook.EventTypePreToolUse &&
+		(ctx.ToolName == hook.ToolTypeEdit || ctx.ToolName == hook.ToolTypeMultiEdit) {
 		log.Debug("skipping Edit operations (no fragment support)")
 		return "", os.ErrNotExist
 	}
@@ -153,6 +153,11 @@ func (v *MarkdownValidator) getContentWithState(
 		return ctx.ToolInput.Content, nil, nil
 	}
 
+	// For MultiEdit operations, validate the merged fragment of all edits
+	if isMultiEdit(ctx) {
+		return v.getMultiEditContentWithState(ctx)
+	}
+
 	// For Edit operations in PreToolUse, validate only the changed fragment with context
 	// to avoid forcing users to fix all existing linting issues
 	if ctx.EventType == hook.EventTypePreToolUse && ctx.ToolName == hook.ToolTypeEdit {
@@ -228,6 +233,23 @@ func (v *MarkdownValidator) getContentWithState(
 	return "", nil, errNoContent
 }
 
+// getMultiEditContentWithState extracts the merged MultiEdit fragment and
+// detects the markdown state at its start in the edited content.
+func (v *MarkdownValidator) getMultiEditContentWithState(
+	ctx *hook.Context,
+) (string, *validators.MarkdownState, error) {
+	fragment, err := readMultiEditFragment(ctx, v.getContextLines(), v.Logger())
+	if err != nil {
+		return "", nil, err
+	}
+
+	state := validators.DetectMarkdownState(fragment.Content, fragment.StartLine)
+	state.StartLine = fragment.StartLine
+	state.EndsAtEOF = fragment.EndsAtEOF
+
+	return fragment.Fragment, &state, nil
+}
+
 // formatTableSuggestion formats a table suggestion for display in error details.
 func formatTableSuggestion(lineNum int, suggestion string) string {
 	return fmt.Sprintf("Line %d - Use this properly formatted table:\n\n%s", lineNum, suggestion)
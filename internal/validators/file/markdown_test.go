@@ -2,6 +2,8 @@ package file_test
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -305,6 +307,59 @@ Text
 			})
 		})
 
+		Context("MultiEdit operations", func() {
+			var filePath string
+
+			BeforeEach(func() {
+				filePath = filepath.Join(GinkgoT().TempDir(), "doc.md")
+				Expect(os.WriteFile(filePath, []byte(`# Title
+
+## Setup
+
+Install the tool.
+
+## Usage
+
+Run the tool.
+`), 0o600)).To(Succeed())
+
+				ctx.ToolName = hook.ToolTypeMultiEdit
+				ctx.ToolInput.FilePath = filePath
+			})
+
+			It("validates the combined result of all edits", func() {
+				ctx.ToolInput.Edits = []hook.Edit{
+					{OldString: "Install the tool.", NewString: "Install the tool:"},
+					{OldString: "Run the tool.", NewString: "Run the tool:\n```bash\ntool run\n```"},
+				}
+
+				result := v.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeFalse())
+				Expect(
+					result.Details["errors"],
+				).To(ContainSubstring("Code block should have empty line before it"))
+			})
+
+			It("passes when the edited file is valid", func() {
+				ctx.ToolInput.Edits = []hook.Edit{
+					{OldString: "Install the tool.", NewString: "Install the tool first."},
+					{OldString: "Run the tool.", NewString: "Run the tool:\n\n```bash\ntool run\n```"},
+				}
+
+				result := v.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeTrue())
+			})
+
+			It("skips validation when an edit does not apply", func() {
+				ctx.ToolInput.Edits = []hook.Edit{
+					{OldString: "Missing text", NewString: "Replacement"},
+				}
+
+				result := v.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeTrue())
+			})
+		})
+
 		Context("edge cases", func() {
 			It("skips validation for Edit operations", func() {
 				ctx.ToolName = hook.ToolTypeEdit
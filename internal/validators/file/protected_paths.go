@@ -0,0 +1,259 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/internal/templates"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+	"github.com/smykla-labs/klaudiush/pkg/pathutil"
+)
+
+// defaultReplacementDir is suggested instead of the default protected paths.
+const defaultReplacementDir = "tmp"
+
+// defaultProtectedDirs are the directories writes to which are protected when
+// no protected paths are configured.
+var defaultProtectedDirs = []string{"/tmp", "/var/tmp"}
+
+// ProtectedPathsValidator checks writes through Write, Edit and MultiEdit
+// against the protected paths policy. File writes of Bash commands reach it as
+// synthetic Write calls from the dispatcher.
+type ProtectedPathsValidator struct {
+	validator.BaseValidator
+	paths       []protectedPath
+	ruleAdapter *rules.RuleValidatorAdapter
+}
+
+// protectedPath is a protected path entry with its compiled regex.
+type protectedPath struct {
+	config.ProtectedPathConfig
+	regex *regexp.Regexp
+}
+
+// pathTarget is a path to match together with the directories patterns are
+// resolved against. Symlink-resolved paths use symlink-resolved directories.
+type pathTarget struct {
+	path string
+	home string
+	root string
+}
+
+// NewProtectedPathsValidator creates a new ProtectedPathsValidator instance.
+func NewProtectedPathsValidator(
+	log logger.Logger,
+	cfg *config.ProtectedPathsValidatorConfig,
+	ruleAdapter *rules.RuleValidatorAdapter,
+) *ProtectedPathsValidator {
+	v := &ProtectedPathsValidator{
+		BaseValidator: *validator.NewBaseValidator("validate-file-protected-paths", log),
+		ruleAdapter:   ruleAdapter,
+	}
+
+	for _, entry := range getProtectedPaths(cfg) {
+		path := protectedPath{ProtectedPathConfig: entry}
+
+		if entry.Regex != "" {
+			regex, err := regexp.Compile(entry.Regex)
+			if err != nil {
+				log.Error("invalid protected path regex", "regex", entry.Regex, "error", err)
+				continue
+			}
+
+			path.regex = regex
+		}
+
+		v.paths = append(v.paths, path)
+	}
+
+	return v
+}
+
+// Validate checks the written file against the protected paths.
+func (v *ProtectedPathsValidator) Validate(
+	ctx context.Context,
+	hookCtx *hook.Context,
+) *validator.Result {
+	if v.ruleAdapter != nil {
+		if result := v.ruleAdapter.CheckRules(ctx, hookCtx); result != nil {
+			return result
+		}
+	}
+
+	raw := hookCtx.GetFilePath()
+	if raw == "" {
+		return validator.Pass()
+	}
+
+	cwd := hookCtx.Cwd
+	if cwd == "" {
+		cwd, _ = os.Getwd()
+	}
+
+	home, _ := os.UserHomeDir()
+	home = filepath.Clean(home)
+
+	resolved, ok := resolvePath(raw, filepath.Clean(cwd), home)
+	if !ok {
+		v.Logger().Debug("skipping unresolvable path", "path", raw)
+		return validator.Pass()
+	}
+
+	root := pathutil.FindProjectRoot(filepath.Clean(cwd))
+	targets := []pathTarget{{path: resolved, home: home, root: root}}
+
+	resolvedReal := pathutil.RealPath(resolved)
+	if resolvedReal != resolved {
+		targets = append(targets, pathTarget{
+			path: resolvedReal,
+			home: pathutil.RealPath(home),
+			root: pathutil.RealPath(root),
+		})
+	}
+
+	entry := v.match(targets)
+	if entry == nil {
+		return validator.Pass()
+	}
+
+	data := templates.ProtectedPathData{
+		Path:     raw,
+		Resolved: resolvedReal,
+		Message:  entry.Message,
+		Entry:    fmt.Sprintf("pattern '%s'", entry.Pattern),
+	}
+
+	if entry.regex != nil {
+		data.Entry = fmt.Sprintf("regex '%s'", entry.Regex)
+	}
+
+	if entry.ReplacementDir != "" {
+		data.ReplacementDir = entry.ReplacementDir
+		data.Replacement = filepath.Join(entry.ReplacementDir, filepath.Base(resolved))
+	}
+
+	message := templates.MustExecute(templates.ProtectedPathTemplate, data)
+
+	switch entry.GetAction() {
+	case config.ProtectedPathActionWarn:
+		return validator.WarnWithRef(validator.RefProtectedPath, message)
+	case config.ProtectedPathActionAsk:
+		return validator.AskWithRef(validator.RefProtectedPath, message)
+	default:
+		return validator.FailWithRef(validator.RefProtectedPath, message)
+	}
+}
+
+// match returns the first protected path matching any of the targets, or nil.
+func (v *ProtectedPathsValidator) match(targets []pathTarget) *protectedPath {
+	for i := range v.paths {
+		for _, target := range targets {
+			if v.paths[i].matches(target) {
+				return &v.paths[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// Category returns the validator category for parallel execution.
+func (*ProtectedPathsValidator) Category() validator.ValidatorCategory {
+	return validator.CategoryCPU
+}
+
+// matches reports whether a target path is protected by the entry. Regexes
+// match the absolute path, patterns also match its parent directories.
+func (p *protectedPath) matches(target pathTarget) bool {
+	if p.regex != nil {
+		return p.regex.MatchString(filepath.ToSlash(target.path))
+	}
+
+	pattern := p.Pattern
+	if len(pattern) > 1 {
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+
+	if !strings.Contains(pattern, "/") {
+		return matchesPathName(pattern, target)
+	}
+
+	pattern = expandPathPattern(pattern, target.home, target.root)
+
+	// A project checked out below a protected directory, such as /tmp, is not
+	// protected as a whole
+	if pathutil.IsWithin(target.root, target.path) && matchesPathOrParent(pattern, target.root) {
+		return false
+	}
+
+	return matchesPathOrParent(pattern, target.path)
+}
+
+// matchesPathOrParent reports whether an absolute path pattern matches path or
+// one of its parent directories.
+func matchesPathOrParent(pattern, path string) bool {
+	for current := path; ; {
+		if matched, _ := doublestar.Match(pattern, current); matched {
+			return true
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return false
+		}
+
+		current = parent
+	}
+}
+
+// matchesPathName reports whether a name pattern such as ".git" or "vendor"
+// matches a component of the target path. Inside the project only components
+// below its root are considered, so a project checked out to a directory named
+// like a protected path is not protected as a whole.
+func matchesPathName(pattern string, target pathTarget) bool {
+	path := target.path
+	if pathutil.IsWithin(target.root, path) {
+		path, _ = filepath.Rel(target.root, path)
+	}
+
+	for name := range strings.SplitSeq(filepath.ToSlash(path), "/") {
+		if matched, _ := doublestar.Match(pattern, name); name != "" && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getProtectedPaths returns the configured protected paths, or the default
+// ones with a project-local replacement directory.
+func getProtectedPaths(cfg *config.ProtectedPathsValidatorConfig) []config.ProtectedPathConfig {
+	if cfg != nil && len(cfg.Paths) > 0 {
+		return cfg.Paths
+	}
+
+	paths := make([]config.ProtectedPathConfig, 0, len(defaultProtectedDirs))
+
+	for _, dir := range defaultProtectedDirs {
+		paths = append(paths, config.ProtectedPathConfig{
+			Pattern:        dir,
+			Action:         config.ProtectedPathActionBlock,
+			ReplacementDir: defaultReplacementDir,
+		})
+	}
+
+	return paths
+}
+
+// Ensure ProtectedPathsValidator implements validator.Validator
+var _ validator.Validator = (*ProtectedPathsValidator)(nil)
@@ -0,0 +1,235 @@
+package file_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/internal/validators/file"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+var _ = Describe("ProtectedPathsValidator", func() {
+	var (
+		home    string
+		project string
+	)
+
+	BeforeEach(func() {
+		home = GinkgoT().TempDir()
+		GinkgoT().Setenv("HOME", home)
+
+		project = filepath.Join(home, "src", "app")
+		Expect(os.MkdirAll(filepath.Join(project, ".git"), 0o755)).To(Succeed())
+	})
+
+	newValidator := func(paths ...config.ProtectedPathConfig) *file.ProtectedPathsValidator {
+		return file.NewProtectedPathsValidator(
+			logger.NewNoOpLogger(),
+			&config.ProtectedPathsValidatorConfig{Paths: paths},
+			nil,
+		)
+	}
+
+	write := func(v *file.ProtectedPathsValidator, path string) *validator.Result {
+		return v.Validate(context.Background(), &hook.Context{
+			EventType: hook.EventTypePreToolUse,
+			ToolName:  hook.ToolTypeWrite,
+			ToolInput: hook.ToolInput{FilePath: path, Content: "x"},
+			Cwd:       project,
+		})
+	}
+
+	It("returns the validator name and category", func() {
+		v := newValidator()
+		Expect(v.Name()).To(Equal("validate-file-protected-paths"))
+		Expect(v.Category()).To(Equal(validator.CategoryCPU))
+	})
+
+	Describe("default paths", func() {
+		It("blocks writes to /tmp and /var/tmp with a project-local suggestion", func() {
+			v := newValidator()
+
+			result := write(v, "/tmp/out.txt")
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Reference).To(Equal(validator.RefProtectedPath))
+			Expect(result.Message).To(ContainSubstring("'/tmp/out.txt'"))
+			Expect(result.Message).To(ContainSubstring("protected pattern '/tmp'"))
+			Expect(result.Message).To(ContainSubstring("Use: tmp/out.txt"))
+
+			Expect(write(v, "/var/tmp/cache/data.json").Passed).To(BeFalse())
+			Expect(write(v, "/var/tmpfile").Passed).To(BeTrue())
+		})
+	})
+
+	Describe("patterns", func() {
+		var v *file.ProtectedPathsValidator
+
+		BeforeEach(func() {
+			v = newValidator(
+				config.ProtectedPathConfig{Pattern: "~/.claude/settings.json"},
+				config.ProtectedPathConfig{Pattern: ".git/"},
+				config.ProtectedPathConfig{Pattern: "/etc"},
+				config.ProtectedPathConfig{
+					Pattern: "vendor/",
+					Message: "Vendored dependencies are managed by go mod vendor.",
+				},
+				config.ProtectedPathConfig{Pattern: "deploy/prod/**"},
+			)
+		})
+
+		It("matches home directory paths", func() {
+			Expect(write(v, "~/.claude/settings.json").Passed).To(BeFalse())
+			Expect(write(v, filepath.Join(home, ".claude", "settings.json")).Passed).To(BeFalse())
+			Expect(write(v, "$HOME/.claude/settings.json").Passed).To(BeFalse())
+			Expect(write(v, "~/.claude/settings.local.json").Passed).To(BeTrue())
+		})
+
+		It("matches directory names anywhere in the project", func() {
+			Expect(write(v, ".git/config").Passed).To(BeFalse())
+			Expect(write(v, "libs/sub/.git/HEAD").Passed).To(BeFalse())
+			Expect(write(v, "vendor/github.com/pkg/errors/errors.go").Passed).To(BeFalse())
+			Expect(write(v, ".gitignore").Passed).To(BeTrue())
+			Expect(write(v, "internal/vendoring.go").Passed).To(BeTrue())
+		})
+
+		It("does not match names above the project root", func() {
+			project = filepath.Join(home, "vendor", "app")
+			Expect(os.MkdirAll(filepath.Join(project, ".git"), 0o755)).To(Succeed())
+
+			Expect(write(v, "main.go").Passed).To(BeTrue())
+			Expect(write(v, "vendor/modules.txt").Passed).To(BeFalse())
+		})
+
+		It("does not protect projects below protected directories", func() {
+			v = newValidator(config.ProtectedPathConfig{Pattern: filepath.Join(home, "src")})
+
+			Expect(write(v, "main.go").Passed).To(BeTrue())
+			Expect(write(v, filepath.Join(home, "src", "other", "main.go")).Passed).To(BeFalse())
+		})
+
+		It("matches everything below protected directories", func() {
+			Expect(write(v, "/etc/hosts").Passed).To(BeFalse())
+			Expect(write(v, "/etc/ssh/sshd_config").Passed).To(BeFalse())
+			Expect(write(v, "/etcetera").Passed).To(BeTrue())
+		})
+
+		It("resolves relative patterns from the project root", func() {
+			Expect(write(v, filepath.Join(project, "deploy", "prod", "values.yaml")).Passed).
+				To(BeFalse())
+			Expect(write(v, "deploy/staging/values.yaml").Passed).To(BeTrue())
+		})
+
+		It("uses the custom message", func() {
+			result := write(v, "vendor/modules.txt")
+			Expect(result.Message).To(ContainSubstring("managed by go mod vendor"))
+			Expect(result.Message).NotTo(ContainSubstring("💡"))
+		})
+
+		It("passes paths that cannot be resolved", func() {
+			Expect(write(v, "$PREFIX/etc/hosts").Passed).To(BeTrue())
+		})
+	})
+
+	Describe("regexes", func() {
+		It("matches the absolute path", func() {
+			v := newValidator(config.ProtectedPathConfig{Regex: `/migrations/\d+_[^/]+\.sql$`})
+
+			Expect(write(v, "db/migrations/0001_init.sql").Passed).To(BeFalse())
+			Expect(write(v, "db/migrations/README.md").Passed).To(BeTrue())
+		})
+	})
+
+	Describe("actions", func() {
+		It("warns without blocking", func() {
+			v := newValidator(config.ProtectedPathConfig{
+				Pattern: "go.sum",
+				Action:  config.ProtectedPathActionWarn,
+			})
+
+			result := write(v, "go.sum")
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeFalse())
+			Expect(result.ShouldAsk).To(BeFalse())
+		})
+
+		It("asks the user", func() {
+			v := newValidator(config.ProtectedPathConfig{
+				Pattern: "go.sum",
+				Action:  config.ProtectedPathActionAsk,
+			})
+
+			result := write(v, "go.sum")
+			Expect(result.ShouldAsk).To(BeTrue())
+			Expect(result.Reference).To(Equal(validator.RefProtectedPath))
+		})
+
+		It("applies the first matching entry", func() {
+			v := newValidator(
+				config.ProtectedPathConfig{
+					Pattern: "/etc/hosts",
+					Action:  config.ProtectedPathActionWarn,
+				},
+				config.ProtectedPathConfig{Pattern: "/etc"},
+			)
+
+			Expect(write(v, "/etc/hosts").ShouldBlock).To(BeFalse())
+			Expect(write(v, "/etc/passwd").ShouldBlock).To(BeTrue())
+		})
+	})
+
+	Describe("symlinks", func() {
+		var v *file.ProtectedPathsValidator
+
+		BeforeEach(func() {
+			v = newValidator(config.ProtectedPathConfig{
+				Pattern:        "~/.claude/**",
+				ReplacementDir: "tmp",
+			})
+
+			Expect(os.MkdirAll(filepath.Join(home, ".claude"), 0o755)).To(Succeed())
+			Expect(os.Symlink(filepath.Join(home, ".claude"), filepath.Join(project, "cfg"))).
+				To(Succeed())
+		})
+
+		It("matches the symlink-resolved path of new files", func() {
+			result := write(v, "cfg/settings.json")
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring(
+				"(" + filepath.Join(home, ".claude", "settings.json") + ")",
+			))
+			Expect(result.Message).To(ContainSubstring("Use: tmp/settings.json"))
+		})
+
+		It("matches symlinks to protected files", func() {
+			target := filepath.Join(home, ".claude", "settings.json")
+			Expect(os.WriteFile(target, []byte("{}"), 0o600)).To(Succeed())
+			Expect(os.Symlink(target, filepath.Join(project, "settings.json"))).To(Succeed())
+
+			Expect(write(v, "settings.json").Passed).To(BeFalse())
+		})
+	})
+
+	Describe("Edit and MultiEdit", func() {
+		It("checks the edited file", func() {
+			v := newValidator(config.ProtectedPathConfig{Pattern: "/etc"})
+
+			for _, tool := range []hook.ToolType{hook.ToolTypeEdit, hook.ToolTypeMultiEdit} {
+				result := v.Validate(context.Background(), &hook.Context{
+					EventType: hook.EventTypePreToolUse,
+					ToolName:  tool,
+					ToolInput: hook.ToolInput{FilePath: "/etc/hosts"},
+					Cwd:       project,
+				})
+				Expect(result.Passed).To(BeFalse())
+			}
+		})
+	})
+})
@@ -129,6 +129,16 @@ func (v *PythonValidator) getContent(
 		return &pythonContent{content: content, isFragment: true}, nil
 	}
 
+	// For MultiEdit operations, validate the merged fragment of all edits
+	if isMultiEdit(ctx) {
+		fragment, err := readMultiEditFragment(ctx, v.getContextLines(), log)
+		if err != nil {
+			return nil, err
+		}
+
+		return &pythonContent{content: fragment.Fragment, isFragment: true}, nil
+	}
+
 	// Get content from context or read from file (Write operation)
 	content := ctx.ToolInput.Content
 	if content != "" {
@@ -130,6 +130,16 @@ func (v *RustValidator) getContent(
 		return &rustContent{content: content, isFragment: true}, nil
 	}
 
+	// For MultiEdit operations, validate the merged fragment of all edits
+	if isMultiEdit(ctx) {
+		fragment, err := readMultiEditFragment(ctx, v.getContextLines(), log)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rustContent{content: fragment.Fragment, isFragment: true}, nil
+	}
+
 	// Get content from context or read from file (Write operation)
 	content := ctx.ToolInput.Content
 	if content != "" {
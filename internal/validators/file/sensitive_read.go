@@ -0,0 +1,511 @@
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/internal/templates"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
+	"github.com/smykla-labs/klaudiush/pkg/pathutil"
+)
+
+// defaultSensitivePatterns is used when no sensitive patterns are configured.
+var defaultSensitivePatterns = []string{
+	".env", ".env.*", "*.pem", "*.key", "*.p12", "*.pfx",
+	"id_rsa", "id_dsa", "id_ecdsa", "id_ed25519", ".netrc", ".pgpass", ".git-credentials",
+	"~/.ssh/**", "~/.aws/credentials", "~/.config/gcloud/**", "~/.azure/**",
+	"~/.kube/config", "~/.docker/config.json", "~/.gnupg/**", "~/.npmrc", "~/.pypirc",
+}
+
+// defaultSensitiveAllow is used when no allowed patterns are configured.
+var defaultSensitiveAllow = []string{
+	".env.example", ".env.sample", ".env.template", "*.pub",
+	"~/.ssh/config", "~/.ssh/known_hosts",
+}
+
+// homeVarRegex matches $HOME and ${HOME}.
+var homeVarRegex = regexp.MustCompile(`\$(?:HOME\b|\{HOME\})`)
+
+// Reasons reported for sensitive reads.
+const (
+	reasonSensitiveFile = "is a sensitive file"
+	reasonSensitiveDir  = "contains sensitive files"
+)
+
+// SensitiveReadValidator blocks reads of files holding credentials or keys
+// through the Read, Grep and Glob tools and through Bash commands such as cat,
+// head or grep.
+type SensitiveReadValidator struct {
+	validator.BaseValidator
+	config      *config.SensitiveReadValidatorConfig
+	ruleAdapter *rules.RuleValidatorAdapter
+}
+
+// NewSensitiveReadValidator creates a new SensitiveReadValidator instance.
+func NewSensitiveReadValidator(
+	log logger.Logger,
+	cfg *config.SensitiveReadValidatorConfig,
+	ruleAdapter *rules.RuleValidatorAdapter,
+) *SensitiveReadValidator {
+	return &SensitiveReadValidator{
+		BaseValidator: *validator.NewBaseValidator("validate-file-sensitive-read", log),
+		config:        cfg,
+		ruleAdapter:   ruleAdapter,
+	}
+}
+
+// Validate checks the files read by the tool call against the sensitive patterns.
+func (v *SensitiveReadValidator) Validate(
+	ctx context.Context,
+	hookCtx *hook.Context,
+) *validator.Result {
+	if v.ruleAdapter != nil {
+		if result := v.ruleAdapter.CheckRules(ctx, hookCtx); result != nil {
+			return result
+		}
+	}
+
+	cwd := hookCtx.Cwd
+	if cwd == "" {
+		cwd, _ = os.Getwd()
+	}
+
+	home, _ := os.UserHomeDir()
+
+	m := &sensitiveMatcher{
+		home:     filepath.Clean(home),
+		cwd:      filepath.Clean(cwd),
+		patterns: v.getPatterns(),
+		allow:    v.getAllow(),
+	}
+	m.root = pathutil.FindProjectRoot(m.cwd)
+
+	var violations []templates.SensitiveReadViolation
+
+	switch hookCtx.ToolName {
+	case hook.ToolTypeRead:
+		violations = m.checkFile("Read", hookCtx.GetFilePath())
+	case hook.ToolTypeGrep:
+		violations = m.checkSearch(hookCtx.ToolInput.Path, hookCtx.ToolInput.Glob)
+	case hook.ToolTypeGlob:
+		violations = m.checkGlob(hookCtx.ToolInput.Path, hookCtx.ToolInput.Pattern)
+	case hook.ToolTypeBash:
+		violations = v.checkCommand(m, hookCtx.GetCommand())
+	default:
+		return validator.Pass()
+	}
+
+	if len(violations) == 0 {
+		return validator.Pass()
+	}
+
+	message := templates.MustExecute(
+		templates.SensitiveReadTemplate,
+		templates.SensitiveReadData{Violations: violations},
+	)
+
+	if v.config.GetAction() == config.SensitiveReadActionAsk {
+		return validator.AskWithRef(validator.RefSensitiveRead, message)
+	}
+
+	return validator.FailWithRef(validator.RefSensitiveRead, message)
+}
+
+// checkCommand returns the sensitive reads of a Bash command.
+func (v *SensitiveReadValidator) checkCommand(
+	m *sensitiveMatcher,
+	command string,
+) []templates.SensitiveReadViolation {
+	if command == "" {
+		return nil
+	}
+
+	result, err := v.BashParser().Parse(command)
+	if err != nil {
+		v.Logger().Debug("failed to parse command", "error", err)
+		return nil
+	}
+
+	var violations []templates.SensitiveReadViolation
+
+	for _, read := range result.FileReads {
+		dir := m.cwd
+		if read.WorkingDirectory != "" {
+			resolved, ok := m.resolve(read.WorkingDirectory, m.cwd)
+			if !ok {
+				dir = ""
+			} else {
+				dir = resolved
+			}
+		}
+
+		violations = append(violations, m.checkRead(read, dir)...)
+	}
+
+	return violations
+}
+
+// getPatterns returns the configured sensitive patterns.
+func (v *SensitiveReadValidator) getPatterns() []string {
+	if v.config != nil && len(v.config.Patterns) > 0 {
+		return v.config.Patterns
+	}
+
+	return defaultSensitivePatterns
+}
+
+// getAllow returns the configured allowed patterns.
+func (v *SensitiveReadValidator) getAllow() []string {
+	if v.config != nil && len(v.config.Allow) > 0 {
+		return v.config.Allow
+	}
+
+	return defaultSensitiveAllow
+}
+
+// Category returns the validator category for parallel execution.
+func (*SensitiveReadValidator) Category() validator.ValidatorCategory {
+	return validator.CategoryCPU
+}
+
+// sensitiveMatcher matches paths against sensitive and allowed patterns.
+type sensitiveMatcher struct {
+	home     string
+	cwd      string
+	root     string
+	patterns []string
+	allow    []string
+}
+
+// checkFile checks a single file read by a tool.
+func (m *sensitiveMatcher) checkFile(source, raw string) []templates.SensitiveReadViolation {
+	if raw == "" {
+		return nil
+	}
+
+	resolved, ok := m.resolve(raw, m.cwd)
+	if !ok || !m.isSensitive(resolved) {
+		return nil
+	}
+
+	return []templates.SensitiveReadViolation{{
+		Source: source,
+		Path:   raw,
+		Reason: reasonSensitiveFile,
+	}}
+}
+
+// checkSearch checks a Grep tool search of a file or directory, with an
+// optional file name filter.
+func (m *sensitiveMatcher) checkSearch(raw, glob string) []templates.SensitiveReadViolation {
+	display := raw
+	if raw == "" {
+		raw, display = m.cwd, "."
+	}
+
+	resolved, ok := m.resolve(raw, m.cwd)
+	if !ok {
+		return nil
+	}
+
+	if reason := m.searchReason(resolved, true); reason != "" {
+		return []templates.SensitiveReadViolation{{Source: "Grep", Path: display, Reason: reason}}
+	}
+
+	// A filter such as ".env" or "*.pem" selects sensitive files in the searched tree
+	if glob != "" && m.isSensitiveName(glob) {
+		return []templates.SensitiveReadViolation{{
+			Source: "Grep",
+			Path:   filepath.Join(display, glob),
+			Reason: reasonSensitiveFile,
+		}}
+	}
+
+	return nil
+}
+
+// checkGlob checks a Glob tool pattern, relative to an optional base directory.
+func (m *sensitiveMatcher) checkGlob(raw, pattern string) []templates.SensitiveReadViolation {
+	if pattern == "" {
+		return nil
+	}
+
+	if raw == "" {
+		raw = m.cwd
+	}
+
+	full := pattern
+	if !filepath.IsAbs(pattern) && !strings.HasPrefix(pattern, "~") {
+		full = filepath.Join(raw, pattern)
+	}
+
+	base, _ := doublestar.SplitPattern(filepath.ToSlash(full))
+
+	resolved, ok := m.resolve(base, m.cwd)
+	if ok && m.isSensitive(resolved) {
+		return []templates.SensitiveReadViolation{{
+			Source: "Glob",
+			Path:   pattern,
+			Reason: reasonSensitiveDir,
+		}}
+	}
+
+	if name := filepath.Base(pattern); m.isSensitiveName(name) {
+		return []templates.SensitiveReadViolation{{
+			Source: "Glob",
+			Path:   pattern,
+			Reason: reasonSensitiveFile,
+		}}
+	}
+
+	return nil
+}
+
+// checkRead checks a file read by a Bash command, resolving relative paths
+// against dir. An empty dir means the working directory is unknown. Brace
+// expansions are checked one alternative at a time.
+func (m *sensitiveMatcher) checkRead(
+	read parser.FileRead,
+	dir string,
+) []templates.SensitiveReadViolation {
+	for _, path := range expandBraces(read.Path) {
+		if reason := m.readReason(path, dir, read.Recursive); reason != "" {
+			return []templates.SensitiveReadViolation{{
+				Source: read.Source,
+				Path:   read.Path,
+				Reason: reason,
+			}}
+		}
+	}
+
+	return nil
+}
+
+// readReason returns why a path read by a Bash command is not allowed, or an
+// empty string. Globs fail when they could match a sensitive path.
+func (m *sensitiveMatcher) readReason(path, dir string, recursive bool) string {
+	resolved, ok := m.resolve(path, dir)
+	if !ok {
+		// Fall back to the literal base name, as in "$DIR/.env"
+		base := path[strings.LastIndex(path, "/")+1:]
+		if base == "" || strings.ContainsAny(base, "$`") {
+			return ""
+		}
+
+		if hasGlobMeta(base) && m.mayMatchSensitive(base, false) && !m.allowsGlob(base) ||
+			!hasGlobMeta(base) && m.isSensitiveName(base) {
+			return reasonSensitiveFile
+		}
+
+		return ""
+	}
+
+	if !hasGlobMeta(resolved) {
+		return m.searchReason(resolved, recursive)
+	}
+
+	if m.allowsGlob(resolved) {
+		return ""
+	}
+
+	if m.mayMatchSensitive(resolved, true) {
+		return reasonSensitiveFile
+	}
+
+	if recursive && m.mayMatchSensitive(resolved+"/**", true) {
+		return reasonSensitiveDir
+	}
+
+	return ""
+}
+
+// mayMatchSensitive reports whether a glob could match a path matching a
+// sensitive pattern. Base name patterns are compared with the last segment of
+// the glob, and path patterns with the whole glob when it is absolute.
+func (m *sensitiveMatcher) mayMatchSensitive(glob string, absolute bool) bool {
+	base := glob[strings.LastIndex(glob, "/")+1:]
+
+	for _, pattern := range m.patterns {
+		switch {
+		case !strings.Contains(pattern, "/"):
+			if globsIntersect(base, pattern, true) {
+				return true
+			}
+		case absolute:
+			if globsIntersect(glob, m.expandPattern(pattern), true) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// allowsGlob reports whether every path a glob matches is clearly allowed:
+// the glob is an allowed pattern itself, or its base name ends with the
+// literal suffix of a base name allowed pattern, as "~/.ssh/*.pub" does for
+// "*.pub".
+func (m *sensitiveMatcher) allowsGlob(glob string) bool {
+	base := glob[strings.LastIndex(glob, "/")+1:]
+
+	for _, pattern := range m.allow {
+		if !strings.Contains(pattern, "/") {
+			suffix, ok := strings.CutPrefix(pattern, "*")
+			if pattern == base || ok && !hasGlobMeta(suffix) && strings.HasSuffix(base, suffix) {
+				return true
+			}
+
+			continue
+		}
+
+		if m.expandPattern(pattern) == glob {
+			return true
+		}
+	}
+
+	return false
+}
+
+// searchReason returns why reading a path is not allowed, or an empty string.
+// Recursive searches also fail when a sensitive path lies below the path.
+func (m *sensitiveMatcher) searchReason(resolved string, recursive bool) string {
+	if m.isSensitive(resolved) {
+		return reasonSensitiveFile
+	}
+
+	if recursive && m.containsSensitive(resolved) {
+		return reasonSensitiveDir
+	}
+
+	return ""
+}
+
+// resolve converts a raw path to a cleaned absolute path, expanding ~ and
+// $HOME. Returns false when the path depends on other variables or command
+// substitution, or is relative to an unknown directory.
+func (m *sensitiveMatcher) resolve(raw, dir string) (string, bool) {
+	return resolvePath(raw, dir, m.home)
+}
+
+// isSensitive reports whether a resolved path, or the file it links to,
+// matches a sensitive pattern and no allowed pattern.
+func (m *sensitiveMatcher) isSensitive(resolved string) bool {
+	if m.matchesSensitive(resolved, m.home, m.root) {
+		return true
+	}
+
+	// Symlinks such as notes.txt -> .env read the file they point to
+	target := pathutil.RealPath(resolved)
+
+	return target != resolved &&
+		m.matchesSensitive(target, pathutil.RealPath(m.home), pathutil.RealPath(m.root))
+}
+
+// matchesSensitive reports whether a path matches a sensitive pattern and no
+// allowed pattern, with path patterns expanded from home and root.
+func (m *sensitiveMatcher) matchesSensitive(path, home, root string) bool {
+	return matchAny(m.patterns, path, home, root) && !matchAny(m.allow, path, home, root)
+}
+
+// isSensitiveName reports whether a file name matches a base name sensitive
+// pattern and no base name allowed pattern.
+func (m *sensitiveMatcher) isSensitiveName(name string) bool {
+	matchName := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			if strings.Contains(pattern, "/") {
+				continue
+			}
+
+			if matched, _ := doublestar.Match(pattern, name); matched {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return matchName(m.patterns) && !matchName(m.allow)
+}
+
+// containsSensitive reports whether a path pattern selects files below dir,
+// as "~/.ssh/**" does for the home directory. Base name patterns are not
+// considered, so searching a project is not blocked by its .env files.
+func (m *sensitiveMatcher) containsSensitive(dir string) bool {
+	for _, pattern := range m.patterns {
+		if !strings.Contains(pattern, "/") {
+			continue
+		}
+
+		base, _ := doublestar.SplitPattern(m.expandPattern(pattern))
+		if base != dir && pathutil.IsWithin(dir, base) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchAny reports whether a resolved path matches any of the patterns, with
+// path patterns expanded from home and root. Patterns without a slash match
+// the base name.
+func matchAny(patterns []string, resolved, home, root string) bool {
+	for _, pattern := range patterns {
+		target := resolved
+		if strings.Contains(pattern, "/") {
+			pattern = expandPathPattern(pattern, home, root)
+		} else {
+			target = filepath.Base(resolved)
+		}
+
+		if matched, _ := doublestar.Match(pattern, target); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// expandPattern makes a path pattern absolute: a leading "~/" stands for the
+// home directory and relative patterns are resolved from the project root.
+func (m *sensitiveMatcher) expandPattern(pattern string) string {
+	return expandPathPattern(pattern, m.home, m.root)
+}
+
+// resolvePath converts a raw path to a cleaned absolute path, expanding ~ and
+// $HOME to home. Returns false when the path depends on other variables or
+// command substitution, or is relative to an unknown (empty) dir.
+func resolvePath(raw, dir, home string) (string, bool) {
+	path := homeVarRegex.ReplaceAllLiteralString(raw, home)
+	if strings.ContainsAny(path, "$`") {
+		return "", false
+	}
+
+	return pathutil.Resolve(path, dir, home)
+}
+
+// expandPathPattern makes a path pattern absolute: a leading "~/" stands for
+// home and relative patterns are resolved from root.
+func expandPathPattern(pattern, home, root string) string {
+	if rest, ok := strings.CutPrefix(pattern, "~/"); ok {
+		return home + "/" + rest
+	}
+
+	if !strings.HasPrefix(pattern, "/") {
+		return root + "/" + pattern
+	}
+
+	return pattern
+}
+
+// Ensure SensitiveReadValidator implements validator.Validator
+var _ validator.Validator = (*SensitiveReadValidator)(nil)
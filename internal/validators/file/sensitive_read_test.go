@@ -0,0 +1,479 @@
+package file_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/internal/validators/file"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+var _ = Describe("SensitiveReadValidator", func() {
+	var (
+		v       *file.SensitiveReadValidator
+		cfg     *config.SensitiveReadValidatorConfig
+		home    string
+		project string
+	)
+
+	BeforeEach(func() {
+		home = GinkgoT().TempDir()
+		GinkgoT().Setenv("HOME", home)
+
+		project = filepath.Join(home, "src", "app")
+		Expect(os.MkdirAll(filepath.Join(project, ".git"), 0o755)).To(Succeed())
+
+		cfg = &config.SensitiveReadValidatorConfig{}
+		v = file.NewSensitiveReadValidator(logger.NewNoOpLogger(), cfg, nil)
+	})
+
+	// Helper function to create context with tool input
+	createContext := func(tool hook.ToolType, input hook.ToolInput) *hook.Context {
+		return &hook.Context{
+			EventType: hook.EventTypePreToolUse,
+			ToolName:  tool,
+			ToolInput: input,
+			Cwd:       project,
+		}
+	}
+
+	bash := func(command string) hook.ToolInput {
+		return hook.ToolInput{Command: command}
+	}
+
+	read := func(path string) hook.ToolInput {
+		return hook.ToolInput{FilePath: path}
+	}
+
+	It("returns the validator name and category", func() {
+		Expect(v.Name()).To(Equal("validate-file-sensitive-read"))
+		Expect(v.Category()).To(Equal(validator.CategoryCPU))
+	})
+
+	Describe("Read", func() {
+		It("blocks secret files by base name", func() {
+			ctx := createContext(hook.ToolTypeRead, read(".env"))
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Reference).To(Equal(validator.RefSensitiveRead))
+			Expect(result.Message).To(ContainSubstring("'.env' is a sensitive file"))
+
+			ctx = createContext(hook.ToolTypeRead, read("config/.env.production"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeRead, read("/etc/ssl/private/server.key"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+		})
+
+		It("blocks secret files in the home directory", func() {
+			ctx := createContext(hook.ToolTypeRead, read("~/.aws/credentials"))
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Reference).To(Equal(validator.RefSensitiveRead))
+
+			ctx = createContext(
+				hook.ToolTypeRead,
+				read(filepath.Join(home, ".ssh", "config.d", "work")),
+			)
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeRead, read(filepath.Join(home, ".kube", "config")))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+		})
+
+		It("allows example files and public keys", func() {
+			ctx := createContext(hook.ToolTypeRead, read(".env.example"))
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(hook.ToolTypeRead, read("~/.ssh/id_ed25519.pub"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(hook.ToolTypeRead, read("~/.ssh/known_hosts"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+
+		It("blocks symlinks to sensitive files", func() {
+			Expect(os.WriteFile(filepath.Join(project, ".env"), []byte("KEY=1\n"), 0o600)).
+				To(Succeed())
+			Expect(os.Symlink(".env", filepath.Join(project, "notes.txt"))).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(home, ".ssh"), 0o700)).To(Succeed())
+			Expect(os.Symlink(filepath.Join(home, ".ssh"), filepath.Join(project, "keys"))).
+				To(Succeed())
+
+			ctx := createContext(hook.ToolTypeRead, read("notes.txt"))
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Reference).To(Equal(validator.RefSensitiveRead))
+			Expect(result.Message).To(ContainSubstring("'notes.txt' is a sensitive file"))
+
+			ctx = createContext(hook.ToolTypeRead, read("keys/id_ed25519"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeRead, read("keys/id_ed25519.pub"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(hook.ToolTypeBash, bash("cat notes.txt"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeBash, bash("cd keys && cat id_ed25519"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+		})
+
+		It("allows other files", func() {
+			ctx := createContext(hook.ToolTypeRead, read("main.go"))
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(hook.ToolTypeRead, read("docs/environment.md"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(hook.ToolTypeRead, read(filepath.Join(home, ".config", "app.toml")))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+	})
+
+	Describe("Grep", func() {
+		It("blocks searching sensitive files and directories", func() {
+			ctx := createContext(hook.ToolTypeGrep, hook.ToolInput{Pattern: "TOKEN", Path: ".env"})
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Reference).To(Equal(validator.RefSensitiveRead))
+
+			ctx = createContext(hook.ToolTypeGrep, hook.ToolInput{Pattern: "BEGIN", Path: "~/.ssh"})
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+		})
+
+		It("blocks searching directories containing sensitive paths", func() {
+			ctx := createContext(hook.ToolTypeGrep, hook.ToolInput{Pattern: "secret", Path: home})
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Reference).To(Equal(validator.RefSensitiveRead))
+			Expect(result.Message).To(ContainSubstring("contains sensitive files"))
+		})
+
+		It("blocks filters selecting sensitive files", func() {
+			ctx := createContext(hook.ToolTypeGrep, hook.ToolInput{Pattern: "KEY", Glob: ".env"})
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Reference).To(Equal(validator.RefSensitiveRead))
+
+			ctx = createContext(hook.ToolTypeGrep, hook.ToolInput{Pattern: "KEY", Glob: "*.pem"})
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+		})
+
+		It("allows searching the project", func() {
+			ctx := createContext(hook.ToolTypeGrep, hook.ToolInput{Pattern: "func main"})
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(hook.ToolTypeGrep, hook.ToolInput{Pattern: "KEY", Glob: "*.go"})
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(
+				hook.ToolTypeGrep,
+				hook.ToolInput{Pattern: "KEY", Glob: ".env.example"},
+			)
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+	})
+
+	Describe("Glob", func() {
+		It("blocks patterns selecting sensitive files", func() {
+			ctx := createContext(hook.ToolTypeGlob, hook.ToolInput{Pattern: "**/.env"})
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Reference).To(Equal(validator.RefSensitiveRead))
+
+			ctx = createContext(hook.ToolTypeGlob, hook.ToolInput{Pattern: "~/.ssh/*"})
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("contains sensitive files"))
+
+			ctx = createContext(
+				hook.ToolTypeGlob,
+				hook.ToolInput{Pattern: "*", Path: filepath.Join(home, ".gnupg")},
+			)
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+		})
+
+		It("allows other patterns", func() {
+			ctx := createContext(hook.ToolTypeGlob, hook.ToolInput{Pattern: "**/*.go"})
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(hook.ToolTypeGlob, hook.ToolInput{Pattern: "*", Path: home})
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+	})
+
+	Describe("Bash", func() {
+		It("blocks commands printing sensitive files", func() {
+			ctx := createContext(hook.ToolTypeBash, bash("cat .env"))
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Reference).To(Equal(validator.RefSensitiveRead))
+			Expect(result.Message).To(ContainSubstring("cat: '.env' is a sensitive file"))
+
+			ctx = createContext(hook.ToolTypeBash, bash("head -n 5 ~/.aws/credentials"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeBash, bash("less $HOME/.netrc"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeBash, bash("base64 < certs/server.key"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeBash, bash(`sudo cat "$DIR/id_rsa"`))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeBash, bash(`bash -c "tail -f .env.local"`))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+		})
+
+		It("blocks searches of sensitive files", func() {
+			ctx := createContext(hook.ToolTypeBash, bash("grep API_KEY .env"))
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Reference).To(Equal(validator.RefSensitiveRead))
+
+			ctx = createContext(hook.ToolTypeBash, bash("grep -r password ~"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("contains sensitive files"))
+
+			ctx = createContext(hook.ToolTypeBash, bash("rg token ~/.config"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+		})
+
+		It("resolves paths after cd", func() {
+			ctx := createContext(hook.ToolTypeBash, bash("cd ~ && cat .aws/credentials"))
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Reference).To(Equal(validator.RefSensitiveRead))
+		})
+
+		It("blocks globs that could match sensitive files", func() {
+			ctx := createContext(hook.ToolTypeBash, bash("cat .env*"))
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Reference).To(Equal(validator.RefSensitiveRead))
+			Expect(result.Message).To(ContainSubstring("cat: '.env*' is a sensitive file"))
+
+			ctx = createContext(hook.ToolTypeBash, bash("cat .en?"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeBash, bash("cat ./.e[n]v"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeBash, bash("cat .e{n,x}v"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeBash, bash("cat ~/.ssh/id_*"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeBash, bash("cat ~/.a*/cred*"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeBash, bash(`cat "$DIR"/*.pem`))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeBash, bash("grep -r token ~/.ss*"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+		})
+
+		It("blocks files read by other commands", func() {
+			ctx := createContext(hook.ToolTypeBash, bash("cp .env /tmp/x"))
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Reference).To(Equal(validator.RefSensitiveRead))
+			Expect(result.Message).To(ContainSubstring("cp: '.env'"))
+
+			ctx = createContext(hook.ToolTypeBash, bash("source .env"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeBash, bash(". .env"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeBash, bash("awk 1 .env"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeBash, bash("sed -n p .env"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeBash, bash("sort .env"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeBash, bash("diff .env /dev/null"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeBash, bash("jq . ~/.docker/config.json"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeBash, bash("tar cf - ~/.ssh"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeBash, bash("git show HEAD:.env"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeBash, bash("tar czf out.tgz ~"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("contains sensitive files"))
+		})
+
+		It("allows other reads", func() {
+			ctx := createContext(hook.ToolTypeBash, bash("cat README.md"))
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(hook.ToolTypeBash, bash("cat .env.example"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(hook.ToolTypeBash, bash("grep -r .env ."))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(hook.ToolTypeBash, bash("grep -e .env -r src"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(hook.ToolTypeBash, bash("echo .env"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(hook.ToolTypeBash, bash(`cat "$FILE"`))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(hook.ToolTypeBash, bash("cat *.go docs/*.md"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(hook.ToolTypeBash, bash("cat ~/.ssh/*.pub"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(hook.ToolTypeBash, bash("cp .env.example .env"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(hook.ToolTypeBash, bash("ln -s ../shared/.env.example .env"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(hook.ToolTypeBash, bash("ls ~/.ssh && rm -f .env"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+	})
+
+	Describe("configuration", func() {
+		It("uses configured patterns", func() {
+			cfg.Patterns = []string{"secrets/**", "*.tfvars"}
+
+			ctx := createContext(hook.ToolTypeRead, read("secrets/prod.yaml"))
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Reference).To(Equal(validator.RefSensitiveRead))
+
+			ctx = createContext(hook.ToolTypeRead, read("prod.tfvars"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+
+			ctx = createContext(hook.ToolTypeRead, read(".env"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(hook.ToolTypeRead, read("pkg/secrets/doc.go"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+
+		It("allows configured files", func() {
+			cfg.Allow = []string{"testdata/**"}
+
+			ctx := createContext(hook.ToolTypeRead, read("testdata/tls/server.key"))
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(hook.ToolTypeRead, read("certs/server.key"))
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Reference).To(Equal(validator.RefSensitiveRead))
+		})
+
+		It("asks instead of blocking", func() {
+			cfg.Action = config.SensitiveReadActionAsk
+
+			ctx := createContext(hook.ToolTypeRead, read(".env"))
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeFalse())
+			Expect(result.ShouldAsk).To(BeTrue())
+			Expect(result.Reference).To(Equal(validator.RefSensitiveRead))
+		})
+	})
+})
@@ -134,6 +134,21 @@ func (v *ShellScriptValidator) getContent(
 		return &shellContent{content: content, isFragment: true}, nil
 	}
 
+	// For MultiEdit operations, validate the merged fragment of all edits
+	if isMultiEdit(ctx) {
+		fragment, err := readMultiEditFragment(ctx, v.getContextLines(), log)
+		if err != nil {
+			return nil, err
+		}
+
+		content := fragment.Fragment
+		if fragment.StartLine > 0 {
+			content = v.prependShellDirective(fragment.Content, content, log)
+		}
+
+		return &shellContent{content: content, isFragment: true}, nil
+	}
+
 	// Get content from context or read from file (Write operation)
 	content := ctx.ToolInput.Content
 	if content != "" {
@@ -125,6 +125,16 @@ func (v *TerraformValidator) getContent(ctx *hook.Context) (string, error) {
 		return ctx.ToolInput.Content, nil
 	}
 
+	// For MultiEdit operations, validate the merged fragment of all edits
+	if isMultiEdit(ctx) {
+		fragment, err := readMultiEditFragment(ctx, v.getContextLines(), log)
+		if err != nil {
+			return "", err
+		}
+
+		return fragment.Fragment, nil
+	}
+
 	// For Edit operations in PreToolUse, validate only the changed fragment with context
 	// to avoid forcing users to fix all existing linting issues
 	if ctx.EventType == hook.EventTypePreToolUse && ctx.ToolName == hook.ToolTypeEdit {
@@ -77,7 +77,7 @@ func (v *AddValidator) Validate(ctx context.Context, hookCtx *hook.Context) *val
 	log.Debug("Git root found", "path", gitRoot)
 
 	// Parse the command
-	bashParser := parser.NewBashParser()
+	bashParser := v.BashParser()
 
 	result, err := bashParser.Parse(hookCtx.GetCommand())
 	if err != nil {
@@ -200,8 +200,27 @@ func (v *AddValidator) checkFilesAgainstPatterns(files, patterns []string) []str
 
 // isFileBlocked checks if a file matches any blocked pattern
 func (v *AddValidator) isFileBlocked(file string, patterns []string) bool {
-	log := v.Logger()
+	return matchesBlockedPattern(file, patterns, v.Logger())
+}
+
+// getBlockedPatterns returns the blocked patterns from config, or defaults to ["tmp/*"]
+func (v *AddValidator) getBlockedPatterns() []string {
+	return BlockedPatterns(v.config)
+}
+
+// BlockedPatterns returns the blocked patterns of the add validator config, or
+// the default ["tmp/*"]
+func BlockedPatterns(cfg *config.AddValidatorConfig) []string {
+	if cfg != nil && len(cfg.BlockedPatterns) > 0 {
+		return cfg.BlockedPatterns
+	}
+
+	// Default: block tmp/ files
+	return []string{"tmp/*"}
+}
 
+// matchesBlockedPattern checks if a file matches any blocked pattern
+func matchesBlockedPattern(file string, patterns []string, log logger.Logger) bool {
 	for _, pattern := range patterns {
 		// Try glob pattern match
 		matched, err := filepath.Match(pattern, file)
@@ -227,16 +246,6 @@ func (v *AddValidator) isFileBlocked(file string, patterns []string) bool {
 	return false
 }
 
-// getBlockedPatterns returns the blocked patterns from config, or defaults to ["tmp/*"]
-func (v *AddValidator) getBlockedPatterns() []string {
-	if v.config != nil && len(v.config.BlockedPatterns) > 0 {
-		return v.config.BlockedPatterns
-	}
-
-	// Default: block tmp/ files
-	return []string{"tmp/*"}
-}
-
 // Category returns the validator category for parallel execution.
 // AddValidator uses CategoryGit because it queries git repository state.
 func (*AddValidator) Category() validator.ValidatorCategory {
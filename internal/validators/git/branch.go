@@ -136,7 +136,7 @@ func (v *BranchValidator) Validate(ctx context.Context, hookCtx *hook.Context) *
 		}
 	}
 
-	bashParser := parser.NewBashParser()
+	bashParser := v.BashParser()
 
 	parseResult, err := bashParser.Parse(hookCtx.ToolInput.Command)
 	if err != nil {
@@ -37,6 +37,10 @@ type CommitValidator struct {
 	gitRunner   GitRunner
 	config      *config.CommitValidatorConfig
 	ruleAdapter *rules.RuleValidatorAdapter
+
+	// Optional checks of the staged changes
+	secretsScanner  SecretsScanner
+	blockedPatterns []string
 }
 
 // NewCommitValidator creates a new CommitValidator instance
@@ -45,17 +49,24 @@ func NewCommitValidator(
 	gitRunner GitRunner,
 	cfg *config.CommitValidatorConfig,
 	ruleAdapter *rules.RuleValidatorAdapter,
+	opts ...CommitValidatorOption,
 ) *CommitValidator {
 	if gitRunner == nil {
 		gitRunner = NewGitRunner()
 	}
 
-	return &CommitValidator{
+	v := &CommitValidator{
 		BaseValidator: *validator.NewBaseValidator("validate-commit", log),
 		gitRunner:     gitRunner,
 		config:        cfg,
 		ruleAdapter:   ruleAdapter,
 	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
 }
 
 // Validate checks git commit command and message
@@ -71,7 +82,7 @@ func (v *CommitValidator) Validate(ctx context.Context, hookCtx *hook.Context) *
 	}
 
 	// Parse the command
-	bashParser := parser.NewBashParser()
+	bashParser := v.BashParser()
 
 	result, err := bashParser.Parse(hookCtx.GetCommand())
 	if err != nil {
@@ -101,7 +112,7 @@ func (v *CommitValidator) Validate(ctx context.Context, hookCtx *hook.Context) *
 		}
 
 		// Validate the git commit command
-		return v.validateGitCommit(gitCmd, hasGitAdd)
+		return v.validateGitCommit(gitCmd, hasGitAdd, v.pendingStages(result.Commands, gitCmd))
 	}
 
 	log.Debug("No git commit commands found")
@@ -113,6 +124,7 @@ func (v *CommitValidator) Validate(ctx context.Context, hookCtx *hook.Context) *
 func (v *CommitValidator) validateGitCommit(
 	gitCmd *parser.GitCommand,
 	hasGitAdd bool,
+	pending []pendingStage,
 ) *validator.Result {
 	log := v.Logger()
 
@@ -128,6 +140,11 @@ func (v *CommitValidator) validateGitCommit(
 		}
 	}
 
+	// Check staged files against blocked patterns and added lines for secrets
+	if res := v.checkStagedChanges(pending); !res.Passed {
+		return res
+	}
+
 	// Extract and validate commit message (if enabled)
 	if !v.isMessageValidationEnabled() {
 		log.Debug("Commit message validation is disabled")
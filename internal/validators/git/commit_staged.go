@@ -0,0 +1,324 @@
+package git
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	gitpkg "github.com/smykla-labs/klaudiush/internal/git"
+	"github.com/smykla-labs/klaudiush/internal/templates"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
+)
+
+// SecretFinding is a secret found in the content of a file
+type SecretFinding struct {
+	// Line is the line of the secret in the scanned content (1-indexed)
+	Line int
+
+	// Name identifies the kind of secret, e.g. "aws-access-key-id"
+	Name string
+
+	// Description explains what kind of secret was found
+	Description string
+
+	// Reference is the error reference of the kind of secret
+	Reference validator.Reference
+}
+
+// SecretsScanner scans the files of a repository for secrets
+type SecretsScanner interface {
+	// ScanRepoFile returns the findings in content of file, a slash-separated
+	// path relative to the repository root
+	ScanRepoFile(root, file, content string) []SecretFinding
+
+	// ShouldBlock returns whether findings should block the operation
+	ShouldBlock() bool
+
+	// MaxFileSize returns the size in bytes above which content is not scanned
+	MaxFileSize() int64
+}
+
+// CommitValidatorOption configures optional checks of a CommitValidator
+type CommitValidatorOption func(*CommitValidator)
+
+// WithSecretsScanner scans the lines added by the staged changes for secrets
+func WithSecretsScanner(scanner SecretsScanner) CommitValidatorOption {
+	return func(v *CommitValidator) {
+		v.secretsScanner = scanner
+	}
+}
+
+// WithBlockedPatterns blocks commits of staged files matching the patterns,
+// which use the syntax of the add validator's blocked patterns
+func WithBlockedPatterns(patterns []string) CommitValidatorOption {
+	return func(v *CommitValidator) {
+		v.blockedPatterns = patterns
+	}
+}
+
+// addedBlock is a run of consecutive lines added to a file
+type addedBlock struct {
+	start   int
+	content string
+}
+
+// pendingStage is a set of worktree changes the command stages right before
+// committing, which are not in the staging area yet when the hook runs, as in
+// "git commit -a" or "git add -A && git commit"
+type pendingStage struct {
+	// pathspecs limit the changes, relative to the repository root. No
+	// pathspecs stage all changes.
+	pathspecs []string
+
+	// untracked stages untracked files too
+	untracked bool
+}
+
+// includes reports whether the stage includes the worktree change
+func (s pendingStage) includes(change gitpkg.StagedChange) bool {
+	if change.Untracked && !s.untracked {
+		return false
+	}
+
+	return len(filterPathspecs([]string{change.Path}, s.pathspecs)) > 0
+}
+
+// checkStagedChanges checks the staged diff, and the worktree changes the
+// command stages itself, for files matching blocked patterns and for secrets
+// in the added lines. Files staged by "git add -A" or by other tools are only
+// visible here, not to the add validator.
+func (v *CommitValidator) checkStagedChanges(pending []pendingStage) *validator.Result {
+	if !v.shouldCheckStagedChanges() {
+		return validator.Pass()
+	}
+
+	if v.secretsScanner == nil && len(v.blockedPatterns) == 0 {
+		return validator.Pass()
+	}
+
+	if !v.gitRunner.IsInRepo() {
+		v.Logger().Debug("Not in git repository, skipping staged changes check")
+		return validator.Pass()
+	}
+
+	changes, err := v.gitRunner.GetStagedChanges()
+	if err != nil {
+		v.Logger().Debug("Failed to get staged changes", "error", err)
+		return validator.Pass() // Don't block if we can't check
+	}
+
+	if len(pending) > 0 {
+		worktree, err := v.gitRunner.GetWorktreeChanges(v.maxScanSize())
+		if err != nil {
+			v.Logger().Debug("Failed to get worktree changes", "error", err)
+			return validator.Pass() // Don't block if we can't check
+		}
+
+		// Lines already staged and lines added on top of them are separate
+		// changes of the same file, as their line numbers differ
+		for _, change := range worktree {
+			if slices.ContainsFunc(pending, func(s pendingStage) bool {
+				return s.includes(change)
+			}) {
+				changes = append(changes, change)
+			}
+		}
+
+		slices.SortStableFunc(changes, func(a, b gitpkg.StagedChange) int {
+			return strings.Compare(a.Path, b.Path)
+		})
+	}
+
+	if res := v.checkStagedBlockedFiles(changes); !res.Passed {
+		return res
+	}
+
+	return v.checkStagedSecrets(changes)
+}
+
+// maxScanSize returns the size above which the lines of files are not read,
+// zero without a secrets scanner
+func (v *CommitValidator) maxScanSize() int64 {
+	if v.secretsScanner == nil {
+		return 0
+	}
+
+	return v.secretsScanner.MaxFileSize()
+}
+
+// pendingStages returns the worktree changes staged by git add commands of
+// the chain and by the commit itself, with -a or with paths
+func (v *CommitValidator) pendingStages(
+	commands []parser.Command,
+	commitCmd *parser.GitCommand,
+) []pendingStage {
+	var stages []pendingStage
+
+	for _, cmd := range commands {
+		if cmd.Name != gitCommand {
+			continue
+		}
+
+		gitCmd, err := parser.ParseGitCommand(cmd)
+		if err != nil || gitCmd.Subcommand != addSubcommand {
+			continue
+		}
+
+		update := gitCmd.HasFlag("-u") || gitCmd.HasFlag("--update")
+		all := gitCmd.HasFlag("-A") || gitCmd.HasFlag("--all")
+
+		// "git add" without paths only stages anything with -A or -u
+		if len(gitCmd.Args) == 0 && !all && !update {
+			continue
+		}
+
+		stages = append(stages, pendingStage{
+			pathspecs: rootPathspecs(v.gitRunner, gitCmd.GetWorkingDirectory(), gitCmd.Args),
+			untracked: !update,
+		})
+	}
+
+	switch {
+	case commitCmd.HasFlag("-a") || commitCmd.HasFlag("-A") || commitCmd.HasFlag("--all"):
+		stages = append(stages, pendingStage{})
+	case len(commitCmd.Args) > 0:
+		// "git commit <paths>" commits the tracked files as they are in the worktree
+		pathspecs := rootPathspecs(v.gitRunner, commitCmd.GetWorkingDirectory(), commitCmd.Args)
+		stages = append(stages, pendingStage{pathspecs: pathspecs})
+	}
+
+	return stages
+}
+
+// checkStagedBlockedFiles fails when staged files match the blocked patterns
+func (v *CommitValidator) checkStagedBlockedFiles(changes []gitpkg.StagedChange) *validator.Result {
+	if len(v.blockedPatterns) == 0 {
+		return validator.Pass()
+	}
+
+	var blocked []string
+
+	for _, change := range changes {
+		if matchesBlockedPattern(change.Path, v.blockedPatterns, v.Logger()) {
+			blocked = append(blocked, change.Path)
+		}
+	}
+
+	// Files staged and changed again in the worktree are listed twice
+	blocked = slices.Compact(blocked)
+
+	if len(blocked) == 0 {
+		return validator.Pass()
+	}
+
+	message := templates.MustExecute(
+		templates.GitCommitBlockedFilesTemplate,
+		templates.GitCommitBlockedFilesData{
+			Files: blocked,
+		},
+	)
+
+	return validator.FailWithRef(
+		validator.RefGitBlockedFiles,
+		"Staged files match blocked patterns: "+strings.Join(blocked, ", "),
+	).AddDetail("help", message)
+}
+
+// checkStagedSecrets reports secrets in the lines added by the staged changes
+func (v *CommitValidator) checkStagedSecrets(changes []gitpkg.StagedChange) *validator.Result {
+	if v.secretsScanner == nil {
+		return validator.Pass()
+	}
+
+	root, err := v.gitRunner.GetRepoRoot()
+	if err != nil {
+		v.Logger().Debug("Failed to get git root", "error", err)
+	}
+
+	var (
+		messages []string
+		ref      validator.Reference
+	)
+
+	maxSize := v.secretsScanner.MaxFileSize()
+
+	for _, change := range changes {
+		for _, block := range addedBlocks(change.Added) {
+			if int64(len(block.content)) > maxSize {
+				v.Logger().Debug("added lines exceed max file size", "file", change.Path)
+				continue
+			}
+
+			for _, finding := range v.secretsScanner.ScanRepoFile(root, change.Path, block.content) {
+				if ref == "" {
+					ref = finding.Reference
+				}
+
+				messages = append(messages, fmt.Sprintf(
+					"%s:%d: %s (%s)",
+					change.Path,
+					block.start+finding.Line-1,
+					finding.Description,
+					finding.Name,
+				))
+			}
+		}
+	}
+
+	if len(messages) == 0 {
+		return validator.Pass()
+	}
+
+	message := fmt.Sprintf(
+		"Potential secrets in staged changes (%d finding(s)):\n%s",
+		len(messages),
+		strings.Join(messages, "\n"),
+	)
+	help := templates.MustExecute(templates.GitCommitStagedSecretsTemplate, nil)
+
+	if v.secretsScanner.ShouldBlock() {
+		return validator.FailWithRef(ref, message).AddDetail("help", help)
+	}
+
+	return validator.WarnWithRef(ref, message).AddDetail("help", help)
+}
+
+// addedBlocks groups added lines into runs of consecutive lines, so secrets
+// spanning several lines, such as private keys, are detected
+func addedBlocks(lines []gitpkg.DiffLine) []addedBlock {
+	var (
+		blocks  []addedBlock
+		content []string
+		start   int
+	)
+
+	for i, line := range lines {
+		if i > 0 && line.Number != lines[i-1].Number+1 {
+			blocks = append(blocks, addedBlock{start: start, content: strings.Join(content, "\n")})
+			content = nil
+		}
+
+		if len(content) == 0 {
+			start = line.Number
+		}
+
+		content = append(content, line.Text)
+	}
+
+	if len(content) > 0 {
+		blocks = append(blocks, addedBlock{start: start, content: strings.Join(content, "\n")})
+	}
+
+	return blocks
+}
+
+// shouldCheckStagedChanges returns whether the staged changes check is enabled
+func (v *CommitValidator) shouldCheckStagedChanges() bool {
+	if v.config != nil && v.config.CheckStagedChanges != nil {
+		return *v.config.CheckStagedChanges
+	}
+
+	// Default: check staged changes
+	return true
+}
@@ -0,0 +1,542 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/internal/templates"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
+)
+
+const (
+	// maxListedItems is the maximum number of files, commits or stashes listed
+	// in the error message.
+	maxListedItems = 10
+
+	// pathspecSeparator separates revisions from paths in git commands.
+	pathspecSeparator = "--"
+
+	latestStash = "stash@{0}"
+)
+
+// DestructiveSubcommands are the git subcommands that can discard local work.
+var DestructiveSubcommands = []string{
+	"reset", "clean", "checkout", "restore", "branch", "stash", "push", "tag",
+}
+
+// destructiveLoss is the work a git operation would discard.
+type destructiveLoss struct {
+	files   []string
+	commits []string
+	stashes []string
+}
+
+// isEmpty returns true if nothing would be lost.
+func (l destructiveLoss) isEmpty() bool {
+	return len(l.files) == 0 && len(l.commits) == 0 && len(l.stashes) == 0
+}
+
+// DestructiveValidator blocks git operations that would discard uncommitted
+// changes, untracked files, unmerged commits or stash entries. Operations that
+// have nothing to lose pass.
+type DestructiveValidator struct {
+	validator.BaseValidator
+	gitRunner   GitRunner
+	config      *config.DestructiveValidatorConfig
+	ruleAdapter *rules.RuleValidatorAdapter
+}
+
+// NewDestructiveValidator creates a new DestructiveValidator instance
+func NewDestructiveValidator(
+	log logger.Logger,
+	gitRunner GitRunner,
+	cfg *config.DestructiveValidatorConfig,
+	ruleAdapter *rules.RuleValidatorAdapter,
+) *DestructiveValidator {
+	if gitRunner == nil {
+		gitRunner = NewGitRunner()
+	}
+
+	return &DestructiveValidator{
+		BaseValidator: *validator.NewBaseValidator("validate-git-destructive", log),
+		gitRunner:     gitRunner,
+		config:        cfg,
+		ruleAdapter:   ruleAdapter,
+	}
+}
+
+// Validate checks git commands that can discard local work
+func (v *DestructiveValidator) Validate(
+	ctx context.Context,
+	hookCtx *hook.Context,
+) *validator.Result {
+	log := v.Logger()
+
+	// Check rules first if rule adapter is configured
+	if v.ruleAdapter != nil {
+		if result := v.ruleAdapter.CheckRules(ctx, hookCtx); result != nil {
+			return result
+		}
+	}
+
+	command := hookCtx.GetCommand()
+	if command == "" {
+		return validator.Pass()
+	}
+
+	parseResult, err := v.BashParser().Parse(command)
+	if err != nil {
+		log.Debug("failed to parse command", "error", err)
+		return validator.Pass()
+	}
+
+	for _, cmd := range parseResult.Commands {
+		if cmd.Name != gitCommand || len(cmd.Args) == 0 {
+			continue
+		}
+
+		gitCmd, err := parser.ParseGitCommand(cmd)
+		if err != nil {
+			log.Debug("failed to parse git command", "error", err)
+			continue
+		}
+
+		if !slices.Contains(DestructiveSubcommands, gitCmd.Subcommand) {
+			continue
+		}
+
+		if result := v.validateGitCommand(cmd, gitCmd); !result.Passed {
+			return result
+		}
+	}
+
+	return validator.Pass()
+}
+
+// validateGitCommand checks whether a single git command would lose work
+func (v *DestructiveValidator) validateGitCommand(
+	cmd parser.Command,
+	gitCmd *parser.GitCommand,
+) *validator.Result {
+	runner := v.getRunnerForCommand(gitCmd)
+
+	if !runner.IsInRepo() {
+		v.Logger().Debug("not in a git repository, skipping validation")
+		return validator.Pass()
+	}
+
+	var (
+		operation string
+		loss      destructiveLoss
+	)
+
+	switch gitCmd.Subcommand {
+	case "reset":
+		operation, loss = v.checkReset(gitCmd, runner)
+	case "clean":
+		operation, loss = v.checkClean(gitCmd, runner)
+	case "checkout":
+		operation, loss = v.checkCheckout(cmd, gitCmd, runner)
+	case "restore":
+		operation, loss = v.checkRestore(gitCmd, runner)
+	case "branch":
+		operation, loss = v.checkBranchDelete(gitCmd, runner)
+	case "stash":
+		operation, loss = v.checkStash(gitCmd, runner)
+	case "push":
+		operation, loss = v.checkPushDelete(gitCmd, runner)
+	case "tag":
+		operation, loss = v.checkTagDelete(gitCmd, runner)
+	}
+
+	if loss.isEmpty() {
+		return validator.Pass()
+	}
+
+	return validator.FailWithRef(
+		validator.RefGitDestructive,
+		templates.MustExecute(
+			templates.GitDestructiveTemplate,
+			templates.GitDestructiveData{
+				Operation: operation,
+				Files:     limitItems(loss.files),
+				Commits:   limitItems(loss.commits),
+				Stashes:   limitItems(loss.stashes),
+			},
+		),
+	)
+}
+
+// checkReset checks git reset --hard, which discards staged and unstaged changes
+func (v *DestructiveValidator) checkReset(
+	gitCmd *parser.GitCommand,
+	runner GitRunner,
+) (string, destructiveLoss) {
+	if !gitCmd.HasFlag("--hard") {
+		return "", destructiveLoss{}
+	}
+
+	return "git reset --hard", destructiveLoss{files: v.changedFiles(runner, nil, true)}
+}
+
+// checkClean checks git clean -f, which removes untracked files, and with -x
+// or -X ignored files such as .env as well
+func (v *DestructiveValidator) checkClean(
+	gitCmd *parser.GitCommand,
+	runner GitRunner,
+) (string, destructiveLoss) {
+	if !hasAnyFlag(gitCmd, []string{"-f", "--force"}) ||
+		hasAnyFlag(gitCmd, []string{"-n", "--dry-run", "-i", "--interactive"}) {
+		return "", destructiveLoss{}
+	}
+
+	var files []string
+
+	// -X removes only ignored files
+	if !gitCmd.HasFlag("-X") {
+		untracked, err := runner.GetUntrackedFiles()
+		if err != nil {
+			v.Logger().Debug("failed to get untracked files", "error", err)
+			return "", destructiveLoss{}
+		}
+
+		files = untracked
+	}
+
+	if hasAnyFlag(gitCmd, []string{"-x", "-X"}) {
+		ignored, err := runner.GetIgnoredFiles()
+		if err != nil {
+			v.Logger().Debug("failed to get ignored files", "error", err)
+			return "", destructiveLoss{}
+		}
+
+		files = append(slices.Clone(files), ignored...)
+	}
+
+	// Without paths, git clean only cleans the directory it runs in
+	pathspecs := gitCmd.Args
+	if len(pathspecs) == 0 {
+		pathspecs = []string{"."}
+	}
+
+	pathspecs = rootPathspecs(runner, gitCmd.GetWorkingDirectory(), pathspecs)
+
+	return "git clean", destructiveLoss{files: filterPathspecs(files, pathspecs)}
+}
+
+// checkCheckout checks git checkout with paths, which discards unstaged changes
+// (and staged ones when restoring from a revision), and git checkout --force
+func (v *DestructiveValidator) checkCheckout(
+	cmd parser.Command,
+	gitCmd *parser.GitCommand,
+	runner GitRunner,
+) (string, destructiveLoss) {
+	revisions, pathspecs, hasSeparator := splitPathspecs(cmd, gitCmd)
+
+	if !hasSeparator {
+		if !slices.Contains(gitCmd.Args, ".") {
+			if hasAnyFlag(gitCmd, []string{"-f", "--force"}) {
+				return "git checkout --force",
+					destructiveLoss{files: v.changedFiles(runner, nil, true)}
+			}
+
+			return "", destructiveLoss{}
+		}
+
+		// git checkout [<revision>] . treats every argument after the first as a path
+		pathspecs = gitCmd.Args
+		if gitCmd.Args[0] != "." {
+			revisions, pathspecs = gitCmd.Args[:1], gitCmd.Args[1:]
+		}
+	}
+
+	if len(pathspecs) == 0 {
+		return "", destructiveLoss{}
+	}
+
+	operation := "git checkout -- " + strings.Join(pathspecs, " ")
+	if len(revisions) > 0 {
+		operation = "git checkout " + strings.Join(revisions, " ") + " -- " +
+			strings.Join(pathspecs, " ")
+	}
+
+	pathspecs = rootPathspecs(runner, gitCmd.GetWorkingDirectory(), pathspecs)
+
+	return operation, destructiveLoss{
+		files: v.changedFiles(runner, pathspecs, len(revisions) > 0),
+	}
+}
+
+// checkRestore checks git restore, which discards unstaged changes (and staged
+// ones with --staged --worktree)
+func (v *DestructiveValidator) checkRestore(
+	gitCmd *parser.GitCommand,
+	runner GitRunner,
+) (string, destructiveLoss) {
+	staged := hasAnyFlag(gitCmd, []string{"-S", "--staged"})
+	worktree := hasAnyFlag(gitCmd, []string{"-W", "--worktree"}) || !staged
+
+	if !worktree || len(gitCmd.Args) == 0 {
+		return "", destructiveLoss{}
+	}
+
+	pathspecs := rootPathspecs(runner, gitCmd.GetWorkingDirectory(), gitCmd.Args)
+
+	return "git restore " + strings.Join(gitCmd.Args, " "),
+		destructiveLoss{files: v.changedFiles(runner, pathspecs, staged)}
+}
+
+// checkBranchDelete checks git branch -D, which deletes unmerged branches
+func (v *DestructiveValidator) checkBranchDelete(
+	gitCmd *parser.GitCommand,
+	runner GitRunner,
+) (string, destructiveLoss) {
+	forceDelete := gitCmd.HasFlag("-D") ||
+		(hasAnyFlag(gitCmd, []string{"-d", "--delete"}) &&
+			hasAnyFlag(gitCmd, []string{"-f", "--force"}))
+	if !forceDelete || len(gitCmd.Args) == 0 {
+		return "", destructiveLoss{}
+	}
+
+	refPrefix := refsHeadsPrefix
+	if hasAnyFlag(gitCmd, []string{"-r", "--remotes"}) {
+		refPrefix = "refs/remotes/"
+	}
+
+	refs := make([]string, 0, len(gitCmd.Args))
+	for _, branch := range gitCmd.Args {
+		refs = append(refs, refPrefix+branch)
+	}
+
+	return "git branch -D " + strings.Join(gitCmd.Args, " "),
+		destructiveLoss{commits: v.unmergedCommits(runner, refs)}
+}
+
+// checkStash checks git stash drop and git stash clear
+func (v *DestructiveValidator) checkStash(
+	gitCmd *parser.GitCommand,
+	runner GitRunner,
+) (string, destructiveLoss) {
+	if len(gitCmd.Args) == 0 {
+		return "", destructiveLoss{}
+	}
+
+	action := gitCmd.Args[0]
+	if action != "drop" && action != "clear" {
+		return "", destructiveLoss{}
+	}
+
+	stashes, err := runner.GetStashes()
+	if err != nil {
+		v.Logger().Debug("failed to get stashes", "error", err)
+		return "", destructiveLoss{}
+	}
+
+	if action == "clear" {
+		return "git stash clear", destructiveLoss{stashes: stashes}
+	}
+
+	target := latestStash
+	if len(gitCmd.Args) > 1 {
+		target = normalizeStashRef(gitCmd.Args[1])
+	}
+
+	var dropped []string
+
+	for _, stash := range stashes {
+		if strings.HasPrefix(stash, target+":") {
+			dropped = append(dropped, stash)
+		}
+	}
+
+	return "git stash drop " + target, destructiveLoss{stashes: dropped}
+}
+
+// checkPushDelete checks git push --delete and git push <remote> :<branch>,
+// which delete remote branches
+func (v *DestructiveValidator) checkPushDelete(
+	gitCmd *parser.GitCommand,
+	runner GitRunner,
+) (string, destructiveLoss) {
+	if len(gitCmd.Args) < 2 { //nolint:mnd // remote and at least one branch
+		return "", destructiveLoss{}
+	}
+
+	remote := gitCmd.Args[0]
+	deleteFlag := hasAnyFlag(gitCmd, []string{"-d", "--delete"})
+
+	var branches []string
+
+	for _, refspec := range gitCmd.Args[1:] {
+		branch := refspec
+		if !deleteFlag {
+			var isDelete bool
+
+			branch, isDelete = strings.CutPrefix(refspec, ":")
+			if !isDelete {
+				continue
+			}
+		}
+
+		branch = strings.TrimPrefix(branch, refsHeadsPrefix)
+		if branch == "" || strings.HasPrefix(branch, refsPrefix) {
+			continue
+		}
+
+		branches = append(branches, branch)
+	}
+
+	if len(branches) == 0 {
+		return "", destructiveLoss{}
+	}
+
+	refs := make([]string, 0, len(branches))
+	for _, branch := range branches {
+		refs = append(refs, "refs/remotes/"+remote+"/"+branch)
+	}
+
+	return "git push " + remote + " --delete " + strings.Join(branches, " "),
+		destructiveLoss{commits: v.unmergedCommits(runner, refs)}
+}
+
+// checkTagDelete checks git tag -d, which deletes tags
+func (v *DestructiveValidator) checkTagDelete(
+	gitCmd *parser.GitCommand,
+	runner GitRunner,
+) (string, destructiveLoss) {
+	if !hasAnyFlag(gitCmd, []string{"-d", "--delete"}) || len(gitCmd.Args) == 0 {
+		return "", destructiveLoss{}
+	}
+
+	refs := make([]string, 0, len(gitCmd.Args))
+	for _, tag := range gitCmd.Args {
+		refs = append(refs, "refs/tags/"+tag)
+	}
+
+	return "git tag -d " + strings.Join(gitCmd.Args, " "),
+		destructiveLoss{commits: v.unmergedCommits(runner, refs)}
+}
+
+// changedFiles returns the modified files matching the pathspecs, relative to
+// the repository root, including staged files if requested. Errors are logged
+// and treated as no changes.
+func (v *DestructiveValidator) changedFiles(
+	runner GitRunner,
+	pathspecs []string,
+	includeStaged bool,
+) []string {
+	files, err := runner.GetModifiedFiles()
+	if err != nil {
+		v.Logger().Debug("failed to get modified files", "error", err)
+		return nil
+	}
+
+	if includeStaged {
+		staged, err := runner.GetStagedFiles()
+		if err != nil {
+			v.Logger().Debug("failed to get staged files", "error", err)
+			return nil
+		}
+
+		files = append(slices.Clone(files), staged...)
+	}
+
+	slices.Sort(files)
+
+	return filterPathspecs(slices.Compact(files), pathspecs)
+}
+
+// unmergedCommits returns the commits only reachable from the given refs.
+// Refs that cannot be resolved are skipped, as git will refuse to delete them.
+func (v *DestructiveValidator) unmergedCommits(runner GitRunner, refs []string) []string {
+	var commits []string
+
+	for _, ref := range refs {
+		refCommits, err := runner.GetUnmergedCommits(ref)
+		if err != nil {
+			v.Logger().Debug("failed to get unmerged commits", "ref", ref, "error", err)
+			continue
+		}
+
+		commits = append(commits, refCommits...)
+	}
+
+	return commits
+}
+
+// getRunnerForCommand returns the appropriate git runner for the command.
+// If the command specifies a working directory with -C, creates a runner for that path.
+// Otherwise, returns the default cached runner.
+//
+//nolint:ireturn // Returns interface for flexibility between cached and path-specific runners
+func (v *DestructiveValidator) getRunnerForCommand(gitCmd *parser.GitCommand) GitRunner {
+	workDir := gitCmd.GetWorkingDirectory()
+	if workDir != "" {
+		v.Logger().Debug("using path-specific runner", "path", workDir)
+		return NewGitRunnerForPath(workDir)
+	}
+
+	return v.gitRunner
+}
+
+// Category returns the validator category for parallel execution.
+// DestructiveValidator uses CategoryGit because it queries working tree and ref state.
+func (*DestructiveValidator) Category() validator.ValidatorCategory {
+	return validator.CategoryGit
+}
+
+// splitPathspecs splits the arguments of a git command at "--" into revisions
+// and pathspecs. Returns false if the command has no "--" separator.
+func splitPathspecs(cmd parser.Command, gitCmd *parser.GitCommand) ([]string, []string, bool) {
+	subcommandIdx := slices.Index(cmd.Args, gitCmd.Subcommand)
+	if subcommandIdx < 0 {
+		return nil, nil, false
+	}
+
+	args := cmd.Args[subcommandIdx+1:]
+
+	separatorIdx := slices.Index(args, pathspecSeparator)
+	if separatorIdx < 0 {
+		return nil, nil, false
+	}
+
+	var revisions []string
+
+	for _, arg := range args[:separatorIdx] {
+		if !strings.HasPrefix(arg, "-") {
+			revisions = append(revisions, arg)
+		}
+	}
+
+	return revisions, args[separatorIdx+1:], true
+}
+
+// normalizeStashRef converts "N" to "stash@{N}"
+func normalizeStashRef(ref string) string {
+	if ref != "" && strings.Trim(ref, "0123456789") == "" {
+		return fmt.Sprintf("stash@{%s}", ref)
+	}
+
+	return ref
+}
+
+// limitItems caps a list for display, noting how many items were left out
+func limitItems(items []string) []string {
+	if len(items) <= maxListedItems {
+		return items
+	}
+
+	limited := slices.Clone(items[:maxListedItems])
+
+	return append(limited, fmt.Sprintf("... and %d more", len(items)-maxListedItems))
+}
+
+// Ensure DestructiveValidator implements validator.Validator
+var _ validator.Validator = (*DestructiveValidator)(nil)
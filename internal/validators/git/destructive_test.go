@@ -0,0 +1,507 @@
+package git_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	gitpkg "github.com/smykla-labs/klaudiush/internal/git"
+	validatorpkg "github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/internal/validators/git"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+var _ = Describe("DestructiveValidator", func() {
+	var (
+		validator *git.DestructiveValidator
+		fakeGit   *gitpkg.FakeRunner
+	)
+
+	BeforeEach(func() {
+		fakeGit = gitpkg.NewFakeRunner()
+		validator = git.NewDestructiveValidator(logger.NewNoOpLogger(), fakeGit, nil, nil)
+	})
+
+	// Helper function to create context with command
+	createContext := func(command string) *hook.Context {
+		return &hook.Context{
+			EventType: hook.EventTypePreToolUse,
+			ToolName:  hook.ToolTypeBash,
+			ToolInput: hook.ToolInput{
+				Command: command,
+			},
+		}
+	}
+
+	Describe("Name", func() {
+		It("returns the validator name", func() {
+			Expect(validator.Name()).To(Equal("validate-git-destructive"))
+		})
+	})
+
+	Describe("Category", func() {
+		It("returns CategoryGit", func() {
+			Expect(validator.Category()).To(Equal(validatorpkg.CategoryGit))
+		})
+	})
+
+	Context("when not in a git repository", func() {
+		It("passes", func() {
+			fakeGit.InRepo = false
+			fakeGit.ModifiedFiles = []string{"main.go"}
+
+			ctx := createContext("git reset --hard")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+	})
+
+	Describe("git reset --hard", func() {
+		It("passes with a clean working tree", func() {
+			ctx := createContext("git reset --hard HEAD~1")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+
+		It("blocks and names staged and modified files", func() {
+			fakeGit.StagedFiles = []string{"api.go"}
+			fakeGit.ModifiedFiles = []string{"main.go", "api.go"}
+
+			ctx := createContext("git reset --hard")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validatorpkg.RefGitDestructive))
+			Expect(result.Message).To(ContainSubstring("'git reset --hard'"))
+			Expect(result.Message).To(ContainSubstring("  - api.go"))
+			Expect(result.Message).To(ContainSubstring("  - main.go"))
+		})
+
+		It("passes for soft and mixed resets", func() {
+			fakeGit.ModifiedFiles = []string{"main.go"}
+
+			ctx := createContext("git reset --soft HEAD~1")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext("git reset HEAD~1")
+			result = validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+
+		It("lists at most ten files", func() {
+			for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l"} {
+				fakeGit.ModifiedFiles = append(fakeGit.ModifiedFiles, name+".go")
+			}
+
+			ctx := createContext("git reset --hard")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("  - j.go"))
+			Expect(result.Message).To(ContainSubstring("... and 2 more"))
+			Expect(result.Message).NotTo(ContainSubstring("k.go"))
+		})
+	})
+
+	Describe("git clean", func() {
+		BeforeEach(func() {
+			fakeGit.UntrackedFiles = []string{"notes.md", "build/out.bin"}
+		})
+
+		It("blocks and names untracked files", func() {
+			ctx := createContext("git clean -fdx")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validatorpkg.RefGitDestructive))
+			Expect(result.Message).To(ContainSubstring("'git clean'"))
+			Expect(result.Message).To(ContainSubstring("notes.md"))
+			Expect(result.Message).To(ContainSubstring("build/out.bin"))
+		})
+
+		It("only names files matching the pathspecs", func() {
+			ctx := createContext("git clean -fd build/")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("build/out.bin"))
+			Expect(result.Message).NotTo(ContainSubstring("notes.md"))
+		})
+
+		It("passes for dry runs", func() {
+			for _, command := range []string{"git clean -n", "git clean -fdn", "git clean -fXn"} {
+				ctx := createContext(command)
+				result := validator.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeTrue(), command)
+			}
+		})
+
+		It("names ignored files removed with -x", func() {
+			fakeGit.IgnoredFiles = []string{".env", "node_modules/"}
+
+			ctx := createContext("git clean -fdx")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("notes.md"))
+			Expect(result.Message).To(ContainSubstring(".env"))
+			Expect(result.Message).To(ContainSubstring("node_modules/"))
+		})
+
+		It("names only ignored files removed with -X", func() {
+			fakeGit.IgnoredFiles = []string{".env"}
+
+			ctx := createContext("git clean -fX")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring(".env"))
+			Expect(result.Message).NotTo(ContainSubstring("notes.md"))
+		})
+
+		It("does not name ignored files without -x or -X", func() {
+			fakeGit.IgnoredFiles = []string{".env"}
+
+			ctx := createContext("git clean -fd")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("notes.md"))
+			Expect(result.Message).NotTo(ContainSubstring(".env"))
+		})
+
+		It("passes for -X without ignored files", func() {
+			ctx := createContext("git clean -fX")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+
+		It("passes without untracked files", func() {
+			fakeGit.UntrackedFiles = nil
+
+			ctx := createContext("git clean -fdx")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+	})
+
+	Describe("git checkout", func() {
+		BeforeEach(func() {
+			fakeGit.ModifiedFiles = []string{"src/app.go", "README.md"}
+			fakeGit.StagedFiles = []string{"go.mod"}
+		})
+
+		It("blocks discarding all unstaged changes", func() {
+			ctx := createContext("git checkout -- .")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validatorpkg.RefGitDestructive))
+			Expect(result.Message).To(ContainSubstring("'git checkout -- .'"))
+			Expect(result.Message).To(ContainSubstring("src/app.go"))
+			Expect(result.Message).To(ContainSubstring("README.md"))
+			Expect(result.Message).NotTo(ContainSubstring("go.mod"))
+		})
+
+		It("blocks git checkout . without a separator", func() {
+			ctx := createContext("git checkout .")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("src/app.go"))
+		})
+
+		It("includes staged files when checking out from a revision", func() {
+			ctx := createContext("git checkout HEAD -- .")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("'git checkout HEAD -- .'"))
+			Expect(result.Message).To(ContainSubstring("go.mod"))
+		})
+
+		It("only names files matching the pathspecs", func() {
+			ctx := createContext("git checkout -- src")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("src/app.go"))
+			Expect(result.Message).NotTo(ContainSubstring("README.md"))
+		})
+
+		It("blocks forced branch switches", func() {
+			ctx := createContext("git checkout -f main")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("'git checkout --force'"))
+			Expect(result.Message).To(ContainSubstring("go.mod"))
+		})
+
+		It("passes for branch switches and paths without changes", func() {
+			for _, command := range []string{
+				"git checkout main",
+				"git checkout -b feat/new",
+				"git checkout -- docs/",
+			} {
+				ctx := createContext(command)
+				result := validator.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeTrue(), command)
+			}
+		})
+	})
+
+	Describe("git restore", func() {
+		BeforeEach(func() {
+			fakeGit.ModifiedFiles = []string{"main.go"}
+			fakeGit.StagedFiles = []string{"api.go"}
+		})
+
+		It("blocks discarding unstaged changes", func() {
+			ctx := createContext("git restore .")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validatorpkg.RefGitDestructive))
+			Expect(result.Message).To(ContainSubstring("'git restore .'"))
+			Expect(result.Message).To(ContainSubstring("main.go"))
+			Expect(result.Message).NotTo(ContainSubstring("api.go"))
+		})
+
+		It("includes staged files with --staged --worktree", func() {
+			ctx := createContext("git restore --staged --worktree .")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("api.go"))
+			Expect(result.Message).To(ContainSubstring("main.go"))
+		})
+
+		It("passes when only unstaging", func() {
+			ctx := createContext("git restore --staged .")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+	})
+
+	Describe("git branch -D", func() {
+		It("blocks deleting a branch with unmerged commits", func() {
+			fakeGit.UnmergedCommits = map[string][]string{
+				"refs/heads/feat/wip": {"abc1234 Add parser"},
+			}
+
+			ctx := createContext("git branch -D feat/wip")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validatorpkg.RefGitDestructive))
+			Expect(result.Message).To(ContainSubstring("'git branch -D feat/wip'"))
+			Expect(result.Message).To(ContainSubstring("abc1234 Add parser"))
+		})
+
+		It("treats --delete --force like -D", func() {
+			fakeGit.UnmergedCommits = map[string][]string{
+				"refs/heads/feat/wip": {"abc1234 Add parser"},
+			}
+
+			ctx := createContext("git branch --delete --force feat/wip")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("abc1234"))
+		})
+
+		It("passes for merged branches and safe deletes", func() {
+			ctx := createContext("git branch -D feat/merged")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			fakeGit.UnmergedCommits = map[string][]string{
+				"refs/heads/feat/wip": {"abc1234 Add parser"},
+			}
+
+			ctx = createContext("git branch -d feat/wip")
+			result = validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+
+		It("checks remote-tracking branches with -r", func() {
+			fakeGit.UnmergedCommits = map[string][]string{
+				"refs/remotes/origin/feat/wip": {"abc1234 Add parser"},
+			}
+
+			ctx := createContext("git branch -D -r origin/feat/wip")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("abc1234"))
+		})
+	})
+
+	Describe("git stash", func() {
+		BeforeEach(func() {
+			fakeGit.Stashes = []string{
+				"stash@{0}: WIP on main: abc1234 latest",
+				"stash@{1}: On main: older",
+			}
+		})
+
+		It("blocks dropping the latest stash", func() {
+			ctx := createContext("git stash drop")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validatorpkg.RefGitDestructive))
+			Expect(result.Message).To(ContainSubstring("'git stash drop stash@{0}'"))
+			Expect(result.Message).To(ContainSubstring("stash@{0}: WIP on main"))
+			Expect(result.Message).NotTo(ContainSubstring("stash@{1}"))
+		})
+
+		It("blocks dropping a specific stash", func() {
+			ctx := createContext("git stash drop 1")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("stash@{1}: On main: older"))
+		})
+
+		It("blocks clearing all stashes", func() {
+			ctx := createContext("git stash clear")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("stash@{0}"))
+			Expect(result.Message).To(ContainSubstring("stash@{1}"))
+		})
+
+		It("passes without stashes and for other stash commands", func() {
+			ctx := createContext("git stash pop")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext("git stash list")
+			result = validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			fakeGit.Stashes = nil
+
+			ctx = createContext("git stash clear")
+			result = validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+	})
+
+	Describe("git push --delete", func() {
+		BeforeEach(func() {
+			fakeGit.UnmergedCommits = map[string][]string{
+				"refs/remotes/origin/feat/wip": {"abc1234 Remote only work"},
+			}
+		})
+
+		It("blocks deleting a remote branch with commits not saved locally", func() {
+			ctx := createContext("git push origin --delete feat/wip")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validatorpkg.RefGitDestructive))
+			Expect(result.Message).To(ContainSubstring("'git push origin --delete feat/wip'"))
+			Expect(result.Message).To(ContainSubstring("abc1234 Remote only work"))
+		})
+
+		It("detects deletion refspecs", func() {
+			ctx := createContext("git push origin :feat/wip")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("abc1234"))
+		})
+
+		It("passes for regular pushes and saved branches", func() {
+			ctx := createContext("git push origin feat/wip")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext("git push origin --delete feat/merged")
+			result = validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+	})
+
+	Describe("git tag -d", func() {
+		It("blocks deleting the only ref to commits", func() {
+			fakeGit.UnmergedCommits = map[string][]string{
+				"refs/tags/experiment": {"abc1234 Experiment"},
+			}
+
+			ctx := createContext("git tag -d experiment")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validatorpkg.RefGitDestructive))
+			Expect(result.Message).To(ContainSubstring("'git tag -d experiment'"))
+			Expect(result.Message).To(ContainSubstring("abc1234"))
+		})
+
+		It("passes for tags on saved commits", func() {
+			ctx := createContext("git tag -d v1.0.0")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+	})
+
+	Context("in a subdirectory of the repository", func() {
+		var dir string
+
+		BeforeEach(func() {
+			cwd, err := os.Getwd()
+			Expect(err).NotTo(HaveOccurred())
+
+			// Files are listed relative to the repository root, pathspecs relative
+			// to the directory git runs in
+			fakeGit.RepoRoot = filepath.Dir(cwd)
+			dir = filepath.Base(cwd)
+			fakeGit.ModifiedFiles = []string{dir + "/main.go", "main.go"}
+			fakeGit.UntrackedFiles = []string{dir + "/notes.md", "notes.md"}
+		})
+
+		It("resolves pathspecs from the directory git runs in", func() {
+			for _, command := range []string{"git checkout -- main.go", "git restore main.go"} {
+				ctx := createContext(command)
+				result := validator.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeFalse(), command)
+				Expect(result.Message).To(ContainSubstring("  - " + dir + "/main.go"))
+				Expect(result.Message).NotTo(ContainSubstring("  - main.go"))
+			}
+
+			for _, command := range []string{
+				"git checkout -- " + dir + "/main.go",
+				"git restore " + dir + "/main.go",
+			} {
+				ctx := createContext(command)
+				result := validator.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeTrue(), command)
+			}
+		})
+
+		It("resolves :/ pathspecs from the repository root", func() {
+			ctx := createContext("git restore :/main.go")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("  - main.go"))
+			Expect(result.Message).NotTo(ContainSubstring(dir + "/main.go"))
+
+			ctx = createContext("git checkout -- :/")
+			result = validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("  - main.go"))
+			Expect(result.Message).To(ContainSubstring(dir + "/main.go"))
+		})
+
+		It("limits . to the directory git runs in", func() {
+			ctx := createContext("git checkout .")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring(dir + "/main.go"))
+			Expect(result.Message).NotTo(ContainSubstring("  - main.go"))
+		})
+
+		It("only names untracked files of the directory git clean runs in", func() {
+			ctx := createContext("git clean -f")
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring(dir + "/notes.md"))
+			Expect(result.Message).NotTo(ContainSubstring("  - notes.md"))
+		})
+	})
+
+	It("checks every git command in a chain", func() {
+		fakeGit.ModifiedFiles = []string{"main.go"}
+
+		ctx := createContext("git fetch origin && git reset --hard origin/main")
+		result := validator.Validate(context.Background(), ctx)
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Message).To(ContainSubstring("main.go"))
+	})
+})
@@ -53,6 +53,7 @@ func (v *FetchValidator) Validate(ctx context.Context, hookCtx *hook.Context) *v
 		hookCtx,
 		v.ruleAdapter,
 		v.Logger(),
+		v.BashParser(),
 		"fetch",
 		v.validateFetchCommand,
 	)
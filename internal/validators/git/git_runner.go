@@ -3,9 +3,14 @@ package git
 import (
 	"context"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/cockroachdb/errors"
+	"github.com/go-git/go-billy/v6/osfs"
+
 	"github.com/smykla-labs/klaudiush/internal/exec"
 	gitpkg "github.com/smykla-labs/klaudiush/internal/git"
 )
@@ -13,6 +18,36 @@ import (
 // GitRunner is an alias for the git.Runner interface for cleaner imports
 type GitRunner = gitpkg.Runner
 
+// stagedDiffArgs are the arguments of the staged diff parsed by GetStagedChanges.
+// Only added lines are needed, and user configuration must not change the format.
+var stagedDiffArgs = []string{
+	"diff",
+	"--cached",
+	"--unified=0",
+	"--no-color",
+	"--no-ext-diff",
+	"--no-textconv",
+	"--no-renames",
+	"--src-prefix=a/",
+	"--dst-prefix=b/",
+}
+
+// worktreeDiffArgs are the arguments of the worktree diff parsed by
+// GetWorktreeChanges, comparing the worktree with the staging area.
+var worktreeDiffArgs = slices.DeleteFunc(slices.Clone(stagedDiffArgs), func(arg string) bool {
+	return arg == "--cached"
+})
+
+// untrackedFilesArgs are the arguments listing the untracked files of the whole
+// repository relative to its root, like the diffs, wherever git runs.
+var untrackedFilesArgs = []string{"ls-files", "--others", "--exclude-standard", "--full-name", ":/"}
+
+// ignoredFilesArgs are the arguments listing the ignored files of the whole
+// repository relative to its root, with ignored directories as "dir/".
+var ignoredFilesArgs = []string{
+	"ls-files", "--others", "--ignored", "--exclude-standard", "--directory", "--full-name", ":/",
+}
+
 // CLIGitRunner implements GitRunner using actual git commands
 type CLIGitRunner struct {
 	runner  exec.CommandRunner
@@ -64,6 +99,28 @@ func (r *CLIGitRunnerWithPath) GetStagedFiles() ([]string, error) {
 	return parseLines(result.Stdout), nil
 }
 
+// GetStagedChanges returns the added or modified staged files with their added lines
+func (r *CLIGitRunnerWithPath) GetStagedChanges() ([]gitpkg.StagedChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result := r.runner.Run(ctx, "git", append([]string{"-C", r.path}, stagedDiffArgs...)...)
+	if result.Err != nil {
+		return nil, result.Err
+	}
+
+	return gitpkg.ParseUnifiedDiff(result.Stdout), nil
+}
+
+// GetWorktreeChanges returns the modified and untracked worktree files with the
+// lines they add relative to the staging area
+func (r *CLIGitRunnerWithPath) GetWorktreeChanges(maxSize int64) ([]gitpkg.StagedChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	return worktreeChanges(ctx, r.runner, []string{"-C", r.path}, maxSize)
+}
+
 // GetModifiedFiles returns the list of modified but unstaged files
 func (r *CLIGitRunnerWithPath) GetModifiedFiles() ([]string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
@@ -82,7 +139,20 @@ func (r *CLIGitRunnerWithPath) GetUntrackedFiles() ([]string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
 	defer cancel()
 
-	result := r.runner.Run(ctx, "git", "-C", r.path, "ls-files", "--others", "--exclude-standard")
+	result := r.runner.Run(ctx, "git", append([]string{"-C", r.path}, untrackedFilesArgs...)...)
+	if result.Err != nil {
+		return nil, result.Err
+	}
+
+	return parseLines(result.Stdout), nil
+}
+
+// GetIgnoredFiles returns the untracked files matched by ignore rules
+func (r *CLIGitRunnerWithPath) GetIgnoredFiles() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result := r.runner.Run(ctx, "git", append([]string{"-C", r.path}, ignoredFilesArgs...)...)
 	if result.Err != nil {
 		return nil, result.Err
 	}
@@ -179,6 +249,84 @@ func (r *CLIGitRunnerWithPath) GetRemotes() (map[string]string, error) {
 	return remotes, nil
 }
 
+// GetUnmergedCommits returns the commits reachable from ref but from no other ref
+func (r *CLIGitRunnerWithPath) GetUnmergedCommits(ref string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result := r.runner.Run(
+		ctx,
+		"git",
+		"-C",
+		r.path,
+		"log",
+		"--format=%h %s",
+		ref,
+		"--not",
+		"--exclude="+ref,
+		"--all",
+	)
+	if result.Err != nil {
+		return nil, result.Err
+	}
+
+	return parseLines(result.Stdout), nil
+}
+
+// GetStashes returns the stash entries, newest first
+func (r *CLIGitRunnerWithPath) GetStashes() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result := r.runner.Run(ctx, "git", "-C", r.path, "stash", "list")
+	if result.Err != nil {
+		return nil, result.Err
+	}
+
+	return parseLines(result.Stdout), nil
+}
+
+// GetUpstream returns the upstream of the given branch, e.g. "origin/main"
+func (r *CLIGitRunnerWithPath) GetUpstream(branch string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result := r.runner.Run(ctx, "git", append([]string{"-C", r.path}, upstreamArgs(branch)...)...)
+	if result.Err != nil {
+		return "", result.Err
+	}
+
+	return strings.TrimSpace(result.Stdout), nil
+}
+
+// GetAheadBehind returns how many commits HEAD is ahead of and behind upstream
+func (r *CLIGitRunnerWithPath) GetAheadBehind(upstream string) (ahead, behind int, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	args := append([]string{"-C", r.path}, aheadBehindArgs(upstream)...)
+
+	result := r.runner.Run(ctx, "git", args...)
+	if result.Err != nil {
+		return 0, 0, result.Err
+	}
+
+	return parseAheadBehind(result.Stdout)
+}
+
+// GetHeadCommit returns the author and signature status of the HEAD commit
+func (r *CLIGitRunnerWithPath) GetHeadCommit() (*gitpkg.HeadCommit, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result := r.runner.Run(ctx, "git", "-C", r.path, "cat-file", "commit", "HEAD")
+	if result.Err != nil {
+		return nil, result.Err
+	}
+
+	return gitpkg.ParseCommitHeader(result.Stdout), nil
+}
+
 // NewGitRunner creates a GitRunner instance based on environment configuration
 // By default, uses SDK-based implementation for better performance
 // Set KLAUDIUSH_USE_SDK_GIT to "false" or "0" to use CLI-based implementation
@@ -254,6 +402,28 @@ func (r *CLIGitRunner) GetStagedFiles() ([]string, error) {
 	return parseLines(result.Stdout), nil
 }
 
+// GetStagedChanges returns the added or modified staged files with their added lines
+func (r *CLIGitRunner) GetStagedChanges() ([]gitpkg.StagedChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result := r.runner.Run(ctx, "git", stagedDiffArgs...)
+	if result.Err != nil {
+		return nil, result.Err
+	}
+
+	return gitpkg.ParseUnifiedDiff(result.Stdout), nil
+}
+
+// GetWorktreeChanges returns the modified and untracked worktree files with the
+// lines they add relative to the staging area
+func (r *CLIGitRunner) GetWorktreeChanges(maxSize int64) ([]gitpkg.StagedChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	return worktreeChanges(ctx, r.runner, nil, maxSize)
+}
+
 // GetModifiedFiles returns the list of modified but unstaged files
 func (r *CLIGitRunner) GetModifiedFiles() ([]string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
@@ -272,7 +442,20 @@ func (r *CLIGitRunner) GetUntrackedFiles() ([]string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
 	defer cancel()
 
-	result := r.runner.Run(ctx, "git", "ls-files", "--others", "--exclude-standard")
+	result := r.runner.Run(ctx, "git", untrackedFilesArgs...)
+	if result.Err != nil {
+		return nil, result.Err
+	}
+
+	return parseLines(result.Stdout), nil
+}
+
+// GetIgnoredFiles returns the untracked files matched by ignore rules
+func (r *CLIGitRunner) GetIgnoredFiles() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result := r.runner.Run(ctx, "git", ignoredFilesArgs...)
 	if result.Err != nil {
 		return nil, result.Err
 	}
@@ -369,6 +552,165 @@ func (r *CLIGitRunner) GetRemotes() (map[string]string, error) {
 	return remotes, nil
 }
 
+// GetUnmergedCommits returns the commits reachable from ref but from no other ref
+func (r *CLIGitRunner) GetUnmergedCommits(ref string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result := r.runner.Run(
+		ctx,
+		"git",
+		"log",
+		"--format=%h %s",
+		ref,
+		"--not",
+		"--exclude="+ref,
+		"--all",
+	)
+	if result.Err != nil {
+		return nil, result.Err
+	}
+
+	return parseLines(result.Stdout), nil
+}
+
+// GetStashes returns the stash entries, newest first
+func (r *CLIGitRunner) GetStashes() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result := r.runner.Run(ctx, "git", "stash", "list")
+	if result.Err != nil {
+		return nil, result.Err
+	}
+
+	return parseLines(result.Stdout), nil
+}
+
+// GetUpstream returns the upstream of the given branch, e.g. "origin/main"
+func (r *CLIGitRunner) GetUpstream(branch string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result := r.runner.Run(ctx, "git", upstreamArgs(branch)...)
+	if result.Err != nil {
+		return "", result.Err
+	}
+
+	return strings.TrimSpace(result.Stdout), nil
+}
+
+// GetAheadBehind returns how many commits HEAD is ahead of and behind upstream
+func (r *CLIGitRunner) GetAheadBehind(upstream string) (ahead, behind int, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result := r.runner.Run(ctx, "git", aheadBehindArgs(upstream)...)
+	if result.Err != nil {
+		return 0, 0, result.Err
+	}
+
+	return parseAheadBehind(result.Stdout)
+}
+
+// GetHeadCommit returns the author and signature status of the HEAD commit
+func (r *CLIGitRunner) GetHeadCommit() (*gitpkg.HeadCommit, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result := r.runner.Run(ctx, "git", "cat-file", "commit", "HEAD")
+	if result.Err != nil {
+		return nil, result.Err
+	}
+
+	return gitpkg.ParseCommitHeader(result.Stdout), nil
+}
+
+// upstreamArgs returns the arguments printing the upstream of branch
+func upstreamArgs(branch string) []string {
+	return []string{"rev-parse", "--abbrev-ref", "--symbolic-full-name", branch + "@{upstream}"}
+}
+
+// aheadBehindArgs returns the arguments counting the commits only on HEAD and
+// only on upstream
+func aheadBehindArgs(upstream string) []string {
+	return []string{"rev-list", "--left-right", "--count", "HEAD..." + upstream}
+}
+
+// parseAheadBehind parses the "<ahead>\t<behind>" output of aheadBehindArgs
+func parseAheadBehind(output string) (ahead, behind int, err error) {
+	fields := strings.Fields(output)
+
+	const aheadBehindFields = 2
+
+	if len(fields) != aheadBehindFields {
+		return 0, 0, errors.Newf("unexpected rev-list output %q", output)
+	}
+
+	if ahead, err = strconv.Atoi(fields[0]); err != nil {
+		return 0, 0, errors.Wrap(err, "invalid ahead count")
+	}
+
+	if behind, err = strconv.Atoi(fields[1]); err != nil {
+		return 0, 0, errors.Wrap(err, "invalid behind count")
+	}
+
+	return ahead, behind, nil
+}
+
+// worktreeChanges returns the changes of the worktree of the repository git
+// runs in with the given leading arguments, such as "-C <path>". Lines of
+// untracked files larger than maxSize are not read.
+func worktreeChanges(
+	ctx context.Context,
+	runner exec.CommandRunner,
+	gitArgs []string,
+	maxSize int64,
+) ([]gitpkg.StagedChange, error) {
+	git := func(args ...string) (string, error) {
+		result := runner.Run(ctx, "git", append(slices.Clone(gitArgs), args...)...)
+
+		return result.Stdout, result.Err
+	}
+
+	diff, err := git(worktreeDiffArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := git("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+
+	root = strings.TrimSpace(root)
+
+	untracked, err := git("-C", root, "ls-files", "-z", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+
+	changes := gitpkg.ParseUnifiedDiff(diff)
+	worktree := osfs.New(root)
+
+	for path := range strings.SplitSeq(untracked, "\x00") {
+		// Nested repositories are listed as directories
+		if path == "" || strings.HasSuffix(path, "/") {
+			continue
+		}
+
+		if content, ok := gitpkg.ReadWorktreeFile(worktree, path, maxSize); ok {
+			changes = append(changes, gitpkg.NewUntrackedChange(path, content))
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b gitpkg.StagedChange) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return changes, nil
+}
+
 // parseLines splits output by newlines and filters empty lines
 func parseLines(output string) []string {
 	output = strings.TrimSpace(output)
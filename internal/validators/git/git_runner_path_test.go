@@ -1,8 +1,11 @@
 package git_test
 
 import (
+	"context"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 
 	gogit "github.com/go-git/go-git/v6"
 	"github.com/go-git/go-git/v6/config"
@@ -10,7 +13,10 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	gitpkg "github.com/smykla-labs/klaudiush/internal/git"
 	"github.com/smykla-labs/klaudiush/internal/validators/git"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
 )
 
 // gitEnvVars lists environment variables that can interfere with git command isolation.
@@ -163,6 +169,135 @@ var _ = Describe("CLIGitRunnerWithPath", func() {
 		})
 	})
 
+	Describe("GetStagedChanges", func() {
+		It("should return the lines added by the staged changes", func() {
+			worktree, err := repo.Worktree()
+			Expect(err).NotTo(HaveOccurred())
+
+			testFile := filepath.Join(tempDir, "test.txt")
+			err = os.WriteFile(testFile, []byte("one\ntwo\n"), 0o644)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = worktree.Add("test.txt")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = worktree.Commit("Initial commit", &gogit.CommitOptions{
+				Author: testAuthor,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = os.WriteFile(testFile, []byte("one\nTWO\nthree\n"), 0o644)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = worktree.Add("test.txt")
+			Expect(err).NotTo(HaveOccurred())
+
+			changes, err := runner.GetStagedChanges()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(HaveLen(1))
+			Expect(changes[0].Path).To(Equal("test.txt"))
+			Expect(changes[0].Added).To(HaveLen(2))
+			Expect(changes[0].Added[0].Number).To(Equal(2))
+			Expect(changes[0].Added[0].Text).To(Equal("TWO"))
+			Expect(changes[0].Added[1].Number).To(Equal(3))
+			Expect(changes[0].Added[1].Text).To(Equal("three"))
+		})
+	})
+
+	Describe("GetWorktreeChanges", func() {
+		It("should return the lines added by unstaged changes and untracked files", func() {
+			worktree, err := repo.Worktree()
+			Expect(err).NotTo(HaveOccurred())
+
+			write := func(name, content string) {
+				path := filepath.Join(tempDir, name)
+				Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+				Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+			}
+
+			write("test.txt", "one\ntwo\n")
+			write("removed.txt", "gone\n")
+			write(".gitignore", "*.log\n")
+
+			_, err = worktree.Add(".")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = worktree.Commit("Initial commit", &gogit.CommitOptions{
+				Author: testAuthor,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			// Staged lines are not part of the worktree changes
+			write("test.txt", "one\nTWO\n")
+
+			_, err = worktree.Add("test.txt")
+			Expect(err).NotTo(HaveOccurred())
+
+			write("test.txt", "one\nTWO\nthree\n")
+			write("sub/new.txt", "new\n")
+			write("debug.log", "ignored\n")
+			Expect(os.Remove(filepath.Join(tempDir, "removed.txt"))).To(Succeed())
+
+			expected := []gitpkg.StagedChange{
+				{
+					Path:      "sub/new.txt",
+					Untracked: true,
+					Added:     []gitpkg.DiffLine{{Number: 1, Text: "new"}},
+				},
+				{
+					Path:  "test.txt",
+					Added: []gitpkg.DiffLine{{Number: 3, Text: "three"}},
+				},
+			}
+
+			changes, err := runner.GetWorktreeChanges(1024)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(Equal(expected))
+
+			sdkRunner := git.NewGitRunnerForPath(filepath.Join(tempDir, "sub"))
+
+			changes, err = sdkRunner.GetWorktreeChanges(1024)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(Equal(expected))
+		})
+
+		It("should not read symlinks, special files or files above the size limit", func() {
+			write := func(name, content string) {
+				err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0o644)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			write("creds.txt", "token\n")
+			write("large.txt", strings.Repeat("line\n", 10))
+			Expect(os.Symlink("creds.txt", filepath.Join(tempDir, "link.txt"))).To(Succeed())
+			Expect(os.Symlink("missing.txt", filepath.Join(tempDir, "dangling.txt"))).To(Succeed())
+
+			mkfifo := exec.Command("mkfifo", filepath.Join(tempDir, "fifo"))
+			if mkfifo.Run() != nil {
+				Skip("mkfifo is not available")
+			}
+
+			expected := []gitpkg.StagedChange{
+				{
+					Path:      "creds.txt",
+					Untracked: true,
+					Added:     []gitpkg.DiffLine{{Number: 1, Text: "token"}},
+				},
+				{Path: "dangling.txt", Untracked: true},
+				{Path: "large.txt", Untracked: true},
+				{Path: "link.txt", Untracked: true},
+			}
+
+			changes, err := runner.GetWorktreeChanges(16)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(Equal(expected))
+
+			changes, err = git.NewGitRunnerForPath(tempDir).GetWorktreeChanges(16)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(Equal(expected))
+		})
+	})
+
 	Describe("GetModifiedFiles", func() {
 		BeforeEach(func() {
 			// Create initial commit
@@ -226,6 +361,25 @@ var _ = Describe("CLIGitRunnerWithPath", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(files).To(ConsistOf("untracked.txt"))
 			})
+
+			It("should list the files of the repository relative to its root", func() {
+				Expect(os.Mkdir(filepath.Join(tempDir, "sub"), 0o755)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(tempDir, ".gitignore"), []byte("*.log\n"), 0o644)).
+					To(Succeed())
+				Expect(os.WriteFile(filepath.Join(tempDir, "sub", "new.txt"), nil, 0o644)).
+					To(Succeed())
+				Expect(os.WriteFile(filepath.Join(tempDir, "debug.log"), nil, 0o644)).To(Succeed())
+
+				subRunner := git.NewCLIGitRunnerForPath(filepath.Join(tempDir, "sub"))
+
+				files, err := subRunner.GetUntrackedFiles()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files).To(ConsistOf(".gitignore", "untracked.txt", "sub/new.txt"))
+
+				files, err = subRunner.GetIgnoredFiles()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files).To(ConsistOf("debug.log"))
+			})
 		})
 	})
 
@@ -435,6 +589,42 @@ var _ = Describe("NewGitRunnerForPath", func() {
 		Expect(root).To(Equal(tempDir))
 	})
 
+	Context("with untracked files only", func() {
+		BeforeEach(func() {
+			err = os.WriteFile(filepath.Join(tempDir, "untracked.txt"), []byte("new\n"), 0o644)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should not report them as staged", func() {
+			staged, stagedErr := git.NewGitRunnerForPath(tempDir).GetStagedFiles()
+			Expect(stagedErr).NotTo(HaveOccurred())
+			Expect(staged).To(BeEmpty())
+
+			os.Setenv("KLAUDIUSH_USE_SDK_GIT", "false")
+
+			staged, stagedErr = git.NewGitRunnerForPath(tempDir).GetStagedFiles()
+			Expect(stagedErr).NotTo(HaveOccurred())
+			Expect(staged).To(BeEmpty())
+		})
+
+		It("should block commits without staged files", func() {
+			v := git.NewCommitValidator(
+				logger.NewNoOpLogger(),
+				git.NewGitRunnerForPath(tempDir),
+				nil,
+				nil,
+			)
+
+			result := v.Validate(context.Background(), &hook.Context{
+				EventType: hook.EventTypePreToolUse,
+				ToolName:  hook.ToolTypeBash,
+				ToolInput: hook.ToolInput{Command: `git commit -sS -m "feat: add file"`},
+			})
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("No files staged for commit"))
+		})
+	})
+
 	Context("when SDK is explicitly disabled", func() {
 		BeforeEach(func() {
 			os.Setenv("KLAUDIUSH_USE_SDK_GIT", "false")
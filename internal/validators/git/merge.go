@@ -90,7 +90,7 @@ func (v *MergeValidator) Validate(ctx context.Context, hookCtx *hook.Context) *v
 	}
 
 	// Parse the command
-	bashParser := parser.NewBashParser()
+	bashParser := v.BashParser()
 
 	result, err := bashParser.Parse(hookCtx.GetCommand())
 	if err != nil {
@@ -0,0 +1,110 @@
+package git
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// rootPathspecs returns pathspecs of a git command running in workDir relative
+// to the repository root instead of that directory. ":/" pathspecs are already
+// relative to the root.
+func rootPathspecs(runner GitRunner, workDir string, pathspecs []string) []string {
+	prefix := pathspecPrefix(runner, workDir)
+	rooted := make([]string, 0, len(pathspecs))
+
+	for _, spec := range pathspecs {
+		switch {
+		case spec == pathspecSeparator:
+			continue
+		case strings.HasPrefix(spec, ":/"):
+			if spec = strings.TrimPrefix(spec, ":/"); spec == "" {
+				spec = "."
+			}
+		default:
+			spec = path.Join(prefix, spec)
+		}
+
+		rooted = append(rooted, spec)
+	}
+
+	return rooted
+}
+
+// pathspecPrefix returns the directory git runs in, relative to the repository
+// root, or "" if it cannot be determined
+func pathspecPrefix(runner GitRunner, workDir string) string {
+	root, err := runner.GetRepoRoot()
+	if err != nil {
+		return ""
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+
+	if workDir != "" {
+		if !filepath.IsAbs(workDir) {
+			workDir = filepath.Join(dir, workDir)
+		}
+
+		dir = workDir
+	}
+
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+		return ""
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+// filterPathspecs returns the files matching any of the pathspecs. Files and
+// pathspecs are relative to the repository root. No pathspecs and "." match
+// all files.
+func filterPathspecs(files, pathspecs []string) []string {
+	if len(pathspecs) == 0 {
+		return files
+	}
+
+	var matched []string
+
+	for _, file := range files {
+		if slices.ContainsFunc(pathspecs, func(spec string) bool {
+			return matchesPathspec(file, spec)
+		}) {
+			matched = append(matched, file)
+		}
+	}
+
+	return matched
+}
+
+// matchesPathspec reports whether a file matches a pathspec: the file itself,
+// a directory containing it, or a glob pattern.
+func matchesPathspec(file, spec string) bool {
+	if spec == "." {
+		return true
+	}
+
+	spec = strings.TrimSuffix(strings.TrimPrefix(spec, "./"), "/")
+
+	if file == spec || strings.HasPrefix(file, spec+"/") {
+		return true
+	}
+
+	matched, _ := path.Match(spec, file)
+
+	return matched
+}
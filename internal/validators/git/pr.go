@@ -153,7 +153,7 @@ func (v *PRValidator) Validate(ctx context.Context, hookCtx *hook.Context) *vali
 	}
 
 	// Parse the command
-	bashParser := parser.NewBashParser()
+	bashParser := v.BashParser()
 
 	result, err := bashParser.Parse(hookCtx.GetCommand())
 	if err != nil {
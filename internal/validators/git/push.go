@@ -57,6 +57,7 @@ func (v *PushValidator) Validate(ctx context.Context, hookCtx *hook.Context) *va
 		hookCtx,
 		v.ruleAdapter,
 		v.Logger(),
+		v.BashParser(),
 		"push",
 		v.validatePushCommand,
 	)
@@ -85,7 +86,11 @@ func (v *PushValidator) validatePushCommand(gitCmd *parser.GitCommand) *validato
 		return result
 	}
 
-	return v.validateRemoteExists(remote, runner)
+	if result := v.validateRemoteExists(remote, runner); !result.Passed {
+		return result
+	}
+
+	return v.validateForcePush(gitCmd, remote, runner)
 }
 
 // getRunnerForCommand returns the appropriate git runner for the command.
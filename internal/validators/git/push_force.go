@@ -0,0 +1,259 @@
+package git
+
+import (
+	"path"
+	"strings"
+
+	"github.com/smykla-labs/klaudiush/internal/templates"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
+)
+
+// forceKind describes how a push may overwrite remote history.
+type forceKind int
+
+const (
+	// forceNone is a regular fast-forward push.
+	forceNone forceKind = iota
+
+	// forceWithLease is a force push guarded by --force-with-lease.
+	forceWithLease
+
+	// forcePlain is an unguarded force push (--force, -f, --mirror or a "+" refspec).
+	forcePlain
+)
+
+const (
+	forceWithLeaseFlag = "--force-with-lease"
+	refsHeadsPrefix    = "refs/heads/"
+	refsPrefix         = "refs/"
+	headRef            = "HEAD"
+)
+
+// Push flags that update every branch on the remote.
+var allBranchesFlags = []string{"--all", "--branches", "--mirror"}
+
+// pushTarget is a branch updated by a push and how it is forced.
+type pushTarget struct {
+	// branch is the remote branch name. Empty when the push updates all branches.
+	branch string
+
+	// force is how the branch is forced.
+	force forceKind
+
+	// flag is the flag or refspec that forces the push.
+	flag string
+}
+
+// validateForcePush enforces the force-push policy: force pushes to protected
+// branches are blocked, and force pushes elsewhere must use --force-with-lease.
+func (v *PushValidator) validateForcePush(
+	gitCmd *parser.GitCommand,
+	remote string,
+	runner GitRunner,
+) *validator.Result {
+	if gitCmd.HasFlag("-d") || gitCmd.HasFlag("--delete") {
+		return validator.Pass()
+	}
+
+	targets := v.resolvePushTargets(gitCmd, runner)
+	protected := v.getProtectedBranches()
+
+	for _, target := range targets {
+		if target.force == forceNone {
+			continue
+		}
+
+		if branch, ok := matchProtectedBranch(target.branch, protected); ok {
+			return validator.FailWithRef(
+				validator.RefGitForcePushProtected,
+				templates.MustExecute(
+					templates.PushForceProtectedTemplate,
+					templates.PushForceProtectedData{
+						Branch:               branch,
+						ProtectedBranchesStr: strings.Join(protected, ", "),
+					},
+				),
+			)
+		}
+	}
+
+	if !v.isRequireForceWithLease() {
+		return validator.Pass()
+	}
+
+	for _, target := range targets {
+		if target.force != forcePlain {
+			continue
+		}
+
+		branch := target.branch
+		if branch == "" {
+			branch = "<branch>"
+		}
+
+		return validator.FailWithRef(
+			validator.RefGitForcePushNoLease,
+			templates.MustExecute(
+				templates.PushForceNoLeaseTemplate,
+				templates.PushForceNoLeaseData{
+					Remote: remote,
+					Branch: branch,
+					Flag:   target.flag,
+				},
+			),
+		)
+	}
+
+	return validator.Pass()
+}
+
+// resolvePushTargets returns the branches updated by a push command. Refspecs
+// follow the remote in the positional arguments; without refspecs the current
+// branch is pushed.
+func (v *PushValidator) resolvePushTargets(
+	gitCmd *parser.GitCommand,
+	runner GitRunner,
+) []pushTarget {
+	force, flag := commandForce(gitCmd)
+
+	for _, allFlag := range allBranchesFlags {
+		if gitCmd.HasFlag(allFlag) {
+			if allFlag == "--mirror" && force == forceNone {
+				force, flag = forcePlain, allFlag
+			}
+
+			return []pushTarget{{force: force, flag: flag}}
+		}
+	}
+
+	var refspecs []string
+	if len(gitCmd.Args) > 1 {
+		refspecs = gitCmd.Args[1:]
+	}
+
+	if len(refspecs) == 0 {
+		return []pushTarget{{branch: v.currentBranch(runner), force: force, flag: flag}}
+	}
+
+	targets := make([]pushTarget, 0, len(refspecs))
+
+	for _, refspec := range refspecs {
+		target := pushTarget{force: force, flag: flag}
+
+		spec, forced := strings.CutPrefix(refspec, "+")
+		if forced {
+			target.force, target.flag = forcePlain, refspec
+		}
+
+		src, dst, hasDst := strings.Cut(spec, ":")
+		if hasDst && src == "" {
+			// ":branch" deletes the remote branch
+			continue
+		}
+
+		if !hasDst {
+			dst = src
+		}
+
+		branch, ok := v.resolveBranch(dst, runner)
+		if !ok {
+			continue
+		}
+
+		target.branch = branch
+		targets = append(targets, target)
+	}
+
+	return targets
+}
+
+// resolveBranch converts a refspec destination to a branch name. HEAD is
+// resolved to the current branch. Returns false for refs that are not
+// branches, such as tags. A wildcard destination resolves to all branches.
+func (v *PushValidator) resolveBranch(dst string, runner GitRunner) (string, bool) {
+	if dst == "" || dst == headRef {
+		return v.currentBranch(runner), true
+	}
+
+	if branch, ok := strings.CutPrefix(dst, refsHeadsPrefix); ok {
+		dst = branch
+	} else if strings.HasPrefix(dst, refsPrefix) {
+		return "", false
+	}
+
+	if strings.Contains(dst, "*") {
+		return "", true
+	}
+
+	return dst, true
+}
+
+// currentBranch returns the current branch, or HEAD if it cannot be resolved.
+func (v *PushValidator) currentBranch(runner GitRunner) string {
+	branch, err := runner.GetCurrentBranch()
+	if err != nil || branch == "" {
+		v.Logger().Debug("could not resolve current branch for push", "error", err)
+
+		return headRef
+	}
+
+	return branch
+}
+
+// getProtectedBranches returns the branch patterns that must not be force pushed.
+func (v *PushValidator) getProtectedBranches() []string {
+	if v.config != nil && len(v.config.ProtectedBranches) > 0 {
+		return v.config.ProtectedBranches
+	}
+
+	return defaultProtectedBranches
+}
+
+// isRequireForceWithLease returns whether force pushes must use --force-with-lease.
+func (v *PushValidator) isRequireForceWithLease() bool {
+	if v.config != nil && v.config.RequireForceWithLease != nil {
+		return *v.config.RequireForceWithLease
+	}
+
+	return true // default: required
+}
+
+// commandForce returns how the push flags force every refspec, and the flag
+// that does it. A plain force overrides --force-with-lease, as it does in git.
+func commandForce(gitCmd *parser.GitCommand) (forceKind, string) {
+	for _, flag := range []string{"--force", "-f"} {
+		if gitCmd.HasFlag(flag) {
+			return forcePlain, flag
+		}
+	}
+
+	for _, flag := range gitCmd.Flags {
+		if flag == forceWithLeaseFlag || strings.HasPrefix(flag, forceWithLeaseFlag+"=") {
+			return forceWithLease, flag
+		}
+	}
+
+	return forceNone, ""
+}
+
+// matchProtectedBranch reports whether a branch matches a protected pattern and
+// returns the name to report. An empty branch stands for all branches and
+// matches the first pattern.
+func matchProtectedBranch(branch string, patterns []string) (string, bool) {
+	if branch == "" {
+		if len(patterns) == 0 {
+			return "", false
+		}
+
+		return patterns[0], true
+	}
+
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, branch); matched {
+			return branch, true
+		}
+	}
+
+	return "", false
+}
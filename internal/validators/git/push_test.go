@@ -110,12 +110,12 @@ var _ = Describe("PushValidator", func() {
 						description: "nonexistent remote",
 					},
 					{
-						command:     "git push --force-with-lease upstream main",
+						command:     "git push --force-with-lease upstream feature-branch",
 						shouldPass:  true,
 						description: "with flags before remote",
 					},
 					{
-						command:     "git push origin main --force",
+						command:     "git push origin feature-branch --force-with-lease",
 						shouldPass:  true,
 						description: "with flags after branch",
 					},
@@ -346,6 +346,146 @@ var _ = Describe("PushValidator", func() {
 			})
 		})
 
+		Context("force push policy", func() {
+			It("allows force push with lease to a feature branch", func() {
+				ctx := createContext("git push --force-with-lease origin feat/my-feature")
+				result := validator.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeTrue())
+			})
+
+			It("allows force push with explicit lease expectation", func() {
+				ctx := createContext("git push --force-with-lease=feat/x:abc123 origin feat/x")
+				result := validator.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeTrue())
+			})
+
+			It("blocks force push to a protected branch", func() {
+				ctx := createContext("git push --force origin main")
+				result := validator.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.Reference).To(Equal(validatorpkg.RefGitForcePushProtected))
+				Expect(result.Message).To(ContainSubstring("protected branch 'main'"))
+			})
+
+			It("blocks force push with lease to a protected branch", func() {
+				ctx := createContext("git push --force-with-lease origin master")
+				result := validator.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.Reference).To(Equal(validatorpkg.RefGitForcePushProtected))
+			})
+
+			It("detects force push hidden in a refspec", func() {
+				ctx := createContext("git push origin +main")
+				result := validator.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.Reference).To(Equal(validatorpkg.RefGitForcePushProtected))
+			})
+
+			It("uses the refspec destination", func() {
+				ctx := createContext("git push origin +feat/x:refs/heads/main")
+				result := validator.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.Reference).To(Equal(validatorpkg.RefGitForcePushProtected))
+			})
+
+			It("resolves the current branch when no refspec is given", func() {
+				fakeGit.CurrentBranch = "main"
+				ctx := createContext("git push -f")
+				result := validator.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.Reference).To(Equal(validatorpkg.RefGitForcePushProtected))
+			})
+
+			It("resolves HEAD to the current branch", func() {
+				fakeGit.CurrentBranch = "master"
+				ctx := createContext("git push --force-with-lease origin HEAD")
+				result := validator.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.Reference).To(Equal(validatorpkg.RefGitForcePushProtected))
+			})
+
+			It("blocks mirror pushes when branches are protected", func() {
+				ctx := createContext("git push --mirror origin")
+				result := validator.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.Reference).To(Equal(validatorpkg.RefGitForcePushProtected))
+			})
+
+			It("requires lease for plain force push to a feature branch", func() {
+				ctx := createContext("git push -fu origin feat/my-feature")
+				result := validator.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.Reference).To(Equal(validatorpkg.RefGitForcePushNoLease))
+				Expect(
+					result.Message,
+				).To(ContainSubstring("git push --force-with-lease origin feat/my-feature"))
+			})
+
+			It("requires lease for a forced refspec to a feature branch", func() {
+				ctx := createContext("git push origin feat/a +feat/b")
+				result := validator.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.Reference).To(Equal(validatorpkg.RefGitForcePushNoLease))
+				Expect(result.Message).To(ContainSubstring("+feat/b"))
+			})
+
+			It("treats --force as overriding --force-with-lease", func() {
+				ctx := createContext("git push --force-with-lease --force origin feat/x")
+				result := validator.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.Reference).To(Equal(validatorpkg.RefGitForcePushNoLease))
+			})
+
+			It("ignores branch deletions and tags", func() {
+				for _, command := range []string{
+					"git push origin :main",
+					"git push origin --delete main",
+					"git push origin +refs/tags/v1.0.0",
+				} {
+					result := validator.Validate(context.Background(), createContext(command))
+					Expect(result.Passed).To(BeTrue(), command)
+				}
+			})
+
+			It("matches protected branch patterns from config", func() {
+				validator = git.NewPushValidator(log, fakeGit, &config.PushValidatorConfig{
+					ProtectedBranches: []string{"release/*"},
+				}, nil)
+
+				result := validator.Validate(
+					context.Background(),
+					createContext("git push --force-with-lease origin release/v1"),
+				)
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.Reference).To(Equal(validatorpkg.RefGitForcePushProtected))
+
+				result = validator.Validate(
+					context.Background(),
+					createContext("git push --force-with-lease origin main"),
+				)
+				Expect(result.Passed).To(BeTrue())
+			})
+
+			It("allows plain force push when lease is not required", func() {
+				requireLease := false
+				validator = git.NewPushValidator(log, fakeGit, &config.PushValidatorConfig{
+					RequireForceWithLease: &requireLease,
+				}, nil)
+
+				result := validator.Validate(
+					context.Background(),
+					createContext("git push --force origin feat/x"),
+				)
+				Expect(result.Passed).To(BeTrue())
+
+				result = validator.Validate(
+					context.Background(),
+					createContext("git push --force origin main"),
+				)
+				Expect(result.Passed).To(BeFalse())
+			})
+		})
+
 		Context("with -C flag for different directory", func() {
 			It("passes for git push with -C flag to valid repo", func() {
 				ctx := createContext("git -C /path/to/worktree push origin main")
@@ -88,6 +88,7 @@ func ValidateGitSubcommand(
 	hookCtx *hook.Context,
 	ruleAdapter *rules.RuleValidatorAdapter,
 	log logger.Logger,
+	bashParser *parser.BashParser,
 	subcommand string,
 	validateCmd GitCommandValidatorFunc,
 ) *validator.Result {
@@ -103,8 +104,6 @@ func ValidateGitSubcommand(
 		return validator.Pass()
 	}
 
-	bashParser := parser.NewBashParser()
-
 	parseResult, err := bashParser.Parse(command)
 	if err != nil {
 		log.Debug("failed to parse command", "error", err)
@@ -0,0 +1,47 @@
+// Package lifecycle provides validators for Claude Code session lifecycle events.
+package lifecycle
+
+import (
+	"context"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// EventValidator applies user-defined rules to lifecycle events.
+// It has no built-in checks, so events pass unless a rule matches.
+type EventValidator struct {
+	*validator.BaseValidator
+	ruleAdapter *rules.RuleValidatorAdapter
+}
+
+// NewEventValidator creates a new EventValidator.
+func NewEventValidator(
+	log logger.Logger,
+	ruleAdapter *rules.RuleValidatorAdapter,
+) *EventValidator {
+	return &EventValidator{
+		BaseValidator: validator.NewBaseValidator("validate-lifecycle-event", log),
+		ruleAdapter:   ruleAdapter,
+	}
+}
+
+// Validate evaluates rules for the lifecycle event.
+func (v *EventValidator) Validate(ctx context.Context, hookCtx *hook.Context) *validator.Result {
+	v.Logger().Debug("handling lifecycle event", "event", hookCtx.EventType)
+
+	if v.ruleAdapter != nil {
+		if result := v.ruleAdapter.CheckRules(ctx, hookCtx); result != nil {
+			return result
+		}
+	}
+
+	return validator.Pass()
+}
+
+// Category returns the validator category for parallel execution.
+func (*EventValidator) Category() validator.ValidatorCategory {
+	return validator.CategoryCPU
+}
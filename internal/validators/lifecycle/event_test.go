@@ -0,0 +1,68 @@
+package lifecycle_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/internal/validators/lifecycle"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+var _ = Describe("EventValidator", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	Context("without rules", func() {
+		It("should pass", func() {
+			v := lifecycle.NewEventValidator(logger.NewNoOpLogger(), nil)
+
+			result := v.Validate(ctx, &hook.Context{EventType: hook.EventTypeStop})
+			Expect(result.Passed).To(BeTrue())
+		})
+	})
+
+	Context("with an event type rule", func() {
+		var v *lifecycle.EventValidator
+
+		BeforeEach(func() {
+			engine, err := rules.NewRuleEngine([]*rules.Rule{
+				{
+					Name:    "block-compact",
+					Enabled: true,
+					Match: &rules.RuleMatch{
+						EventType: "PreCompact",
+					},
+					Action: &rules.RuleAction{
+						Type:    rules.ActionBlock,
+						Message: "compaction is disabled",
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			v = lifecycle.NewEventValidator(
+				logger.NewNoOpLogger(),
+				rules.NewRuleValidatorAdapter(engine, rules.ValidatorLifecycle),
+			)
+		})
+
+		It("should block the matching event", func() {
+			result := v.Validate(ctx, &hook.Context{EventType: hook.EventTypePreCompact})
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Message).To(ContainSubstring("compaction is disabled"))
+		})
+
+		It("should pass other events", func() {
+			result := v.Validate(ctx, &hook.Context{EventType: hook.EventTypeSessionEnd})
+			Expect(result.Passed).To(BeTrue())
+		})
+	})
+})
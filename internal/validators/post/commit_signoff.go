@@ -0,0 +1,144 @@
+package post
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/smykla-labs/klaudiush/internal/exec"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
+)
+
+const (
+	signoffPrefix = "Signed-off-by:"
+
+	// amendSignoffHint replaces the PreToolUse suggestion, as the commit already exists.
+	amendSignoffHint = "Amend the commit with a signoff: git commit --amend --no-edit -s"
+)
+
+// CommitSignoffValidator checks that a commit created by a Bash git commit
+// carries a Signed-off-by trailer. It catches commits that were created
+// without signoff despite the PreToolUse checks, e.g. through aliases or scripts.
+type CommitSignoffValidator struct {
+	*validator.BaseValidator
+	runner exec.CommandRunner
+	config *config.CommitSignoffValidatorConfig
+}
+
+// NewCommitSignoffValidator creates a new CommitSignoffValidator.
+func NewCommitSignoffValidator(
+	log logger.Logger,
+	runner exec.CommandRunner,
+	cfg *config.CommitSignoffValidatorConfig,
+) *CommitSignoffValidator {
+	return &CommitSignoffValidator{
+		BaseValidator: validator.NewBaseValidator("post-commit-signoff", log),
+		runner:        runner,
+		config:        cfg,
+	}
+}
+
+// Validate reads the message of the created commit and checks its signoff.
+func (v *CommitSignoffValidator) Validate(ctx context.Context, hookCtx *hook.Context) *validator.Result {
+	log := v.Logger()
+
+	if !hookCtx.ToolResponse.Succeeded() {
+		log.Debug("commit command failed, skipping signoff check")
+		return validator.Pass()
+	}
+
+	gitCmd := lastCommitCommand(v.BashParser(), hookCtx.GetCommand())
+	if gitCmd == nil || gitCmd.HasFlag("--dry-run") {
+		return validator.Pass()
+	}
+
+	args := []string{"log", "-1", "--format=%B"}
+	if dir := gitCmd.GetWorkingDirectory(); dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+
+	result := v.runner.Run(ctx, "git", args...)
+	if result.Failed() {
+		log.Debug("failed to read commit message, skipping", "error", result.Err)
+		return validator.Pass()
+	}
+
+	return v.checkSignoff(result.Stdout)
+}
+
+// checkSignoff validates the Signed-off-by trailers of the commit message.
+func (v *CommitSignoffValidator) checkSignoff(message string) *validator.Result {
+	var signoffs []string
+
+	for line := range strings.SplitSeq(message, "\n") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), signoffPrefix); ok {
+			signoffs = append(signoffs, strings.TrimSpace(value))
+		}
+	}
+
+	if len(signoffs) == 0 {
+		result := validator.FailWithRef(
+			validator.RefGitNoSignoff,
+			"Commit was created without a Signed-off-by trailer",
+		)
+		result.FixHint = amendSignoffHint
+
+		return result
+	}
+
+	expected := v.expectedSignoff()
+	if expected == "" || strings.Contains(strings.Join(signoffs, "\n"), expected) {
+		return validator.Pass()
+	}
+
+	result := validator.FailWithRef(
+		validator.RefGitSignoffMismatch,
+		fmt.Sprintf("Commit signoff does not match the expected %q", expected),
+	)
+	result.FixHint = amendSignoffHint
+
+	return result.AddDetail("signoff", strings.Join(signoffs, ", "))
+}
+
+// expectedSignoff returns the configured expected signoff, if any.
+func (v *CommitSignoffValidator) expectedSignoff() string {
+	if v.config == nil {
+		return ""
+	}
+
+	return v.config.ExpectedSignoff
+}
+
+// Category returns the validator category for parallel execution.
+func (*CommitSignoffValidator) Category() validator.ValidatorCategory {
+	return validator.CategoryGit
+}
+
+// lastCommitCommand returns the last git commit in the command, which created HEAD.
+func lastCommitCommand(bashParser *parser.BashParser, command string) *parser.GitCommand {
+	result, err := bashParser.Parse(command)
+	if err != nil {
+		return nil
+	}
+
+	var last *parser.GitCommand
+
+	for _, cmd := range result.Commands {
+		if cmd.Name != "git" {
+			continue
+		}
+
+		gitCmd, err := parser.ParseGitCommand(cmd)
+		if err != nil || gitCmd.Subcommand != "commit" {
+			continue
+		}
+
+		last = gitCmd
+	}
+
+	return last
+}
@@ -0,0 +1,115 @@
+package post_test
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	execpkg "github.com/smykla-labs/klaudiush/internal/exec"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/internal/validators/post"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+var errNotARepository = errors.New("not a git repository")
+
+var _ = Describe("CommitSignoffValidator", func() {
+	var (
+		ctrl       *gomock.Controller
+		mockRunner *execpkg.MockCommandRunner
+		cfg        *config.CommitSignoffValidatorConfig
+		ctx        context.Context
+	)
+
+	commitCtx := func(command string) *hook.Context {
+		return &hook.Context{
+			EventType:    hook.EventTypePostToolUse,
+			ToolName:     hook.ToolTypeBash,
+			ToolInput:    hook.ToolInput{Command: command},
+			ToolResponse: &hook.ToolResponse{Stdout: "[main abc1234] feat: add"},
+		}
+	}
+
+	validate := func(hookCtx *hook.Context) *validator.Result {
+		return post.NewCommitSignoffValidator(logger.NewNoOpLogger(), mockRunner, cfg).
+			Validate(ctx, hookCtx)
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockRunner = execpkg.NewMockCommandRunner(ctrl)
+		cfg = &config.CommitSignoffValidatorConfig{}
+		ctx = context.Background()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("passes when the commit has a signoff", func() {
+		mockRunner.EXPECT().
+			Run(gomock.Any(), "git", "log", "-1", "--format=%B").
+			Return(execpkg.CommandResult{
+				Stdout: "feat: add\n\nSigned-off-by: Jane Doe <jane@klaudiu.sh>\n",
+			})
+
+		Expect(validate(commitCtx(`git commit -m "feat: add"`)).Passed).To(BeTrue())
+	})
+
+	It("fails when the commit has no signoff", func() {
+		mockRunner.EXPECT().
+			Run(gomock.Any(), "git", "log", "-1", "--format=%B").
+			Return(execpkg.CommandResult{Stdout: "feat: add\n"})
+
+		result := validate(commitCtx(`git commit -m "feat: add"`))
+
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.ShouldBlock).To(BeTrue())
+		Expect(result.Reference).To(Equal(validator.RefGitNoSignoff))
+		Expect(result.FixHint).To(ContainSubstring("--amend"))
+	})
+
+	It("fails when the signoff does not match the expected value", func() {
+		cfg.ExpectedSignoff = "Jane Doe <jane@klaudiu.sh>"
+
+		mockRunner.EXPECT().
+			Run(gomock.Any(), "git", "log", "-1", "--format=%B").
+			Return(execpkg.CommandResult{
+				Stdout: "feat: add\n\nSigned-off-by: John Doe <john@klaudiu.sh>\n",
+			})
+
+		result := validate(commitCtx(`git commit -sm "feat: add"`))
+
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Reference).To(Equal(validator.RefGitSignoffMismatch))
+	})
+
+	It("reads the commit from the -C directory", func() {
+		mockRunner.EXPECT().
+			Run(gomock.Any(), "git", "-C", "/repo", "log", "-1", "--format=%B").
+			Return(execpkg.CommandResult{Stdout: "feat: add\n\nSigned-off-by: Jane <j@klaudiu.sh>\n"})
+
+		Expect(validate(commitCtx(`git -C /repo commit -sm "feat: add"`)).Passed).To(BeTrue())
+	})
+
+	It("skips failed commit commands", func() {
+		exitCode := 1
+		hookCtx := commitCtx(`git commit -m "feat: add"`)
+		hookCtx.ToolResponse.ExitCode = &exitCode
+
+		Expect(validate(hookCtx).Passed).To(BeTrue())
+	})
+
+	It("passes when the commit message cannot be read", func() {
+		mockRunner.EXPECT().
+			Run(gomock.Any(), "git", "log", "-1", "--format=%B").
+			Return(execpkg.CommandResult{Err: errNotARepository})
+
+		Expect(validate(commitCtx(`git commit -m "feat: add"`)).Passed).To(BeTrue())
+	})
+})
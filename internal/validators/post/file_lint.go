@@ -0,0 +1,81 @@
+// Package post provides validators for PostToolUse events, which run after
+// a tool call has completed and report problems back to Claude as feedback.
+package post
+
+import (
+	"context"
+	"os"
+
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// FileLintValidator re-runs a file validator against the file on disk after
+// Write, Edit or MultiEdit. In PreToolUse, edits are linted as fragments; here
+// the whole file is linted as it was actually written.
+type FileLintValidator struct {
+	*validator.BaseValidator
+	inner validator.Validator
+}
+
+// NewFileLintValidator creates a new FileLintValidator wrapping the given file validator.
+func NewFileLintValidator(log logger.Logger, inner validator.Validator) *FileLintValidator {
+	return &FileLintValidator{
+		BaseValidator: validator.NewBaseValidator("post-"+inner.Name(), log),
+		inner:         inner,
+	}
+}
+
+// Validate reads the written file and validates its content with the wrapped validator.
+func (v *FileLintValidator) Validate(ctx context.Context, hookCtx *hook.Context) *validator.Result {
+	log := v.Logger()
+
+	filePath := hookCtx.GetResultFilePath()
+	if filePath == "" {
+		log.Debug("no file path in tool response, skipping")
+		return validator.Pass()
+	}
+
+	data, err := os.ReadFile(filePath) //nolint:gosec // filePath is from Claude Code context
+	if err != nil {
+		log.Debug("failed to read written file, skipping", "file", filePath, "error", err)
+		return validator.Pass()
+	}
+
+	return v.inner.Validate(ctx, writtenFileContext(hookCtx, filePath, string(data)))
+}
+
+// Category returns the category of the wrapped validator.
+func (v *FileLintValidator) Category() validator.ValidatorCategory {
+	return v.inner.Category()
+}
+
+// FilePredicate adapts a file validator predicate written for PreToolUse so
+// that it matches the corresponding PostToolUse event for the written file.
+func FilePredicate(predicate validator.Predicate) validator.Predicate {
+	return func(ctx *hook.Context) bool {
+		if ctx.EventType != hook.EventTypePostToolUse || !ctx.IsFileTool() {
+			return false
+		}
+
+		preCtx := *ctx
+		preCtx.EventType = hook.EventTypePreToolUse
+		preCtx.ToolInput.FilePath = ctx.GetResultFilePath()
+
+		return predicate(&preCtx)
+	}
+}
+
+// writtenFileContext returns a copy of the context that presents the file on
+// disk as a Write of its full content, so file validators lint the whole file.
+func writtenFileContext(hookCtx *hook.Context, filePath, content string) *hook.Context {
+	fileCtx := *hookCtx
+	fileCtx.ToolName = hook.ToolTypeWrite
+	fileCtx.ToolInput = hook.ToolInput{
+		FilePath: filePath,
+		Content:  content,
+	}
+
+	return &fileCtx
+}
@@ -0,0 +1,109 @@
+package post_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/internal/validators/post"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+var _ = Describe("FileLintValidator", func() {
+	var (
+		ctrl     *gomock.Controller
+		inner    *validator.MockValidator
+		v        *post.FileLintValidator
+		ctx      context.Context
+		filePath string
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		inner = validator.NewMockValidator(ctrl)
+		inner.EXPECT().Name().Return("validate-markdown").AnyTimes()
+		ctx = context.Background()
+
+		filePath = filepath.Join(GinkgoT().TempDir(), "README.md")
+		Expect(os.WriteFile(filePath, []byte("# Title\n\nBody\n"), 0o600)).To(Succeed())
+
+		v = post.NewFileLintValidator(logger.NewNoOpLogger(), inner)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("prefixes the wrapped validator name", func() {
+		Expect(v.Name()).To(Equal("post-validate-markdown"))
+	})
+
+	It("validates the full file content from disk as a Write", func() {
+		inner.EXPECT().
+			Validate(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, hookCtx *hook.Context) *validator.Result {
+				Expect(hookCtx.ToolName).To(Equal(hook.ToolTypeWrite))
+				Expect(hookCtx.GetFilePath()).To(Equal(filePath))
+				Expect(hookCtx.GetContent()).To(Equal("# Title\n\nBody\n"))
+
+				return validator.Fail("line too long")
+			})
+
+		result := v.Validate(ctx, &hook.Context{
+			EventType:    hook.EventTypePostToolUse,
+			ToolName:     hook.ToolTypeEdit,
+			ToolInput:    hook.ToolInput{FilePath: "README.md", OldString: "a", NewString: "b"},
+			ToolResponse: &hook.ToolResponse{FilePath: filePath},
+		})
+
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Message).To(Equal("line too long"))
+	})
+
+	It("passes when the file cannot be read", func() {
+		result := v.Validate(ctx, &hook.Context{
+			EventType: hook.EventTypePostToolUse,
+			ToolName:  hook.ToolTypeWrite,
+			ToolInput: hook.ToolInput{FilePath: filepath.Join(filepath.Dir(filePath), "missing.md")},
+		})
+
+		Expect(result.Passed).To(BeTrue())
+	})
+
+	Describe("FilePredicate", func() {
+		predicate := post.FilePredicate(validator.And(
+			validator.EventTypeIs(hook.EventTypePreToolUse),
+			validator.FileExtensionIs(".md"),
+		))
+
+		It("matches PostToolUse file tools using the PreToolUse predicate", func() {
+			Expect(predicate(&hook.Context{
+				EventType: hook.EventTypePostToolUse,
+				ToolName:  hook.ToolTypeEdit,
+				ToolInput: hook.ToolInput{FilePath: "README.md"},
+			})).To(BeTrue())
+		})
+
+		It("does not match PreToolUse events", func() {
+			Expect(predicate(&hook.Context{
+				EventType: hook.EventTypePreToolUse,
+				ToolName:  hook.ToolTypeEdit,
+				ToolInput: hook.ToolInput{FilePath: "README.md"},
+			})).To(BeFalse())
+		})
+
+		It("does not match other tools", func() {
+			Expect(predicate(&hook.Context{
+				EventType: hook.EventTypePostToolUse,
+				ToolName:  hook.ToolTypeRead,
+				ToolInput: hook.ToolInput{FilePath: "README.md"},
+			})).To(BeFalse())
+		})
+	})
+})
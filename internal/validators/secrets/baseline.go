@@ -0,0 +1,208 @@
+package secrets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// BaselineVersion is the current version of the secrets baseline format.
+const BaselineVersion = 1
+
+// Baseline file permissions.
+const (
+	baselineDirMode  = 0o755
+	baselineFileMode = 0o644
+)
+
+// Baseline records known findings, such as test fixtures, that should not be
+// reported again. It is meant to be committed with the project.
+type Baseline struct {
+	// Version is the baseline format version.
+	Version int `json:"version"`
+
+	// Entries are the known findings, sorted by file and line.
+	Entries []BaselineEntry `json:"entries"`
+}
+
+// BaselineEntry is a known finding in a file. The secret itself is never
+// stored, only a hash of it.
+type BaselineEntry struct {
+	// Fingerprint is the SHA-256 of the pattern name and the matched text.
+	Fingerprint string `json:"fingerprint"`
+
+	// File is the slash-separated path of the file relative to the project root.
+	File string `json:"file"`
+
+	// LineHash is the SHA-256 of the trimmed line holding the finding.
+	LineHash string `json:"line_hash"`
+
+	// Line is the line number at the time the entry was recorded (1-indexed).
+	Line int `json:"line"`
+
+	// Pattern is the name of the pattern that matched.
+	Pattern string `json:"pattern"`
+}
+
+// Fingerprint returns the fingerprint of a finding.
+func Fingerprint(finding Finding) string {
+	return hashString(finding.Pattern.Name + "\x00" + finding.Match)
+}
+
+// NewBaselineEntries returns baseline entries for the findings in the content
+// of a file. The file path should be relative to the project root.
+func NewBaselineEntries(file, content string, findings []Finding) []BaselineEntry {
+	lines := strings.Split(content, "\n")
+	entries := make([]BaselineEntry, 0, len(findings))
+
+	for _, finding := range findings {
+		entries = append(entries, BaselineEntry{
+			Fingerprint: Fingerprint(finding),
+			File:        filepath.ToSlash(file),
+			LineHash:    LineHash(lineAt(lines, finding.Line)),
+			Line:        finding.Line,
+			Pattern:     finding.Pattern.Name,
+		})
+	}
+
+	return entries
+}
+
+// LineHash returns the hash of a line, ignoring surrounding whitespace.
+func LineHash(line string) string {
+	return hashString(strings.TrimSpace(line))
+}
+
+// NewBaseline creates a baseline from entries.
+func NewBaseline(entries []BaselineEntry) *Baseline {
+	b := &Baseline{
+		Version: BaselineVersion,
+		Entries: slices.Clone(entries),
+	}
+
+	b.sort()
+
+	return b
+}
+
+// LoadBaseline reads a baseline file. The error wraps fs.ErrNotExist when the
+// file does not exist.
+func LoadBaseline(path string) (*Baseline, error) {
+	//nolint:gosec // G304: path comes from configuration
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading secrets baseline")
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, errors.Wrapf(err, "parsing secrets baseline %s", path)
+	}
+
+	if b.Version > BaselineVersion {
+		return nil, errors.Newf(
+			"secrets baseline %s has unsupported version %d",
+			path,
+			b.Version,
+		)
+	}
+
+	return &b, nil
+}
+
+// Save writes the baseline to path, creating parent directories as needed.
+func (b *Baseline) Save(path string) error {
+	b.sort()
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encoding secrets baseline")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), baselineDirMode); err != nil {
+		return errors.Wrap(err, "creating secrets baseline directory")
+	}
+
+	//nolint:gosec // G306: the baseline holds hashes only and is meant to be committed
+	if err := os.WriteFile(path, append(data, '\n'), baselineFileMode); err != nil {
+		return errors.Wrap(err, "writing secrets baseline")
+	}
+
+	return nil
+}
+
+// Contains reports whether a finding in a file is recorded in the baseline.
+// Entries match by fingerprint and file, so known findings stay known when
+// surrounding lines move.
+func (b *Baseline) Contains(file string, finding Finding) bool {
+	if b == nil {
+		return false
+	}
+
+	file = filepath.ToSlash(file)
+	fingerprint := Fingerprint(finding)
+
+	for _, entry := range b.Entries {
+		if entry.Fingerprint == fingerprint && entry.File == file {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sort orders entries by file, line and pattern for stable diffs.
+func (b *Baseline) sort() {
+	slices.SortFunc(b.Entries, func(x, y BaselineEntry) int {
+		if c := strings.Compare(x.File, y.File); c != 0 {
+			return c
+		}
+
+		if x.Line != y.Line {
+			return x.Line - y.Line
+		}
+
+		return strings.Compare(x.Pattern, y.Pattern)
+	})
+}
+
+// FindBaseline looks for the baseline file in dir and its parents. It returns
+// the path of the baseline and the project root it is relative to, or empty
+// strings when there is no baseline.
+func FindBaseline(dir, baselineFile string) (path, root string) {
+	for {
+		candidate := filepath.Join(dir, baselineFile)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, dir
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ""
+		}
+
+		dir = parent
+	}
+}
+
+// lineAt returns the 1-indexed line, or an empty string when out of range.
+func lineAt(lines []string, line int) string {
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+
+	return lines[line-1]
+}
+
+// hashString returns the hex-encoded SHA-256 of s.
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -12,6 +12,25 @@ type Detector interface {
 	Detect(content string) []Finding
 }
 
+// FileDetector is implemented by detectors whose findings depend on the path
+// of the scanned file, such as detectors with path allowlists.
+type FileDetector interface {
+	Detector
+
+	// DetectFile scans the content of a file for secrets. The path may be empty.
+	DetectFile(path, content string) []Finding
+}
+
+// DetectFile scans content with detector, passing the path of the file when
+// the detector supports it.
+func DetectFile(detector Detector, path, content string) []Finding {
+	if fileDetector, ok := detector.(FileDetector); ok {
+		return fileDetector.DetectFile(path, content)
+	}
+
+	return detector.Detect(content)
+}
+
 // PatternDetector implements Detector using compiled regex patterns.
 type PatternDetector struct {
 	patterns []Pattern
@@ -43,7 +62,7 @@ func (d *PatternDetector) Detect(content string) []Finding {
 		matches := d.patterns[i].Regex.FindAllStringIndex(content, -1)
 
 		for _, match := range matches {
-			line, col := d.getPosition(lines, content, match[0])
+			line, col := getPosition(lines, match[0])
 
 			findings = append(findings, Finding{
 				Pattern: &d.patterns[i],
@@ -60,7 +79,7 @@ func (d *PatternDetector) Detect(content string) []Finding {
 }
 
 // getPosition returns the 1-indexed line and column for a byte offset.
-func (*PatternDetector) getPosition(lines []string, _ string, offset int) (line, column int) {
+func getPosition(lines []string, offset int) (line, column int) {
 	pos := 0
 
 	for lineIdx, lineContent := range lines {
@@ -84,3 +103,51 @@ func (*PatternDetector) getPosition(lines []string, _ string, offset int) (line,
 func (d *PatternDetector) AddPatterns(patterns ...Pattern) {
 	d.patterns = append(d.patterns, patterns...)
 }
+
+// CompositeDetector combines the findings of several detectors. Findings of a
+// later detector that overlap a finding of an earlier one are dropped, so a
+// token matched by a specific pattern is not reported again as high entropy.
+type CompositeDetector struct {
+	detectors []Detector
+}
+
+// NewCompositeDetector creates a CompositeDetector running the given detectors in order.
+func NewCompositeDetector(detectors ...Detector) *CompositeDetector {
+	return &CompositeDetector{detectors: detectors}
+}
+
+// Detect scans content with all detectors.
+func (d *CompositeDetector) Detect(content string) []Finding {
+	return d.DetectFile("", content)
+}
+
+// DetectFile scans the content of a file with all detectors.
+func (d *CompositeDetector) DetectFile(path, content string) []Finding {
+	var findings []Finding
+
+	for _, detector := range d.detectors {
+		for _, finding := range DetectFile(detector, path, content) {
+			if !overlapsAny(findings, finding) {
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	return findings
+}
+
+// overlapsAny reports whether a finding overlaps one of the findings on the same line.
+func overlapsAny(findings []Finding, finding Finding) bool {
+	for _, other := range findings {
+		if other.Line != finding.Line {
+			continue
+		}
+
+		if strings.Contains(other.Match, finding.Match) ||
+			strings.Contains(finding.Match, other.Match) {
+			return true
+		}
+	}
+
+	return false
+}
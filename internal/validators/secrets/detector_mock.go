@@ -52,3 +52,55 @@ func (mr *MockDetectorMockRecorder) Detect(content any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detect", reflect.TypeOf((*MockDetector)(nil).Detect), content)
 }
+
+// MockFileDetector is a mock of FileDetector interface.
+type MockFileDetector struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileDetectorMockRecorder
+	isgomock struct{}
+}
+
+// MockFileDetectorMockRecorder is the mock recorder for MockFileDetector.
+type MockFileDetectorMockRecorder struct {
+	mock *MockFileDetector
+}
+
+// NewMockFileDetector creates a new mock instance.
+func NewMockFileDetector(ctrl *gomock.Controller) *MockFileDetector {
+	mock := &MockFileDetector{ctrl: ctrl}
+	mock.recorder = &MockFileDetectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileDetector) EXPECT() *MockFileDetectorMockRecorder {
+	return m.recorder
+}
+
+// Detect mocks base method.
+func (m *MockFileDetector) Detect(content string) []Finding {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detect", content)
+	ret0, _ := ret[0].([]Finding)
+	return ret0
+}
+
+// Detect indicates an expected call of Detect.
+func (mr *MockFileDetectorMockRecorder) Detect(content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detect", reflect.TypeOf((*MockFileDetector)(nil).Detect), content)
+}
+
+// DetectFile mocks base method.
+func (m *MockFileDetector) DetectFile(path, content string) []Finding {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetectFile", path, content)
+	ret0, _ := ret[0].([]Finding)
+	return ret0
+}
+
+// DetectFile indicates an expected call of DetectFile.
+func (mr *MockFileDetectorMockRecorder) DetectFile(path, content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetectFile", reflect.TypeOf((*MockFileDetector)(nil).DetectFile), path, content)
+}
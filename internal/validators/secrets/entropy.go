@@ -0,0 +1,126 @@
+package secrets
+
+import (
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/smykla-labs/klaudiush/internal/validator"
+)
+
+// Character sets of the strings checked for entropy.
+const (
+	hexCharset    = "0123456789abcdefABCDEF"
+	base64Charset = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/=_-"
+)
+
+// secretAssignmentRegex matches a value assigned to a secret-like name, such as
+// `api_key = "..."`, `SECRET: ...` or `--token=...`. The value is the first group.
+var secretAssignmentRegex = regexp.MustCompile(
+	`(?i)[\w.-]*(?:key|secret|token|passw(?:or)?d|pwd|credential|auth)[\w.-]*["']?` +
+		`\s*(?::=|=>|[:=])\s*["']?([A-Za-z0-9+/=_-]+)`,
+)
+
+// highEntropyPattern describes findings of the EntropyDetector.
+var highEntropyPattern = Pattern{
+	Name:        "high-entropy-string",
+	Description: "High-entropy string assigned to a secret-like name",
+	Regex:       secretAssignmentRegex,
+	Reference:   validator.RefSecretsHighEntropy,
+}
+
+// EntropyDetector implements Detector by measuring the Shannon entropy of
+// values assigned to secret-like names. It catches random keys and tokens
+// that no specific pattern knows about.
+type EntropyDetector struct {
+	base64Threshold float64
+	hexThreshold    float64
+	minLength       int
+}
+
+// NewEntropyDetector creates an EntropyDetector. Base64 and hex values of at
+// least minLength characters are reported when their entropy in bits per
+// character reaches the respective threshold.
+func NewEntropyDetector(base64Threshold, hexThreshold float64, minLength int) *EntropyDetector {
+	return &EntropyDetector{
+		base64Threshold: base64Threshold,
+		hexThreshold:    hexThreshold,
+		minLength:       minLength,
+	}
+}
+
+// Detect scans content for high-entropy values assigned to secret-like names.
+func (d *EntropyDetector) Detect(content string) []Finding {
+	if content == "" {
+		return nil
+	}
+
+	var findings []Finding
+
+	lines := strings.Split(content, "\n")
+
+	for _, match := range secretAssignmentRegex.FindAllStringSubmatchIndex(content, -1) {
+		value := content[match[2]:match[3]]
+		if !d.isHighEntropy(value) {
+			continue
+		}
+
+		line, col := getPosition(lines, match[2])
+
+		findings = append(findings, Finding{
+			Pattern: &highEntropyPattern,
+			Match:   value,
+			Line:    line,
+			Column:  col,
+		})
+	}
+
+	return findings
+}
+
+// isHighEntropy reports whether a value looks like a random hex or base64 string.
+func (d *EntropyDetector) isHighEntropy(value string) bool {
+	if len(value) < d.minLength {
+		return false
+	}
+
+	if containsOnly(value, hexCharset) {
+		return ShannonEntropy(value) >= d.hexThreshold
+	}
+
+	return containsOnly(value, base64Charset) && ShannonEntropy(value) >= d.base64Threshold
+}
+
+// containsOnly reports whether every character of s is in charset.
+func containsOnly(s, charset string) bool {
+	for _, r := range s {
+		if !strings.ContainsRune(charset, r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ShannonEntropy returns the Shannon entropy of s in bits per character.
+func ShannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+
+	length := float64(len(s))
+
+	var entropy float64
+
+	for _, count := range counts {
+		p := float64(count) / length
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy
+}
@@ -0,0 +1,467 @@
+package secrets
+
+import (
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/smykla-labs/klaudiush/internal/validator"
+)
+
+// Gitleaks allowlist conditions and regex targets.
+const (
+	gitleaksConditionAnd = "AND"
+	gitleaksConditionOr  = "OR"
+
+	gitleaksTargetSecret = "secret"
+	gitleaksTargetMatch  = "match"
+	gitleaksTargetLine   = "line"
+)
+
+// gitleaksFile is the subset of the gitleaks configuration format
+// (https://github.com/gitleaks/gitleaks#configuration) used by the native detector.
+type gitleaksFile struct {
+	Rules      []gitleaksRuleConfig      `toml:"rules"`
+	Allowlist  *gitleaksAllowlistConfig  `toml:"allowlist"`
+	Allowlists []gitleaksAllowlistConfig `toml:"allowlists"`
+}
+
+// gitleaksRuleConfig is a [[rules]] entry of a gitleaks configuration.
+type gitleaksRuleConfig struct {
+	ID          string                    `toml:"id"`
+	Description string                    `toml:"description"`
+	Regex       string                    `toml:"regex"`
+	Path        string                    `toml:"path"`
+	SecretGroup int                       `toml:"secretGroup"`
+	Entropy     float64                   `toml:"entropy"`
+	Keywords    []string                  `toml:"keywords"`
+	Allowlist   *gitleaksAllowlistConfig  `toml:"allowlist"`
+	Allowlists  []gitleaksAllowlistConfig `toml:"allowlists"`
+}
+
+// gitleaksAllowlistConfig is an allowlist of a gitleaks configuration, either
+// global or attached to a rule.
+type gitleaksAllowlistConfig struct {
+	Condition   string   `toml:"condition"`
+	RegexTarget string   `toml:"regexTarget"`
+	Regexes     []string `toml:"regexes"`
+	Paths       []string `toml:"paths"`
+	Stopwords   []string `toml:"stopwords"`
+	TargetRules []string `toml:"targetRules"`
+}
+
+// GitleaksConfig holds the compiled rules and allowlists of a gitleaks configuration.
+type GitleaksConfig struct {
+	rules      []gitleaksRule
+	allowlists []gitleaksAllowlist
+}
+
+// gitleaksRule is a compiled gitleaks rule.
+type gitleaksRule struct {
+	pattern     Pattern
+	path        *regexp.Regexp
+	secretGroup int
+	entropy     float64
+	keywords    []string
+	allowlists  []gitleaksAllowlist
+}
+
+// gitleaksAllowlist is a compiled gitleaks allowlist.
+type gitleaksAllowlist struct {
+	and         bool
+	target      string
+	regexes     []*regexp.Regexp
+	paths       []*regexp.Regexp
+	stopwords   []string
+	targetRules []string
+}
+
+// LoadGitleaksConfig reads and compiles a gitleaks configuration file.
+func LoadGitleaksConfig(path string) (*GitleaksConfig, error) {
+	//nolint:gosec // G304: path comes from configuration
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading gitleaks config")
+	}
+
+	cfg, err := ParseGitleaksConfig(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading gitleaks config %s", path)
+	}
+
+	return cfg, nil
+}
+
+// ParseGitleaksConfig compiles a gitleaks configuration. Rules extending the
+// default gitleaks configuration ([extend]) are not supported; the built-in
+// patterns cover the common secrets instead.
+func ParseGitleaksConfig(data []byte) (*GitleaksConfig, error) {
+	var file gitleaksFile
+	if err := toml.Unmarshal(data, &file); err != nil {
+		return nil, errors.Wrap(err, "parsing gitleaks config")
+	}
+
+	cfg := &GitleaksConfig{
+		rules: make([]gitleaksRule, 0, len(file.Rules)),
+	}
+
+	allowlists := file.Allowlists
+	if file.Allowlist != nil {
+		allowlists = append(allowlists, *file.Allowlist)
+	}
+
+	for i := range allowlists {
+		allowlist, err := compileGitleaksAllowlist(&allowlists[i])
+		if err != nil {
+			return nil, errors.Wrap(err, "global allowlist")
+		}
+
+		cfg.allowlists = append(cfg.allowlists, allowlist)
+	}
+
+	for i := range file.Rules {
+		rule, err := compileGitleaksRule(&file.Rules[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "rule %q", file.Rules[i].ID)
+		}
+
+		cfg.rules = append(cfg.rules, rule)
+	}
+
+	return cfg, nil
+}
+
+// compileGitleaksRule compiles a rule and its allowlists.
+func compileGitleaksRule(rc *gitleaksRuleConfig) (gitleaksRule, error) {
+	if rc.ID == "" {
+		return gitleaksRule{}, errors.New("rule id is required")
+	}
+
+	if rc.Regex == "" && rc.Path == "" {
+		return gitleaksRule{}, errors.New("rule needs a regex or a path")
+	}
+
+	description := rc.Description
+	if description == "" {
+		description = rc.ID
+	}
+
+	rule := gitleaksRule{
+		pattern: Pattern{
+			Name:        rc.ID,
+			Description: description,
+			Reference:   validator.RefSecretsAPIKey,
+		},
+		secretGroup: rc.SecretGroup,
+		entropy:     rc.Entropy,
+		keywords:    lowerAll(rc.Keywords),
+	}
+
+	var err error
+
+	if rc.Regex != "" {
+		if rule.pattern.Regex, err = regexp.Compile(rc.Regex); err != nil {
+			return gitleaksRule{}, errors.Wrap(err, "invalid regex")
+		}
+
+		if rc.SecretGroup > rule.pattern.Regex.NumSubexp() {
+			return gitleaksRule{}, errors.Newf(
+				"secretGroup %d exceeds the %d group(s) of the regex",
+				rc.SecretGroup,
+				rule.pattern.Regex.NumSubexp(),
+			)
+		}
+	}
+
+	if rc.Path != "" {
+		if rule.path, err = regexp.Compile(rc.Path); err != nil {
+			return gitleaksRule{}, errors.Wrap(err, "invalid path")
+		}
+	}
+
+	allowlists := rc.Allowlists
+	if rc.Allowlist != nil {
+		allowlists = append(allowlists, *rc.Allowlist)
+	}
+
+	for i := range allowlists {
+		allowlist, err := compileGitleaksAllowlist(&allowlists[i])
+		if err != nil {
+			return gitleaksRule{}, err
+		}
+
+		rule.allowlists = append(rule.allowlists, allowlist)
+	}
+
+	return rule, nil
+}
+
+// compileGitleaksAllowlist compiles the regexes and paths of an allowlist.
+func compileGitleaksAllowlist(ac *gitleaksAllowlistConfig) (gitleaksAllowlist, error) {
+	allowlist := gitleaksAllowlist{
+		target:      gitleaksTargetSecret,
+		stopwords:   lowerAll(ac.Stopwords),
+		targetRules: ac.TargetRules,
+	}
+
+	switch strings.ToUpper(ac.Condition) {
+	case "", gitleaksConditionOr:
+	case gitleaksConditionAnd:
+		allowlist.and = true
+	default:
+		return gitleaksAllowlist{}, errors.Newf("invalid allowlist condition %q", ac.Condition)
+	}
+
+	switch ac.RegexTarget {
+	case "":
+	case gitleaksTargetSecret, gitleaksTargetMatch, gitleaksTargetLine:
+		allowlist.target = ac.RegexTarget
+	default:
+		return gitleaksAllowlist{}, errors.Newf("invalid allowlist regexTarget %q", ac.RegexTarget)
+	}
+
+	var err error
+
+	if allowlist.regexes, err = compileRegexes(ac.Regexes); err != nil {
+		return gitleaksAllowlist{}, errors.Wrap(err, "invalid allowlist regex")
+	}
+
+	if allowlist.paths, err = compileRegexes(ac.Paths); err != nil {
+		return gitleaksAllowlist{}, errors.Wrap(err, "invalid allowlist path")
+	}
+
+	return allowlist, nil
+}
+
+// GitleaksDetector implements Detector with the rules of a gitleaks
+// configuration, combined with a base detector. Findings of the rules take
+// precedence over overlapping base findings, and the global allowlists of the
+// configuration apply to the findings of both.
+type GitleaksDetector struct {
+	config *GitleaksConfig
+	base   Detector
+}
+
+// NewGitleaksDetector creates a GitleaksDetector. The base detector may be nil.
+func NewGitleaksDetector(cfg *GitleaksConfig, base Detector) *GitleaksDetector {
+	return &GitleaksDetector{config: cfg, base: base}
+}
+
+// Detect scans content for secrets.
+func (d *GitleaksDetector) Detect(content string) []Finding {
+	return d.DetectFile("", content)
+}
+
+// DetectFile scans the content of a file for secrets. Path allowlists and
+// rule paths only match when the path is known.
+func (d *GitleaksDetector) DetectFile(path, content string) []Finding {
+	if content == "" {
+		return nil
+	}
+
+	lines := strings.Split(content, "\n")
+	lowerContent := strings.ToLower(content)
+
+	var findings []Finding
+
+	for i := range d.config.rules {
+		rule := &d.config.rules[i]
+
+		for _, match := range rule.detect(path, content, lowerContent, lines) {
+			if !d.config.allowed(path, &match, rule) {
+				findings = append(findings, match.finding)
+			}
+		}
+	}
+
+	if d.base == nil {
+		return findings
+	}
+
+	// The rules of the config take precedence over overlapping base findings
+	for _, finding := range DetectFile(d.base, path, content) {
+		match := gitleaksMatch{finding: finding, line: lineAt(lines, finding.Line)}
+		if d.config.allowed(path, &match, nil) || overlapsAny(findings, finding) {
+			continue
+		}
+
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
+
+// gitleaksMatch is a finding with the text the allowlist regexes can target.
+type gitleaksMatch struct {
+	finding Finding
+	match   string
+	line    string
+}
+
+// detect returns the matches of a rule in the content of a file.
+func (r *gitleaksRule) detect(path, content, lowerContent string, lines []string) []gitleaksMatch {
+	if r.path != nil && (path == "" || !r.path.MatchString(path)) {
+		return nil
+	}
+
+	// Rules without a regex flag the file itself
+	if r.pattern.Regex == nil {
+		return []gitleaksMatch{{
+			finding: Finding{Pattern: &r.pattern, Match: path, Line: 1, Column: 1},
+			match:   path,
+		}}
+	}
+
+	if len(r.keywords) > 0 && !slices.ContainsFunc(r.keywords, func(keyword string) bool {
+		return strings.Contains(lowerContent, keyword)
+	}) {
+		return nil
+	}
+
+	var matches []gitleaksMatch
+
+	for _, loc := range r.pattern.Regex.FindAllStringSubmatchIndex(content, -1) {
+		start, end := r.secretBounds(loc)
+		secret := content[start:end]
+
+		if r.entropy > 0 && ShannonEntropy(secret) < r.entropy {
+			continue
+		}
+
+		line, col := getPosition(lines, start)
+
+		matches = append(matches, gitleaksMatch{
+			finding: Finding{Pattern: &r.pattern, Match: secret, Line: line, Column: col},
+			match:   content[loc[0]:loc[1]],
+			line:    lineAt(lines, line),
+		})
+	}
+
+	return matches
+}
+
+// secretBounds returns the bounds of the secret in a match: the secretGroup
+// when set, otherwise the first non-empty group, otherwise the whole match.
+func (r *gitleaksRule) secretBounds(loc []int) (start, end int) {
+	if r.secretGroup > 0 && loc[2*r.secretGroup] >= 0 {
+		return loc[2*r.secretGroup], loc[2*r.secretGroup+1]
+	}
+
+	if r.secretGroup == 0 {
+		for group := 1; group < len(loc)/2; group++ {
+			if loc[2*group] >= 0 && loc[2*group+1] > loc[2*group] {
+				return loc[2*group], loc[2*group+1]
+			}
+		}
+	}
+
+	return loc[0], loc[1]
+}
+
+// allowed reports whether a match is allowed by the allowlists of its rule or
+// the global allowlists. Findings of the base detector have no rule.
+func (c *GitleaksConfig) allowed(path string, match *gitleaksMatch, rule *gitleaksRule) bool {
+	if rule != nil {
+		for i := range rule.allowlists {
+			if rule.allowlists[i].allows(path, match) {
+				return true
+			}
+		}
+	}
+
+	for i := range c.allowlists {
+		allowlist := &c.allowlists[i]
+		if len(allowlist.targetRules) > 0 &&
+			!slices.Contains(allowlist.targetRules, match.finding.Pattern.Name) {
+			continue
+		}
+
+		if allowlist.allows(path, match) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allows reports whether an allowlist allows a match. With the OR condition
+// any check allows the match, with AND all configured checks must.
+func (a *gitleaksAllowlist) allows(path string, match *gitleaksMatch) bool {
+	checks := make([]bool, 0, 3)
+
+	if len(a.paths) > 0 {
+		checks = append(checks, path != "" && matchesAny(a.paths, path))
+	}
+
+	if len(a.regexes) > 0 {
+		checks = append(checks, matchesAny(a.regexes, a.targetText(match)))
+	}
+
+	if len(a.stopwords) > 0 {
+		secret := strings.ToLower(match.finding.Match)
+		checks = append(checks, slices.ContainsFunc(a.stopwords, func(word string) bool {
+			return strings.Contains(secret, word)
+		}))
+	}
+
+	if len(checks) == 0 {
+		return false
+	}
+
+	if a.and {
+		return !slices.Contains(checks, false)
+	}
+
+	return slices.Contains(checks, true)
+}
+
+// targetText returns the text the allowlist regexes are matched against.
+func (a *gitleaksAllowlist) targetText(match *gitleaksMatch) string {
+	switch a.target {
+	case gitleaksTargetMatch:
+		if match.match != "" {
+			return match.match
+		}
+	case gitleaksTargetLine:
+		return match.line
+	}
+
+	return match.finding.Match
+}
+
+// compileRegexes compiles a list of regular expressions.
+func compileRegexes(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%q", pattern)
+		}
+
+		compiled = append(compiled, re)
+	}
+
+	return compiled, nil
+}
+
+// matchesAny reports whether any of the regular expressions matches s.
+func matchesAny(regexes []*regexp.Regexp, s string) bool {
+	return slices.ContainsFunc(regexes, func(re *regexp.Regexp) bool {
+		return re.MatchString(s)
+	})
+}
+
+// lowerAll returns the lowercase versions of words.
+func lowerAll(words []string) []string {
+	lowered := make([]string, 0, len(words))
+	for _, word := range words {
+		lowered = append(lowered, strings.ToLower(word))
+	}
+
+	return lowered
+}
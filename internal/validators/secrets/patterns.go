@@ -35,6 +35,10 @@ type Finding struct {
 
 	// Column is the column number where the match starts (1-indexed).
 	Column int
+
+	// Source describes where in a Bash command the match was found (e.g., the
+	// command name or "heredoc"). Empty for file content.
+	Source string
 }
 
 // defaultPatterns contains the built-in secret detection patterns.
@@ -176,6 +180,22 @@ var defaultPatterns = []Pattern{
 		Regex:       regexp.MustCompile(`redis://[^:]+:[^@]+@[^\s"']+`),
 		Reference:   validator.RefSecretsConnString,
 	},
+	{
+		Name:        "url-credentials",
+		Description: "Credentials in URL",
+		Regex: regexp.MustCompile(
+			`(?i)(?:https?|ftp|git|ssh)://[^/\s:@'"]+:[^/\s@'"$]{3,}@[^\s/'"]+`,
+		),
+		Reference: validator.RefSecretsConnString,
+	},
+
+	// HTTP Authorization Headers
+	{
+		Name:        "bearer-token",
+		Description: "Bearer Token in Authorization Header",
+		Regex:       regexp.MustCompile(`(?i)authorization:\s*bearer\s+[A-Za-z0-9._~+/=-]{20,}`),
+		Reference:   validator.RefSecretsToken,
+	},
 
 	// Generic Patterns (higher false positive risk, but useful)
 	{
@@ -3,8 +3,11 @@ package secrets
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/smykla-labs/klaudiush/internal/linters"
 	"github.com/smykla-labs/klaudiush/internal/rules"
@@ -12,9 +15,11 @@ import (
 	"github.com/smykla-labs/klaudiush/pkg/config"
 	"github.com/smykla-labs/klaudiush/pkg/hook"
 	"github.com/smykla-labs/klaudiush/pkg/logger"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
+	"github.com/smykla-labs/klaudiush/pkg/pathutil"
 )
 
-// SecretsValidator validates file content for secrets and sensitive data.
+// SecretsValidator validates file content and Bash commands for secrets and sensitive data.
 type SecretsValidator struct {
 	validator.BaseValidator
 	detector        Detector
@@ -23,6 +28,11 @@ type SecretsValidator struct {
 	allowListRegex  []*regexp.Regexp
 	disabledPatters map[string]bool
 	ruleAdapter     *rules.RuleValidatorAdapter
+
+	// gitleaksConfigs caches the gitleaks configs by path, nil when loading
+	// failed
+	gitleaksConfigs map[string]*GitleaksConfig
+	gitleaksMu      sync.Mutex
 }
 
 // NewSecretsValidator creates a new SecretsValidator.
@@ -40,6 +50,7 @@ func NewSecretsValidator(
 		config:          cfg,
 		disabledPatters: make(map[string]bool),
 		ruleAdapter:     ruleAdapter,
+		gitleaksConfigs: make(map[string]*GitleaksConfig),
 	}
 
 	// Compile allow list patterns
@@ -72,7 +83,7 @@ func compileAllowList(patterns []string, log logger.Logger) []*regexp.Regexp {
 	return compiled
 }
 
-// Validate checks file content for secrets.
+// Validate checks file content and Bash commands for secrets.
 func (v *SecretsValidator) Validate(ctx context.Context, hookCtx *hook.Context) *validator.Result {
 	log := v.Logger()
 	log.Debug("validating content for secrets")
@@ -84,6 +95,17 @@ func (v *SecretsValidator) Validate(ctx context.Context, hookCtx *hook.Context)
 		}
 	}
 
+	cwd := hookCtx.Cwd
+	if cwd == "" {
+		cwd, _ = os.Getwd()
+	}
+
+	root := pathutil.FindProjectRoot(cwd)
+
+	if hookCtx.ToolName == hook.ToolTypeBash {
+		return v.validateCommand(ctx, v.detectorAt(root), hookCtx.GetCommand())
+	}
+
 	// Get content to validate
 	content := v.getContent(hookCtx)
 	if content == "" {
@@ -99,16 +121,211 @@ func (v *SecretsValidator) Validate(ctx context.Context, hookCtx *hook.Context)
 	}
 
 	// Run pattern detection
-	findings := v.detector.Detect(content)
+	findings := DetectFile(v.detectorAt(root), relativePath(hookCtx, cwd, root), content)
 
 	// Filter findings
 	findings = v.filterFindings(findings)
+	findings = v.filterBaseline(hookCtx, findings)
+
+	if len(findings) > 0 {
+		return v.createResult(findings)
+	}
+
+	return v.checkGitleaks(ctx, content)
+}
+
+// validateCommand checks the arguments, variable assignments and heredoc bodies
+// of a Bash command for secrets. Findings are reported at the position of the
+// command, assignment or heredoc they were found in.
+func (v *SecretsValidator) validateCommand(
+	ctx context.Context,
+	detector Detector,
+	command string,
+) *validator.Result {
+	log := v.Logger()
+
+	if command == "" {
+		log.Debug("no command to validate")
+		return validator.Pass()
+	}
+
+	maxSize := v.getMaxFileSize()
+	if int64(len(command)) > int64(maxSize) {
+		log.Debug("command exceeds max file size", "size", len(command), "max", maxSize)
+		return validator.Pass()
+	}
+
+	result, err := v.BashParser().Parse(command)
+	if err != nil {
+		// Scan unparsable commands as plain text
+		log.Debug("failed to parse command", "error", err)
+
+		if findings := v.filterFindings(detector.Detect(command)); len(findings) > 0 {
+			return v.createResult(findings)
+		}
+
+		return v.checkGitleaks(ctx, command)
+	}
+
+	var findings []Finding
+
+	seen := make(map[string]bool)
+
+	for _, segment := range commandSegments(result) {
+		for _, finding := range v.filterFindings(detector.Detect(segment.text)) {
+			// Nested commands repeat the text of their wrapper
+			key := finding.Pattern.Name + "\x00" + finding.Match
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+
+			finding.Line = int(segment.location.Line)
+			finding.Column = int(segment.location.Column)
+			finding.Source = segment.source
+			findings = append(findings, finding)
+		}
+	}
 
 	if len(findings) > 0 {
 		return v.createResult(findings)
 	}
 
-	// Optionally run gitleaks as second-tier check
+	return v.checkGitleaks(ctx, command)
+}
+
+// Scan returns the findings in the content of a file, a slash-separated path
+// relative to the project root, that are not allowed or disabled by the
+// configuration. The baseline is not applied.
+func (v *SecretsValidator) Scan(root, file, content string) []Finding {
+	return v.filterFindings(DetectFile(v.detectorAt(root), file, content))
+}
+
+// detectorAt returns the detector for the project at root, combined with the
+// rules of its gitleaks config when one is configured. A config that cannot be
+// loaded is logged and skipped, and reported by "klaudiush doctor".
+//
+//nolint:ireturn // interface for polymorphism
+func (v *SecretsValidator) detectorAt(root string) Detector {
+	path := v.config.GetGitleaksConfigPath(root)
+	if path == "" {
+		return v.detector
+	}
+
+	v.gitleaksMu.Lock()
+	defer v.gitleaksMu.Unlock()
+
+	cfg, ok := v.gitleaksConfigs[path]
+	if !ok {
+		var err error
+
+		cfg, err = LoadGitleaksConfig(path)
+		if err != nil {
+			v.Logger().Error("invalid gitleaks config", "path", path, "error", err)
+		}
+
+		v.gitleaksConfigs[path] = cfg
+	}
+
+	if cfg == nil {
+		return v.detector
+	}
+
+	return NewGitleaksDetector(cfg, v.detector)
+}
+
+// relativePath returns the path of the file being written, relative to the
+// project root when it is inside it. Relative paths start at cwd.
+func relativePath(hookCtx *hook.Context, cwd, root string) string {
+	filePath := hookCtx.GetFilePath()
+	if filePath == "" || root == "" {
+		return filePath
+	}
+
+	if !filepath.IsAbs(filePath) {
+		if cwd == "" {
+			return filePath
+		}
+
+		filePath = filepath.Join(cwd, filePath)
+	}
+
+	rel, err := filepath.Rel(root, filePath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filePath
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+// ScanRepoFile returns the findings in content of file, a slash-separated path
+// relative to the repository root, that are not allowed, disabled or recorded
+// in the secrets baseline.
+func (v *SecretsValidator) ScanRepoFile(root, file, content string) []Finding {
+	return v.filterBaselineAt(
+		filepath.Join(root, filepath.FromSlash(file)),
+		v.Scan(root, file, content),
+	)
+}
+
+// filterBaseline removes findings recorded in the secrets baseline of the
+// project holding the file being written.
+func (v *SecretsValidator) filterBaseline(hookCtx *hook.Context, findings []Finding) []Finding {
+	filePath := hookCtx.GetFilePath()
+	if filePath == "" {
+		return findings
+	}
+
+	if !filepath.IsAbs(filePath) {
+		filePath = filepath.Join(hookCtx.Cwd, filePath)
+	}
+
+	return v.filterBaselineAt(filePath, findings)
+}
+
+// filterBaselineAt removes findings recorded in the secrets baseline of the
+// project holding the file at filePath.
+func (v *SecretsValidator) filterBaselineAt(filePath string, findings []Finding) []Finding {
+	if len(findings) == 0 {
+		return findings
+	}
+
+	path, root := FindBaseline(filepath.Dir(filePath), v.config.GetBaselineFile())
+	if path == "" {
+		return findings
+	}
+
+	baseline, err := LoadBaseline(path)
+	if err != nil {
+		v.Logger().Error("failed to load secrets baseline", "path", path, "error", err)
+
+		return findings
+	}
+
+	file, err := filepath.Rel(root, filePath)
+	if err != nil {
+		return findings
+	}
+
+	filtered := make([]Finding, 0, len(findings))
+
+	for _, finding := range findings {
+		if baseline.Contains(file, finding) {
+			v.Logger().Debug("skipping baselined finding",
+				"pattern", finding.Pattern.Name, "file", file)
+
+			continue
+		}
+
+		filtered = append(filtered, finding)
+	}
+
+	return filtered
+}
+
+// checkGitleaks optionally runs gitleaks as a second-tier check.
+func (v *SecretsValidator) checkGitleaks(ctx context.Context, content string) *validator.Result {
 	if v.shouldUseGitleaks() {
 		result := v.gitleaks.Check(ctx, content)
 		if !result.Success && len(result.Findings) > 0 {
@@ -116,11 +333,61 @@ func (v *SecretsValidator) Validate(ctx context.Context, hookCtx *hook.Context)
 		}
 	}
 
-	log.Debug("no secrets detected")
+	v.Logger().Debug("no secrets detected")
 
 	return validator.Pass()
 }
 
+// commandSegment is a part of a Bash command scanned for secrets.
+type commandSegment struct {
+	text     string
+	source   string
+	location parser.Location
+}
+
+// commandSegments returns the parts of a parsed Bash command to scan: the
+// arguments of each command, each variable assignment and each heredoc body.
+func commandSegments(result *parser.ParseResult) []commandSegment {
+	segments := make([]commandSegment, 0,
+		len(result.Commands)+len(result.Assignments)+len(result.Heredocs))
+
+	for _, cmd := range result.Commands {
+		// Unwrapped commands are part of the arguments of their wrapper
+		if cmd.Depth > 0 {
+			continue
+		}
+
+		segments = append(segments, commandSegment{
+			text:     strings.Join(append([]string{cmd.Name}, cmd.RawArgs...), " "),
+			source:   cmd.Name,
+			location: cmd.Location,
+		})
+	}
+
+	for _, assign := range result.Assignments {
+		source := assign.Name
+		if assign.Source != "" {
+			source = assign.Source + " " + assign.Name
+		}
+
+		segments = append(segments, commandSegment{
+			text:     assign.Raw,
+			source:   source,
+			location: assign.Location,
+		})
+	}
+
+	for _, heredoc := range result.Heredocs {
+		segments = append(segments, commandSegment{
+			text:     heredoc.Content,
+			source:   "heredoc",
+			location: heredoc.Location,
+		})
+	}
+
+	return segments
+}
+
 // getContent extracts content to validate from the hook context.
 func (*SecretsValidator) getContent(hookCtx *hook.Context) string {
 	// For Write operations, use the content directly
@@ -133,6 +400,16 @@ func (*SecretsValidator) getContent(hookCtx *hook.Context) string {
 		return hookCtx.ToolInput.NewString
 	}
 
+	// For MultiEdit operations, validate the new content of all edits
+	if hookCtx.ToolName == hook.ToolTypeMultiEdit {
+		newStrings := make([]string, 0, len(hookCtx.ToolInput.Edits))
+		for _, edit := range hookCtx.ToolInput.Edits {
+			newStrings = append(newStrings, edit.NewString)
+		}
+
+		return strings.Join(newStrings, "\n")
+	}
+
 	return ""
 }
 
@@ -206,6 +483,18 @@ func (v *SecretsValidator) createResult(findings []Finding) *validator.Result {
 			finding.Pattern.Description,
 			finding.Pattern.Name,
 		)
+
+		if finding.Source != "" {
+			msg = fmt.Sprintf(
+				"Line %d, column %d: %s (%s) in %s",
+				finding.Line,
+				finding.Column,
+				finding.Pattern.Description,
+				finding.Pattern.Name,
+				finding.Source,
+			)
+		}
+
 		messages = append(messages, msg)
 	}
 
@@ -216,7 +505,7 @@ func (v *SecretsValidator) createResult(findings []Finding) *validator.Result {
 		strings.Join(messages, "\n"),
 	)
 
-	if v.shouldBlock() {
+	if v.ShouldBlock() {
 		return validator.FailWithRef(ref, message)
 	}
 
@@ -238,15 +527,15 @@ func (v *SecretsValidator) createGitleaksResult(findings []linters.LintFinding)
 		strings.Join(messages, "\n"),
 	)
 
-	if v.shouldBlock() {
+	if v.ShouldBlock() {
 		return validator.FailWithRef(validator.RefSecretsToken, message)
 	}
 
 	return validator.WarnWithRef(validator.RefSecretsToken, message)
 }
 
-// shouldBlock returns whether detection should block the operation.
-func (v *SecretsValidator) shouldBlock() bool {
+// ShouldBlock returns whether detection should block the operation.
+func (v *SecretsValidator) ShouldBlock() bool {
 	if v.config == nil {
 		return true // default to blocking
 	}
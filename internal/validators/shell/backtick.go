@@ -71,7 +71,7 @@ func (v *BacktickValidator) validateLegacy(_ context.Context, command string) *v
 	log := v.Logger()
 
 	// Parse the command to detect backticks
-	bashParser := parser.NewBashParser()
+	bashParser := v.BashParser()
 
 	issues, err := bashParser.FindDoubleQuotedBackticks(command)
 	if err != nil {
@@ -115,7 +115,7 @@ func (v *BacktickValidator) validateComprehensive(
 	log := v.Logger()
 
 	// Parse the command with comprehensive analysis
-	bashParser := parser.NewBashParser()
+	bashParser := v.BashParser()
 
 	locations, err := bashParser.FindAllBacktickIssues(command)
 	if err != nil {
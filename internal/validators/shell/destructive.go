@@ -0,0 +1,629 @@
+package shell
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/internal/templates"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+	"github.com/smykla-labs/klaudiush/pkg/pathutil"
+)
+
+// DestructiveCommands lists the commands checked by DestructiveValidator.
+var DestructiveCommands = []string{
+	"rm", "find", "chmod", "chown", "chgrp", "dd", "truncate", "mkfs", "mke2fs",
+}
+
+// modeOptionLetters are the short options of chmod, chown and chgrp. Other
+// arguments starting with "-" are modes, such as "-w".
+const modeOptionLetters = "cfvRhHLP"
+
+// Devices dd may write to without destroying anything.
+var harmlessDevices = []string{"/dev/null", "/dev/zero", "/dev/stdout", "/dev/stderr"}
+
+// find expressions that select every entry, so a find that only uses them
+// deletes the whole tree rather than matching entries.
+var findWholeTreeArgs = []string{
+	"-delete", "-depth", "-xdev", "-mount", "-print", "-follow", "-ignore_readdir_race",
+}
+
+// find expressions that take a value.
+var findValueArgs = []string{"-maxdepth", "-mindepth"}
+
+// find actions that run a command, and the arguments that end the command.
+var (
+	findExecArgs = []string{"-exec", "-execdir", "-ok", "-okdir"}
+	findExecEnds = []string{";", "+"}
+)
+
+var (
+	// shellVarRegex matches $NAME and ${NAME} expansions.
+	shellVarRegex = regexp.MustCompile(`\$(?:[A-Za-z_][A-Za-z0-9_]*|\{[A-Za-z_][A-Za-z0-9_]*\})`)
+
+	// braceExpansionRegex matches brace expansions such as {a,b} and {1..3}.
+	braceExpansionRegex = regexp.MustCompile(`\{[^{}]*(?:,|\.\.)[^{}]*\}`)
+
+	// unquoteReplacer strips quotes and escapes from a raw argument.
+	unquoteReplacer = strings.NewReplacer(`"`, "", `'`, "", `\`, "")
+)
+
+// stdinTarget stands for the arguments xargs appends to the command it runs.
+const stdinTarget = "<arguments from stdin>"
+
+// destructiveTarget is a path a command deletes or rewrites.
+type destructiveTarget struct {
+	// raw is the argument as written in the command.
+	raw string
+
+	// deletes is true when the target is removed, false when it is modified in place.
+	deletes bool
+
+	// partial is true when only some entries below the target are affected,
+	// such as "find dir -name '*.log' -delete".
+	partial bool
+}
+
+// DestructiveValidator blocks rm -rf, find -delete, recursive chmod/chown, dd, mkfs
+// and truncate when they target the filesystem root, the home directory, the
+// project root or paths outside the project.
+type DestructiveValidator struct {
+	validator.BaseValidator
+	config      *config.ShellDestructiveValidatorConfig
+	ruleAdapter *rules.RuleValidatorAdapter
+}
+
+// NewDestructiveValidator creates a new DestructiveValidator instance.
+func NewDestructiveValidator(
+	log logger.Logger,
+	cfg *config.ShellDestructiveValidatorConfig,
+	ruleAdapter *rules.RuleValidatorAdapter,
+) *DestructiveValidator {
+	return &DestructiveValidator{
+		BaseValidator: *validator.NewBaseValidator("validate-shell-destructive", log),
+		config:        cfg,
+		ruleAdapter:   ruleAdapter,
+	}
+}
+
+// Validate checks destructive commands for protected and out-of-project targets.
+func (v *DestructiveValidator) Validate(
+	ctx context.Context,
+	hookCtx *hook.Context,
+) *validator.Result {
+	log := v.Logger()
+
+	if v.ruleAdapter != nil {
+		if result := v.ruleAdapter.CheckRules(ctx, hookCtx); result != nil {
+			return result
+		}
+	}
+
+	command := hookCtx.GetCommand()
+	if command == "" {
+		return validator.Pass()
+	}
+
+	result, err := v.BashParser().Parse(command)
+	if err != nil {
+		log.Debug("failed to parse command", "error", err)
+		return validator.Pass()
+	}
+
+	cwd := hookCtx.Cwd
+	if cwd == "" {
+		cwd, _ = os.Getwd()
+	}
+
+	home, _ := os.UserHomeDir()
+
+	resolver := &pathResolver{
+		home: pathutil.RealPath(home),
+		dir:  pathutil.RealPath(cwd),
+	}
+	resolver.root = pathutil.FindProjectRoot(resolver.dir)
+
+	var violations []templates.ShellDestructiveViolation
+
+	// Depth of the commands xargs runs with arguments read from stdin, -1 if none
+	stdinDepth := -1
+
+	for _, cmd := range result.Commands {
+		name, args := filepath.Base(cmd.Name), cmd.RawArgs
+
+		if stdinDepth >= 0 && cmd.Depth < stdinDepth {
+			stdinDepth = -1
+		}
+
+		switch {
+		case name == "cd":
+			resolver.changeDir(args)
+			continue
+		case name == "xargs":
+			stdinDepth = cmd.Depth + 1
+			continue
+		case stdinDepth >= 0:
+			args = append(slices.Clone(args), stdinTarget)
+		}
+
+		violations = append(violations, v.checkCommand(name, args, resolver)...)
+	}
+
+	if len(violations) == 0 {
+		return validator.Pass()
+	}
+
+	return validator.FailWithRef(
+		validator.RefShellDestructive,
+		templates.MustExecute(
+			templates.ShellDestructiveTemplate,
+			templates.ShellDestructiveData{
+				Violations:  violations,
+				ProjectRoot: resolver.root,
+			},
+		),
+	)
+}
+
+// checkCommand returns the violations of a single command.
+func (v *DestructiveValidator) checkCommand(
+	name string,
+	args []string,
+	resolver *pathResolver,
+) []templates.ShellDestructiveViolation {
+	display := strings.TrimSpace(name + " " + strings.Join(args, " "))
+
+	if name == "mkfs" || name == "mke2fs" || strings.HasPrefix(name, "mkfs.") {
+		return []templates.ShellDestructiveViolation{{
+			Command: display,
+			Target:  strings.Join(positionalArgs(args), " "),
+			Reason:  "would be formatted, destroying all data on it",
+		}}
+	}
+
+	var violations []templates.ShellDestructiveViolation
+
+	for _, target := range destructiveTargets(name, args) {
+		if reason := v.checkTarget(target, resolver); reason != "" {
+			violations = append(violations, templates.ShellDestructiveViolation{
+				Command: display,
+				Target:  target.raw,
+				Reason:  reason,
+			})
+		}
+	}
+
+	return violations
+}
+
+// checkTarget returns why a target must not be touched, or an empty string if it is allowed.
+func (v *DestructiveValidator) checkTarget(
+	target destructiveTarget,
+	resolver *pathResolver,
+) string {
+	resolved, whole, ok := resolver.resolve(target.raw)
+	if !ok {
+		if v.isSafeUnresolved(target.raw) {
+			return ""
+		}
+
+		return "cannot be resolved before the command runs"
+	}
+
+	if strings.HasPrefix(resolved, "/dev/") && !target.deletes {
+		if slices.Contains(harmlessDevices, resolved) {
+			return ""
+		}
+
+		return "is a device and would be overwritten"
+	}
+
+	if whole && !target.partial {
+		if reason := resolver.protectedReason(resolved, target.deletes); reason != "" {
+			return reason
+		}
+	}
+
+	if v.isSafeTarget(resolved, resolver) {
+		return ""
+	}
+
+	if !pathutil.IsWithin(resolver.root, resolved) {
+		return "is outside the project"
+	}
+
+	return ""
+}
+
+// isSafeTarget reports whether a resolved path matches a safe target pattern.
+// Base name patterns only match inside the project, so "dist" does not allow
+// "/etc/dist". A leading "~/" in a pattern stands for the home directory.
+func (v *DestructiveValidator) isSafeTarget(resolved string, resolver *pathResolver) bool {
+	for _, pattern := range v.getSafeTargets() {
+		if !strings.Contains(pattern, "/") {
+			if !pathutil.IsWithin(resolver.root, resolved) {
+				continue
+			}
+
+			if matched, _ := doublestar.Match(pattern, filepath.Base(resolved)); matched {
+				return true
+			}
+
+			continue
+		}
+
+		if rest, ok := strings.CutPrefix(pattern, "~/"); ok && resolver.home != "" {
+			pattern = resolver.home + "/" + rest
+		}
+
+		if matched, _ := doublestar.Match(pattern, resolved); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSafeUnresolved reports whether the last path segment of an unresolved target
+// is a literal name matching a "**/<name>" safe target, as "$OUT/node_modules"
+// matches "**/node_modules". Base name patterns never match, as the target may
+// be anywhere.
+func (v *DestructiveValidator) isSafeUnresolved(raw string) bool {
+	base := raw[strings.LastIndex(raw, "/")+1:]
+	if raw == stdinTarget || base == "" || strings.ContainsAny(base, "$`*?[{") {
+		return false
+	}
+
+	base = unquoteReplacer.Replace(base)
+
+	for _, pattern := range v.getSafeTargets() {
+		name, ok := strings.CutPrefix(pattern, "**/")
+		if !ok || strings.Contains(name, "/") {
+			continue
+		}
+
+		if matched, _ := doublestar.Match(name, base); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getSafeTargets returns the configured safe target patterns.
+func (v *DestructiveValidator) getSafeTargets() []string {
+	if v.config != nil && len(v.config.SafeTargets) > 0 {
+		return v.config.SafeTargets
+	}
+
+	return config.DefaultShellSafeTargets
+}
+
+// Category returns the validator category for parallel execution.
+func (*DestructiveValidator) Category() validator.ValidatorCategory {
+	return validator.CategoryCPU
+}
+
+// destructiveTargets returns the paths a command deletes or rewrites.
+func destructiveTargets(name string, args []string) []destructiveTarget {
+	switch name {
+	case "rm":
+		return toTargets(positionalArgs(args), true, false)
+
+	case "find":
+		return findTargets(args)
+
+	case "chmod", "chown", "chgrp":
+		if !hasShortFlag(args, 'R') && !slices.Contains(args, "--recursive") {
+			return nil
+		}
+
+		return toTargets(modeOperandTargets(args), false, false)
+
+	case "dd":
+		for _, arg := range args {
+			if output, ok := strings.CutPrefix(arg, "of="); ok {
+				return toTargets([]string{output}, false, false)
+			}
+		}
+
+	case "truncate":
+		return toTargets(truncateTargets(args), false, false)
+	}
+
+	return nil
+}
+
+// findTargets returns the starting points of a find command that deletes entries.
+func findTargets(args []string) []destructiveTarget {
+	var (
+		roots    []string
+		deletes  bool
+		filtered bool
+	)
+
+	inRoots := true
+
+	for i := 0; i < len(args); i++ {
+		arg := unquoteReplacer.Replace(args[i])
+
+		if inRoots {
+			switch {
+			case arg == "-H" || arg == "-L" || arg == "-P":
+				continue
+			case strings.HasPrefix(arg, "-") || arg == "(" || arg == "!":
+				inRoots = false
+			default:
+				roots = append(roots, args[i])
+				continue
+			}
+		}
+
+		switch {
+		case arg == "-delete":
+			deletes = true
+		case slices.Contains(findExecArgs, arg):
+			end := i + 1
+			for end < len(args) && !slices.Contains(findExecEnds, unquoteReplacer.Replace(args[end])) {
+				end++
+			}
+
+			if i+1 < end && filepath.Base(args[i+1]) == "rm" {
+				deletes = true
+			} else {
+				// Other commands act as a test on their exit status
+				filtered = true
+			}
+
+			i = end
+		case slices.Contains(findValueArgs, arg):
+			i++
+		case !slices.Contains(findWholeTreeArgs, arg):
+			filtered = true
+		}
+	}
+
+	if !deletes {
+		return nil
+	}
+
+	if len(roots) == 0 {
+		roots = []string{"."}
+	}
+
+	return toTargets(roots, true, filtered)
+}
+
+// truncateTargets returns the files of a truncate command.
+func truncateTargets(args []string) []string {
+	var files []string
+
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "-s" || arg == "--size" || arg == "-r" || arg == "--reference":
+			i++
+		case strings.HasPrefix(arg, "-"):
+			continue
+		default:
+			files = append(files, arg)
+		}
+	}
+
+	return files
+}
+
+// toTargets wraps raw paths as destructive targets.
+func toTargets(paths []string, deletes, partial bool) []destructiveTarget {
+	targets := make([]destructiveTarget, 0, len(paths))
+
+	for _, path := range paths {
+		targets = append(targets, destructiveTarget{raw: path, deletes: deletes, partial: partial})
+	}
+
+	return targets
+}
+
+// positionalArgs returns the arguments that are not flags. Every argument after
+// "--" is positional.
+func positionalArgs(args []string) []string {
+	var positional []string
+
+	for i, arg := range args {
+		if arg == "--" {
+			return append(positional, args[i+1:]...)
+		}
+
+		if strings.HasPrefix(arg, "-") && arg != "-" {
+			continue
+		}
+
+		positional = append(positional, arg)
+	}
+
+	return positional
+}
+
+// hasShortFlag reports whether a short flag is set, alone or combined (e.g. -Rf).
+func hasShortFlag(args []string, flag rune) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			return false
+		}
+
+		if strings.HasPrefix(arg, "-") && !strings.HasPrefix(arg, "--") &&
+			strings.ContainsRune(arg[1:], flag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// modeOperandTargets returns the files of a chmod, chown or chgrp command. The
+// first operand is the mode or owner unless --reference is given. It may start
+// with "-" (e.g. "chmod -R -w dir"), so only arguments made of known option
+// letters are options.
+func modeOperandTargets(args []string) []string {
+	var (
+		operands     []string
+		hasReference bool
+	)
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		switch {
+		case arg == "--":
+			operands = append(operands, args[i+1:]...)
+			i = len(args)
+		case strings.HasPrefix(arg, "--"):
+			if isReferenceFlag(arg) {
+				hasReference = true
+			}
+
+			// --reference and --from may take their value as the next argument
+			if (arg == "--reference" || arg == "--from") && i+1 < len(args) {
+				i++
+			}
+		case len(arg) > 1 && arg[0] == '-' && strings.Trim(arg[1:], modeOptionLetters) == "":
+			continue
+		default:
+			operands = append(operands, arg)
+		}
+	}
+
+	if len(operands) > 0 && !hasReference {
+		return operands[1:]
+	}
+
+	return operands
+}
+
+// isReferenceFlag reports whether an argument is --reference, which replaces the
+// mode or owner operand of chmod, chown and chgrp.
+func isReferenceFlag(arg string) bool {
+	return strings.HasPrefix(arg, "--reference")
+}
+
+// pathResolver resolves command arguments to absolute paths.
+type pathResolver struct {
+	// home is the user's home directory.
+	home string
+
+	// root is the project root: the repository root, or the session directory
+	// outside a repository.
+	root string
+
+	// dir is the directory relative paths are resolved against. Empty when a
+	// preceding cd could not be resolved.
+	dir string
+}
+
+// changeDir follows a cd command.
+func (r *pathResolver) changeDir(args []string) {
+	paths := positionalArgs(args)
+	if len(paths) == 0 {
+		r.dir = r.home
+		return
+	}
+
+	resolved, _, ok := r.resolve(paths[0])
+	if !ok || paths[0] == "-" {
+		r.dir = ""
+		return
+	}
+
+	r.dir = resolved
+}
+
+// resolve converts a raw argument to a cleaned absolute path. For globs it
+// returns the directory being matched in; whole is false when the glob only
+// matches some of its entries (e.g. "dir/*.log"), true when it matches all of
+// them (e.g. "dir/*"). Returns false when the path depends on anything that is
+// only known when the command runs, such as variables, command substitution,
+// brace expansion or arguments read by xargs.
+func (r *pathResolver) resolve(raw string) (resolved string, whole, ok bool) {
+	if raw == stdinTarget || strings.Contains(raw, "$(") || strings.Contains(raw, "`") ||
+		braceExpansionRegex.MatchString(raw) {
+		return "", false, false
+	}
+
+	unresolved := false
+
+	path := shellVarRegex.ReplaceAllStringFunc(raw, func(match string) string {
+		switch strings.Trim(match, "${}") {
+		case "HOME":
+			return r.home
+		case "PWD":
+			if r.dir != "" {
+				return r.dir
+			}
+		}
+
+		unresolved = true
+
+		return match
+	})
+
+	if unresolved || strings.Contains(path, "$") {
+		return "", false, false
+	}
+
+	path, ok = pathutil.Resolve(unquoteReplacer.Replace(path), r.dir, r.home)
+	if !ok {
+		return "", false, false
+	}
+
+	globIndex := strings.IndexAny(path, "*?[")
+	if globIndex == -1 {
+		return canonicalParent(path), true, true
+	}
+
+	dir := path[:strings.LastIndex(path[:globIndex], "/")+1]
+	segment, rest, _ := strings.Cut(path[len(dir):], "/")
+
+	whole = rest == "" && strings.Trim(segment, "*.") == ""
+
+	return canonicalParent(filepath.Clean(dir)), whole, true
+}
+
+// protectedReason returns why a path must never be targeted, or an empty string.
+// The project root itself may be modified in place but not deleted.
+func (r *pathResolver) protectedReason(path string, deletes bool) string {
+	switch {
+	case path == "/":
+		return "is the filesystem root"
+	case path == r.home:
+		return "is the home directory"
+	case path == r.root && deletes:
+		return "is the project root"
+	case r.home != "" && pathutil.IsWithin(path, r.home):
+		return "contains the home directory"
+	case path != r.root && pathutil.IsWithin(path, r.root):
+		return "contains the project"
+	}
+
+	return ""
+}
+
+// canonicalParent resolves symlinks in the parent directories of a path but not
+// in the path itself, since rm and friends act on a symlink, not its target.
+func canonicalParent(path string) string {
+	if path == "/" {
+		return path
+	}
+
+	return filepath.Join(pathutil.RealPath(filepath.Dir(path)), filepath.Base(path))
+}
+
+// Ensure DestructiveValidator implements validator.Validator
+var _ validator.Validator = (*DestructiveValidator)(nil)
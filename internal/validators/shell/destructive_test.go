@@ -0,0 +1,622 @@
+package shell_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/internal/validators/shell"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+var _ = Describe("DestructiveValidator", func() {
+	var (
+		v       *shell.DestructiveValidator
+		cfg     *config.ShellDestructiveValidatorConfig
+		home    string
+		project string
+		cwd     string
+	)
+
+	BeforeEach(func() {
+		home = GinkgoT().TempDir()
+		GinkgoT().Setenv("HOME", home)
+
+		project = filepath.Join(home, "src", "app")
+		Expect(os.MkdirAll(filepath.Join(project, ".git"), 0o755)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(project, "pkg", "api"), 0o755)).To(Succeed())
+
+		cwd = project
+
+		// The temporary home directory is below /tmp, which the defaults treat as safe
+		cfg = &config.ShellDestructiveValidatorConfig{
+			SafeTargets: []string{"**/node_modules", "dist"},
+		}
+		v = shell.NewDestructiveValidator(logger.NewNoOpLogger(), cfg, nil)
+	})
+
+	// Helper function to create context with command
+	createContext := func(command string) *hook.Context {
+		return &hook.Context{
+			EventType: hook.EventTypePreToolUse,
+			ToolName:  hook.ToolTypeBash,
+			ToolInput: hook.ToolInput{
+				Command: command,
+			},
+			Cwd: cwd,
+		}
+	}
+
+	It("returns the validator name and category", func() {
+		Expect(v.Name()).To(Equal("validate-shell-destructive"))
+		Expect(v.Category()).To(Equal(validator.CategoryCPU))
+	})
+
+	Describe("rm", func() {
+		It("blocks deleting the filesystem root", func() {
+			ctx := createContext("rm -rf /")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("'/' is the filesystem root"))
+
+			ctx = createContext("rm -rf /*")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("'/*' is the filesystem root"))
+		})
+
+		It("blocks deleting the home directory", func() {
+			ctx := createContext("rm -rf ~")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("'~' is the home directory"))
+
+			ctx = createContext("rm -rf $HOME")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("'$HOME' is the home directory"))
+
+			ctx = createContext(`rm -rf "${HOME}/"`)
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("is the home directory"))
+
+			ctx = createContext("rm -rf ~/*")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("is the home directory"))
+		})
+
+		It("blocks deleting the project root and its parents", func() {
+			ctx := createContext("rm -rf .")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("'.' is the project root"))
+
+			ctx = createContext("rm -rf ./*")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("is the project root"))
+
+			ctx = createContext("rm -rf " + project)
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("is the project root"))
+
+			ctx = createContext("rm -rf ..")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("'..' contains the project"))
+		})
+
+		It("blocks deleting paths outside the project", func() {
+			ctx := createContext("rm -rf ../other")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("'../other' is outside the project"))
+
+			ctx = createContext("rm ~/.bashrc")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("'~/.bashrc' is outside the project"))
+
+			ctx = createContext("rm -rf /etc/*.d")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("is outside the project"))
+		})
+
+		It("passes for paths inside the project", func() {
+			for _, command := range []string{
+				"rm -rf pkg/api",
+				"rm -rf pkg/*",
+				"rm -f *.log",
+				"rm -rf -- ./pkg",
+			} {
+				ctx := createContext(command)
+				result := v.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeTrue(), command)
+			}
+		})
+
+		It("resolves paths relative to the session directory", func() {
+			cwd = filepath.Join(project, "pkg")
+
+			ctx := createContext("rm -rf api")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext("rm -rf ..")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("'..' is the project root"))
+		})
+
+		It("follows cd commands", func() {
+			ctx := createContext("cd pkg && rm -rf *")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext("cd .. && rm -rf app")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("'app' is the project root"))
+
+			ctx = createContext("cd ~ && rm -rf *")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("is the home directory"))
+		})
+
+		It("blocks targets that depend on variables or command substitution", func() {
+			ctx := createContext("rm -rf $BUILD_DIR")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("'$BUILD_DIR' cannot be resolved"))
+
+			ctx = createContext(`rm -rf "$DIR"/*`)
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("cannot be resolved"))
+
+			ctx = createContext("rm -rf $(pwd)/pkg")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("cannot be resolved"))
+
+			ctx = createContext("cd $WORKDIR && rm -rf pkg")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("'pkg' cannot be resolved"))
+		})
+
+		It("checks every command in a chain", func() {
+			ctx := createContext("rm -rf pkg/api && rm -rf /")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("'/' is the filesystem root"))
+		})
+
+		It("reports the project root", func() {
+			ctx := createContext("rm -rf /")
+			result := v.Validate(context.Background(), ctx)
+
+			Expect(result.Message).To(ContainSubstring("Project root: "))
+			Expect(result.Message).To(ContainSubstring(filepath.Join("src", "app")))
+		})
+	})
+
+	Describe("safe targets", func() {
+		It("allows default safe targets outside the project", func() {
+			cfg.SafeTargets = nil
+
+			for _, command := range []string{
+				"rm -rf ../other/node_modules",
+				"rm -rf /tmp/build-output",
+			} {
+				ctx := createContext(command)
+				result := v.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeTrue(), command)
+			}
+		})
+
+		It("only matches base name safe targets inside the project", func() {
+			ctx := createContext("rm -rf pkg/dist")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext("rm -rf /etc/dist")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("'/etc/dist' is outside the project"))
+
+			ctx = createContext("rm -rf ../other/dist")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("is outside the project"))
+		})
+
+		It("allows explicit path safe targets behind unresolved variables", func() {
+			ctx := createContext("rm -rf $OUT/node_modules")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+
+			ctx = createContext(`rm -rf "$PROJECT/dist"`)
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("cannot be resolved"))
+		})
+
+		It("never allows protected paths", func() {
+			cfg.SafeTargets = []string{"/**"}
+
+			ctx := createContext("rm -rf ~")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("is the home directory"))
+
+			ctx = createContext("rm -rf ../other")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+
+		It("uses configured safe targets", func() {
+			cfg.SafeTargets = []string{"~/.cache/**", "**/.venv"}
+
+			for _, command := range []string{
+				"rm -rf ~/.cache/pip",
+				"rm -rf ../other/.venv",
+			} {
+				ctx := createContext(command)
+				result := v.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeTrue(), command)
+			}
+
+			ctx := createContext("rm -rf ../other/node_modules")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("is outside the project"))
+		})
+	})
+
+	Describe("find", func() {
+		It("blocks deleting whole trees of protected paths", func() {
+			ctx := createContext("find / -delete")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("'/' is the filesystem root"))
+
+			ctx = createContext("find . -delete")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("'.' is the project root"))
+
+			ctx = createContext("find ~ -depth -exec rm -rf {} +")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("is the home directory"))
+		})
+
+		It("blocks filtered deletes outside the project", func() {
+			ctx := createContext("find ~ -name '*.log' -delete")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("'~' is outside the project"))
+		})
+
+		It("passes for filtered deletes inside the project", func() {
+			for _, command := range []string{
+				"find . -name '*.pyc' -delete",
+				`find . -type f -name '*.orig' -exec rm {} \;`,
+			} {
+				ctx := createContext(command)
+				result := v.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeTrue(), command)
+			}
+		})
+
+		It("passes for finds that do not delete", func() {
+			for _, command := range []string{
+				"find / -name passwd",
+				`find ~ -exec grep -l TODO {} \;`,
+			} {
+				ctx := createContext(command)
+				result := v.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeTrue(), command)
+			}
+		})
+	})
+
+	Describe("chmod and chown", func() {
+		It("blocks recursive changes to protected paths", func() {
+			ctx := createContext("chmod -R 777 /")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("'/' is the filesystem root"))
+
+			ctx = createContext("chown -R user:group ~")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("is the home directory"))
+		})
+
+		It("blocks recursive changes outside the project", func() {
+			ctx := createContext("chmod -R 755 /usr/local")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("is outside the project"))
+		})
+
+		It("passes for recursive changes inside the project, including its root", func() {
+			for _, command := range []string{
+				"chmod -R u+w .",
+				"chmod -R 755 pkg",
+			} {
+				ctx := createContext(command)
+				result := v.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeTrue(), command)
+			}
+		})
+
+		It("passes for non-recursive changes", func() {
+			ctx := createContext("chmod 600 ~/.ssh/config")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+
+		It("treats modes starting with a dash as the mode operand", func() {
+			ctx := createContext("chmod -R -w /")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("'/' is the filesystem root"))
+
+			ctx = createContext("chmod -R -w ~")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("'~' is the home directory"))
+
+			ctx = createContext("chmod -w -Rv -- ~")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("'~' is the home directory"))
+
+			ctx = createContext("chmod -R -x,+X /usr/local")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("'/usr/local' is outside the project"))
+
+			ctx = createContext("chmod -R -w pkg")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+
+		It("treats every operand as a file with --reference", func() {
+			ctx := createContext("chmod -R --reference=pkg /")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("'/' is the filesystem root"))
+
+			ctx = createContext("chown -R --reference pkg ~")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("'~' is the home directory"))
+		})
+	})
+
+	Describe("dd", func() {
+		It("blocks writing to devices", func() {
+			ctx := createContext("dd if=image.iso of=/dev/sda bs=4M")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("'/dev/sda' is a device"))
+		})
+
+		It("passes for harmless devices and project files", func() {
+			for _, command := range []string{
+				"dd if=/dev/zero of=/dev/null count=1",
+				"dd if=/dev/zero of=disk.img bs=1M count=10",
+			} {
+				ctx := createContext(command)
+				result := v.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeTrue(), command)
+			}
+		})
+
+		It("blocks writing outside the project", func() {
+			ctx := createContext("dd if=/dev/zero of=/etc/hosts")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("is outside the project"))
+		})
+	})
+
+	Describe("mkfs", func() {
+		It("blocks formatting filesystems", func() {
+			ctx := createContext("mkfs.ext4 /dev/sdb1")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("'/dev/sdb1' would be formatted"))
+
+			ctx = createContext("mkfs -t ext4 /dev/sdb1")
+			result = v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("would be formatted"))
+		})
+	})
+
+	Describe("truncate", func() {
+		It("blocks truncating files outside the project", func() {
+			ctx := createContext("truncate -s 0 /var/log/syslog")
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+			Expect(result.Message).To(ContainSubstring("'/var/log/syslog' is outside the project"))
+		})
+
+		It("passes for project files", func() {
+			for _, command := range []string{
+				"truncate -s 0 app.log",
+				"truncate --size=0 app.log",
+			} {
+				ctx := createContext(command)
+				result := v.Validate(context.Background(), ctx)
+				Expect(result.Passed).To(BeTrue(), command)
+			}
+		})
+	})
+
+	It("checks commands run through wrappers", func() {
+		ctx := createContext("sudo rm -rf /")
+		result := v.Validate(context.Background(), ctx)
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+		Expect(result.Message).To(ContainSubstring("'/' is the filesystem root"))
+
+		ctx = createContext(`bash -c "rm -rf ~"`)
+		result = v.Validate(context.Background(), ctx)
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Message).To(ContainSubstring("is the home directory"))
+
+		ctx = createContext("sudo mkfs.ext4 /dev/sdb1")
+		result = v.Validate(context.Background(), ctx)
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Message).To(ContainSubstring("would be formatted"))
+
+		ctx = createContext(`sh -c "rm -rf pkg/api"`)
+		result = v.Validate(context.Background(), ctx)
+		Expect(result.Passed).To(BeTrue())
+	})
+
+	It("unquotes command names", func() {
+		ctx := createContext(`\rm -rf /`)
+		result := v.Validate(context.Background(), ctx)
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+		Expect(result.Message).To(ContainSubstring("'/' is the filesystem root"))
+
+		ctx = createContext(`'rm' -rf ~`)
+		result = v.Validate(context.Background(), ctx)
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Message).To(ContainSubstring("is the home directory"))
+
+		ctx = createContext("/bin/rm -rf /")
+		result = v.Validate(context.Background(), ctx)
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Message).To(ContainSubstring("'/' is the filesystem root"))
+	})
+
+	It("checks commands run through the command, exec and builtin builtins", func() {
+		ctx := createContext("command rm -rf /")
+		result := v.Validate(context.Background(), ctx)
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+		Expect(result.Message).To(ContainSubstring("'/' is the filesystem root"))
+
+		ctx = createContext("command -p rm -rf ~")
+		result = v.Validate(context.Background(), ctx)
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Message).To(ContainSubstring("is the home directory"))
+
+		ctx = createContext("exec -a cleanup rm -rf /")
+		result = v.Validate(context.Background(), ctx)
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Message).To(ContainSubstring("'/' is the filesystem root"))
+
+		ctx = createContext("builtin cd / && rm -rf *")
+		result = v.Validate(context.Background(), ctx)
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Message).To(ContainSubstring("is the filesystem root"))
+
+		for _, command := range []string{
+			"command -v rm",
+			"command rm -rf pkg/api",
+		} {
+			ctx := createContext(command)
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue(), command)
+		}
+	})
+
+	It("blocks targets that depend on brace expansion", func() {
+		ctx := createContext("rm -rf {a,/}")
+		result := v.Validate(context.Background(), ctx)
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+		Expect(result.Message).To(ContainSubstring("'{a,/}' cannot be resolved"))
+
+		ctx = createContext("rm -rf pkg/{api,../../..}")
+		result = v.Validate(context.Background(), ctx)
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Message).To(ContainSubstring("cannot be resolved"))
+
+		ctx = createContext("rm -rf dir{1..3}")
+		result = v.Validate(context.Background(), ctx)
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Message).To(ContainSubstring("cannot be resolved"))
+	})
+
+	It("blocks commands run by xargs, whose targets come from stdin", func() {
+		ctx := createContext("xargs rm -rf < list")
+		result := v.Validate(context.Background(), ctx)
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Reference).To(Equal(validator.RefShellDestructive))
+		Expect(result.Message).To(ContainSubstring("'<arguments from stdin>' cannot be resolved"))
+
+		ctx = createContext("find . -name '*.tmp' | xargs rm -f")
+		result = v.Validate(context.Background(), ctx)
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Message).To(ContainSubstring("cannot be resolved"))
+
+		ctx = createContext("cat dirs | xargs -0 chmod -R 777")
+		result = v.Validate(context.Background(), ctx)
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Message).To(ContainSubstring("cannot be resolved"))
+
+		for _, command := range []string{
+			"find . -name '*.go' | xargs grep -l TODO",
+			"xargs grep -l TODO < files && rm -rf pkg/api",
+		} {
+			ctx := createContext(command)
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue(), command)
+		}
+	})
+
+	It("passes for unrelated commands", func() {
+		for _, command := range []string{
+			"ls -la /",
+			"git status",
+		} {
+			ctx := createContext(command)
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue(), command)
+		}
+	})
+})
@@ -0,0 +1,52 @@
+// Package tool provides validators for tools that are neither shell nor file
+// operations, such as MCP server tools, WebFetch and Task.
+package tool
+
+import (
+	"context"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// UseValidator applies user-defined rules to tool invocations such as MCP
+// tools or WebFetch. It has no built-in checks, so tools pass unless a rule matches.
+type UseValidator struct {
+	*validator.BaseValidator
+	ruleAdapter *rules.RuleValidatorAdapter
+}
+
+// NewUseValidator creates a new UseValidator.
+func NewUseValidator(
+	log logger.Logger,
+	ruleAdapter *rules.RuleValidatorAdapter,
+) *UseValidator {
+	return &UseValidator{
+		BaseValidator: validator.NewBaseValidator("validate-tool-use", log),
+		ruleAdapter:   ruleAdapter,
+	}
+}
+
+// Validate evaluates rules for the tool invocation.
+func (v *UseValidator) Validate(ctx context.Context, hookCtx *hook.Context) *validator.Result {
+	v.Logger().Debug("handling tool use",
+		"tool", hookCtx.ToolName,
+		"mcp_server", hookCtx.MCPServer,
+		"mcp_tool", hookCtx.MCPTool,
+	)
+
+	if v.ruleAdapter != nil {
+		if result := v.ruleAdapter.CheckRules(ctx, hookCtx); result != nil {
+			return result
+		}
+	}
+
+	return validator.Pass()
+}
+
+// Category returns the validator category for parallel execution.
+func (*UseValidator) Category() validator.ValidatorCategory {
+	return validator.CategoryCPU
+}
@@ -0,0 +1,113 @@
+package tool_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/internal/validators/tool"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+var _ = Describe("UseValidator", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	Context("without rules", func() {
+		It("should pass", func() {
+			v := tool.NewUseValidator(logger.NewNoOpLogger(), nil)
+
+			result := v.Validate(ctx, &hook.Context{
+				EventType: hook.EventTypePreToolUse,
+				ToolName:  hook.ToolTypeWebSearch,
+			})
+			Expect(result.Passed).To(BeTrue())
+		})
+	})
+
+	Context("with MCP and URL domain rules", func() {
+		var v *tool.UseValidator
+
+		BeforeEach(func() {
+			engine, err := rules.NewRuleEngine([]*rules.Rule{
+				{
+					Name:    "block-github-writes",
+					Enabled: true,
+					Match: &rules.RuleMatch{
+						MCPServer: "github",
+						MCPTool:   "create_*",
+					},
+					Action: &rules.RuleAction{
+						Type:    rules.ActionBlock,
+						Message: "GitHub MCP write tools are disabled",
+					},
+				},
+				{
+					Name:    "ask-unknown-domains",
+					Enabled: true,
+					Match: &rules.RuleMatch{
+						ToolType:  "WebFetch",
+						URLDomain: "!*.example.com",
+					},
+					Action: &rules.RuleAction{
+						Type:    rules.ActionAsk,
+						Message: "Fetching from an unapproved domain",
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			v = tool.NewUseValidator(
+				logger.NewNoOpLogger(),
+				rules.NewRuleValidatorAdapter(engine, rules.ValidatorToolUse),
+			)
+		})
+
+		It("should block matching MCP tools", func() {
+			result := v.Validate(ctx, &hook.Context{
+				EventType: hook.EventTypePreToolUse,
+				ToolName:  hook.ToolTypeMCP,
+				MCPServer: "github",
+				MCPTool:   "create_pull_request",
+			})
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Message).To(ContainSubstring("GitHub MCP write tools are disabled"))
+		})
+
+		It("should pass read-only MCP tools", func() {
+			result := v.Validate(ctx, &hook.Context{
+				EventType: hook.EventTypePreToolUse,
+				ToolName:  hook.ToolTypeMCP,
+				MCPServer: "github",
+				MCPTool:   "get_issue",
+			})
+			Expect(result.Passed).To(BeTrue())
+		})
+
+		It("should ask for WebFetch outside the allowed domain", func() {
+			result := v.Validate(ctx, &hook.Context{
+				EventType: hook.EventTypePreToolUse,
+				ToolName:  hook.ToolTypeWebFetch,
+				ToolInput: hook.ToolInput{URL: "https://evil.test/payload"},
+			})
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldAsk).To(BeTrue())
+		})
+
+		It("should pass WebFetch within the allowed domain", func() {
+			result := v.Validate(ctx, &hook.Context{
+				EventType: hook.EventTypePreToolUse,
+				ToolName:  hook.ToolTypeWebFetch,
+				ToolInput: hook.ToolInput{URL: "https://docs.example.com/guide"},
+			})
+			Expect(result.Passed).To(BeTrue())
+		})
+	})
+})
@@ -1,6 +1,8 @@
 // Package config provides configuration schema types for klaudiush validators.
 package config
 
+import "github.com/smykla-labs/klaudiush/pkg/parser"
+
 // Config represents the root configuration for klaudiush.
 type Config struct {
 	// Validators groups all validator configurations.
@@ -47,6 +49,9 @@ type ValidatorsConfig struct {
 
 	// Shell validator configurations.
 	Shell *ShellConfig `json:"shell,omitempty" koanf:"shell" toml:"shell"`
+
+	// Post validator configurations (PostToolUse events).
+	Post *PostConfig `json:"post,omitempty" koanf:"post" toml:"post"`
 }
 
 // GlobalConfig contains global settings that apply to all validators.
@@ -75,8 +80,32 @@ type GlobalConfig struct {
 	// MaxGitWorkers is the maximum number of concurrent git operations.
 	// Default: 1 (serialized to avoid index lock contention)
 	MaxGitWorkers *int `json:"max_git_workers,omitempty" koanf:"max_git_workers" toml:"max_git_workers"`
+
+	// MaxNestingDepth is how many wrapper levels (bash -c, eval, sudo, env, xargs,
+	// find -exec, ...) are unwrapped so validators see the commands they run.
+	// Commands nested deeper ask for approval. 0 disables unwrapping.
+	// Default: 3
+	MaxNestingDepth *int `json:"max_nesting_depth,omitempty" koanf:"max_nesting_depth" toml:"max_nesting_depth"`
+
+	// OutputFormat controls how hook results are reported to Claude Code.
+	// "text" prints errors to stderr and blocks with exit code 2.
+	// "json" prints a permission decision (allow/deny/ask) to stdout.
+	// Default: "text"
+	OutputFormat string `json:"output_format,omitempty" koanf:"output_format" toml:"output_format"`
 }
 
+// Output formats for hook results.
+const (
+	// OutputFormatText reports results on stderr with exit codes.
+	OutputFormatText = "text"
+
+	// OutputFormatJSON reports results as Claude Code hook JSON on stdout.
+	OutputFormatJSON = "json"
+)
+
+// ValidOutputFormats are the valid values for global.output_format.
+var ValidOutputFormats = []string{OutputFormatText, OutputFormatJSON}
+
 // IsParallelExecutionEnabled returns whether parallel execution is enabled.
 func (g *GlobalConfig) IsParallelExecutionEnabled() bool {
 	if g == nil || g.ParallelExecution == nil {
@@ -86,6 +115,25 @@ func (g *GlobalConfig) IsParallelExecutionEnabled() bool {
 	return *g.ParallelExecution
 }
 
+// GetMaxNestingDepth returns the wrapper unwrapping depth, defaulting to
+// parser.DefaultMaxNestingDepth.
+func (g *GlobalConfig) GetMaxNestingDepth() int {
+	if g == nil || g.MaxNestingDepth == nil {
+		return parser.DefaultMaxNestingDepth
+	}
+
+	return *g.MaxNestingDepth
+}
+
+// GetOutputFormat returns the hook output format, defaulting to "text".
+func (g *GlobalConfig) GetOutputFormat() string {
+	if g == nil || g.OutputFormat == "" {
+		return OutputFormatText
+	}
+
+	return g.OutputFormat
+}
+
 // GetValidators returns the validators config, creating it if it doesn't exist.
 func (c *Config) GetValidators() *ValidatorsConfig {
 	if c.Validators == nil {
@@ -149,6 +197,15 @@ func (v *ValidatorsConfig) GetSecrets() *SecretsConfig {
 	return v.Secrets
 }
 
+// GetPost returns the post validators config, creating it if it doesn't exist.
+func (v *ValidatorsConfig) GetPost() *PostConfig {
+	if v.Post == nil {
+		v.Post = &PostConfig{}
+	}
+
+	return v.Post
+}
+
 // GetPlugins returns the plugins config, creating it if it doesn't exist.
 func (c *Config) GetPlugins() *PluginConfig {
 	if c.Plugins == nil {
@@ -26,6 +26,12 @@ type FileConfig struct {
 
 	// Rust validator configuration
 	Rust *RustValidatorConfig `json:"rust,omitempty" koanf:"rust" toml:"rust"`
+
+	// SensitiveRead validator configuration (reads of secret files)
+	SensitiveRead *SensitiveReadValidatorConfig `json:"sensitive_read,omitempty" koanf:"sensitive_read" toml:"sensitive_read"`
+
+	// ProtectedPaths validator configuration (writes to protected locations)
+	ProtectedPaths *ProtectedPathsValidatorConfig `json:"protected_paths,omitempty" koanf:"protected_paths" toml:"protected_paths"`
 }
 
 // MarkdownValidatorConfig configures the Markdown file validator.
@@ -304,3 +310,119 @@ type RustValidatorConfig struct {
 	// Default: "" (use rustfmt defaults)
 	RustfmtConfig string `json:"rustfmt_config,omitempty" koanf:"rustfmt_config" toml:"rustfmt_config"`
 }
+
+// Actions for reads of sensitive files.
+const (
+	// SensitiveReadActionBlock blocks the read.
+	SensitiveReadActionBlock = "block"
+
+	// SensitiveReadActionAsk asks the user to approve the read.
+	SensitiveReadActionAsk = "ask"
+)
+
+// ValidSensitiveReadActions are the valid values for sensitive_read.action.
+var ValidSensitiveReadActions = []string{SensitiveReadActionBlock, SensitiveReadActionAsk}
+
+// SensitiveReadValidatorConfig configures the validator for reads of secret files
+// through Read, Grep, Glob and Bash commands such as cat, head or grep.
+type SensitiveReadValidatorConfig struct {
+	ValidatorConfig `koanf:",squash"`
+
+	// Patterns is a list of glob patterns for sensitive files. Patterns without a
+	// slash match the base name (e.g., ".env", "*.pem"), patterns with a slash match
+	// the absolute path (e.g., "~/.ssh/**"), with "~/" standing for the home
+	// directory and relative patterns resolved from the project root.
+	// Default: [".env", ".env.*", "*.pem", "*.key", "*.p12", "*.pfx", "id_rsa",
+	// "id_dsa", "id_ecdsa", "id_ed25519", ".netrc", ".pgpass", ".git-credentials",
+	// "~/.ssh/**", "~/.aws/credentials", "~/.config/gcloud/**", "~/.azure/**",
+	// "~/.kube/config", "~/.docker/config.json", "~/.gnupg/**", "~/.npmrc", "~/.pypirc"]
+	Patterns []string `json:"patterns,omitempty" koanf:"patterns" toml:"patterns"`
+
+	// Allow is a list of glob patterns for files that may be read even though they
+	// match Patterns, using the same syntax. Set it in a project config to allow
+	// files such as fixtures or example certificates in that repository.
+	// Default: [".env.example", ".env.sample", ".env.template", "*.pub",
+	// "~/.ssh/config", "~/.ssh/known_hosts"]
+	Allow []string `json:"allow,omitempty" koanf:"allow" toml:"allow"`
+
+	// Action is what happens when a sensitive file is read: "block" or "ask".
+	// Default: "block"
+	Action string `json:"action,omitempty" koanf:"action" toml:"action"`
+}
+
+// GetAction returns the action for sensitive reads, defaulting to "block".
+func (c *SensitiveReadValidatorConfig) GetAction() string {
+	if c == nil || c.Action == "" {
+		return SensitiveReadActionBlock
+	}
+
+	return c.Action
+}
+
+// Actions for writes to protected paths.
+const (
+	// ProtectedPathActionBlock blocks the write.
+	ProtectedPathActionBlock = "block"
+
+	// ProtectedPathActionWarn allows the write with a warning.
+	ProtectedPathActionWarn = "warn"
+
+	// ProtectedPathActionAsk asks the user to approve the write.
+	ProtectedPathActionAsk = "ask"
+)
+
+// ValidProtectedPathActions are the valid values for protected_paths.paths action.
+var ValidProtectedPathActions = []string{
+	ProtectedPathActionBlock,
+	ProtectedPathActionWarn,
+	ProtectedPathActionAsk,
+}
+
+// ProtectedPathsValidatorConfig configures the validator for writes to protected
+// paths through Write, Edit and MultiEdit and through file writes of Bash commands
+// (redirections, tee, cp, mv, sed -i, ...). Disabled by default.
+type ProtectedPathsValidatorConfig struct {
+	ValidatorConfig `koanf:",squash"`
+
+	// Paths is the list of protected paths. Entries are checked in order and the
+	// first one matching the written path, or its symlink-resolved path, applies.
+	// Default: "/tmp" and "/var/tmp", blocked with "tmp" as replacement directory
+	Paths []ProtectedPathConfig `json:"paths,omitempty" koanf:"paths" toml:"paths"`
+}
+
+// ProtectedPathConfig is a single protected path entry. Exactly one of Pattern
+// and Regex must be set.
+type ProtectedPathConfig struct {
+	// Pattern is a glob pattern for the protected path. A path is protected when
+	// it or one of its parent directories matches, so "/etc" protects everything
+	// below /etc. Patterns without a slash match a file or directory name (e.g.,
+	// ".git", "vendor/"), patterns with a slash match the absolute path, with "~/"
+	// standing for the home directory and relative patterns resolved from the
+	// project root (e.g., "~/.claude/settings.json", "deploy/prod/**").
+	Pattern string `json:"pattern,omitempty" koanf:"pattern" toml:"pattern"`
+
+	// Regex is a regular expression matched against the absolute path.
+	Regex string `json:"regex,omitempty" koanf:"regex" toml:"regex"`
+
+	// Action is what happens when the path is written: "block", "warn" or "ask".
+	// Default: "block"
+	Action string `json:"action,omitempty" koanf:"action" toml:"action"`
+
+	// Message explains why the path is protected.
+	// Default: "" (a generic message)
+	Message string `json:"message,omitempty" koanf:"message" toml:"message"`
+
+	// ReplacementDir is a directory to suggest writing to instead (e.g., "tmp").
+	// Relative directories are resolved from the project root.
+	// Default: "" (no suggestion)
+	ReplacementDir string `json:"replacement_dir,omitempty" koanf:"replacement_dir" toml:"replacement_dir"`
+}
+
+// GetAction returns the action for writes to the path, defaulting to "block".
+func (c *ProtectedPathConfig) GetAction() string {
+	if c.Action == "" {
+		return ProtectedPathActionBlock
+	}
+
+	return c.Action
+}
@@ -26,6 +26,9 @@ type GitConfig struct {
 
 	// NoVerify validator configuration
 	NoVerify *NoVerifyValidatorConfig `json:"no_verify,omitempty" koanf:"no_verify" toml:"no_verify"`
+
+	// Destructive validator configuration
+	Destructive *DestructiveValidatorConfig `json:"destructive,omitempty" koanf:"destructive" toml:"destructive"`
 }
 
 // CommitValidatorConfig configures the git commit validator.
@@ -40,6 +43,12 @@ type CommitValidatorConfig struct {
 	// Default: true
 	CheckStagingArea *bool `json:"check_staging_area,omitempty" koanf:"check_staging_area" toml:"check_staging_area"`
 
+	// CheckStagedChanges enables checking the staged diff before commit: staged files
+	// must not match the add validator's blocked patterns, and the added lines must
+	// not contain secrets when the secrets validator is enabled.
+	// Default: true
+	CheckStagedChanges *bool `json:"check_staged_changes,omitempty" koanf:"check_staged_changes" toml:"check_staged_changes"`
+
 	// Message contains commit message validation settings.
 	Message *CommitMessageConfig `json:"message,omitempty" koanf:"message" toml:"message"`
 }
@@ -125,6 +134,16 @@ type PushValidatorConfig struct {
 	// RequireTracking requires branches to have remote tracking configured before push.
 	// Default: true
 	RequireTracking *bool `json:"require_tracking,omitempty" koanf:"require_tracking" toml:"require_tracking"`
+
+	// ProtectedBranches is a list of branch patterns that must never be force pushed,
+	// not even with --force-with-lease. Patterns use path.Match syntax (e.g., "release/*").
+	// Default: ["main", "master"]
+	ProtectedBranches []string `json:"protected_branches,omitempty" koanf:"protected_branches" toml:"protected_branches"`
+
+	// RequireForceWithLease requires force pushes to other branches to use
+	// --force-with-lease instead of --force, -f or a "+" refspec.
+	// Default: true
+	RequireForceWithLease *bool `json:"require_force_with_lease,omitempty" koanf:"require_force_with_lease" toml:"require_force_with_lease"`
 }
 
 // AddValidatorConfig configures the git add validator.
@@ -287,6 +306,15 @@ type NoVerifyValidatorConfig struct {
 	// This validator blocks --no-verify flag on git commit commands
 }
 
+// DestructiveValidatorConfig configures the destructive git operation validator.
+type DestructiveValidatorConfig struct {
+	ValidatorConfig `koanf:",squash"`
+
+	// No additional configuration beyond base ValidatorConfig
+	// This validator blocks git reset --hard, clean, checkout, restore, branch -D,
+	// stash drop/clear, push --delete and tag -d when they would lose work
+}
+
 // FetchValidatorConfig configures the git fetch validator.
 type FetchValidatorConfig struct {
 	ValidatorConfig `koanf:",squash"`
@@ -0,0 +1,34 @@
+// Package config provides configuration schema types for klaudiush validators.
+package config
+
+// PostConfig groups all validators that run on PostToolUse events,
+// after a tool call has completed.
+type PostConfig struct {
+	// FileLint validator configuration
+	FileLint *FileLintValidatorConfig `json:"file_lint,omitempty" koanf:"file_lint" toml:"file_lint"`
+
+	// CommitSignoff validator configuration
+	CommitSignoff *CommitSignoffValidatorConfig `json:"commit_signoff,omitempty" koanf:"commit_signoff" toml:"commit_signoff"`
+}
+
+// FileLintValidatorConfig configures the post-edit file lint validator.
+// It re-runs the enabled file validators against the file on disk after
+// Write, Edit and MultiEdit, so the whole file is linted instead of the edit fragment.
+type FileLintValidatorConfig struct {
+	ValidatorConfig `koanf:",squash"`
+}
+
+// CommitSignoffValidatorConfig configures the post-commit signoff validator.
+// It checks the commit created by a successful Bash git commit for a
+// Signed-off-by trailer. It only runs when the git commit validator is enabled
+// and its required flags include -s.
+type CommitSignoffValidatorConfig struct {
+	ValidatorConfig `koanf:",squash"`
+
+	// ExpectedSignoff is the expected Signed-off-by trailer value.
+	// When empty, the expected_signoff of the git commit message config is used,
+	// and any Signed-off-by trailer is accepted if that is empty too.
+	// Format: "Name <email@klaudiu.sh>"
+	// Default: ""
+	ExpectedSignoff string `json:"expected_signoff,omitempty" koanf:"expected_signoff" toml:"expected_signoff"`
+}
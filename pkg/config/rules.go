@@ -1,17 +1,32 @@
 // Package config provides configuration schema types for klaudiush validators.
 package config
 
+import "slices"
+
 // Valid values for rules configuration.
 // These are exported for use by validation and doctor packages.
 var (
 	// ValidActionTypes are the valid action types for rules.
-	ValidActionTypes = []string{"allow", "block", "warn"}
+	ValidActionTypes = []string{"allow", "ask", "block", "warn"}
 
 	// ValidEventTypes are the valid event types for rules (case-insensitive matching supported).
-	ValidEventTypes = []string{"PreToolUse", "PostToolUse", "Notification"}
+	ValidEventTypes = []string{
+		"PreToolUse",
+		"PostToolUse",
+		"Notification",
+		"UserPromptSubmit",
+		"Stop",
+		"SubagentStop",
+		"SessionStart",
+		"SessionEnd",
+		"PreCompact",
+	}
 
 	// ValidToolTypes are the valid tool types for rules (case-insensitive matching supported).
-	ValidToolTypes = []string{"Bash", "Write", "Edit", "MultiEdit", "Grep", "Read", "Glob"}
+	ValidToolTypes = []string{
+		"Bash", "Write", "Edit", "MultiEdit", "Grep", "Read", "Glob",
+		"WebFetch", "WebSearch", "Task", "NotebookEdit", "TodoWrite", "MCP",
+	}
 )
 
 // RulesConfig contains the dynamic rule configuration.
@@ -26,6 +41,59 @@ type RulesConfig struct {
 
 	// Rules is the list of validation rules.
 	Rules []RuleConfig `json:"rules,omitempty" koanf:"rules" toml:"rules"`
+
+	// Freezes is the freeze calendar, referenced by name from rule schedules.
+	Freezes []FreezeConfig `json:"freezes,omitempty" koanf:"freezes" toml:"freezes"`
+}
+
+// FreezeConfig is a named period, such as a release freeze, that rule
+// schedules can reference.
+type FreezeConfig struct {
+	// Name identifies the freeze in rule schedules.
+	Name string `json:"name,omitempty" koanf:"name" toml:"name"`
+
+	// Start is when the freeze starts, as a date ("2026-12-20"), a time
+	// ("2026-12-20T18:00") or an RFC 3339 time.
+	Start string `json:"start,omitempty" koanf:"start" toml:"start"`
+
+	// End is when the freeze ends. A date-only end includes the whole day.
+	End string `json:"end,omitempty" koanf:"end" toml:"end"`
+
+	// Reason explains the freeze and is shown in rule messages.
+	Reason string `json:"reason,omitempty" koanf:"reason" toml:"reason"`
+
+	// Timezone is the IANA time zone of start and end without offset.
+	// Default: local time
+	Timezone string `json:"timezone,omitempty" koanf:"timezone" toml:"timezone"`
+}
+
+// ScheduleConfig restricts a rule to points in time. It matches when the time
+// falls into any of its windows, date ranges or freezes.
+type ScheduleConfig struct {
+	// Windows are cron-like expressions of the minutes the rule applies in,
+	// as "minute hour day-of-month month day-of-week".
+	// Example: "* 18-23 * * fri" (Friday evenings), "* * * * sat,sun" (weekends)
+	Windows []string `json:"windows,omitempty" koanf:"windows" toml:"windows"`
+
+	// Dates are date ranges the rule applies in.
+	Dates []DateRangeConfig `json:"dates,omitempty" koanf:"dates" toml:"dates"`
+
+	// Freezes are names of freezes in the freeze calendar, or "*" for all.
+	Freezes []string `json:"freezes,omitempty" koanf:"freezes" toml:"freezes"`
+
+	// Timezone is the IANA time zone of windows and dates.
+	// Default: local time
+	Timezone string `json:"timezone,omitempty" koanf:"timezone" toml:"timezone"`
+}
+
+// DateRangeConfig is a range of dates or times.
+type DateRangeConfig struct {
+	// Start is the first date or time of the range.
+	Start string `json:"start,omitempty" koanf:"start" toml:"start"`
+
+	// End is the last date or the time the range ends. A date-only end
+	// includes the whole day.
+	End string `json:"end,omitempty" koanf:"end" toml:"end"`
 }
 
 // RuleConfig represents a single validation rule configuration.
@@ -104,6 +172,60 @@ type RuleMatchConfig struct {
 	// Examples: "PreToolUse", "PostToolUse"
 	EventType string `json:"event_type,omitempty" koanf:"event_type" toml:"event_type"`
 
+	// MCPServer matches against the MCP server name of mcp__<server>__<tool> tools.
+	// Supports glob patterns, regex, and negation (! prefix).
+	// Example: "github"
+	MCPServer string `json:"mcp_server,omitempty" koanf:"mcp_server" toml:"mcp_server"`
+
+	// MCPTool matches against the MCP tool name without the server prefix.
+	// Supports glob patterns, regex, and negation (! prefix).
+	// Example: "create_*"
+	MCPTool string `json:"mcp_tool,omitempty" koanf:"mcp_tool" toml:"mcp_tool"`
+
+	// URLDomain matches against the host name of the WebFetch URL.
+	// Supports glob patterns, regex, and negation (! prefix).
+	// Example: "*.example.com"
+	URLDomain string `json:"url_domain,omitempty" koanf:"url_domain" toml:"url_domain"`
+
+	// Dirty matches whether the working tree has staged, modified or untracked files.
+	Dirty *bool `json:"dirty,omitempty" koanf:"dirty" toml:"dirty"`
+
+	// StagedPattern matches when any staged file matches the pattern, or when
+	// none does for a negated pattern.
+	// Supports glob patterns (e.g., "migrations/**"), regex, and negation (! prefix).
+	StagedPattern string `json:"staged_pattern,omitempty" koanf:"staged_pattern" toml:"staged_pattern"`
+
+	// StagedPatterns allows multiple staged file patterns (any/all based on PatternMode).
+	StagedPatterns []string `json:"staged_patterns,omitempty" koanf:"staged_patterns" toml:"staged_patterns"`
+
+	// UpstreamPattern matches against the upstream of the current branch.
+	// Supports glob patterns (e.g., "origin/*"), regex, and negation (! prefix).
+	UpstreamPattern string `json:"upstream_pattern,omitempty" koanf:"upstream_pattern" toml:"upstream_pattern"`
+
+	// MinAhead matches when HEAD is at least this many commits ahead of the upstream.
+	MinAhead int `json:"min_ahead,omitempty" koanf:"min_ahead" toml:"min_ahead"`
+
+	// MinBehind matches when HEAD is at least this many commits behind the upstream.
+	MinBehind int `json:"min_behind,omitempty" koanf:"min_behind" toml:"min_behind"`
+
+	// HeadSigned matches whether the HEAD commit carries a signature.
+	HeadSigned *bool `json:"head_signed,omitempty" koanf:"head_signed" toml:"head_signed"`
+
+	// HeadAuthorPattern matches against the HEAD commit author as "Name <email>".
+	// Supports glob patterns, regex, and negation (! prefix).
+	// Example: "*@example.com>"
+	HeadAuthorPattern string `json:"head_author_pattern,omitempty" koanf:"head_author_pattern" toml:"head_author_pattern"`
+
+	// Schedule restricts the rule to time windows, date ranges and freezes.
+	Schedule *ScheduleConfig `json:"schedule,omitempty" koanf:"schedule" toml:"schedule"`
+
+	// When is an expression over the match context that must evaluate to true.
+	// Supports fields such as git.branch, git.ahead, file.path, command.argv and
+	// tool.name, the operators &&, ||, !, ==, !=, <, <=, > and >=, and the methods
+	// matches(), contains(), startsWith() and endsWith().
+	// Example: `git.branch == "main" && command.argv.contains("--force")`
+	When string `json:"when,omitempty" koanf:"when" toml:"when"`
+
 	// CaseInsensitive enables case-insensitive pattern matching for all patterns.
 	// Default: false
 	CaseInsensitive *bool `json:"case_insensitive,omitempty" koanf:"case_insensitive" toml:"case_insensitive"`
@@ -152,20 +274,39 @@ func (m *RuleMatchConfig) HasMatchConditions() bool {
 		m.CommandPattern != "" ||
 		len(m.CommandPatterns) > 0 ||
 		m.ToolType != "" ||
-		m.EventType != ""
+		m.EventType != "" ||
+		m.MCPServer != "" ||
+		m.MCPTool != "" ||
+		m.URLDomain != "" ||
+		m.Dirty != nil ||
+		m.StagedPattern != "" ||
+		len(m.StagedPatterns) > 0 ||
+		m.UpstreamPattern != "" ||
+		m.MinAhead > 0 ||
+		m.MinBehind > 0 ||
+		m.HeadSigned != nil ||
+		m.HeadAuthorPattern != "" ||
+		m.Schedule != nil ||
+		m.When != ""
 }
 
 // RuleActionConfig specifies what happens when a rule matches.
 type RuleActionConfig struct {
-	// Type is the action to take (block, warn, allow).
+	// Type is the action to take (block, warn, allow, ask).
+	// "ask" escalates to the user for approval instead of blocking or allowing.
 	// Default: "block"
 	Type string `json:"type,omitempty" koanf:"type" toml:"type"`
 
 	// Message is the human-readable message to display.
+	// Supports Go templates with match data, e.g. "Push to {{.Git.Branch}} blocked".
 	Message string `json:"message,omitempty" koanf:"message" toml:"message"`
 
 	// Reference is an optional error reference code (e.g., "GIT019").
 	Reference string `json:"reference,omitempty" koanf:"reference" toml:"reference"`
+
+	// FixHint is an optional short suggestion for fixing the issue.
+	// Supports the same templates as Message.
+	FixHint string `json:"fix_hint,omitempty" koanf:"fix_hint" toml:"fix_hint"`
 }
 
 // IsEnabled returns true if the rules engine is enabled.
@@ -188,6 +329,38 @@ func (r *RulesConfig) ShouldStopOnFirstMatch() bool {
 	return *r.StopOnFirstMatch
 }
 
+// FreezeWildcard references all freezes of the freeze calendar.
+const FreezeWildcard = "*"
+
+// ResolveFreezes returns the freezes referenced by name, with FreezeWildcard
+// referencing all of them, and the names missing from the freeze calendar.
+func (r *RulesConfig) ResolveFreezes(names []string) (freezes []FreezeConfig, unknown []string) {
+	for _, name := range names {
+		if name == FreezeWildcard {
+			if r != nil {
+				freezes = append(freezes, r.Freezes...)
+			}
+
+			continue
+		}
+
+		i := -1
+		if r != nil {
+			i = slices.IndexFunc(r.Freezes, func(f FreezeConfig) bool { return f.Name == name })
+		}
+
+		if i < 0 {
+			unknown = append(unknown, name)
+
+			continue
+		}
+
+		freezes = append(freezes, r.Freezes[i])
+	}
+
+	return freezes, unknown
+}
+
 // IsRuleEnabled returns true if the rule is enabled.
 // Returns true if Enabled is nil (default behavior).
 func (r *RuleConfig) IsRuleEnabled() bool {
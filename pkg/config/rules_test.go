@@ -55,6 +55,34 @@ var _ = Describe("RulesConfig", func() {
 			Expect(cfg.ShouldStopOnFirstMatch()).To(BeTrue())
 		})
 	})
+
+	Describe("ResolveFreezes", func() {
+		cfg := &config.RulesConfig{
+			Freezes: []config.FreezeConfig{{Name: "q4"}, {Name: "release-2.0"}},
+		}
+
+		It("should return the freezes referenced by name", func() {
+			freezes, unknown := cfg.ResolveFreezes([]string{"release-2.0"})
+			Expect(freezes).To(Equal([]config.FreezeConfig{{Name: "release-2.0"}}))
+			Expect(unknown).To(BeEmpty())
+		})
+
+		It("should return all freezes for the wildcard", func() {
+			freezes, unknown := cfg.ResolveFreezes([]string{config.FreezeWildcard})
+			Expect(freezes).To(HaveLen(2))
+			Expect(unknown).To(BeEmpty())
+		})
+
+		It("should return unknown names", func() {
+			_, unknown := cfg.ResolveFreezes([]string{"q4", "q1"})
+			Expect(unknown).To(Equal([]string{"q1"}))
+
+			var empty *config.RulesConfig
+
+			_, unknown = empty.ResolveFreezes([]string{"q4"})
+			Expect(unknown).To(Equal([]string{"q4"}))
+		})
+	})
 })
 
 var _ = Describe("RuleConfig", func() {
@@ -205,6 +233,13 @@ var _ = Describe("RuleMatchConfig", func() {
 			cfg := &config.RuleMatchConfig{EventType: "PreToolUse"}
 			Expect(cfg.HasMatchConditions()).To(BeTrue())
 		})
+
+		It("should return true when Schedule is set", func() {
+			cfg := &config.RuleMatchConfig{
+				Schedule: &config.ScheduleConfig{Windows: []string{"* * * * sat,sun"}},
+			}
+			Expect(cfg.HasMatchConditions()).To(BeTrue())
+		})
 	})
 })
 
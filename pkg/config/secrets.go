@@ -1,5 +1,7 @@
 package config
 
+import "path/filepath"
+
 // SecretsConfig groups all secrets-related validator configurations.
 type SecretsConfig struct {
 	// Secrets validator configuration
@@ -20,6 +22,13 @@ type SecretsValidatorConfig struct {
 	// Default: "" (use PATH)
 	GitleaksPath string `json:"gitleaks_path,omitempty" koanf:"gitleaks_path" toml:"gitleaks_path"`
 
+	// GitleaksConfig is the path of a gitleaks configuration file (.gitleaks.toml).
+	// Its rules, allowlists, keywords and entropy thresholds are applied by the
+	// built-in detector, so the gitleaks binary is not needed. Relative paths
+	// are resolved from the project root.
+	// Default: "" (none)
+	GitleaksConfig string `json:"gitleaks_config,omitempty" koanf:"gitleaks_config" toml:"gitleaks_config"`
+
 	// MaxFileSize is the maximum file size to scan in bytes.
 	// Files larger than this are skipped to avoid performance issues.
 	// Default: "1MB"
@@ -43,6 +52,37 @@ type SecretsValidatorConfig struct {
 	// DisabledPatterns is a list of built-in pattern names to disable.
 	// Use this to reduce false positives from specific pattern types.
 	DisabledPatterns []string `json:"disabled_patterns,omitempty" koanf:"disabled_patterns" toml:"disabled_patterns"`
+
+	// Entropy configures detection of random-looking strings assigned to
+	// secret-like names such as api_key, secret or token.
+	Entropy *SecretsEntropyConfig `json:"entropy,omitempty" koanf:"entropy" toml:"entropy"`
+
+	// BaselineFile is the path of the secrets baseline, relative to the project root.
+	// Findings recorded in the baseline are ignored. Manage it with
+	// "klaudiush secrets baseline create|audit|update".
+	// Default: ".klaudiush/secrets-baseline.json"
+	BaselineFile string `json:"baseline_file,omitempty" koanf:"baseline_file" toml:"baseline_file"`
+}
+
+// SecretsEntropyConfig configures high-entropy string detection.
+type SecretsEntropyConfig struct {
+	// Enabled controls whether high-entropy strings are reported.
+	// Default: true
+	Enabled *bool `json:"enabled,omitempty" koanf:"enabled" toml:"enabled"`
+
+	// Base64Threshold is the minimum Shannon entropy (bits per character) of a
+	// base64 string to be reported.
+	// Default: 4.5
+	Base64Threshold float64 `json:"base64_threshold,omitempty" koanf:"base64_threshold" toml:"base64_threshold"`
+
+	// HexThreshold is the minimum Shannon entropy (bits per character) of a
+	// hex string to be reported.
+	// Default: 3.0
+	HexThreshold float64 `json:"hex_threshold,omitempty" koanf:"hex_threshold" toml:"hex_threshold"`
+
+	// MinLength is the minimum length of a string to be checked.
+	// Default: 20
+	MinLength int `json:"min_length,omitempty" koanf:"min_length" toml:"min_length"`
 }
 
 // CustomPatternConfig defines a custom secret detection pattern.
@@ -70,6 +110,21 @@ const (
 // DefaultMaxFileSize is the default maximum file size for secrets scanning.
 const DefaultMaxFileSize = MB
 
+// Secrets baseline and entropy defaults.
+const (
+	// DefaultSecretsBaselineFile is the default secrets baseline path.
+	DefaultSecretsBaselineFile = ".klaudiush/secrets-baseline.json"
+
+	// DefaultEntropyBase64Threshold is the default entropy threshold for base64 strings.
+	DefaultEntropyBase64Threshold = 4.5
+
+	// DefaultEntropyHexThreshold is the default entropy threshold for hex strings.
+	DefaultEntropyHexThreshold = 3.0
+
+	// DefaultEntropyMinLength is the default minimum length of checked strings.
+	DefaultEntropyMinLength = 20
+)
+
 // IsUseGitleaksEnabled returns whether gitleaks integration is enabled.
 func (c *SecretsValidatorConfig) IsUseGitleaksEnabled() bool {
 	if c == nil || c.UseGitleaks == nil {
@@ -96,3 +151,62 @@ func (c *SecretsValidatorConfig) GetMaxFileSize() ByteSize {
 
 	return c.MaxFileSize
 }
+
+// GetBaselineFile returns the configured baseline path or the default.
+func (c *SecretsValidatorConfig) GetBaselineFile() string {
+	if c == nil || c.BaselineFile == "" {
+		return DefaultSecretsBaselineFile
+	}
+
+	return c.BaselineFile
+}
+
+// GetGitleaksConfigPath returns the path of the gitleaks config of the project
+// at root, or an empty string when none is configured.
+func (c *SecretsValidatorConfig) GetGitleaksConfigPath(root string) string {
+	if c == nil || c.GitleaksConfig == "" {
+		return ""
+	}
+
+	if filepath.IsAbs(c.GitleaksConfig) {
+		return c.GitleaksConfig
+	}
+
+	return filepath.Join(root, c.GitleaksConfig)
+}
+
+// IsEnabled returns whether high-entropy detection is enabled.
+func (c *SecretsEntropyConfig) IsEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		return true
+	}
+
+	return *c.Enabled
+}
+
+// GetBase64Threshold returns the configured base64 threshold or the default.
+func (c *SecretsEntropyConfig) GetBase64Threshold() float64 {
+	if c == nil || c.Base64Threshold <= 0 {
+		return DefaultEntropyBase64Threshold
+	}
+
+	return c.Base64Threshold
+}
+
+// GetHexThreshold returns the configured hex threshold or the default.
+func (c *SecretsEntropyConfig) GetHexThreshold() float64 {
+	if c == nil || c.HexThreshold <= 0 {
+		return DefaultEntropyHexThreshold
+	}
+
+	return c.HexThreshold
+}
+
+// GetMinLength returns the configured minimum length or the default.
+func (c *SecretsEntropyConfig) GetMinLength() int {
+	if c == nil || c.MinLength <= 0 {
+		return DefaultEntropyMinLength
+	}
+
+	return c.MinLength
+}
@@ -82,4 +82,40 @@ var _ = Describe("SecretsValidatorConfig", func() {
 			Expect(cfg.GetMaxFileSize()).To(Equal(5 * config.MB))
 		})
 	})
+
+	Describe("GetBaselineFile", func() {
+		It("returns default for nil config", func() {
+			var cfg *config.SecretsValidatorConfig
+			Expect(cfg.GetBaselineFile()).To(Equal(".klaudiush/secrets-baseline.json"))
+		})
+
+		It("returns configured value when set", func() {
+			cfg := &config.SecretsValidatorConfig{BaselineFile: "secrets.json"}
+			Expect(cfg.GetBaselineFile()).To(Equal("secrets.json"))
+		})
+	})
+})
+
+var _ = Describe("SecretsEntropyConfig", func() {
+	It("returns defaults for nil config", func() {
+		var cfg *config.SecretsEntropyConfig
+		Expect(cfg.IsEnabled()).To(BeTrue())
+		Expect(cfg.GetBase64Threshold()).To(Equal(config.DefaultEntropyBase64Threshold))
+		Expect(cfg.GetHexThreshold()).To(Equal(config.DefaultEntropyHexThreshold))
+		Expect(cfg.GetMinLength()).To(Equal(config.DefaultEntropyMinLength))
+	})
+
+	It("returns configured values when set", func() {
+		enabled := false
+		cfg := &config.SecretsEntropyConfig{
+			Enabled:         &enabled,
+			Base64Threshold: 5,
+			HexThreshold:    3.5,
+			MinLength:       32,
+		}
+		Expect(cfg.IsEnabled()).To(BeFalse())
+		Expect(cfg.GetBase64Threshold()).To(Equal(5.0))
+		Expect(cfg.GetHexThreshold()).To(Equal(3.5))
+		Expect(cfg.GetMinLength()).To(Equal(32))
+	})
 })
@@ -5,6 +5,9 @@ package config
 type ShellConfig struct {
 	// Backtick validator configuration
 	Backtick *BacktickValidatorConfig `json:"backtick,omitempty" koanf:"backtick" toml:"backtick"`
+
+	// Destructive validator configuration
+	Destructive *ShellDestructiveValidatorConfig `json:"destructive,omitempty" koanf:"destructive" toml:"destructive"`
 }
 
 // BacktickValidatorConfig configures the backtick validator.
@@ -42,3 +45,22 @@ func (c *BacktickValidatorConfig) SuggestSingleQuotesOrDefault() bool {
 
 	return *c.SuggestSingleQuotes
 }
+
+// DefaultShellSafeTargets is the default for shell.destructive.safe_targets.
+var DefaultShellSafeTargets = []string{
+	"**/node_modules", "**/__pycache__", "/tmp/**", "/private/tmp/**",
+}
+
+// ShellDestructiveValidatorConfig configures the destructive shell command validator.
+type ShellDestructiveValidatorConfig struct {
+	ValidatorConfig `koanf:",squash"`
+
+	// SafeTargets is a list of glob patterns for paths that may always be removed or
+	// modified. Patterns with a slash match the absolute path (e.g., "/tmp/**"), and
+	// "**/<name>" patterns (e.g., "**/node_modules") also match targets behind
+	// variables such as "$OUT/node_modules". Patterns without a slash only match the
+	// base name of paths inside the project, so "dist" does not allow "/etc/dist".
+	// The filesystem root, the home directory and the project root are never safe.
+	// Default: ["**/node_modules", "**/__pycache__", "/tmp/**", "/private/tmp/**"]
+	SafeTargets []string `json:"safe_targets,omitempty" koanf:"safe_targets" toml:"safe_targets"`
+}
@@ -1,7 +1,11 @@
 // Package hook provides core types for Claude Code hook context.
 package hook
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
 
 //go:generate enumer -type=EventType -trimprefix=EventType -json -text -yaml -sql
 //go:generate go run github.com/smykla-labs/klaudiush/tools/enumerfix eventtype_enumer.go
@@ -23,8 +27,47 @@ const (
 
 	// EventTypeNotification is triggered for user notifications.
 	EventTypeNotification
+
+	// EventTypeUserPromptSubmit is triggered when the user submits a prompt.
+	EventTypeUserPromptSubmit
+
+	// EventTypeStop is triggered when the main agent finishes responding.
+	EventTypeStop
+
+	// EventTypeSubagentStop is triggered when a subagent finishes responding.
+	EventTypeSubagentStop
+
+	// EventTypeSessionStart is triggered when a session starts or resumes.
+	EventTypeSessionStart
+
+	// EventTypeSessionEnd is triggered when a session ends.
+	EventTypeSessionEnd
+
+	// EventTypePreCompact is triggered before the conversation is compacted.
+	EventTypePreCompact
 )
 
+// IsLifecycleEvent returns true if the event belongs to the session lifecycle
+// rather than to a tool invocation or notification.
+func (i EventType) IsLifecycleEvent() bool {
+	switch i {
+	case EventTypeUserPromptSubmit,
+		EventTypeStop,
+		EventTypeSubagentStop,
+		EventTypeSessionStart,
+		EventTypeSessionEnd,
+		EventTypePreCompact:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsStopEvent returns true if the event is triggered when an agent finishes responding.
+func (i EventType) IsStopEvent() bool {
+	return i == EventTypeStop || i == EventTypeSubagentStop
+}
+
 // ToolType represents the type of tool being used.
 type ToolType int
 
@@ -52,8 +95,49 @@ const (
 
 	// ToolTypeGlob represents the Glob tool for finding files by pattern.
 	ToolTypeGlob
+
+	// ToolTypeWebFetch represents the WebFetch tool for fetching URLs.
+	ToolTypeWebFetch
+
+	// ToolTypeWebSearch represents the WebSearch tool for searching the web.
+	ToolTypeWebSearch
+
+	// ToolTypeTask represents the Task tool for launching subagents.
+	ToolTypeTask
+
+	// ToolTypeNotebookEdit represents the NotebookEdit tool for editing Jupyter notebook cells.
+	ToolTypeNotebookEdit
+
+	// ToolTypeTodoWrite represents the TodoWrite tool for managing the todo list.
+	ToolTypeTodoWrite
+
+	// ToolTypeMCP represents any tool provided by an MCP server (mcp__<server>__<tool>).
+	// The server and tool names are available in Context.MCPServer and Context.MCPTool.
+	ToolTypeMCP
 )
 
+// MCPToolPrefix is the tool name prefix of tools provided by MCP servers.
+const MCPToolPrefix = "mcp__"
+
+// mcpNameSeparator separates the MCP server name from the tool name.
+const mcpNameSeparator = "__"
+
+// ParseMCPToolName splits an MCP tool name of the form mcp__<server>__<tool>
+// into its server and tool names. Returns false if the name is not an MCP tool name.
+func ParseMCPToolName(name string) (server, tool string, ok bool) {
+	rest, found := strings.CutPrefix(name, MCPToolPrefix)
+	if !found {
+		return "", "", false
+	}
+
+	server, tool, found = strings.Cut(rest, mcpNameSeparator)
+	if !found || server == "" || tool == "" {
+		return "", "", false
+	}
+
+	return server, tool, true
+}
+
 // ToolInput contains the raw tool input data.
 type ToolInput struct {
 	// Command is the shell command for Bash tool.
@@ -77,13 +161,104 @@ type ToolInput struct {
 	// Pattern is the search pattern for Grep/Glob tools.
 	Pattern string `json:"pattern,omitempty"`
 
+	// Glob is the file name filter for Grep tool (e.g., "*.go").
+	Glob string `json:"glob,omitempty"`
+
+	// Edits is the list of replacements for MultiEdit tool, applied in order.
+	Edits []Edit `json:"edits,omitempty"`
+
+	// URL is the URL to fetch for WebFetch tool.
+	URL string `json:"url,omitempty"`
+
+	// Prompt is the prompt for WebFetch and Task tools.
+	Prompt string `json:"prompt,omitempty"`
+
+	// Query is the search query for WebSearch tool.
+	Query string `json:"query,omitempty"`
+
+	// Description is the short task description for Task tool.
+	Description string `json:"description,omitempty"`
+
+	// SubagentType is the type of subagent to launch for Task tool.
+	SubagentType string `json:"subagent_type,omitempty"`
+
+	// NotebookPath is the notebook file path for NotebookEdit tool.
+	NotebookPath string `json:"notebook_path,omitempty"`
+
+	// CellID is the ID of the edited cell for NotebookEdit tool.
+	CellID string `json:"cell_id,omitempty"`
+
+	// NewSource is the new cell source for NotebookEdit tool.
+	NewSource string `json:"new_source,omitempty"`
+
+	// EditMode is the NotebookEdit mode: replace, insert or delete.
+	EditMode string `json:"edit_mode,omitempty"`
+
 	// Additional fields stored as raw JSON.
 	Additional map[string]json.RawMessage `json:"-"`
 }
 
+// Edit is a single string replacement of a MultiEdit operation.
+type Edit struct {
+	// OldString is the string to replace.
+	OldString string `json:"old_string"`
+
+	// NewString is the replacement string.
+	NewString string `json:"new_string"`
+
+	// ReplaceAll replaces all occurrences of OldString instead of the first one.
+	ReplaceAll bool `json:"replace_all,omitempty"`
+}
+
+// ToolResponse contains the result of a completed tool call (for PostToolUse events).
+// Fields that the tool did not report are left at their zero values.
+type ToolResponse struct {
+	// Stdout is the standard output of a Bash command.
+	Stdout string
+
+	// Stderr is the standard error of a Bash command.
+	Stderr string
+
+	// ExitCode is the exit code of a Bash command, nil when not reported.
+	ExitCode *int
+
+	// Interrupted is true when the command was interrupted before completing.
+	Interrupted bool
+
+	// FilePath is the path of the file written by Write, Edit or MultiEdit.
+	FilePath string
+
+	// Success is the success flag reported by some tools, nil when not reported.
+	Success *bool
+
+	// Error is the error message for failed tool calls or string responses.
+	Error string
+
+	// Raw contains the original tool_response JSON.
+	Raw string
+}
+
+// Succeeded returns true unless the response reports an interruption,
+// a non-zero exit code, an explicit failure or an error.
+func (r *ToolResponse) Succeeded() bool {
+	if r == nil {
+		return true
+	}
+
+	if r.Interrupted || r.Error != "" {
+		return false
+	}
+
+	if r.ExitCode != nil && *r.ExitCode != 0 {
+		return false
+	}
+
+	return r.Success == nil || *r.Success
+}
+
 // Context represents the complete hook invocation context.
 type Context struct {
-	// EventType is the type of hook event (PreToolUse, PostToolUse, Notification).
+	// EventType is the type of hook event (PreToolUse, PostToolUse, Stop, ...).
 	EventType EventType
 
 	// ToolName is the name of the tool being invoked.
@@ -92,9 +267,37 @@ type Context struct {
 	// ToolInput contains the tool-specific input parameters.
 	ToolInput ToolInput
 
+	// MCPServer is the MCP server name (for ToolTypeMCP), e.g. "github".
+	MCPServer string
+
+	// MCPTool is the MCP tool name without the server prefix (for ToolTypeMCP),
+	// e.g. "create_issue".
+	MCPTool string
+
 	// NotificationType is the type of notification (for Notification events).
 	NotificationType string
 
+	// Prompt is the submitted prompt text (for UserPromptSubmit events).
+	Prompt string
+
+	// StopHookActive is true when Claude is already continuing because of a
+	// stop hook (for Stop and SubagentStop events).
+	StopHookActive bool
+
+	// Source is how the session started: startup, resume, clear or compact
+	// (for SessionStart events).
+	Source string
+
+	// Trigger is what started the compaction: manual or auto (for PreCompact events).
+	Trigger string
+
+	// Reason is why the session ended, e.g. clear, logout or prompt_input_exit
+	// (for SessionEnd events).
+	Reason string
+
+	// ToolResponse contains the tool result (for PostToolUse events), nil otherwise.
+	ToolResponse *ToolResponse
+
 	// RawJSON contains the original JSON input for advanced parsing.
 	RawJSON string
 
@@ -106,6 +309,9 @@ type Context struct {
 
 	// TranscriptPath is the path to the session transcript file.
 	TranscriptPath string
+
+	// Cwd is the working directory of the Claude Code session.
+	Cwd string
 }
 
 // GetCommand returns the command from ToolInput.
@@ -113,20 +319,60 @@ func (c *Context) GetCommand() string {
 	return c.ToolInput.Command
 }
 
-// GetFilePath returns the file path from ToolInput, preferring FilePath over Path.
+// GetFilePath returns the file path from ToolInput, preferring FilePath over Path,
+// and falling back to the notebook path of NotebookEdit.
 func (c *Context) GetFilePath() string {
 	if c.ToolInput.FilePath != "" {
 		return c.ToolInput.FilePath
 	}
 
-	return c.ToolInput.Path
+	if c.ToolInput.Path != "" {
+		return c.ToolInput.Path
+	}
+
+	return c.ToolInput.NotebookPath
 }
 
-// GetContent returns the file content from ToolInput.
+// GetResultFilePath returns the file path reported in the tool response,
+// falling back to the file path from ToolInput.
+func (c *Context) GetResultFilePath() string {
+	if c.ToolResponse != nil && c.ToolResponse.FilePath != "" {
+		return c.ToolResponse.FilePath
+	}
+
+	return c.GetFilePath()
+}
+
+// GetContent returns the file content from ToolInput. For NotebookEdit, it
+// returns the new cell source.
 func (c *Context) GetContent() string {
+	if c.ToolName == ToolTypeNotebookEdit {
+		return c.ToolInput.NewSource
+	}
+
 	return c.ToolInput.Content
 }
 
+// GetURL returns the URL from ToolInput (for WebFetch).
+func (c *Context) GetURL() string {
+	return c.ToolInput.URL
+}
+
+// GetURLDomain returns the lowercased host name of the WebFetch URL, without
+// the port. Returns an empty string when the URL is missing or invalid.
+func (c *Context) GetURLDomain() string {
+	if c.ToolInput.URL == "" {
+		return ""
+	}
+
+	parsed, err := url.Parse(c.ToolInput.URL)
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(parsed.Hostname())
+}
+
 // IsBashTool returns true if the tool is Bash.
 func (c *Context) IsBashTool() bool {
 	return c.ToolName == ToolTypeBash
@@ -139,6 +385,16 @@ func (c *Context) IsFileTool() bool {
 		c.ToolName == ToolTypeMultiEdit
 }
 
+// IsMCPTool returns true if the tool is provided by an MCP server.
+func (c *Context) IsMCPTool() bool {
+	return c.ToolName == ToolTypeMCP
+}
+
+// HasToolResponse returns true if a tool response is present.
+func (c *Context) HasToolResponse() bool {
+	return c.ToolResponse != nil
+}
+
 // HasSessionID returns true if a session ID is present.
 func (c *Context) HasSessionID() bool {
 	return c.SessionID != ""
@@ -10,11 +10,11 @@ import (
 	"github.com/cockroachdb/errors"
 )
 
-const _EventTypeName = "UnknownPreToolUsePostToolUseNotification"
+const _EventTypeName = "UnknownPreToolUsePostToolUseNotificationUserPromptSubmitStopSubagentStopSessionStartSessionEndPreCompact"
 
-var _EventTypeIndex = [...]uint8{0, 7, 17, 28, 40}
+var _EventTypeIndex = [...]uint8{0, 7, 17, 28, 40, 56, 60, 72, 84, 94, 104}
 
-const _EventTypeLowerName = "unknownpretooluseposttoolusenotification"
+const _EventTypeLowerName = "unknownpretooluseposttoolusenotificationuserpromptsubmitstopsubagentstopsessionstartsessionendprecompact"
 
 func (i EventType) String() string {
 	if i < 0 || i >= EventType(len(_EventTypeIndex)-1) {
@@ -31,19 +31,37 @@ func _EventTypeNoOp() {
 	_ = x[EventTypePreToolUse-(1)]
 	_ = x[EventTypePostToolUse-(2)]
 	_ = x[EventTypeNotification-(3)]
+	_ = x[EventTypeUserPromptSubmit-(4)]
+	_ = x[EventTypeStop-(5)]
+	_ = x[EventTypeSubagentStop-(6)]
+	_ = x[EventTypeSessionStart-(7)]
+	_ = x[EventTypeSessionEnd-(8)]
+	_ = x[EventTypePreCompact-(9)]
 }
 
-var _EventTypeValues = []EventType{EventTypeUnknown, EventTypePreToolUse, EventTypePostToolUse, EventTypeNotification}
+var _EventTypeValues = []EventType{EventTypeUnknown, EventTypePreToolUse, EventTypePostToolUse, EventTypeNotification, EventTypeUserPromptSubmit, EventTypeStop, EventTypeSubagentStop, EventTypeSessionStart, EventTypeSessionEnd, EventTypePreCompact}
 
 var _EventTypeNameToValueMap = map[string]EventType{
-	_EventTypeName[0:7]:        EventTypeUnknown,
-	_EventTypeLowerName[0:7]:   EventTypeUnknown,
-	_EventTypeName[7:17]:       EventTypePreToolUse,
-	_EventTypeLowerName[7:17]:  EventTypePreToolUse,
-	_EventTypeName[17:28]:      EventTypePostToolUse,
-	_EventTypeLowerName[17:28]: EventTypePostToolUse,
-	_EventTypeName[28:40]:      EventTypeNotification,
-	_EventTypeLowerName[28:40]: EventTypeNotification,
+	_EventTypeName[0:7]:         EventTypeUnknown,
+	_EventTypeLowerName[0:7]:    EventTypeUnknown,
+	_EventTypeName[7:17]:        EventTypePreToolUse,
+	_EventTypeLowerName[7:17]:   EventTypePreToolUse,
+	_EventTypeName[17:28]:       EventTypePostToolUse,
+	_EventTypeLowerName[17:28]:  EventTypePostToolUse,
+	_EventTypeName[28:40]:       EventTypeNotification,
+	_EventTypeLowerName[28:40]:  EventTypeNotification,
+	_EventTypeName[40:56]:       EventTypeUserPromptSubmit,
+	_EventTypeLowerName[40:56]:  EventTypeUserPromptSubmit,
+	_EventTypeName[56:60]:       EventTypeStop,
+	_EventTypeLowerName[56:60]:  EventTypeStop,
+	_EventTypeName[60:72]:       EventTypeSubagentStop,
+	_EventTypeLowerName[60:72]:  EventTypeSubagentStop,
+	_EventTypeName[72:84]:       EventTypeSessionStart,
+	_EventTypeLowerName[72:84]:  EventTypeSessionStart,
+	_EventTypeName[84:94]:       EventTypeSessionEnd,
+	_EventTypeLowerName[84:94]:  EventTypeSessionEnd,
+	_EventTypeName[94:104]:      EventTypePreCompact,
+	_EventTypeLowerName[94:104]: EventTypePreCompact,
 }
 
 var _EventTypeNames = []string{
@@ -51,6 +69,12 @@ var _EventTypeNames = []string{
 	_EventTypeName[7:17],
 	_EventTypeName[17:28],
 	_EventTypeName[28:40],
+	_EventTypeName[40:56],
+	_EventTypeName[56:60],
+	_EventTypeName[60:72],
+	_EventTypeName[72:84],
+	_EventTypeName[84:94],
+	_EventTypeName[94:104],
 }
 
 // EventTypeString retrieves an enum value from the enum constants string name.
@@ -10,11 +10,11 @@ import (
 	"github.com/cockroachdb/errors"
 )
 
-const _ToolTypeName = "UnknownBashWriteEditMultiEditGrepReadGlob"
+const _ToolTypeName = "UnknownBashWriteEditMultiEditGrepReadGlobWebFetchWebSearchTaskNotebookEditTodoWriteMCP"
 
-var _ToolTypeIndex = [...]uint8{0, 7, 11, 16, 20, 29, 33, 37, 41}
+var _ToolTypeIndex = [...]uint8{0, 7, 11, 16, 20, 29, 33, 37, 41, 49, 58, 62, 74, 83, 86}
 
-const _ToolTypeLowerName = "unknownbashwriteeditmultieditgrepreadglob"
+const _ToolTypeLowerName = "unknownbashwriteeditmultieditgrepreadglobwebfetchwebsearchtasknotebookedittodowritemcp"
 
 func (i ToolType) String() string {
 	if i < 0 || i >= ToolType(len(_ToolTypeIndex)-1) {
@@ -35,9 +35,15 @@ func _ToolTypeNoOp() {
 	_ = x[ToolTypeGrep-(5)]
 	_ = x[ToolTypeRead-(6)]
 	_ = x[ToolTypeGlob-(7)]
+	_ = x[ToolTypeWebFetch-(8)]
+	_ = x[ToolTypeWebSearch-(9)]
+	_ = x[ToolTypeTask-(10)]
+	_ = x[ToolTypeNotebookEdit-(11)]
+	_ = x[ToolTypeTodoWrite-(12)]
+	_ = x[ToolTypeMCP-(13)]
 }
 
-var _ToolTypeValues = []ToolType{ToolTypeUnknown, ToolTypeBash, ToolTypeWrite, ToolTypeEdit, ToolTypeMultiEdit, ToolTypeGrep, ToolTypeRead, ToolTypeGlob}
+var _ToolTypeValues = []ToolType{ToolTypeUnknown, ToolTypeBash, ToolTypeWrite, ToolTypeEdit, ToolTypeMultiEdit, ToolTypeGrep, ToolTypeRead, ToolTypeGlob, ToolTypeWebFetch, ToolTypeWebSearch, ToolTypeTask, ToolTypeNotebookEdit, ToolTypeTodoWrite, ToolTypeMCP}
 
 var _ToolTypeNameToValueMap = map[string]ToolType{
 	_ToolTypeName[0:7]:        ToolTypeUnknown,
@@ -56,6 +62,18 @@ var _ToolTypeNameToValueMap = map[string]ToolType{
 	_ToolTypeLowerName[33:37]: ToolTypeRead,
 	_ToolTypeName[37:41]:      ToolTypeGlob,
 	_ToolTypeLowerName[37:41]: ToolTypeGlob,
+	_ToolTypeName[41:49]:      ToolTypeWebFetch,
+	_ToolTypeLowerName[41:49]: ToolTypeWebFetch,
+	_ToolTypeName[49:58]:      ToolTypeWebSearch,
+	_ToolTypeLowerName[49:58]: ToolTypeWebSearch,
+	_ToolTypeName[58:62]:      ToolTypeTask,
+	_ToolTypeLowerName[58:62]: ToolTypeTask,
+	_ToolTypeName[62:74]:      ToolTypeNotebookEdit,
+	_ToolTypeLowerName[62:74]: ToolTypeNotebookEdit,
+	_ToolTypeName[74:83]:      ToolTypeTodoWrite,
+	_ToolTypeLowerName[74:83]: ToolTypeTodoWrite,
+	_ToolTypeName[83:86]:      ToolTypeMCP,
+	_ToolTypeLowerName[83:86]: ToolTypeMCP,
 }
 
 var _ToolTypeNames = []string{
@@ -67,6 +85,12 @@ var _ToolTypeNames = []string{
 	_ToolTypeName[29:33],
 	_ToolTypeName[33:37],
 	_ToolTypeName[37:41],
+	_ToolTypeName[41:49],
+	_ToolTypeName[49:58],
+	_ToolTypeName[58:62],
+	_ToolTypeName[62:74],
+	_ToolTypeName[74:83],
+	_ToolTypeName[83:86],
 }
 
 // ToolTypeString retrieves an enum value from the enum constants string name.
@@ -0,0 +1,78 @@
+package parser
+
+import (
+	"strings"
+
+	"mvdan.cc/sh/v3/syntax"
+)
+
+// Assignment represents a variable assignment, either before a command
+// (FOO=bar cmd), on its own (FOO=bar) or in a declaration (export FOO=bar).
+type Assignment struct {
+	Name     string   // Variable name
+	Value    string   // Value after quote removal
+	Raw      string   // Assignment as written, including quotes
+	Source   string   // Declaration builtin (export, declare, local, readonly), empty otherwise
+	Location Location // Position in source
+}
+
+// Heredoc represents the body of a here-document.
+type Heredoc struct {
+	Content  string   // Body of the here-document
+	Location Location // Position of the << operator
+}
+
+// extractAssignments records the assignments of a command or declaration.
+func (w *astWalker) extractAssignments(assigns []*syntax.Assign, source string) {
+	printer := syntax.NewPrinter()
+
+	for _, assign := range assigns {
+		// Naked assignments are declaration options (-x) or names without a value
+		if assign.Naked || assign.Name == nil {
+			continue
+		}
+
+		var raw strings.Builder
+		if err := printer.Print(&raw, assign); err != nil {
+			continue
+		}
+
+		var value string
+		if assign.Value != nil {
+			value = wordLiteral(assign.Value)
+		}
+
+		w.assigns = append(w.assigns, Assignment{
+			Name:   assign.Name.Value,
+			Value:  value,
+			Raw:    raw.String(),
+			Source: source,
+			Location: Location{
+				Line:   assign.Pos().Line(),
+				Column: assign.Pos().Col(),
+			},
+		})
+	}
+}
+
+// extractHeredocs records the here-documents of a statement.
+func (w *astWalker) extractHeredocs(stmt *syntax.Stmt) {
+	for _, redir := range stmt.Redirs {
+		if redir.Op != syntax.Hdoc && redir.Op != syntax.DashHdoc {
+			continue
+		}
+
+		var content string
+		if redir.Hdoc != nil {
+			content = wordToString(redir.Hdoc)
+		}
+
+		w.heredocs = append(w.heredocs, Heredoc{
+			Content: content,
+			Location: Location{
+				Line:   redir.Pos().Line(),
+				Column: redir.Pos().Col(),
+			},
+		})
+	}
+}
@@ -1,6 +1,12 @@
 package parser
 
 import (
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
 	"mvdan.cc/sh/v3/syntax"
 )
 
@@ -8,16 +14,35 @@ import (
 type astWalker struct {
 	commands   []Command
 	fileWrites []FileWrite
-	currentDir string // Tracks the effective working directory from cd commands
+	fileReads  []FileRead
+	assigns    []Assignment
+	heredocs   []Heredoc
+	currentDir string                  // Tracks the effective working directory from cd commands
+	stdin      string                  // Heredoc or piped script fed to the statement being walked
+	piped      map[*syntax.Stmt]string // Scripts piped to pipeline stages by echo, printf or cat
+	depth      int                     // Nesting depth of the commands being extracted
+	maxDepth   int                     // Maximum nesting depth to unwrap wrappers to
+	truncated  bool                    // Whether a wrapper was left unwrapped at maxDepth
 }
 
 // visit is called for each node in the AST.
 func (w *astWalker) visit(node syntax.Node) bool {
 	switch n := node.(type) {
 	case *syntax.CallExpr:
+		w.extractAssignments(n.Assigns, "")
 		w.extractCommand(n)
+	case *syntax.DeclClause:
+		w.extractAssignments(n.Args, n.Variant.Value)
+	case *syntax.BinaryCmd:
+		w.extractPipedScript(n)
 	case *syntax.Stmt:
+		w.stdin = stmtHeredoc(n)
+		if w.stdin == "" {
+			w.stdin = w.piped[n]
+		}
+
 		w.extractRedirect(n)
+		w.extractHeredocs(n)
 	case *syntax.Subshell:
 		// Subshells are handled recursively by syntax.Walk
 		return true
@@ -36,7 +61,7 @@ func (w *astWalker) extractCommand(call *syntax.CallExpr) {
 	}
 
 	// First word is the command name
-	name := wordToString(call.Args[0])
+	name := commandName(call.Args[0])
 	if name == "" {
 		return
 	}
@@ -59,6 +84,8 @@ func (w *astWalker) extractCommand(call *syntax.CallExpr) {
 		Location:         loc,
 		Type:             cmdType,
 		WorkingDirectory: w.currentDir,
+		RawArgs:          wordsToRaw(call.Args[1:]),
+		Depth:            w.depth,
 	}
 
 	w.commands = append(w.commands, cmd)
@@ -68,11 +95,15 @@ func (w *astWalker) extractCommand(call *syntax.CallExpr) {
 		w.currentDir = args[0]
 	}
 
-	// Check if this is a file write command
+	// Check if this is a file write or read command
 	w.extractFileWriteCommand(cmd)
+	w.extractFileReadCommand(cmd)
+
+	// Extract commands run by wrappers such as bash -c, sudo or xargs
+	w.unwrapCommand(call, &cmd)
 }
 
-// extractRedirect extracts file write operations from redirections.
+// extractRedirect extracts file write and read operations from redirections.
 func (w *astWalker) extractRedirect(stmt *syntax.Stmt) {
 	if stmt.Redirs == nil {
 		return
@@ -93,6 +124,20 @@ func (w *astWalker) extractRedirect(stmt *syntax.Stmt) {
 	hasHeredoc := false
 
 	for _, redir := range stmt.Redirs {
+		if redir.Op == syntax.RdrIn {
+			if path := wordToString(redir.Word); path != "" {
+				w.fileReads = append(w.fileReads, FileRead{
+					Path:             path,
+					Source:           "<",
+					WorkingDirectory: w.currentDir,
+					Location: Location{
+						Line:   redir.Pos().Line(),
+						Column: redir.Pos().Col(),
+					},
+				})
+			}
+		}
+
 		if redir.Op == syntax.RdrOut || redir.Op == syntax.AppOut {
 			path := wordToString(redir.Word)
 			if path == "" {
@@ -151,27 +196,69 @@ func (w *astWalker) extractRedirect(stmt *syntax.Stmt) {
 	// (it would just pipe to stdin of a command)
 }
 
-// extractFileWriteCommand detects file write commands (tee, cp, mv).
+// extractPipedScript records the script a pipeline stage writes to the next,
+// which a shell such as "echo 'git push' | bash" runs.
+func (w *astWalker) extractPipedScript(cmd *syntax.BinaryCmd) {
+	if cmd.Op != syntax.Pipe && cmd.Op != syntax.PipeAll {
+		return
+	}
+
+	script := pipedScript(cmd.X)
+	if script == "" {
+		return
+	}
+
+	if w.piped == nil {
+		w.piped = make(map[*syntax.Stmt]string)
+	}
+
+	w.piped[cmd.Y] = script
+}
+
+// stmtHeredoc returns the heredoc content redirected to a statement's stdin.
+func stmtHeredoc(stmt *syntax.Stmt) string {
+	for _, redir := range stmt.Redirs {
+		if (redir.Op == syntax.Hdoc || redir.Op == syntax.DashHdoc) && redir.Hdoc != nil {
+			return wordToString(redir.Hdoc)
+		}
+	}
+
+	return ""
+}
+
+// extractFileWriteCommand detects file write commands (tee, cp, mv, in-place
+// editors and other commands that write to their operands).
 func (w *astWalker) extractFileWriteCommand(cmd Command) {
-	op, targets := getFileWriteOperation(cmd)
+	op, targets := getFileWriteOperation(cmd, w.stdin)
 	if op == WriteOpNone {
 		return
 	}
 
+	var edit *SedScript
+
+	if op == WriteOpSedInPlace {
+		sed := parseSedArgs(cmd.Args)
+		if !sed.scriptFile {
+			edit, _ = ParseSedScript(sed.scripts, sed.extended)
+		}
+	}
+
 	for _, target := range targets {
 		fw := FileWrite{
-			Path:      target,
-			Operation: op,
-			Source:    cmd.Name,
-			Location:  cmd.Location,
+			Path:        target,
+			Operation:   op,
+			Source:      cmd.Name,
+			Location:    cmd.Location,
+			InPlaceEdit: edit,
 		}
 
 		w.fileWrites = append(w.fileWrites, fw)
 	}
 }
 
-// getFileWriteOperation determines if a command writes to files.
-func getFileWriteOperation(cmd Command) (WriteOp, []string) {
+// getFileWriteOperation determines if a command writes to files. Stdin is the
+// heredoc fed to the command, used to find the files a patch changes.
+func getFileWriteOperation(cmd Command, stdin string) (WriteOp, []string) {
 	switch cmd.Name {
 	case "tee":
 		// tee writes to all file arguments
@@ -188,11 +275,54 @@ func getFileWriteOperation(cmd Command) (WriteOp, []string) {
 		if len(cmd.Args) >= 2 { //nolint:mnd // Trivial check for minimum args (source + dest)
 			return WriteOpMove, []string{cmd.Args[len(cmd.Args)-1]}
 		}
+
+	default:
+		return getInPlaceWriteOperation(cmd, stdin)
 	}
 
 	return WriteOpNone, nil
 }
 
+// getInPlaceWriteOperation determines if a command edits files in place or
+// otherwise writes to its operands.
+func getInPlaceWriteOperation(cmd Command, stdin string) (WriteOp, []string) {
+	var (
+		op      WriteOp
+		targets []string
+	)
+
+	switch cmd.Name {
+	case "sed", "gsed":
+		if sed := parseSedArgs(cmd.Args); sed.inPlace {
+			op, targets = WriteOpSedInPlace, sed.files
+		}
+	case "perl":
+		op, targets = WriteOpPerlInPlace, extractPerlInPlaceTargets(cmd.Args)
+	case "awk", "gawk":
+		op, targets = WriteOpAwkInPlace, extractAwkInPlaceTargets(cmd.Args)
+	case "dd":
+		op, targets = WriteOpDd, extractDdTargets(cmd.Args)
+	case "install":
+		op, targets = WriteOpInstall, extractInstallTargets(cmd.Args)
+	case "rsync":
+		op, targets = WriteOpRsync, extractRsyncTargets(cmd.Args)
+	case "ln":
+		op, targets = WriteOpLink, extractLinkTargets(cmd.Args)
+	case "truncate":
+		op, targets = WriteOpTruncate, extractTruncateTargets(cmd.Args)
+	case "patch":
+		op, targets = WriteOpPatch, extractPatchTargets(cmd.Args, stdin)
+	case "git":
+		op, targets = WriteOpGitApply, extractGitApplyTargets(cmd.Args, stdin)
+	}
+
+	if len(targets) == 0 {
+		return WriteOpNone, nil
+	}
+
+	return op, targets
+}
+
 // extractTeeTargets extracts file targets from tee command arguments.
 func extractTeeTargets(args []string) []string {
 	targets := make([]string, 0)
@@ -206,3 +336,512 @@ func extractTeeTargets(args []string) []string {
 
 	return targets
 }
+
+// Value-taking options of commands that write to their operands.
+var (
+	perlValueFlags    = "eEIMmx"
+	awkValueFlags     = []string{"-f", "-v", "-F", "-i", "-l", "-E", "--file", "--assign", "--include", "--load", "--field-separator"}
+	installValueFlags = []string{"-m", "-o", "-g", "-S", "-t", "--mode", "--owner", "--group", "--suffix", "--target-directory"}
+	rsyncValueFlags   = []string{
+		"-e", "-f", "-T", "--rsh", "--filter", "--exclude", "--include", "--exclude-from",
+		"--include-from", "--files-from", "--temp-dir", "--backup-dir", "--chmod", "--chown",
+		"--log-file", "--partial-dir", "--compare-dest", "--copy-dest", "--link-dest",
+		"--password-file",
+	}
+	lnValueFlags       = []string{"-S", "-t", "--suffix", "--target-directory"}
+	truncateValueFlags = []string{"-s", "-r", "--size", "--reference"}
+	patchValueFlags    = []string{
+		"-i", "-o", "-p", "-d", "-B", "-D", "-F", "-r", "-V", "-Y", "-z",
+		"--input", "--output", "--strip", "--directory", "--reject-file", "--suffix",
+	}
+	gitApplyValueFlags = []string{"-p", "-C", "--directory", "--exclude", "--include", "--whitespace"}
+)
+
+var (
+	// awkAssignmentRegex matches var=value operands, which awk treats as assignments.
+	awkAssignmentRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*=`)
+
+	// remotePathRegex matches rsync remote paths (host:path, rsync://host/path).
+	remotePathRegex = regexp.MustCompile(`^([^/]*:|rsync://)`)
+)
+
+// Git apply flags that only inspect the patch or only update the index.
+var gitApplyReadOnlyFlags = []string{"--check", "--stat", "--numstat", "--summary", "--cached"}
+
+// sedArgs holds the parsed arguments of a sed command.
+type sedArgs struct {
+	inPlace    bool
+	extended   bool
+	scriptFile bool     // Script read from a file with -f
+	scripts    []string // Scripts from -e, or the script operand
+	files      []string
+}
+
+// parseSedArgs parses sed arguments, including combined short flags (-ni),
+// in-place suffixes (-i.bak) and the BSD form with a separate empty suffix,
+// which the parser drops from the arguments.
+func parseSedArgs(args []string) sedArgs {
+	var (
+		sed        sedArgs
+		positional []string
+	)
+
+	hasScriptFlag := false
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		switch {
+		case arg == "--":
+			positional = append(positional, args[i+1:]...)
+			i = len(args)
+		case arg == "--in-place" || strings.HasPrefix(arg, "--in-place="):
+			sed.inPlace = true
+		case arg == "--regexp-extended":
+			sed.extended = true
+		case arg == "--expression" || arg == "--file":
+			hasScriptFlag = true
+			sed.scriptFile = sed.scriptFile || arg == "--file"
+
+			if i+1 < len(args) && arg == "--expression" {
+				sed.scripts = append(sed.scripts, args[i+1])
+			}
+
+			i++
+		case strings.HasPrefix(arg, "--expression="):
+			hasScriptFlag = true
+			sed.scripts = append(sed.scripts, strings.TrimPrefix(arg, "--expression="))
+		case strings.HasPrefix(arg, "--file="):
+			hasScriptFlag = true
+			sed.scriptFile = true
+		case strings.HasPrefix(arg, "--"):
+			continue
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			i = parseSedShortFlags(args, i, &sed, &hasScriptFlag)
+		default:
+			positional = append(positional, arg)
+		}
+	}
+
+	if !hasScriptFlag && len(positional) > 0 {
+		sed.scripts = append(sed.scripts, positional[0])
+		positional = positional[1:]
+	}
+
+	sed.files = positional
+
+	return sed
+}
+
+// parseSedShortFlags parses a cluster of short sed flags at args[idx] and
+// returns the index of the last argument consumed.
+func parseSedShortFlags(args []string, idx int, sed *sedArgs, hasScriptFlag *bool) int {
+	arg := args[idx]
+
+	for j := 1; j < len(arg); j++ {
+		switch arg[j] {
+		case 'i':
+			// The rest of the cluster is the backup suffix
+			sed.inPlace = true
+
+			return idx
+		case 'E', 'r':
+			sed.extended = true
+		case 'e', 'f':
+			*hasScriptFlag = true
+			sed.scriptFile = sed.scriptFile || arg[j] == 'f'
+
+			value := arg[j+1:]
+			if value == "" && idx+1 < len(args) {
+				idx++
+				value = args[idx]
+			}
+
+			if arg[j] == 'e' {
+				sed.scripts = append(sed.scripts, value)
+			}
+
+			return idx
+		case 'l':
+			if j == len(arg)-1 {
+				return idx + 1
+			}
+
+			return idx
+		}
+	}
+
+	return idx
+}
+
+// extractPerlInPlaceTargets returns the files of a perl -i command. Without -e
+// the first operand is the script file.
+func extractPerlInPlaceTargets(args []string) []string {
+	var positional []string
+
+	inPlace, hasScript := false, false
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+
+		if !strings.HasPrefix(arg, "-") || len(arg) == 1 {
+			positional = append(positional, arg)
+			continue
+		}
+
+	cluster:
+		for j := 1; j < len(arg); j++ {
+			switch c := arg[j]; {
+			case c == 'i':
+				// The rest of the cluster is the backup suffix
+				inPlace = true
+
+				break cluster
+			case strings.IndexByte(perlValueFlags, c) >= 0:
+				hasScript = hasScript || c == 'e' || c == 'E'
+
+				if j == len(arg)-1 {
+					i++
+				}
+
+				break cluster
+			}
+		}
+	}
+
+	if !inPlace {
+		return nil
+	}
+
+	if !hasScript && len(positional) > 0 {
+		positional = positional[1:]
+	}
+
+	return positional
+}
+
+// extractAwkInPlaceTargets returns the files of a gawk -i inplace command.
+// Without -f or -E the first operand is the program.
+func extractAwkInPlaceTargets(args []string) []string {
+	values, positional := splitOptions(args, awkValueFlags)
+
+	inPlace := false
+
+	for _, flag := range []string{"-i", "--include"} {
+		for _, value := range values[flag] {
+			if value == "inplace" || value == "inplace.awk" {
+				inPlace = true
+			}
+		}
+	}
+
+	if !inPlace {
+		return nil
+	}
+
+	hasProgram := len(values["-f"]) > 0 || len(values["--file"]) > 0 || len(values["-E"]) > 0
+	if !hasProgram && len(positional) > 0 {
+		positional = positional[1:]
+	}
+
+	files := make([]string, 0, len(positional))
+
+	for _, arg := range positional {
+		if !awkAssignmentRegex.MatchString(arg) {
+			files = append(files, arg)
+		}
+	}
+
+	return files
+}
+
+// extractDdTargets returns the output file of a dd command.
+func extractDdTargets(args []string) []string {
+	for _, arg := range args {
+		if output, ok := strings.CutPrefix(arg, "of="); ok && output != "" {
+			return []string{output}
+		}
+	}
+
+	return nil
+}
+
+// extractInstallTargets returns the files written by install. Directories
+// created with -d are not file writes.
+func extractInstallTargets(args []string) []string {
+	values, positional := splitOptions(args, installValueFlags)
+
+	if hasShortFlag(args, 'd') || slices.Contains(args, "--directory") {
+		return nil
+	}
+
+	return destinationTargets(values, positional)
+}
+
+// extractRsyncTargets returns the local destination of an rsync command.
+func extractRsyncTargets(args []string) []string {
+	_, positional := splitOptions(args, rsyncValueFlags)
+
+	if len(positional) < 2 { //nolint:mnd // Trivial check for minimum args (source + dest)
+		return nil
+	}
+
+	dest := positional[len(positional)-1]
+	if remotePathRegex.MatchString(dest) {
+		return nil
+	}
+
+	return []string{dest}
+}
+
+// extractLinkTargets returns the links created by ln. With a single operand
+// the link is created in the current directory.
+func extractLinkTargets(args []string) []string {
+	values, positional := splitOptions(args, lnValueFlags)
+
+	if len(positional) == 1 && len(targetDirectories(values)) == 0 {
+		return []string{path.Base(positional[0])}
+	}
+
+	return destinationTargets(values, positional)
+}
+
+// extractTruncateTargets returns the files of a truncate command.
+func extractTruncateTargets(args []string) []string {
+	_, positional := splitOptions(args, truncateValueFlags)
+
+	return positional
+}
+
+// extractPatchTargets returns the files changed by patch: the file operand or
+// -o output, or else the files named in a heredoc patch.
+func extractPatchTargets(args []string, stdin string) []string {
+	values, positional := splitOptions(args, patchValueFlags)
+
+	for _, flag := range []string{"-o", "--output"} {
+		if outputs := values[flag]; len(outputs) > 0 && outputs[0] != "-" {
+			return outputs[:1]
+		}
+	}
+
+	if len(positional) > 0 {
+		return positional[:1]
+	}
+
+	if len(values["-i"]) > 0 || len(values["--input"]) > 0 {
+		return nil
+	}
+
+	// Without -p, patch uses the base name
+	strip := -1
+
+	for _, flag := range []string{"-p", "--strip"} {
+		if value := values[flag]; len(value) > 0 {
+			if n, err := strconv.Atoi(value[0]); err == nil {
+				strip = n
+			}
+		}
+	}
+
+	return prefixTargets(values, []string{"-d", "--directory"}, diffTargets(stdin, strip))
+}
+
+// extractGitApplyTargets returns the files changed by git apply, read from a
+// heredoc patch. Patch files given as operands are not read.
+func extractGitApplyTargets(args []string, stdin string) []string {
+	if stdin == "" {
+		return nil
+	}
+
+	applyIdx := parseGlobalOptions(args, &GitCommand{GlobalOptions: make(map[string]string)})
+	if applyIdx >= len(args) || args[applyIdx] != "apply" {
+		return nil
+	}
+
+	applyArgs := args[applyIdx+1:]
+	if slices.ContainsFunc(applyArgs, func(arg string) bool {
+		return slices.Contains(gitApplyReadOnlyFlags, arg)
+	}) {
+		return nil
+	}
+
+	values, positional := splitOptions(applyArgs, gitApplyValueFlags)
+	if len(positional) > 0 && positional[0] != "-" {
+		return nil
+	}
+
+	strip := 1
+
+	if value := values["-p"]; len(value) > 0 {
+		if n, err := strconv.Atoi(value[0]); err == nil {
+			strip = n
+		}
+	}
+
+	return prefixTargets(values, []string{"--directory"}, diffTargets(stdin, strip))
+}
+
+// diffTargets returns the files changed by a unified diff. Strip removes that
+// many leading path components; a negative strip keeps only the base name.
+func diffTargets(diff string, strip int) []string {
+	var (
+		targets []string
+		oldPath string
+	)
+
+	previous := ""
+
+	for line := range strings.SplitSeq(diff, "\n") {
+		header := previous
+		previous = line
+
+		switch {
+		case strings.HasPrefix(line, "--- "):
+			oldPath = diffHeaderPath(line[4:])
+		case strings.HasPrefix(line, "+++ ") && strings.HasPrefix(header, "--- "):
+			target := diffHeaderPath(line[4:])
+			if target == "/dev/null" {
+				// Deleted file
+				target = oldPath
+			}
+
+			if target = stripPath(target, strip); target != "" && !slices.Contains(targets, target) {
+				targets = append(targets, target)
+			}
+		}
+	}
+
+	return targets
+}
+
+// diffHeaderPath returns the path of a ---/+++ header line, without the timestamp.
+func diffHeaderPath(header string) string {
+	header, _, _ = strings.Cut(header, "\t")
+
+	return strings.TrimSpace(header)
+}
+
+// stripPath removes strip leading components from a path, like patch -p.
+// A negative strip returns the base name.
+func stripPath(p string, strip int) string {
+	if p == "" || p == "/dev/null" {
+		return ""
+	}
+
+	if strip < 0 {
+		return path.Base(p)
+	}
+
+	parts := strings.Split(p, "/")
+	if strip >= len(parts) {
+		return ""
+	}
+
+	return strings.Join(parts[strip:], "/")
+}
+
+// destinationTargets returns the files written by a command copying its
+// operands to a destination: into each -t directory, or the last operand.
+func destinationTargets(values map[string][]string, positional []string) []string {
+	if dirs := targetDirectories(values); len(dirs) > 0 {
+		targets := make([]string, 0, len(positional))
+
+		for _, source := range positional {
+			targets = append(targets, path.Join(dirs[0], path.Base(source)))
+		}
+
+		return targets
+	}
+
+	if len(positional) < 2 { //nolint:mnd // Trivial check for minimum args (source + dest)
+		return nil
+	}
+
+	return positional[len(positional)-1:]
+}
+
+// targetDirectories returns the values of -t and --target-directory.
+func targetDirectories(values map[string][]string) []string {
+	return append(values["-t"], values["--target-directory"]...)
+}
+
+// prefixTargets joins targets to the first directory given by any of flags.
+func prefixTargets(values map[string][]string, flags, targets []string) []string {
+	for _, flag := range flags {
+		if dirs := values[flag]; len(dirs) > 0 {
+			for i, target := range targets {
+				targets[i] = path.Join(dirs[0], target)
+			}
+
+			break
+		}
+	}
+
+	return targets
+}
+
+// splitOptions separates option values from operands. Options listed in
+// valueFlags take a value, given as the next argument, inline for short flags
+// (-p1) or after "=" for long flags (--strip=1). Every argument after "--" is
+// an operand.
+func splitOptions(args, valueFlags []string) (map[string][]string, []string) {
+	values := make(map[string][]string)
+
+	var positional []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		switch {
+		case arg == "--":
+			return values, append(positional, args[i+1:]...)
+		case strings.HasPrefix(arg, "--"):
+			name, value, hasValue := strings.Cut(arg, "=")
+
+			switch {
+			case hasValue:
+				values[name] = append(values[name], value)
+			case slices.Contains(valueFlags, name) && i+1 < len(args):
+				values[name] = append(values[name], args[i+1])
+				i++
+			}
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			name := arg[:2]
+			if !slices.Contains(valueFlags, name) {
+				continue
+			}
+
+			if len(arg) > 2 { //nolint:mnd // Inline value after a short flag
+				values[name] = append(values[name], arg[2:])
+			} else if i+1 < len(args) {
+				values[name] = append(values[name], args[i+1])
+				i++
+			}
+		default:
+			positional = append(positional, arg)
+		}
+	}
+
+	return values, positional
+}
+
+// hasShortFlag reports whether a short flag is set, alone or combined (e.g. -Dm).
+func hasShortFlag(args []string, flag byte) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			return false
+		}
+
+		if strings.HasPrefix(arg, "-") && !strings.HasPrefix(arg, "--") &&
+			strings.IndexByte(arg[1:], flag) >= 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -16,21 +16,48 @@ var (
 
 // ParseResult contains the results of parsing a Bash command.
 type ParseResult struct {
-	Commands      []Command   // All commands found
-	FileWrites    []FileWrite // All file write operations
-	GitOperations []Command   // Git commands only
+	Commands      []Command    // All commands found, including those unwrapped from wrappers
+	FileWrites    []FileWrite  // All file write operations
+	FileReads     []FileRead   // All files read by commands and input redirections
+	Assignments   []Assignment // Variable assignments, including export and other declarations
+	Heredocs      []Heredoc    // Here-document bodies
+	GitOperations []Command    // Git commands only
+
+	// Truncated reports that wrappers nest deeper than the maximum nesting depth,
+	// so the commands they run are missing from Commands.
+	Truncated bool
 }
 
 // BashParser parses Bash commands using mvdan.cc/sh.
 type BashParser struct {
-	parser *syntax.Parser
+	parser   *syntax.Parser
+	maxDepth int
 }
 
-// NewBashParser creates a new BashParser instance.
-func NewBashParser() *BashParser {
-	return &BashParser{
-		parser: syntax.NewParser(),
+// BashParserOption configures a BashParser.
+type BashParserOption func(*BashParser)
+
+// WithMaxNestingDepth sets how many wrapper levels are unwrapped. Zero disables
+// unwrapping, negative depths are treated as zero.
+func WithMaxNestingDepth(depth int) BashParserOption {
+	return func(p *BashParser) {
+		p.maxDepth = max(depth, 0)
+	}
+}
+
+// NewBashParser creates a new BashParser instance. Wrappers are unwrapped up to
+// DefaultMaxNestingDepth levels unless set with WithMaxNestingDepth.
+func NewBashParser(opts ...BashParserOption) *BashParser {
+	p := &BashParser{
+		parser:   syntax.NewParser(),
+		maxDepth: DefaultMaxNestingDepth,
 	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
 }
 
 // Parse parses a Bash command string and extracts all commands and operations.
@@ -50,6 +77,10 @@ func (p *BashParser) Parse(command string) (*ParseResult, error) {
 	walker := &astWalker{
 		commands:   make([]Command, 0),
 		fileWrites: make([]FileWrite, 0),
+		fileReads:  make([]FileRead, 0),
+		assigns:    make([]Assignment, 0),
+		heredocs:   make([]Heredoc, 0),
+		maxDepth:   p.maxDepth,
 	}
 
 	syntax.Walk(file, walker.visit)
@@ -66,7 +97,11 @@ func (p *BashParser) Parse(command string) (*ParseResult, error) {
 	return &ParseResult{
 		Commands:      walker.commands,
 		FileWrites:    walker.fileWrites,
+		FileReads:     walker.fileReads,
+		Assignments:   walker.assigns,
+		Heredocs:      walker.heredocs,
 		GitOperations: gitOps,
+		Truncated:     walker.truncated,
 	}, nil
 }
 
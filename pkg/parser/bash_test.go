@@ -173,6 +173,16 @@ var _ = Describe("BashParser", func() {
 				cmd := result.Commands[0]
 				Expect(cmd.Args).To(ContainElement("msg && trick"))
 			})
+
+			It("keeps raw arguments with expansions", func() {
+				result, err := p.Parse(`rm -rf "$DIR"/* ~/tmp`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Commands).To(HaveLen(1))
+
+				cmd := result.Commands[0]
+				Expect(cmd.Args).To(Equal([]string{"-rf", "/*", "~/tmp"}))
+				Expect(cmd.RawArgs).To(Equal([]string{"-rf", `"$DIR"/*`, "~/tmp"}))
+			})
 		})
 
 		Context("with redirections", func() {
@@ -309,6 +319,252 @@ EOF`
 			})
 		})
 
+		Context("with in-place edit commands", func() {
+			expectWrites := func(cmd string, op parser.WriteOp, paths ...string) []parser.FileWrite {
+				GinkgoHelper()
+
+				result, err := p.Parse(cmd)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.FileWrites).To(HaveLen(len(paths)), "writes of %q", cmd)
+
+				for i, path := range paths {
+					Expect(result.FileWrites[i].Path).To(Equal(path))
+					Expect(result.FileWrites[i].Operation).To(Equal(op))
+				}
+
+				return result.FileWrites
+			}
+
+			expectNoWrites := func(cmd string) {
+				GinkgoHelper()
+
+				result, err := p.Parse(cmd)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.FileWrites).To(BeEmpty(), "writes of %q", cmd)
+			}
+
+			It("detects sed -i targets", func() {
+				expectWrites("sed -i 's/a/b/' a.txt b.txt", parser.WriteOpSedInPlace, "a.txt", "b.txt")
+				expectWrites("sed -i '' 's/a/b/' a.txt", parser.WriteOpSedInPlace, "a.txt")
+				expectWrites("sed -i.bak -e 's/a/b/' a.txt", parser.WriteOpSedInPlace, "a.txt")
+				expectWrites("sed --in-place -E 's/a+/b/' a.txt", parser.WriteOpSedInPlace, "a.txt")
+				expectWrites("sed -ni '/x/p' a.txt", parser.WriteOpSedInPlace, "a.txt")
+			})
+
+			It("computes the edit of simple sed substitutions", func() {
+				writes := expectWrites(
+					"sed -i -e 's/foo/bar/g' -e 's/^x/y/' a.txt",
+					parser.WriteOpSedInPlace,
+					"a.txt",
+				)
+
+				Expect(writes[0].InPlaceEdit).NotTo(BeNil())
+				Expect(writes[0].InPlaceEdit.Apply("foo foo\nxfoo\n")).To(Equal("bar bar\nybar\n"))
+			})
+
+			It("does not compute the edit of other sed scripts", func() {
+				writes := expectWrites("sed -i '1d' a.txt", parser.WriteOpSedInPlace, "a.txt")
+				Expect(writes[0].InPlaceEdit).To(BeNil())
+
+				writes = expectWrites("sed -i -f script.sed a.txt", parser.WriteOpSedInPlace, "a.txt")
+				Expect(writes[0].InPlaceEdit).To(BeNil())
+			})
+
+			It("ignores sed without -i", func() {
+				expectNoWrites("sed 's/a/b/' a.txt")
+				expectNoWrites("sed -n '/x/p' a.txt")
+			})
+
+			It("detects perl -i targets", func() {
+				expectWrites("perl -pi -e 's/a/b/' a.txt", parser.WriteOpPerlInPlace, "a.txt")
+				expectWrites("perl -i.bak -pe 's/a/b/' a.txt b.txt", parser.WriteOpPerlInPlace, "a.txt", "b.txt")
+				expectNoWrites("perl -pe 's/a/b/' a.txt")
+			})
+
+			It("detects awk -i inplace targets", func() {
+				expectWrites("gawk -i inplace '{print}' a.txt", parser.WriteOpAwkInPlace, "a.txt")
+				expectWrites("awk -i inplace -v x=1 '{print}' a.txt", parser.WriteOpAwkInPlace, "a.txt")
+				expectNoWrites("awk '{print}' a.txt")
+			})
+
+			It("detects dd output files", func() {
+				expectWrites("dd if=in.img of=out.img bs=1M", parser.WriteOpDd, "out.img")
+				expectNoWrites("dd if=in.img")
+			})
+
+			It("detects install destinations", func() {
+				expectWrites("install -m 0755 tool bin/tool", parser.WriteOpInstall, "bin/tool")
+				expectWrites("install -t bin a b", parser.WriteOpInstall, "bin/a", "bin/b")
+				expectNoWrites("install -d bin")
+			})
+
+			It("detects local rsync destinations", func() {
+				expectWrites("rsync -av src/ dest/", parser.WriteOpRsync, "dest/")
+				expectNoWrites("rsync -av src/ host:/srv/app")
+			})
+
+			It("detects link names", func() {
+				expectWrites("ln -sf target.txt link.txt", parser.WriteOpLink, "link.txt")
+			})
+
+			It("detects truncated files", func() {
+				expectWrites("truncate -s 0 a.log b.log", parser.WriteOpTruncate, "a.log", "b.log")
+			})
+
+			It("detects patch targets", func() {
+				expectWrites("patch a.txt < fix.diff", parser.WriteOpPatch, "a.txt")
+				expectWrites("patch -o out.txt a.txt < fix.diff", parser.WriteOpPatch, "out.txt")
+
+				diff := "--- a/pkg/app.go\n+++ b/pkg/app.go\n@@ -1 +1 @@\n-x\n+y\n"
+				expectWrites("patch -p1 <<'EOF'\n"+diff+"EOF", parser.WriteOpPatch, "pkg/app.go")
+				expectWrites("patch <<'EOF'\n"+diff+"EOF", parser.WriteOpPatch, "app.go")
+			})
+
+			It("detects git apply targets from heredoc diffs", func() {
+				diff := "--- a/main.go\n+++ b/main.go\n@@ -1 +1 @@\n-x\n+y\n" +
+					"--- /dev/null\n+++ b/docs/new.md\n@@ -0,0 +1 @@\n+z\n"
+
+				expectWrites(
+					"git apply <<'EOF'\n"+diff+"EOF",
+					parser.WriteOpGitApply,
+					"main.go",
+					"docs/new.md",
+				)
+				expectNoWrites("git apply --check <<'EOF'\n" + diff + "EOF")
+				expectNoWrites("git apply fix.patch")
+			})
+		})
+
+		Context("with file read commands", func() {
+			readPaths := func(cmd string) []string {
+				GinkgoHelper()
+
+				result, err := p.Parse(cmd)
+				Expect(err).NotTo(HaveOccurred())
+
+				paths := make([]string, 0, len(result.FileReads))
+				for _, read := range result.FileReads {
+					paths = append(paths, read.Path)
+				}
+
+				return paths
+			}
+
+			It("detects files printed by read commands", func() {
+				Expect(readPaths("cat a.txt b.txt")).To(Equal([]string{"a.txt", "b.txt"}))
+				Expect(readPaths("head -n 5 .env")).To(Equal([]string{".env"}))
+				Expect(readPaths("tail -f log.txt")).To(Equal([]string{"log.txt"}))
+				Expect(readPaths("od -A x -t x1z key.bin")).To(Equal([]string{"key.bin"}))
+				Expect(readPaths("cat - notes.md")).To(Equal([]string{"notes.md"}))
+			})
+
+			It("detects files searched by grep-like commands", func() {
+				Expect(readPaths("grep TOKEN .env")).To(Equal([]string{".env"}))
+				Expect(readPaths("grep -e TOKEN -e KEY .env config.yaml")).
+					To(Equal([]string{".env", "config.yaml"}))
+				Expect(readPaths("grep -A 2 TOKEN")).To(BeEmpty())
+			})
+
+			It("marks recursive searches", func() {
+				result, err := p.Parse("grep -rn TOKEN src && rg KEY docs && grep KEY main.go")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.FileReads).To(HaveLen(3))
+				Expect(result.FileReads[0].Recursive).To(BeTrue())
+				Expect(result.FileReads[1].Recursive).To(BeTrue())
+				Expect(result.FileReads[2].Recursive).To(BeFalse())
+			})
+
+			It("detects input redirections", func() {
+				result, err := p.Parse("base64 < server.key")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.FileReads).To(HaveLen(1))
+				Expect(result.FileReads[0].Path).To(Equal("server.key"))
+				Expect(result.FileReads[0].Source).To(Equal("<"))
+			})
+
+			It("records the working directory", func() {
+				result, err := p.Parse("cd ~/.aws && cat credentials")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.FileReads).To(HaveLen(1))
+				Expect(result.FileReads[0].WorkingDirectory).To(Equal("~/.aws"))
+			})
+
+			It("detects the sources of copy and archive commands", func() {
+				Expect(readPaths("cp .env /tmp/x")).To(Equal([]string{".env"}))
+				Expect(readPaths("cp -t /tmp a.txt b.txt")).To(Equal([]string{"a.txt", "b.txt"}))
+				Expect(readPaths("mv -f old.txt new.txt")).To(Equal([]string{"old.txt"}))
+				Expect(readPaths("rsync -a --exclude=tmp src/ host:dst/")).
+					To(Equal([]string{"src/"}))
+
+				result, err := p.Parse("tar cf - ~/.ssh")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.FileReads).To(HaveLen(1))
+				Expect(result.FileReads[0].Path).To(Equal("~/.ssh"))
+				Expect(result.FileReads[0].Recursive).To(BeTrue())
+			})
+
+			It("treats the operands of other commands as reads", func() {
+				Expect(readPaths("source .env")).To(Equal([]string{".env"}))
+				Expect(readPaths(". .env")).To(Equal([]string{".env"}))
+				Expect(readPaths("awk 1 .env")).To(Equal([]string{"1", ".env"}))
+				Expect(readPaths("sort -u .env")).To(Equal([]string{".env"}))
+				Expect(readPaths("diff .env /dev/null")).To(Equal([]string{".env", "/dev/null"}))
+				Expect(readPaths("jq . ~/.docker/config.json")).
+					To(Equal([]string{".", "~/.docker/config.json"}))
+			})
+
+			It("detects files read by git", func() {
+				Expect(readPaths("git show HEAD:.env")).To(ContainElement(".env"))
+				Expect(readPaths("git diff -- .env")).To(ContainElement(".env"))
+				Expect(readPaths("git status")).To(BeEmpty())
+			})
+
+			It("does not count wrapped commands twice", func() {
+				Expect(readPaths("sudo cat .env")).To(Equal([]string{".env"}))
+				Expect(readPaths("timeout 5 sort .env")).To(Equal([]string{".env"}))
+			})
+
+			It("ignores commands that do not read files", func() {
+				Expect(readPaths("echo .env && ls ~/.ssh")).To(BeEmpty())
+				Expect(readPaths("rm -f .env && touch .env && ln -s a .env")).
+					To(Equal([]string{"a"}))
+			})
+		})
+
+		Context("with assignments", func() {
+			It("extracts assignments before commands and on their own", func() {
+				result, err := p.Parse(`TOKEN="a b" gh pr list; COUNT=1`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Assignments).To(HaveLen(2))
+				Expect(result.Assignments[0].Name).To(Equal("TOKEN"))
+				Expect(result.Assignments[0].Value).To(Equal("a b"))
+				Expect(result.Assignments[0].Raw).To(Equal(`TOKEN="a b"`))
+				Expect(result.Assignments[0].Source).To(BeEmpty())
+				Expect(result.Assignments[1].Name).To(Equal("COUNT"))
+				Expect(result.Assignments[1].Location.Column).To(Equal(uint(25)))
+			})
+
+			It("extracts declarations", func() {
+				result, err := p.Parse("export -n OLD KEY=secret\nlocal -r DIR=/tmp")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Assignments).To(HaveLen(2))
+				Expect(result.Assignments[0].Source).To(Equal("export"))
+				Expect(result.Assignments[0].Raw).To(Equal("KEY=secret"))
+				Expect(result.Assignments[1].Source).To(Equal("local"))
+				Expect(result.Assignments[1].Location.Line).To(Equal(uint(2)))
+			})
+		})
+
+		Context("with heredoc bodies", func() {
+			It("extracts heredocs fed to commands", func() {
+				result, err := p.Parse("kubectl apply -f - <<EOF\nkind: Secret\nEOF")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Heredocs).To(HaveLen(1))
+				Expect(result.Heredocs[0].Content).To(Equal("kind: Secret\n"))
+				Expect(result.Heredocs[0].Location.Column).To(Equal(uint(20)))
+			})
+		})
+
 		Context("with git operations", func() {
 			It("extracts git commands", func() {
 				result, err := p.Parse("git status && git diff")
@@ -56,6 +56,15 @@ type Command struct {
 	Type             CmdType  // Command type
 	Raw              string   // Raw command string
 	WorkingDirectory string   // Effective working directory from preceding cd commands
+
+	// RawArgs holds every argument as written in the source, including quotes and
+	// expansions such as $VAR, ${VAR} and $(cmd). Unlike Args, arguments that
+	// expand to nothing are kept, so RawArgs[i] is not necessarily Args[i].
+	RawArgs []string
+
+	// Depth is the number of wrappers the command was unwrapped from, 0 for
+	// commands written directly. For sudo bash -c "git push", git push has depth 2.
+	Depth int
 }
 
 // String returns a string representation of the command.
@@ -75,6 +84,19 @@ func (c *Command) FullCommand() []string {
 	return result
 }
 
+// commandName returns the name of a command after quote removal, so \git,
+// 'git' and "git" are all git.
+func commandName(word *syntax.Word) string {
+	name := wordToString(word)
+	if strings.Contains(name, `\`) {
+		if literal := wordLiteral(word); literal != "" {
+			return literal
+		}
+	}
+
+	return name
+}
+
 // wordToString converts syntax.Word to string, handling quotes and expansions.
 func wordToString(word *syntax.Word) string {
 	if word == nil {
@@ -152,6 +174,24 @@ func wordsToStrings(words []*syntax.Word) []string {
 	return result
 }
 
+// wordsToRaw converts a slice of syntax.Word to their source text.
+func wordsToRaw(words []*syntax.Word) []string {
+	result := make([]string, 0, len(words))
+	printer := syntax.NewPrinter()
+
+	for _, word := range words {
+		var buf strings.Builder
+
+		if err := printer.Print(&buf, word); err != nil {
+			continue
+		}
+
+		result = append(result, buf.String())
+	}
+
+	return result
+}
+
 // hasDoubleQuotedBackticks checks if a word contains backticks within double quotes.
 // Backticks in double quotes are parsed as CmdSubst nodes by the shell parser.
 func hasDoubleQuotedBackticks(word *syntax.Word) bool {
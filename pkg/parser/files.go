@@ -1,6 +1,8 @@
 package parser
 
-import "fmt"
+import (
+	"fmt"
+)
 
 // WriteOp represents the type of file write operation.
 type WriteOp int
@@ -20,6 +22,26 @@ const (
 	WriteOpMove
 	// WriteOpHeredoc indicates heredoc (<<).
 	WriteOpHeredoc
+	// WriteOpSedInPlace indicates sed -i.
+	WriteOpSedInPlace
+	// WriteOpPerlInPlace indicates perl -i.
+	WriteOpPerlInPlace
+	// WriteOpAwkInPlace indicates gawk -i inplace.
+	WriteOpAwkInPlace
+	// WriteOpDd indicates dd of=.
+	WriteOpDd
+	// WriteOpInstall indicates install command.
+	WriteOpInstall
+	// WriteOpRsync indicates rsync command.
+	WriteOpRsync
+	// WriteOpLink indicates ln command.
+	WriteOpLink
+	// WriteOpTruncate indicates truncate command.
+	WriteOpTruncate
+	// WriteOpPatch indicates patch command.
+	WriteOpPatch
+	// WriteOpGitApply indicates git apply.
+	WriteOpGitApply
 )
 
 // String returns string representation of WriteOp.
@@ -39,6 +61,26 @@ func (w WriteOp) String() string {
 		return "Move"
 	case WriteOpHeredoc:
 		return "Heredoc"
+	case WriteOpSedInPlace:
+		return "SedInPlace"
+	case WriteOpPerlInPlace:
+		return "PerlInPlace"
+	case WriteOpAwkInPlace:
+		return "AwkInPlace"
+	case WriteOpDd:
+		return "Dd"
+	case WriteOpInstall:
+		return "Install"
+	case WriteOpRsync:
+		return "Rsync"
+	case WriteOpLink:
+		return "Link"
+	case WriteOpTruncate:
+		return "Truncate"
+	case WriteOpPatch:
+		return "Patch"
+	case WriteOpGitApply:
+		return "GitApply"
 	default:
 		return "Unknown"
 	}
@@ -51,30 +93,14 @@ type FileWrite struct {
 	Source    string   // Source command (for cp, mv, tee)
 	Content   string   // Content for heredoc operations
 	Location  Location // Position in source
+
+	// InPlaceEdit is the edit made by sed -i when it only uses simple
+	// substitutions, so the post-edit content can be computed from the file on
+	// disk. Nil for other operations.
+	InPlaceEdit *SedScript
 }
 
 // String returns a string representation of the file write operation.
 func (f *FileWrite) String() string {
 	return fmt.Sprintf("%s %s -> %s", f.Operation, f.Source, f.Path)
 }
-
-// IsProtectedPath checks if the path is a protected location.
-func (f *FileWrite) IsProtectedPath() bool {
-	return IsProtectedPath(f.Path)
-}
-
-// IsProtectedPath checks if a path is protected (e.g., /tmp, /var/tmp).
-func IsProtectedPath(path string) bool {
-	// Check for /tmp prefix
-	if len(path) >= 4 && path[:4] == "/tmp" {
-		// Exact match or /tmp/...
-		return len(path) == 4 || path[4] == '/'
-	}
-
-	// Check for /var/tmp prefix
-	if len(path) >= 8 && path[:8] == "/var/tmp" {
-		return len(path) == 8 || path[8] == '/'
-	}
-
-	return false
-}
@@ -8,76 +8,7 @@ import (
 )
 
 var _ = Describe("Files", func() {
-	Describe("IsProtectedPath", func() {
-		Context("with /tmp paths", func() {
-			It("detects exact /tmp", func() {
-				Expect(parser.IsProtectedPath("/tmp")).To(BeTrue())
-			})
-
-			It("detects /tmp/ prefix", func() {
-				Expect(parser.IsProtectedPath("/tmp/file.txt")).To(BeTrue())
-				Expect(parser.IsProtectedPath("/tmp/dir/file.txt")).To(BeTrue())
-			})
-
-			It("does not match /tmpdir", func() {
-				Expect(parser.IsProtectedPath("/tmpdir")).To(BeFalse())
-				Expect(parser.IsProtectedPath("/tmpfile")).To(BeFalse())
-			})
-
-			It("does not match tmp/ without leading slash", func() {
-				Expect(parser.IsProtectedPath("tmp/file.txt")).To(BeFalse())
-				Expect(parser.IsProtectedPath("./tmp/file.txt")).To(BeFalse())
-			})
-		})
-
-		Context("with /var/tmp paths", func() {
-			It("detects exact /var/tmp", func() {
-				Expect(parser.IsProtectedPath("/var/tmp")).To(BeTrue())
-			})
-
-			It("detects /var/tmp/ prefix", func() {
-				Expect(parser.IsProtectedPath("/var/tmp/file.txt")).To(BeTrue())
-				Expect(parser.IsProtectedPath("/var/tmp/dir/file.txt")).To(BeTrue())
-			})
-
-			It("does not match /var/tmpdir", func() {
-				Expect(parser.IsProtectedPath("/var/tmpdir")).To(BeFalse())
-			})
-		})
-
-		Context("with allowed paths", func() {
-			It("allows project tmp/", func() {
-				Expect(parser.IsProtectedPath("tmp/file.txt")).To(BeFalse())
-			})
-
-			It("allows relative paths", func() {
-				Expect(parser.IsProtectedPath("./file.txt")).To(BeFalse())
-				Expect(parser.IsProtectedPath("../file.txt")).To(BeFalse())
-			})
-
-			It("allows home directory", func() {
-				Expect(parser.IsProtectedPath("/home/user/file.txt")).To(BeFalse())
-			})
-
-			It("allows current directory", func() {
-				Expect(parser.IsProtectedPath("file.txt")).To(BeFalse())
-				Expect(parser.IsProtectedPath("dir/file.txt")).To(BeFalse())
-			})
-		})
-	})
-
 	Describe("FileWrite", func() {
-		It("checks if path is protected", func() {
-			fw := parser.FileWrite{
-				Path:      "/tmp/output.txt",
-				Operation: parser.WriteOpRedirect,
-			}
-			Expect(fw.IsProtectedPath()).To(BeTrue())
-
-			fw.Path = "tmp/output.txt"
-			Expect(fw.IsProtectedPath()).To(BeFalse())
-		})
-
 		It("converts to string", func() {
 			fw := parser.FileWrite{
 				Path:      "/tmp/output.txt",
@@ -89,65 +20,6 @@ var _ = Describe("Files", func() {
 		})
 	})
 
-	Describe("PathValidator", func() {
-		var validator *parser.PathValidator
-
-		BeforeEach(func() {
-			validator = parser.NewPathValidator()
-		})
-
-		It("detects protected path violations", func() {
-			writes := []parser.FileWrite{
-				{
-					Path:      "/tmp/output.txt",
-					Operation: parser.WriteOpRedirect,
-					Location:  parser.Location{Line: 1, Column: 10},
-				},
-				{
-					Path:      "tmp/safe.txt",
-					Operation: parser.WriteOpRedirect,
-					Location:  parser.Location{Line: 2, Column: 10},
-				},
-			}
-
-			violations := validator.CheckProtectedPaths(writes)
-			Expect(violations).To(HaveLen(1))
-			Expect(violations[0].Path).To(Equal("/tmp/output.txt"))
-			Expect(violations[0].Suggestion).To(ContainSubstring("tmp/"))
-		})
-
-		It("returns empty list for safe paths", func() {
-			writes := []parser.FileWrite{
-				{
-					Path:      "tmp/output.txt",
-					Operation: parser.WriteOpRedirect,
-				},
-				{
-					Path:      "./file.txt",
-					Operation: parser.WriteOpRedirect,
-				},
-			}
-
-			violations := validator.CheckProtectedPaths(writes)
-			Expect(violations).To(BeEmpty())
-		})
-
-		It("provides helpful suggestions", func() {
-			writes := []parser.FileWrite{
-				{
-					Path:      "/tmp/test.txt",
-					Operation: parser.WriteOpRedirect,
-					Location:  parser.Location{Line: 5, Column: 20},
-				},
-			}
-
-			violations := validator.CheckProtectedPaths(writes)
-			Expect(violations).To(HaveLen(1))
-			Expect(violations[0].Suggestion).To(ContainSubstring("mkdir -p tmp/"))
-			Expect(violations[0].Suggestion).To(ContainSubstring("tmp/test.txt"))
-		})
-	})
-
 	Describe("File write detection in commands", func() {
 		var p *parser.BashParser
 
@@ -163,7 +35,6 @@ var _ = Describe("Files", func() {
 
 				fw := result.FileWrites[0]
 				Expect(fw.Path).To(Equal("/tmp/output.txt"))
-				Expect(fw.IsProtectedPath()).To(BeTrue())
 			})
 
 			It("allows redirection to project tmp/", func() {
@@ -173,7 +44,6 @@ var _ = Describe("Files", func() {
 
 				fw := result.FileWrites[0]
 				Expect(fw.Path).To(Equal("tmp/output.txt"))
-				Expect(fw.IsProtectedPath()).To(BeFalse())
 			})
 		})
 
@@ -186,7 +56,6 @@ var _ = Describe("Files", func() {
 				fw := result.FileWrites[0]
 				Expect(fw.Path).To(Equal("/tmp/output.txt"))
 				Expect(fw.Operation).To(Equal(parser.WriteOpTee))
-				Expect(fw.IsProtectedPath()).To(BeTrue())
 			})
 
 			It("allows tee to project tmp/", func() {
@@ -194,8 +63,7 @@ var _ = Describe("Files", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(result.FileWrites).To(HaveLen(1))
 
-				fw := result.FileWrites[0]
-				Expect(fw.IsProtectedPath()).To(BeFalse())
+				Expect(result.FileWrites[0].Path).To(Equal("tmp/output.txt"))
 			})
 		})
 
@@ -208,7 +76,6 @@ var _ = Describe("Files", func() {
 				fw := result.FileWrites[0]
 				Expect(fw.Path).To(Equal("/tmp/dest.txt"))
 				Expect(fw.Operation).To(Equal(parser.WriteOpCopy))
-				Expect(fw.IsProtectedPath()).To(BeTrue())
 			})
 
 			It("detects mv to /var/tmp", func() {
@@ -219,7 +86,6 @@ var _ = Describe("Files", func() {
 				fw := result.FileWrites[0]
 				Expect(fw.Path).To(Equal("/var/tmp/new.txt"))
 				Expect(fw.Operation).To(Equal(parser.WriteOpMove))
-				Expect(fw.IsProtectedPath()).To(BeTrue())
 			})
 		})
 
@@ -230,9 +96,7 @@ var _ = Describe("Files", func() {
 				Expect(result.FileWrites).To(HaveLen(2))
 
 				Expect(result.FileWrites[0].Path).To(Equal("/tmp/a.txt"))
-				Expect(result.FileWrites[0].IsProtectedPath()).To(BeTrue())
 				Expect(result.FileWrites[1].Path).To(Equal("/tmp/b.txt"))
-				Expect(result.FileWrites[1].IsProtectedPath()).To(BeTrue())
 			})
 		})
 	})
@@ -0,0 +1,422 @@
+package parser
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	"mvdan.cc/sh/v3/expand"
+	"mvdan.cc/sh/v3/syntax"
+)
+
+// DefaultMaxNestingDepth is the default number of wrapper levels unwrapped by
+// the parser (e.g. sudo bash -c "git push" is two levels deep).
+const DefaultMaxNestingDepth = 3
+
+var (
+	// shellWrappers run their -c argument as a script.
+	shellWrappers = []string{"bash", "sh", "zsh", "dash", "ksh"}
+
+	// sudoValueFlags are sudo options that take a value.
+	sudoValueFlags = []string{
+		"-u", "-g", "-C", "-D", "-p", "-r", "-t", "-U", "-T", "-R",
+		"--user", "--group", "--close-from", "--chdir", "--prompt", "--role",
+		"--type", "--other-user", "--command-timeout", "--chroot",
+	}
+
+	// envValueFlags are env options that take a value.
+	envValueFlags = []string{"-u", "-C", "-S", "--unset", "--chdir", "--split-string"}
+
+	// timeoutValueFlags are timeout options that take a value.
+	timeoutValueFlags = []string{"-s", "-k", "--signal", "--kill-after"}
+
+	// watchValueFlags are watch options that take a value.
+	watchValueFlags = []string{"-n", "-q", "--interval", "--equexit"}
+
+	// xargsValueFlags are xargs options that take a value.
+	xargsValueFlags = []string{
+		"-I", "-L", "-n", "-P", "-s", "-d", "-E", "-a",
+		"--replace", "--max-lines", "--max-args", "--max-procs", "--max-chars",
+		"--delimiter", "--eof", "--arg-file", "--process-slot-var",
+	}
+
+	// findExecActions run the command that follows them, up to ; or +.
+	findExecActions = []string{"-exec", "-execdir", "-ok", "-okdir"}
+
+	// commandWrappers run their first operand as a command, mapped to their
+	// options that take a value.
+	commandWrappers = map[string][]string{
+		"builtin": nil,
+		"command": nil,
+		"doas":    {"-u", "-C"},
+		"exec":    {"-a"},
+		"nice":    {"-n", "--adjustment"},
+		"nohup":   nil,
+		"stdbuf":  {"-i", "-o", "-e", "--input", "--output", "--error"},
+	}
+
+	// scriptPrinters print their arguments, which a pipe may feed to a shell.
+	scriptPrinters = []string{"echo", "printf"}
+)
+
+// unwrapCommand extracts the commands run by wrappers such as bash -c, eval,
+// sudo, env, nohup, command, timeout, watch, xargs and find -exec, and scripts
+// fed to a shell on stdin, as virtual commands one level deeper than the wrapper.
+func (w *astWalker) unwrapCommand(call *syntax.CallExpr, cmd *Command) {
+	words := call.Args[1:]
+	args := make([]string, len(words))
+
+	for i, word := range words {
+		args[i] = wordLiteral(word)
+	}
+
+	name := path.Base(cmd.Name)
+
+	if _, ok := commandWrappers[name]; ok {
+		w.unwrapCommandWrapper(name, words, args)
+		return
+	}
+
+	switch {
+	case slices.Contains(shellWrappers, name):
+		if script, ok := shellScript(args); ok {
+			w.unwrapScript(script, cmd.Location)
+		} else if w.stdin != "" && shellReadsStdin(args) {
+			w.unwrapScript(w.stdin, cmd.Location)
+		}
+
+	case name == "eval":
+		w.unwrapScript(strings.Join(args, " "), cmd.Location)
+
+	case name == "sudo":
+		_, idx := wrapperOptions(args, sudoValueFlags)
+		w.unwrapWords(words[skipAssignments(args, idx):])
+
+	case name == "env":
+		values, idx := wrapperOptions(args, envValueFlags)
+
+		// -S splits its value into the command and its arguments
+		if split := append(values["-S"], values["--split-string"]...); len(split) > 0 {
+			w.unwrapScript(strings.Join(append(split, args[idx:]...), " "), cmd.Location)
+
+			return
+		}
+
+		w.unwrapWords(words[skipAssignments(args, idx):])
+
+	case name == "timeout":
+		// The first operand is the duration
+		if _, idx := wrapperOptions(args, timeoutValueFlags); idx < len(args) {
+			w.unwrapWords(words[idx+1:])
+		}
+
+	case name == "watch":
+		_, idx := wrapperOptions(args, watchValueFlags)
+		if hasShortFlag(args[:idx], 'x') || slices.Contains(args[:idx], "--exec") {
+			w.unwrapWords(words[idx:])
+		} else if idx < len(args) {
+			// watch passes its arguments to sh -c
+			w.unwrapScript(strings.Join(args[idx:], " "), cmd.Location)
+		}
+
+	case name == "xargs":
+		_, idx := wrapperOptions(args, xargsValueFlags)
+		w.unwrapWords(words[idx:])
+
+	case name == "find":
+		w.unwrapFindExec(words, args)
+	}
+}
+
+// unwrapCommandWrapper extracts the command run by a wrapper that runs its
+// first operand, such as nohup, command or nice.
+func (w *astWalker) unwrapCommandWrapper(name string, words []*syntax.Word, args []string) {
+	_, idx := wrapperOptions(args, commandWrappers[name])
+
+	// command -v and -V only describe the command
+	if name == "command" && (hasShortFlag(args[:idx], 'v') || hasShortFlag(args[:idx], 'V')) {
+		return
+	}
+
+	w.unwrapWords(words[idx:])
+}
+
+// unwrapWords extracts a virtual command from the words of a wrapped command.
+func (w *astWalker) unwrapWords(words []*syntax.Word) {
+	if len(words) == 0 || w.atMaxDepth() {
+		return
+	}
+
+	w.depth++
+	w.extractCommand(&syntax.CallExpr{Args: words})
+	w.depth--
+}
+
+// unwrapScript parses a script run by a wrapper and adds its commands, file
+// writes, file reads, assignments and heredocs, located at the wrapper.
+func (w *astWalker) unwrapScript(script string, loc Location) {
+	if strings.TrimSpace(script) == "" || w.atMaxDepth() {
+		return
+	}
+
+	file, err := syntax.NewParser().Parse(strings.NewReader(script), "")
+	if err != nil {
+		return
+	}
+
+	nested := &astWalker{
+		currentDir: w.currentDir,
+		depth:      w.depth + 1,
+		maxDepth:   w.maxDepth,
+	}
+
+	syntax.Walk(file, nested.visit)
+
+	w.truncated = w.truncated || nested.truncated
+
+	for _, cmd := range nested.commands {
+		cmd.Location = loc
+		w.commands = append(w.commands, cmd)
+	}
+
+	for _, fw := range nested.fileWrites {
+		fw.Location = loc
+		w.fileWrites = append(w.fileWrites, fw)
+	}
+
+	for _, fr := range nested.fileReads {
+		fr.Location = loc
+		w.fileReads = append(w.fileReads, fr)
+	}
+
+	for _, assign := range nested.assigns {
+		assign.Location = loc
+		w.assigns = append(w.assigns, assign)
+	}
+
+	for _, heredoc := range nested.heredocs {
+		heredoc.Location = loc
+		w.heredocs = append(w.heredocs, heredoc)
+	}
+}
+
+// atMaxDepth reports whether wrapped commands are too deep to unwrap, and marks
+// the result as truncated unless unwrapping is disabled.
+func (w *astWalker) atMaxDepth() bool {
+	if w.depth < w.maxDepth {
+		return false
+	}
+
+	if w.maxDepth > 0 {
+		w.truncated = true
+	}
+
+	return true
+}
+
+// unwrapFindExec extracts the commands run by find -exec and its variants.
+func (w *astWalker) unwrapFindExec(words []*syntax.Word, args []string) {
+	for i := 0; i < len(args); i++ {
+		if !slices.Contains(findExecActions, args[i]) {
+			continue
+		}
+
+		end := i + 1
+		for end < len(args) && args[end] != ";" && args[end] != "+" {
+			end++
+		}
+
+		w.unwrapWords(words[i+1 : end])
+		i = end
+	}
+}
+
+// wordLiteral returns the value of a word after quote removal, so scripts given
+// to wrappers keep their inner quotes. Parameter expansions are left empty.
+func wordLiteral(word *syntax.Word) string {
+	fields, err := expand.Fields(&expand.Config{}, word)
+	if err != nil {
+		return wordToString(word)
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// shellScript returns the script given to a shell with -c, skipping options
+// such as -e, -x, -l and -o pipefail.
+func shellScript(args []string) (string, bool) {
+	hasCommand := false
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		switch {
+		case arg == "--":
+			if hasCommand && i+1 < len(args) {
+				return args[i+1], true
+			}
+
+			return "", false
+		case strings.HasPrefix(arg, "--"):
+			continue
+		case len(arg) > 1 && (arg[0] == '-' || arg[0] == '+'):
+			if strings.IndexByte(arg[1:], 'c') >= 0 {
+				hasCommand = true
+			}
+
+			// -o and +o take an option name
+			if strings.HasSuffix(arg, "o") || strings.HasSuffix(arg, "O") {
+				i++
+			}
+		default:
+			return arg, hasCommand
+		}
+	}
+
+	return "", false
+}
+
+// shellReadsStdin reports whether a shell run without -c reads its script from
+// stdin: it has no script file operand, or -s is given.
+func shellReadsStdin(args []string) bool {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		switch {
+		case arg == "--":
+			return i+1 == len(args)
+		case strings.HasPrefix(arg, "--"):
+			continue
+		case len(arg) > 1 && (arg[0] == '-' || arg[0] == '+'):
+			if strings.IndexByte(arg[1:], 's') >= 0 {
+				return true
+			}
+
+			// -o and +o take an option name
+			if strings.HasSuffix(arg, "o") || strings.HasSuffix(arg, "O") {
+				i++
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// pipedScript returns the text a pipeline stage writes to the next one when it
+// is a script: the arguments of echo or printf, or a heredoc given to cat.
+func pipedScript(stmt *syntax.Stmt) string {
+	call, ok := stmt.Cmd.(*syntax.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return ""
+	}
+
+	name := path.Base(commandName(call.Args[0]))
+
+	if name == "cat" && len(call.Args) == 1 {
+		return stmtHeredoc(stmt)
+	}
+
+	if !slices.Contains(scriptPrinters, name) {
+		return ""
+	}
+
+	lines := make([]string, 0, len(call.Args)-1)
+
+	for i, word := range call.Args[1:] {
+		arg := wordLiteral(word)
+
+		// Skip echo options and printf formats such as '%s\n'
+		if (name == "echo" && strings.HasPrefix(arg, "-")) ||
+			(name == "printf" && i == 0 && strings.Contains(arg, "%") && len(call.Args) > 2) {
+			continue
+		}
+
+		if name == "printf" {
+			arg = strings.ReplaceAll(arg, `\n`, "\n")
+		}
+
+		lines = append(lines, arg)
+	}
+
+	separator := " "
+	if name == "printf" {
+		separator = "\n"
+	}
+
+	return strings.Join(lines, separator)
+}
+
+// wrapperOptions parses the options of a wrapper command up to its first
+// operand, returning the option values and the index of that operand.
+func wrapperOptions(args, valueFlags []string) (map[string][]string, int) {
+	values := make(map[string][]string)
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		switch {
+		case arg == "--":
+			return values, i + 1
+		case strings.HasPrefix(arg, "--"):
+			name, value, hasValue := strings.Cut(arg, "=")
+
+			switch {
+			case hasValue:
+				values[name] = append(values[name], value)
+			case slices.Contains(valueFlags, name) && i+1 < len(args):
+				values[name] = append(values[name], args[i+1])
+				i++
+			}
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			// A value flag consumes the rest of a combined flag (-Eu root, -uroot)
+			for j := 1; j < len(arg); j++ {
+				name := "-" + string(arg[j])
+				if !slices.Contains(valueFlags, name) {
+					continue
+				}
+
+				if j+1 < len(arg) {
+					values[name] = append(values[name], arg[j+1:])
+				} else if i+1 < len(args) {
+					values[name] = append(values[name], args[i+1])
+					i++
+				}
+
+				break
+			}
+		default:
+			return values, i
+		}
+	}
+
+	return values, len(args)
+}
+
+// skipAssignments returns the index of the first argument from idx that is not
+// an environment assignment (NAME=value).
+func skipAssignments(args []string, idx int) int {
+	for idx < len(args) && isAssignment(args[idx]) {
+		idx++
+	}
+
+	return idx
+}
+
+// isAssignment reports whether arg is an environment assignment (NAME=value).
+func isAssignment(arg string) bool {
+	name, _, ok := strings.Cut(arg, "=")
+	if !ok || name == "" {
+		return false
+	}
+
+	for i, c := range name {
+		isLetter := c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if !isLetter && (i == 0 || c < '0' || c > '9') {
+			return false
+		}
+	}
+
+	return true
+}
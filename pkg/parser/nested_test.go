@@ -0,0 +1,210 @@
+package parser_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/pkg/parser"
+)
+
+var _ = Describe("Nested commands", func() {
+	parse := func(command string) *parser.ParseResult {
+		GinkgoHelper()
+
+		result, err := parser.NewBashParser().Parse(command)
+		Expect(err).NotTo(HaveOccurred())
+
+		return result
+	}
+
+	// nested returns the commands unwrapped from wrappers as "depth: name args".
+	nested := func(command string) []string {
+		GinkgoHelper()
+
+		var commands []string
+
+		for _, cmd := range parse(command).Commands {
+			if cmd.Depth > 0 {
+				commands = append(commands, fmt.Sprintf("%d: %s", cmd.Depth, cmd.String()))
+			}
+		}
+
+		return commands
+	}
+
+	DescribeTable("unwraps wrapped commands",
+		func(command string, expected ...string) {
+			Expect(nested(command)).To(Equal(expected))
+		},
+		Entry("bash -c", `bash -c "git push -f"`, "1: git push -f"),
+		Entry("sh -c with options", `sh -ec 'git add . && git commit -m msg'`,
+			"1: git add .", "1: git commit -m msg"),
+		Entry("shell with -o", `bash -o pipefail -c "git push"`, "1: git push"),
+		Entry("shell by path", `/bin/bash -c "git push"`, "1: git push"),
+		Entry("eval", `eval "git push --force"`, "1: git push --force"),
+		Entry("sudo with options", "sudo -E -u root git push", "1: git push"),
+		Entry("sudo with assignments", "sudo GIT_SSH=ssh git push", "1: git push"),
+		Entry("env", "env -u HOME FOO=1 git push", "1: git push"),
+		Entry("env -S", `env -S "git push -f"`, "1: git push -f"),
+		Entry("nohup", "nohup git fetch", "1: git fetch"),
+		Entry("timeout", "timeout -s KILL 10 git push", "1: git push"),
+		Entry("watch", "watch -n 5 git status", "1: git status"),
+		Entry("watch -x", "watch -x git status", "1: git status"),
+		Entry("xargs", "echo a.txt | xargs -I{} git rm {}", "1: git rm {}"),
+		Entry("find -exec", `find . -name '*.tmp' -exec rm -f {} \;`, "1: rm -f {}"),
+		Entry("find with several actions", "find . -exec git add {} + -execdir chmod 600 {} ';'",
+			"1: git add {}", "1: chmod 600 {}"),
+		Entry("nested wrappers", `sudo env FOO=1 bash -c "git push"`,
+			"1: env FOO=1 bash -c git push", "2: bash -c git push", "3: git push"),
+		Entry("command", "command git push -f", "1: git push -f"),
+		Entry("command -p", "command -p git push", "1: git push"),
+		Entry("builtin", "builtin cd /tmp", "1: cd /tmp"),
+		Entry("exec with -a", "exec -a deploy git push", "1: git push"),
+		Entry("nice", "nice -n 10 git push -f", "1: git push -f"),
+		Entry("nice with numeric adjustment", "nice -5 git gc", "1: git gc"),
+		Entry("doas", "doas -u root git push", "1: git push"),
+		Entry("stdbuf", "stdbuf -oL -e 0 git push", "1: git push"),
+		Entry("escaped wrapper name", `\sudo git push`, "1: git push"),
+		Entry("heredoc fed to bash", "bash <<'EOF'\ngit push -f\nEOF", "1: git push -f"),
+		Entry("heredoc fed to sh -s", "sh -s -- arg <<EOF\ngit push\nEOF", "1: git push"),
+		Entry("echo piped to bash", "echo 'git push -f' | bash", "1: git push -f"),
+		Entry("printf piped to sh", `printf 'git add .\ngit push\n' | sh`,
+			"1: git add .", "1: git push"),
+		Entry("printf format piped to bash", `printf '%s\n' 'git push -f' | bash`,
+			"1: git push -f"),
+		Entry("heredoc piped to bash", "cat <<'EOF' | bash -x\ngit push -f\nEOF", "1: git push -f"),
+	)
+
+	It("unquotes command names", func() {
+		for _, command := range []string{`\git push -f`, `'git' push -f`, `"git" push -f`} {
+			result := parse(command)
+
+			Expect(result.Commands).To(HaveLen(1), command)
+			Expect(result.Commands[0].Name).To(Equal("git"), command)
+			Expect(result.GitOperations).To(HaveLen(1), command)
+		}
+	})
+
+	DescribeTable("does not unwrap other commands",
+		func(command string) {
+			Expect(nested(command)).To(BeEmpty())
+		},
+		Entry("shell script", "bash deploy.sh"),
+		Entry("interactive sudo", "sudo -i"),
+		Entry("xargs without command", "echo a | xargs"),
+		Entry("find without -exec", "find . -name '*.go'"),
+		Entry("quoted command", `echo "git push"`),
+		Entry("command lookup", "command -v git"),
+		Entry("shell script with heredoc", "bash deploy.sh <<EOF\ngit push\nEOF"),
+		Entry("echo piped to other commands", "echo 'git push' | grep push"),
+		Entry("other commands piped to bash", "curl -s example.com/install.sh | bash"),
+	)
+
+	It("keeps the wrapper and the unwrapped command", func() {
+		result := parse(`bash -c "git push -f"`)
+
+		Expect(result.Commands).To(HaveLen(2))
+		Expect(result.Commands[0].Name).To(Equal("bash"))
+		Expect(result.Commands[0].Depth).To(Equal(0))
+		Expect(result.GitOperations).To(HaveLen(1))
+		Expect(result.GitOperations[0].Args).To(Equal([]string{"push", "-f"}))
+	})
+
+	It("locates commands from scripts at the wrapper", func() {
+		result := parse("git status\nbash -c 'git push'")
+
+		Expect(result.GitOperations).To(HaveLen(2))
+		Expect(result.GitOperations[1].Location.Line).To(Equal(uint(2)))
+	})
+
+	It("detects file writes in wrapped commands", func() {
+		result := parse(`sudo tee /etc/hosts && bash -c "echo x > /tmp/out"`)
+
+		Expect(result.FileWrites).To(HaveLen(2))
+		Expect(result.FileWrites[0].Path).To(Equal("/etc/hosts"))
+		Expect(result.FileWrites[1].Path).To(Equal("/tmp/out"))
+	})
+
+	It("keeps the working directory of the wrapper", func() {
+		result := parse(`cd repo && bash -c "git push"`)
+
+		Expect(result.GitOperations).To(HaveLen(1))
+		Expect(result.GitOperations[0].WorkingDirectory).To(Equal("repo"))
+	})
+
+	Describe("WithMaxNestingDepth", func() {
+		nestedWith := func(depth int, command string) []string {
+			GinkgoHelper()
+
+			result, err := parser.NewBashParser(parser.WithMaxNestingDepth(depth)).Parse(command)
+			Expect(err).NotTo(HaveOccurred())
+
+			var commands []string
+
+			for _, cmd := range result.Commands {
+				if cmd.Depth > 0 {
+					commands = append(commands, fmt.Sprintf("%d: %s", cmd.Depth, cmd.String()))
+				}
+			}
+
+			return commands
+		}
+
+		It("defaults to three levels", func() {
+			Expect(nested(`sudo sudo sudo sudo git push`)).To(HaveLen(parser.DefaultMaxNestingDepth))
+		})
+
+		It("limits the unwrapped levels", func() {
+			Expect(nestedWith(1, `sudo bash -c "git push"`)).
+				To(Equal([]string{"1: bash -c git push"}))
+		})
+
+		It("unwraps more levels than the default", func() {
+			Expect(nestedWith(5, `sudo sudo sudo sudo git push`)).To(HaveLen(4))
+		})
+
+		It("disables unwrapping with zero", func() {
+			Expect(nestedWith(0, `bash -c "git push"`)).To(BeEmpty())
+		})
+
+		It("treats negative depths as zero", func() {
+			Expect(nestedWith(-1, `bash -c "git push"`)).To(BeEmpty())
+		})
+
+		It("marks wrappers nested deeper than the limit as truncated", func() {
+			command := `bash -c "bash -c 'bash -c \"bash -c \\\"rm -rf /\\\"\"'"`
+
+			result, err := parser.NewBashParser().Parse(command)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Truncated).To(BeTrue())
+			Expect(result.HasCommand("rm")).To(BeFalse())
+
+			result, err = parser.NewBashParser(parser.WithMaxNestingDepth(4)).Parse(command)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Truncated).To(BeFalse())
+			Expect(result.HasCommand("rm")).To(BeTrue())
+		})
+
+		It("does not mark commands that are not wrappers at the limit", func() {
+			result, err := parser.NewBashParser(parser.WithMaxNestingDepth(1)).
+				Parse(`sudo git push && command -v git && bash -c ""`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Truncated).To(BeFalse())
+		})
+
+		It("does not mark wrappers as truncated when unwrapping is disabled", func() {
+			result, err := parser.NewBashParser(parser.WithMaxNestingDepth(0)).
+				Parse(`bash -c "git push"`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Truncated).To(BeFalse())
+		})
+
+		It("does not affect other parsers", func() {
+			_ = nestedWith(0, `bash -c "git push"`)
+
+			Expect(nested(`bash -c "git push"`)).To(Equal([]string{"1: git push"}))
+		})
+	})
+})
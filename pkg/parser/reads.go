@@ -0,0 +1,251 @@
+package parser
+
+import (
+	"path"
+	"slices"
+	"strings"
+)
+
+// FileRead represents a file or directory whose content a command reads.
+type FileRead struct {
+	Path             string   // File or directory path
+	Source           string   // Reading command, or "<" for input redirection
+	Recursive        bool     // Path is searched recursively (grep -r, rg)
+	WorkingDirectory string   // Directory from a preceding cd, empty if none
+	Location         Location // Position in source
+}
+
+var (
+	// readCommands print or dump the content of their file operands.
+	readCommands = []string{
+		"cat", "tac", "nl", "less", "more", "head", "tail", "bat", "batcat",
+		"strings", "xxd", "hexdump", "od", "base64", "view",
+	}
+
+	// batValueFlags are bat options that take a value.
+	batValueFlags = []string{
+		"-l", "-H", "-r", "-m", "--language", "--theme", "--wrap", "--line-range", "--style",
+	}
+
+	// readValueFlags are the options of readCommands that take a value.
+	readValueFlags = map[string][]string{
+		"head":    {"-n", "-c", "--lines", "--bytes"},
+		"tail":    {"-n", "-c", "-s", "--lines", "--bytes", "--sleep-interval", "--pid"},
+		"nl":      {"-b", "-d", "-f", "-h", "-i", "-l", "-n", "-s", "-v", "-w"},
+		"bat":     batValueFlags,
+		"batcat":  batValueFlags,
+		"strings": {"-n", "-t", "-e", "--bytes", "--radix", "--encoding"},
+		"xxd":     {"-c", "-g", "-l", "-o", "-s", "-n", "--cols", "--len", "--seek"},
+		"hexdump": {"-e", "-f", "-n", "-s"},
+		"od": {
+			"-A", "-j", "-N", "-t", "-w",
+			"--address-radix", "--skip-bytes", "--read-bytes", "--format", "--width",
+		},
+		"base64": {"-w", "--wrap"},
+		"less":   {"-b", "-h", "-j", "-p", "-P", "-t", "-T", "-x", "-y", "-z", "-o", "-k"},
+	}
+
+	// searchCommands print the lines of their file operands matching a pattern.
+	searchCommands = []string{"grep", "egrep", "fgrep", "rg", "ag"}
+
+	// recursiveSearchCommands search directories recursively by default.
+	recursiveSearchCommands = []string{"rg", "ag"}
+
+	// searchValueFlags are options of searchCommands that take a value.
+	searchValueFlags = []string{
+		"-e", "-f", "-m", "-A", "-B", "-C", "-g", "-t", "-T", "-d", "-D",
+		"--regexp", "--file", "--max-count", "--after-context", "--before-context",
+		"--context", "--include", "--exclude", "--exclude-dir", "--glob", "--iglob",
+		"--type", "--type-not", "--directories", "--devices", "--label",
+	}
+
+	// copyValueFlags are the options of cp-like commands that take a value.
+	// These commands read their operands except the last, the destination.
+	copyValueFlags = map[string][]string{
+		"cp":      {"-t", "-S", "--target-directory", "--suffix"},
+		"mv":      {"-t", "-S", "--target-directory", "--suffix"},
+		"ln":      {"-t", "-S", "--target-directory", "--suffix"},
+		"install": {"-t", "-S", "-m", "-o", "-g", "--target-directory", "--suffix", "--mode"},
+		"scp":     {"-i", "-c", "-F", "-J", "-l", "-o", "-P", "-S", "-D"},
+		"rsync":   {"-e", "-f", "-T", "--rsh", "--filter", "--exclude", "--include"},
+	}
+
+	// archiveCommands pack their operands, reading directories recursively.
+	archiveCommands = []string{"tar", "zip", "7z", "cpio"}
+
+	// gitReadSubcommands print the content of the files they are given.
+	gitReadSubcommands = []string{"diff", "show", "blame", "log", "grep"}
+
+	// nonReadCommands do not print the content of their operands. The operands
+	// of commands that are not known read, e.g. source, awk, sort or jq, are
+	// treated as reads.
+	nonReadCommands = []string{
+		"ls", "dir", "tree", "find", "fd", "cd", "pushd", "popd", "pwd",
+		"rm", "rmdir", "mkdir", "touch", "shred", "truncate", "mktemp",
+		"chmod", "chown", "chgrp", "umask", "stat", "du", "df", "wc", "file", "test", "[",
+		"echo", "printf", "which", "type", "whereis", "realpath", "readlink",
+		"basename", "dirname", "true", "false", "sleep", "kill", "wait", "date",
+		"export", "unset", "declare", "local", "readonly", "typeset", "set",
+		"shift", "exit", "return", "trap", "alias", "unalias",
+		"ssh", "ssh-add", "ssh-keygen",
+	}
+
+	// unwrappedCommands run their operands as a command, which the parser
+	// unwraps and checks instead.
+	unwrappedCommands = []string{"sudo", "env", "timeout", "watch", "xargs", "eval"}
+)
+
+// extractFileReadCommand detects commands that read files (cat, head, grep,
+// cp, tar, source, ...). The operands of unknown commands count as reads.
+func (w *astWalker) extractFileReadCommand(cmd Command) {
+	name := path.Base(cmd.Name)
+
+	var (
+		targets   []string
+		recursive bool
+	)
+
+	switch {
+	case slices.Contains(readCommands, name):
+		_, targets = splitOptions(cmd.Args, readValueFlags[name])
+	case slices.Contains(searchCommands, name):
+		targets, recursive = searchTargets(name, cmd.Args)
+	case copyValueFlags[name] != nil:
+		targets, recursive = copySources(name, cmd.Args)
+	case slices.Contains(archiveCommands, name):
+		targets, recursive = archiveOperands(name, cmd.Args), true
+	case name == "git":
+		targets = gitReadTargets(cmd)
+	case slices.Contains(nonReadCommands, name), w.unwraps(name, cmd.Args):
+		return
+	default:
+		targets = operands(cmd.Args)
+	}
+
+	for _, target := range targets {
+		if target == "" || target == "-" {
+			continue
+		}
+
+		w.fileReads = append(w.fileReads, FileRead{
+			Path:             target,
+			Source:           cmd.Name,
+			Recursive:        recursive,
+			WorkingDirectory: cmd.WorkingDirectory,
+			Location:         cmd.Location,
+		})
+	}
+}
+
+// unwraps reports whether the command is a wrapper whose command is unwrapped,
+// such as sudo or bash -c, so its own operands are not reads.
+func (w *astWalker) unwraps(name string, args []string) bool {
+	if w.depth >= w.maxDepth {
+		return false
+	}
+
+	if _, ok := commandWrappers[name]; ok {
+		return true
+	}
+
+	if slices.Contains(shellWrappers, name) {
+		_, ok := shellScript(args)
+
+		return ok || (w.stdin != "" && shellReadsStdin(args))
+	}
+
+	return slices.Contains(unwrappedCommands, name)
+}
+
+// operands returns the arguments of a command that may name files: arguments
+// that are not options, values of --option=value and key=value arguments, and
+// files passed as @file.
+func operands(args []string) []string {
+	var paths []string
+
+	for i, arg := range args {
+		if arg == "--" {
+			return append(paths, args[i+1:]...)
+		}
+
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			paths = append(paths, strings.TrimPrefix(arg, "@"))
+		}
+
+		if _, value, ok := strings.Cut(arg, "="); ok {
+			paths = append(paths, strings.TrimPrefix(value, "@"))
+		}
+	}
+
+	return paths
+}
+
+// copySources returns the files read by cp-like commands: all operands but
+// the destination, and whether directories are copied recursively.
+func copySources(name string, args []string) ([]string, bool) {
+	values, positional := splitOptions(args, copyValueFlags[name])
+
+	// The destination is the last operand, unless it is given with -t
+	hasTarget := len(values["-t"]) > 0 || len(values["--target-directory"]) > 0
+	if !hasTarget && len(positional) > 0 {
+		positional = positional[:len(positional)-1]
+	}
+
+	recursive := hasShortFlag(args, 'r') || hasShortFlag(args, 'R') ||
+		(name != "ln" && hasShortFlag(args, 'a')) ||
+		slices.Contains(args, "--recursive") || slices.Contains(args, "--archive")
+
+	return positional, recursive
+}
+
+// archiveOperands returns the operands of an archive command, skipping the
+// old style options of tar such as "cf" in "tar cf - dir".
+func archiveOperands(name string, args []string) []string {
+	if name == "tar" && len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		args = args[1:]
+	}
+
+	return operands(args)
+}
+
+// gitReadTargets returns the files printed by git diff, show, blame, log and
+// grep, including paths of revisions such as HEAD:.env.
+func gitReadTargets(cmd Command) []string {
+	gitCmd, err := ParseGitCommand(cmd)
+	if err != nil || !slices.Contains(gitReadSubcommands, gitCmd.Subcommand) {
+		return nil
+	}
+
+	var paths []string
+
+	for _, arg := range gitCmd.Args {
+		paths = append(paths, arg)
+
+		if _, file, ok := strings.Cut(arg, ":"); ok {
+			paths = append(paths, file)
+		}
+	}
+
+	return paths
+}
+
+// searchTargets returns the files and directories searched by grep-like
+// commands, and whether they are searched recursively.
+func searchTargets(name string, args []string) ([]string, bool) {
+	values, positional := splitOptions(args, searchValueFlags)
+
+	// Without -e or -f, the first operand is the pattern
+	hasPattern := len(values["-e"]) > 0 || len(values["--regexp"]) > 0 ||
+		len(values["-f"]) > 0 || len(values["--file"]) > 0
+	if !hasPattern && len(positional) > 0 {
+		positional = positional[1:]
+	}
+
+	recursive := slices.Contains(recursiveSearchCommands, name) ||
+		hasShortFlag(args, 'r') || hasShortFlag(args, 'R') ||
+		slices.ContainsFunc(args, func(arg string) bool {
+			return strings.HasPrefix(arg, "--recursive") || arg == "--dereference-recursive"
+		})
+
+	return positional, recursive
+}
@@ -0,0 +1,341 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// SedScript is a sed program made only of unaddressed substitutions
+// (s/pattern/replacement/flags), so its effect on a file can be computed
+// without running sed.
+type SedScript struct {
+	substitutions []sedSubstitution
+}
+
+// sedSubstitution is a single compiled s command.
+type sedSubstitution struct {
+	re       *regexp.Regexp
+	template string // Replacement in regexp.Expand syntax
+	global   bool
+}
+
+// ParseSedScript parses sed scripts, as given with -e or as the script operand.
+// Scripts may hold several substitutions separated by ';' or newlines. Extended
+// selects ERE (-E/-r) instead of BRE syntax. Returns false when any command is
+// not a simple substitution, e.g. when it has an address, uses backreferences in
+// the pattern, or has flags other than g and I.
+func ParseSedScript(scripts []string, extended bool) (*SedScript, bool) {
+	script := &SedScript{}
+
+	for _, text := range scripts {
+		substitutions, ok := parseSedCommands(text, extended)
+		if !ok {
+			return nil, false
+		}
+
+		script.substitutions = append(script.substitutions, substitutions...)
+	}
+
+	if len(script.substitutions) == 0 {
+		return nil, false
+	}
+
+	return script, true
+}
+
+// Apply returns content after running the script on every line.
+func (s *SedScript) Apply(content string) string {
+	body, trailingNewline := strings.CutSuffix(content, "\n")
+	lines := strings.Split(body, "\n")
+
+	for i, line := range lines {
+		for _, sub := range s.substitutions {
+			line = sub.apply(line)
+		}
+
+		lines[i] = line
+	}
+
+	result := strings.Join(lines, "\n")
+	if trailingNewline {
+		result += "\n"
+	}
+
+	return result
+}
+
+// apply runs the substitution on a single line.
+func (s *sedSubstitution) apply(line string) string {
+	if s.global {
+		return s.re.ReplaceAllString(line, s.template)
+	}
+
+	loc := s.re.FindStringSubmatchIndex(line)
+	if loc == nil {
+		return line
+	}
+
+	replacement := s.re.ExpandString(nil, s.template, line, loc)
+
+	return line[:loc[0]] + string(replacement) + line[loc[1]:]
+}
+
+// parseSedCommands parses the substitutions of a single script.
+func parseSedCommands(text string, extended bool) ([]sedSubstitution, bool) {
+	var substitutions []sedSubstitution
+
+	for i := 0; i < len(text); {
+		switch text[i] {
+		case ' ', '\t', '\n', ';':
+			i++
+
+			continue
+		case 's':
+		default:
+			return nil, false
+		}
+
+		// s, delimiter, pattern, delimiter, replacement, delimiter, flags
+		if i+1 >= len(text) || text[i+1] == '\\' || text[i+1] == '\n' {
+			return nil, false
+		}
+
+		delim := text[i+1]
+
+		pattern, next, ok := readSedPart(text, i+2, delim)
+		if !ok {
+			return nil, false
+		}
+
+		replacement, next, ok := readSedPart(text, next, delim)
+		if !ok {
+			return nil, false
+		}
+
+		sub, next, ok := buildSedSubstitution(text, next, pattern, replacement, delim, extended)
+		if !ok {
+			return nil, false
+		}
+
+		substitutions = append(substitutions, sub)
+		i = next
+	}
+
+	return substitutions, true
+}
+
+// buildSedSubstitution parses the flags at text[start:] and compiles the
+// substitution. Returns the index after the flags.
+func buildSedSubstitution(
+	text string,
+	start int,
+	pattern, replacement string,
+	delim byte,
+	extended bool,
+) (sedSubstitution, int, bool) {
+	sub := sedSubstitution{}
+	caseInsensitive := false
+
+	i := start
+
+flags:
+	for ; i < len(text); i++ {
+		switch text[i] {
+		case 'g':
+			sub.global = true
+		case 'I', 'i':
+			caseInsensitive = true
+		case ';', '\n', ' ', '\t':
+			break flags
+		default:
+			return sub, 0, false
+		}
+	}
+
+	expr, ok := sedPatternToRegexp(pattern, delim, extended)
+	if !ok {
+		return sub, 0, false
+	}
+
+	if caseInsensitive {
+		expr = "(?i)" + expr
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return sub, 0, false
+	}
+
+	template, ok := sedReplacementToTemplate(replacement, delim)
+	if !ok {
+		return sub, 0, false
+	}
+
+	sub.re = re
+	sub.template = template
+
+	return sub, i, true
+}
+
+// readSedPart reads up to the next unescaped delimiter, returning the part and
+// the index after the delimiter. Escapes are kept for later conversion.
+func readSedPart(text string, start int, delim byte) (string, int, bool) {
+	for i := start; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '\n':
+			return "", 0, false
+		case delim:
+			return text[start:i], i + 1, true
+		}
+	}
+
+	return "", 0, false
+}
+
+// sedPatternToRegexp converts a sed BRE or ERE pattern to Go regexp syntax.
+func sedPatternToRegexp(pattern string, delim byte, extended bool) (string, bool) {
+	var sb strings.Builder
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+
+		switch {
+		case c == '[':
+			end, ok := bracketEnd(pattern, i)
+			if !ok {
+				return "", false
+			}
+
+			// Backslashes are literal inside POSIX bracket expressions
+			sb.WriteString(strings.ReplaceAll(pattern[i:end+1], `\`, `\\`))
+			i = end
+
+		case c == '\\':
+			if i+1 >= len(pattern) {
+				return "", false
+			}
+
+			i++
+
+			converted, ok := convertSedEscape(pattern[i], delim, extended)
+			if !ok {
+				return "", false
+			}
+
+			sb.WriteString(converted)
+
+		case !extended && strings.IndexByte("+?(){}|", c) >= 0:
+			// Literal in BRE, special in Go
+			sb.WriteString(`\` + string(c))
+
+		case !extended && c == '*' && i == 0:
+			// A leading * is literal in BRE
+			sb.WriteString(`\*`)
+
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return sb.String(), true
+}
+
+// convertSedEscape converts the escaped character c of a sed pattern.
+func convertSedEscape(c, delim byte, extended bool) (string, bool) {
+	switch {
+	case c == delim:
+		return regexp.QuoteMeta(string(c)), true
+	case c >= '1' && c <= '9':
+		// Backreferences are not supported by Go regexp
+		return "", false
+	case c == 'n':
+		return `\n`, true
+	case c == 't':
+		return `\t`, true
+	case strings.IndexByte("wWsSbB", c) >= 0:
+		return `\` + string(c), true
+	case !extended && strings.IndexByte("+?(){}|", c) >= 0:
+		// Special in BRE only when escaped
+		return string(c), true
+	case strings.IndexByte(`.*[]^$\+?(){}|/`, c) >= 0:
+		return regexp.QuoteMeta(string(c)), true
+	default:
+		// GNU extensions such as \< \> \` \' have no Go equivalent
+		return "", false
+	}
+}
+
+// bracketEnd returns the index of the ']' closing the bracket expression
+// starting at pattern[start].
+func bracketEnd(pattern string, start int) (int, bool) {
+	i := start + 1
+	if i < len(pattern) && pattern[i] == '^' {
+		i++
+	}
+
+	// A leading ']' is a literal member
+	if i < len(pattern) && pattern[i] == ']' {
+		i++
+	}
+
+	for ; i < len(pattern); i++ {
+		switch {
+		case pattern[i] == '[' && i+1 < len(pattern) && strings.IndexByte(":.=", pattern[i+1]) >= 0:
+			// Character class such as [:alpha:]
+			closing := strings.Index(pattern[i+2:], string(pattern[i+1])+"]")
+			if closing == -1 {
+				return 0, false
+			}
+
+			i += closing + 3
+		case pattern[i] == ']':
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// sedReplacementToTemplate converts a sed replacement to regexp.Expand syntax.
+func sedReplacementToTemplate(replacement string, delim byte) (string, bool) {
+	var sb strings.Builder
+
+	for i := 0; i < len(replacement); i++ {
+		c := replacement[i]
+
+		switch c {
+		case '&':
+			sb.WriteString("${0}")
+		case '$':
+			sb.WriteString("$$")
+		case '\\':
+			if i+1 >= len(replacement) {
+				return "", false
+			}
+
+			i++
+
+			switch e := replacement[i]; {
+			case e >= '0' && e <= '9':
+				sb.WriteString("${" + string(e) + "}")
+			case e == 'n':
+				sb.WriteByte('\n')
+			case e == 't':
+				sb.WriteByte('\t')
+			case e == '&' || e == '\\' || e == delim:
+				sb.WriteByte(e)
+			case e == '$':
+				sb.WriteString("$$")
+			default:
+				// GNU case conversions (\U, \L, ...) are not supported
+				return "", false
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return sb.String(), true
+}
@@ -0,0 +1,70 @@
+package parser_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/pkg/parser"
+)
+
+var _ = Describe("SedScript", func() {
+	apply := func(script string, extended bool, content string) string {
+		GinkgoHelper()
+
+		s, ok := parser.ParseSedScript([]string{script}, extended)
+		Expect(ok).To(BeTrue(), "script %q should be supported", script)
+
+		return s.Apply(content)
+	}
+
+	It("replaces the first match on each line", func() {
+		Expect(apply("s/a/b/", false, "aa\naa\n")).To(Equal("ba\nba\n"))
+	})
+
+	It("replaces every match with the g flag", func() {
+		Expect(apply("s/a/b/g", false, "aa\naa")).To(Equal("bb\nbb"))
+	})
+
+	It("supports case-insensitive matching", func() {
+		Expect(apply("s/todo/DONE/I", false, "TODO: x\n")).To(Equal("DONE: x\n"))
+	})
+
+	It("supports BRE groups and replacement references", func() {
+		Expect(apply(`s/\(foo\)-\(bar\)/\2-\1 [&]/`, false, "foo-bar\n")).
+			To(Equal("bar-foo [foo-bar]\n"))
+	})
+
+	It("treats BRE metacharacters literally when unescaped", func() {
+		Expect(apply("s/a+(b)/x/", false, "a+(b) aab\n")).To(Equal("x aab\n"))
+	})
+
+	It("supports ERE syntax", func() {
+		Expect(apply("s/a+(b)/x/", true, "a+(b) aab\n")).To(Equal("a+(b) x\n"))
+	})
+
+	It("supports custom delimiters and bracket expressions", func() {
+		Expect(apply("s|/usr/[a-z]*|/opt|", false, "/usr/local/bin\n")).To(Equal("/opt/bin\n"))
+	})
+
+	It("runs several commands in order", func() {
+		Expect(apply("s/a/b/; s/b/c/", false, "a\n")).To(Equal("c\n"))
+	})
+
+	It("keeps dollar signs in replacements literal", func() {
+		Expect(apply(`s/price/$5/`, false, "price\n")).To(Equal("$5\n"))
+	})
+
+	DescribeTable("rejects scripts it cannot evaluate",
+		func(script string) {
+			_, ok := parser.ParseSedScript([]string{script}, false)
+			Expect(ok).To(BeFalse())
+		},
+		Entry("addresses", "1s/a/b/"),
+		Entry("other commands", "/x/d"),
+		Entry("pattern backreferences", `s/\(a\)\1/b/`),
+		Entry("case conversion", `s/a/\U&/`),
+		Entry("unsupported flags", "s/a/b/2"),
+		Entry("unterminated commands", "s/a/b"),
+		Entry("empty scripts", ""),
+	)
+})
@@ -0,0 +1,118 @@
+// Package pathutil provides common file path utility functions.
+package pathutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// gitDirName is the name of the git directory, or of the file pointing to it
+// in worktrees and submodules.
+const gitDirName = ".git"
+
+// IsRepoRoot reports whether dir contains .git, a directory or a file for
+// worktrees and submodules.
+func IsRepoRoot(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, gitDirName))
+
+	return err == nil
+}
+
+// FindRepoRoot returns the closest directory containing .git, starting at dir.
+// Returns an empty string outside of a git repository.
+func FindRepoRoot(dir string) string {
+	for current := dir; current != ""; {
+		if IsRepoRoot(current) {
+			return current
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+
+		current = parent
+	}
+
+	return ""
+}
+
+// FindProjectRoot returns the closest directory containing .git, starting at
+// dir, or dir itself outside a repository.
+func FindProjectRoot(dir string) string {
+	if root := FindRepoRoot(dir); root != "" {
+		return root
+	}
+
+	return dir
+}
+
+// ExpandHome replaces a leading "~" of path with home. Returns false for paths
+// relative to the home directory of another user, such as "~root/.ssh".
+func ExpandHome(path, home string) (string, bool) {
+	switch {
+	case path == "~" || strings.HasPrefix(path, "~/"):
+		return home + path[1:], true
+	case strings.HasPrefix(path, "~"):
+		return "", false
+	}
+
+	return path, true
+}
+
+// Resolve converts a path to a cleaned absolute path, expanding a leading "~"
+// to home and resolving relative paths from dir. Returns false when the path
+// is relative to an unknown (empty) dir or to the home directory of another
+// user.
+func Resolve(path, dir, home string) (string, bool) {
+	path, ok := ExpandHome(path, home)
+	if !ok {
+		return "", false
+	}
+
+	if !filepath.IsAbs(path) {
+		if dir == "" {
+			return "", false
+		}
+
+		path = filepath.Join(dir, path)
+	}
+
+	return filepath.Clean(path), true
+}
+
+// IsWithin reports whether path is parent or a descendant of it. Both paths
+// must be absolute or relative to the same directory.
+func IsWithin(parent, path string) bool {
+	rel, err := filepath.Rel(parent, path)
+	if err != nil {
+		return false
+	}
+
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, "../"))
+}
+
+// RealPath resolves the symlinks in a path. Trailing components that do not
+// exist yet, such as a file about to be created, are kept as they are.
+func RealPath(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	var missing []string
+
+	for current := filepath.Clean(path); ; {
+		if resolved, err := filepath.EvalSymlinks(current); err == nil {
+			return filepath.Join(append([]string{resolved}, missing...)...)
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return filepath.Clean(path)
+		}
+
+		missing = append([]string{filepath.Base(current)}, missing...)
+		current = parent
+	}
+}
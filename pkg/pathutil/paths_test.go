@@ -0,0 +1,116 @@
+package pathutil_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/pkg/pathutil"
+)
+
+func TestPathutil(t *testing.T) {
+	t.Parallel()
+
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Pathutil Suite")
+}
+
+var _ = Describe("FindProjectRoot", func() {
+	var root string
+
+	BeforeEach(func() {
+		root = GinkgoT().TempDir()
+		Expect(os.MkdirAll(filepath.Join(root, ".git"), 0o755)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(root, "src", "app"), 0o755)).To(Succeed())
+	})
+
+	It("returns the closest directory containing .git", func() {
+		Expect(pathutil.FindProjectRoot(filepath.Join(root, "src", "app"))).To(Equal(root))
+		Expect(pathutil.FindProjectRoot(root)).To(Equal(root))
+	})
+
+	It("accepts a .git file of worktrees and submodules", func() {
+		module := filepath.Join(root, "src", "app")
+		Expect(os.WriteFile(filepath.Join(module, ".git"), []byte("gitdir: x\n"), 0o600)).
+			To(Succeed())
+
+		Expect(pathutil.FindProjectRoot(module)).To(Equal(module))
+	})
+
+	It("returns the directory itself outside a repository", func() {
+		dir := GinkgoT().TempDir()
+		Expect(pathutil.FindProjectRoot(dir)).To(Equal(dir))
+	})
+})
+
+var _ = Describe("FindRepoRoot", func() {
+	It("returns an empty string outside a repository", func() {
+		Expect(pathutil.FindRepoRoot(GinkgoT().TempDir())).To(BeEmpty())
+	})
+
+	It("returns the closest directory containing .git", func() {
+		root := GinkgoT().TempDir()
+		Expect(os.MkdirAll(filepath.Join(root, ".git"), 0o755)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(root, "src"), 0o755)).To(Succeed())
+
+		Expect(pathutil.FindRepoRoot(filepath.Join(root, "src"))).To(Equal(root))
+		Expect(pathutil.IsRepoRoot(root)).To(BeTrue())
+		Expect(pathutil.IsRepoRoot(filepath.Join(root, "src"))).To(BeFalse())
+	})
+})
+
+var _ = DescribeTable("Resolve",
+	func(path, dir, expected string, ok bool) {
+		resolved, resolvedOK := pathutil.Resolve(path, dir, "/home/user")
+		Expect(resolvedOK).To(Equal(ok))
+		Expect(resolved).To(Equal(expected))
+	},
+	Entry("absolute path", "/etc/../etc/hosts", "/work", "/etc/hosts", true),
+	Entry("relative path", "src/../main.go", "/work", "/work/main.go", true),
+	Entry("home directory", "~", "/work", "/home/user", true),
+	Entry("path in the home directory", "~/.ssh/id_rsa", "/work", "/home/user/.ssh/id_rsa", true),
+	Entry("home directory of another user", "~root/.ssh", "/work", "", false),
+	Entry("relative path without a directory", "main.go", "", "", false),
+)
+
+var _ = DescribeTable("IsWithin",
+	func(parent, path string, expected bool) {
+		Expect(pathutil.IsWithin(parent, path)).To(Equal(expected))
+	},
+	Entry("same path", "/work", "/work", true),
+	Entry("descendant", "/work", "/work/src/main.go", true),
+	Entry("sibling with a common prefix", "/work", "/workspace", false),
+	Entry("parent", "/work/src", "/work", false),
+	Entry("file name starting with two dots", "/work", "/work/..file", true),
+)
+
+var _ = Describe("RealPath", func() {
+	var dir, link string
+
+	BeforeEach(func() {
+		var err error
+
+		dir, err = filepath.EvalSymlinks(GinkgoT().TempDir())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.MkdirAll(filepath.Join(dir, "target"), 0o755)).To(Succeed())
+
+		link = filepath.Join(dir, "link")
+		Expect(os.Symlink(filepath.Join(dir, "target"), link)).To(Succeed())
+	})
+
+	It("resolves symlinks", func() {
+		Expect(pathutil.RealPath(link)).To(Equal(filepath.Join(dir, "target")))
+	})
+
+	It("keeps components that do not exist yet", func() {
+		Expect(pathutil.RealPath(filepath.Join(link, "new", "file.txt"))).
+			To(Equal(filepath.Join(dir, "target", "new", "file.txt")))
+	})
+
+	It("returns an empty path as is", func() {
+		Expect(pathutil.RealPath("")).To(BeEmpty())
+	})
+})